@@ -0,0 +1,150 @@
+package a11y
+
+import (
+	"furoshiki/component"
+	"strings"
+)
+
+// このファイルは、フォーカスの移動、ダイアログの表示、ライブリージョンの更新を、読み上げる文章にして
+// Announcerに渡す仕組みを提供します。読み上げ自体は、ゲームが用意するバックエンド
+// (OSの音声合成、スクリーンリーダーとの連携、字幕表示、ログ出力など)が行います。
+//
+//	a11y.SetAnnouncer(a11y.AnnouncerFunc(func(a a11y.Announcement) {
+//		speech.Say(a.Text, a.Priority == a11y.Assertive)
+//	}))
+//
+//	scoreLabel := widget.NewLabelBuilder().AccessibleLive(component.LivePolite) // スコアが変わるたびに読み上げます
+//	a11y.Announce("Level complete", a11y.Polite)                                 // ゲームの出来事を任意に読み上げます
+
+// Priority は、読み上げの優先度です。
+type Priority int
+
+const (
+	// Polite は、読み上げ中の内容が終わってから読み上げることを示します。
+	Polite Priority = iota
+	// Assertive は、読み上げ中の内容を中断してただちに読み上げることを示します。
+	Assertive
+)
+
+// AnnouncementKind は、読み上げのきっかけになった出来事の種類です。
+type AnnouncementKind int
+
+const (
+	// KindFocus は、フォーカスが移ったことによる読み上げです。
+	KindFocus AnnouncementKind = iota
+	// KindDialog は、ダイアログが表示されたことによる読み上げです。
+	KindDialog
+	// KindLiveRegion は、ライブリージョンのラベルが変わったことによる読み上げです。
+	KindLiveRegion
+	// KindCustom は、Announceで直接要求された読み上げです。
+	KindCustom
+)
+
+// Announcement は、Announcerに渡される1回分の読み上げの内容です。
+type Announcement struct {
+	// Text は、読み上げる文章です(例: "OK, button, disabled")。
+	Text     string
+	Priority Priority
+	Kind     AnnouncementKind
+	// Widget は、読み上げのきっかけになったウィジェットです。Announceによる読み上げではnilです。
+	Widget component.Widget
+}
+
+// Announcer は、読み上げの内容を受け取って利用者に伝えるバックエンドのインターフェースです。
+// Announceはゲームループの中(UIを更新したゴルーチン)から呼び出されるため、時間のかかる処理は別のゴルーチンで行ってください。
+type Announcer interface {
+	Announce(a Announcement)
+}
+
+// AnnouncerFunc は、関数をAnnouncerとして使用するためのアダプタです。
+type AnnouncerFunc func(a Announcement)
+
+// Announce は、Announcerインターフェースの実装です。
+func (f AnnouncerFunc) Announce(a Announcement) {
+	f(a)
+}
+
+// announcer は、現在設定されているバックエンドです。
+var announcer Announcer
+
+// SetAnnouncer は、読み上げのバックエンドを設定し、フレームワークからの通知の受け取りを開始します。
+// nilを渡すと通知の受け取りを停止します。バックエンドは同時に1つだけ設定できます。
+func SetAnnouncer(a Announcer) {
+	announcer = a
+	if a == nil {
+		component.SetAccessibilityObserver(nil)
+		return
+	}
+	component.SetAccessibilityObserver(observe)
+}
+
+// CurrentAnnouncer は、現在設定されているバックエンドを返します。設定されていない場合はnilです。
+func CurrentAnnouncer() Announcer {
+	return announcer
+}
+
+// Announce は、ゲームの出来事など、ウィジェットに結び付かない文章を読み上げます。
+// バックエンドが設定されていない場合や、textが空の場合は何もしません。
+func Announce(text string, priority Priority) {
+	if announcer == nil || text == "" {
+		return
+	}
+	announcer.Announce(Announcement{Text: text, Priority: priority, Kind: KindCustom})
+}
+
+// observe は、フレームワークからの通知を読み上げの内容に変換してバックエンドに渡します。
+func observe(kind component.AccessibilityEventKind, w component.Widget) {
+	if announcer == nil {
+		return
+	}
+	a := Announcement{Widget: w}
+	switch kind {
+	case component.AccessibilityFocusChanged:
+		a.Kind, a.Priority, a.Text = KindFocus, Assertive, Describe(w)
+	case component.AccessibilityDialogOpened:
+		a.Kind, a.Priority, a.Text = KindDialog, Assertive, Describe(w)
+	case component.AccessibilityLiveRegionChanged:
+		a.Kind, a.Priority = KindLiveRegion, Polite
+		if acc, ok := w.(component.Accessible); ok {
+			a.Text = acc.AccessibleLabel()
+			if acc.AccessibleLive() == component.LiveAssertive {
+				a.Priority = Assertive
+			}
+		}
+	default:
+		return
+	}
+	if a.Text != "" {
+		announcer.Announce(a)
+	}
+}
+
+// Describe は、ウィジェットを読み上げるための文章を、名前、役割、値、状態、ヒントの順に組み立てて返します。
+// 例: "Volume, textfield, 80" や "OK, button, disabled, Closes the dialog"
+func Describe(w component.Widget) string {
+	if w == nil {
+		return ""
+	}
+	n := newNode(w)
+	var parts []string
+	if n.Label != "" {
+		parts = append(parts, n.Label)
+	}
+	if n.Role != component.RoleNone {
+		parts = append(parts, string(n.Role))
+	}
+	if n.Value != "" {
+		parts = append(parts, n.Value)
+	}
+	// ホバーや押下は読み上げの対象にしません。
+	if n.States.Disabled {
+		parts = append(parts, "disabled")
+	}
+	if n.States.Selected {
+		parts = append(parts, "selected")
+	}
+	if n.Hint != "" {
+		parts = append(parts, n.Hint)
+	}
+	return strings.Join(parts, ", ")
+}
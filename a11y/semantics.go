@@ -0,0 +1,205 @@
+// Package a11y は、ウィジェットツリーのアクセシビリティ情報を取り出す機能を提供します。
+// スクリーンリーダーとの橋渡しや、ラベルの付け忘れなどを見つける自動検査の土台として使用します。
+//
+//	root := a11y.Export(g.root)
+//	fmt.Print(root) // 役割、名前、状態、境界をインデント付きで出力します
+//	data, _ := json.Marshal(root)
+//
+// ウィジェットの役割と名前は、ビルダーのAccessibleRole、AccessibleLabel、AccessibleHintで設定します。
+// 設定しない場合は、Buttonならbutton、Labelならtextのように、ウィジェットの種類ごとの既定値が使われます。
+// フォーカスの移動やダイアログの表示を読み上げるには、SetAnnouncerでバックエンドを設定します(announcer.go を参照してください)。
+package a11y
+
+import (
+	"fmt"
+	"furoshiki/component"
+	"image"
+	"strings"
+)
+
+// States は、支援技術に伝えるウィジェットの状態です。
+type States struct {
+	Disabled bool `json:"disabled,omitempty"`
+	Focused  bool `json:"focused,omitempty"`
+	Hovered  bool `json:"hovered,omitempty"`
+	Pressed  bool `json:"pressed,omitempty"`
+	Selected bool `json:"selected,omitempty"`
+}
+
+// Node は、意味情報のツリーの1つのノードです。
+type Node struct {
+	Role  component.Role `json:"role"`
+	Label string         `json:"label,omitempty"`
+	Hint  string         `json:"hint,omitempty"`
+	// Value は、入力欄の値など、ラベルとは別に読み上げる値です。
+	Value string `json:"value,omitempty"`
+	// ID は、ウィジェットに設定されたIDです。自動検査でノードを特定するために使用します。
+	ID string `json:"id,omitempty"`
+	// Bounds は、画面上でのウィジェットの境界です。
+	// NOTE: Container.SetTransformで変換されたサブツリーでは、変換前のレイアウト上の境界になります。
+	Bounds   image.Rectangle `json:"bounds"`
+	States   States          `json:"states"`
+	Children []*Node         `json:"children,omitempty"`
+	// Widget は、このノードの元になったウィジェットです。
+	Widget component.Widget `json:"-"`
+}
+
+// Export は、rootを起点に、表示中のウィジェットの意味情報のツリーを返します。
+// 非表示のウィジェットとその子孫は含まれません。役割もラベルもないウィジェット(レイアウトのためのコンテナなど)は省かれ、
+// その子要素が親のノードに直接つながります。rootは役割がなくても常にツリーの根になります。
+// rootがnilまたは非表示の場合はnilを返します。
+func Export(root component.Widget) *Node {
+	if root == nil || !isVisible(root) {
+		return nil
+	}
+	n := newNode(root)
+	n.Children = exportChildren(root, nil)
+	return n
+}
+
+// exportChildren は、wの子要素のノードをdstに追加して返します。
+func exportChildren(w component.Widget, dst []*Node) []*Node {
+	c, ok := w.(component.Container)
+	if !ok {
+		return dst
+	}
+	for _, child := range c.GetChildren() {
+		if child == nil || !isVisible(child) {
+			continue
+		}
+		if !hasSemantics(child) {
+			dst = exportChildren(child, dst)
+			continue
+		}
+		n := newNode(child)
+		n.Children = exportChildren(child, nil)
+		dst = append(dst, n)
+	}
+	return dst
+}
+
+// newNode は、子要素を除いたwのノードを生成します。
+func newNode(w component.Widget) *Node {
+	n := &Node{Widget: w, Bounds: bounds(w)}
+	if a, ok := w.(component.Accessible); ok {
+		n.Role = a.AccessibleRole()
+		n.Label = a.AccessibleLabel()
+		n.Hint = a.AccessibleHint()
+	}
+	if v, ok := w.(component.AccessibleValuer); ok {
+		n.Value = v.AccessibleValue()
+	}
+	if id, ok := w.(component.Identifiable); ok {
+		n.ID = id.GetID()
+	}
+	if is, ok := w.(component.InteractiveState); ok {
+		n.States.Disabled = is.IsDisabled()
+		n.States.Hovered = is.IsHovered()
+		n.States.Pressed = is.IsPressed()
+	}
+	if f, ok := w.(component.Focusable); ok {
+		n.States.Focused = f.IsFocused()
+	}
+	if s, ok := w.(interface{ IsSelected() bool }); ok {
+		n.States.Selected = s.IsSelected()
+	}
+	return n
+}
+
+// hasSemantics は、wが意味情報のツリーにノードとして現れるかどうかを返します。
+func hasSemantics(w component.Widget) bool {
+	a, ok := w.(component.Accessible)
+	return ok && (a.AccessibleRole() != component.RoleNone || a.AccessibleLabel() != "")
+}
+
+// isVisible は、ウィジェットが表示されているかどうかを返します。
+func isVisible(w component.Widget) bool {
+	if is, ok := w.(component.InteractiveState); ok {
+		return is.IsVisible()
+	}
+	return true
+}
+
+// bounds は、ウィジェットの絶対座標での境界矩形を返します。
+func bounds(w component.Widget) image.Rectangle {
+	var x, y, width, height int
+	if ps, ok := w.(component.PositionSetter); ok {
+		x, y = ps.GetPosition()
+	}
+	if ss, ok := w.(component.SizeSetter); ok {
+		width, height = ss.GetSize()
+	}
+	return image.Rect(x, y, x+width, y+height)
+}
+
+// Walk は、nを起点にノードを深さ優先(親が先)で走査し、各ノードに対してvisitを呼び出します。
+// visitがfalseを返すと、そのノードの子孫は走査しません。
+func (n *Node) Walk(visit func(n *Node, depth int) bool) {
+	if n == nil || visit == nil {
+		return
+	}
+	n.walk(0, visit)
+}
+
+func (n *Node) walk(depth int, visit func(n *Node, depth int) bool) {
+	if !visit(n, depth) {
+		return
+	}
+	for _, c := range n.Children {
+		c.walk(depth+1, visit)
+	}
+}
+
+// String は、ツリーを1行1ノードのインデント付きのテキストで返します。
+// 例: `  button "OK" hint="閉じます" [focused] (10,10 80x24)`
+func (n *Node) String() string {
+	var sb strings.Builder
+	n.Walk(func(n *Node, depth int) bool {
+		sb.WriteString(strings.Repeat("  ", depth))
+		role := string(n.Role)
+		if role == "" {
+			role = "none"
+		}
+		sb.WriteString(role)
+		if n.Label != "" {
+			fmt.Fprintf(&sb, " %q", n.Label)
+		}
+		if n.Value != "" {
+			fmt.Fprintf(&sb, " value=%q", n.Value)
+		}
+		if n.Hint != "" {
+			fmt.Fprintf(&sb, " hint=%q", n.Hint)
+		}
+		if n.ID != "" {
+			fmt.Fprintf(&sb, " #%s", n.ID)
+		}
+		if states := n.States.names(); len(states) > 0 {
+			fmt.Fprintf(&sb, " [%s]", strings.Join(states, " "))
+		}
+		b := n.Bounds
+		fmt.Fprintf(&sb, " (%d,%d %dx%d)\n", b.Min.X, b.Min.Y, b.Dx(), b.Dy())
+		return true
+	})
+	return sb.String()
+}
+
+// names は、有効な状態の名前を返します。
+func (s States) names() []string {
+	var names []string
+	if s.Disabled {
+		names = append(names, "disabled")
+	}
+	if s.Focused {
+		names = append(names, "focused")
+	}
+	if s.Hovered {
+		names = append(names, "hovered")
+	}
+	if s.Pressed {
+		names = append(names, "pressed")
+	}
+	if s.Selected {
+		names = append(names, "selected")
+	}
+	return names
+}
@@ -0,0 +1,192 @@
+package binding
+
+import "furoshiki/utils"
+
+// ChangeKind は、監視可能なリストに加えられた変更の種類を表します。
+type ChangeKind int
+
+const (
+	// ChangeInsert は、Index の位置に Count 個の要素が挿入されたことを示します。
+	ChangeInsert ChangeKind = iota
+	// ChangeRemove は、Index の位置から Count 個の要素が削除されたことを示します。
+	ChangeRemove
+	// ChangeMove は、OldIndex の要素が Index の位置へ移動したことを示します。
+	ChangeMove
+	// ChangeUpdate は、Index の位置の要素が別の値に置き換えられたことを示します。
+	ChangeUpdate
+	// ChangeReset は、リストの内容全体が置き換えられたことを示します。
+	ChangeReset
+)
+
+// Change は、リストに加えられた一回分の変更内容を保持します。
+// 購読者はこの情報を使って、UIを差分更新できます。
+type Change struct {
+	Kind     ChangeKind
+	Index    int
+	OldIndex int // ChangeMove の場合のみ有効です。
+	Count    int // ChangeInsert, ChangeRemove の場合のみ有効です。
+}
+
+// Listener は、リストの変更通知を受け取る関数です。
+type Listener func(c Change)
+
+// Observable は、要素数と変更通知を提供する監視可能なリストの振る舞いを定義します。
+// 要素の型に依存しないため、container.Containerのような非ジェネリックなコードから
+// List[T] を扱うために使用されます。
+type Observable interface {
+	Len() int
+	// Subscribe はリスナーを登録し、登録を解除するための関数を返します。
+	Subscribe(l Listener) (unsubscribe func())
+}
+
+// List は、要素の追加・削除・移動を購読者に通知する監視可能なリストです。
+// ContainerBuilder.BindChildren と組み合わせることで、データの変更に合わせて
+// コンテナの子ウィジェットを差分更新できます。
+// NOTE: UIツリーと同様に、List はUpdateループと同じゴルーチンから操作することを前提としています。
+type List[T any] struct {
+	items     []T
+	listeners listenerSet
+}
+
+// listenerSet は、登録されたリスナーの集合です。監視可能なリストの実装で共有します。
+type listenerSet struct {
+	entries []listenerEntry
+	nextID  int
+}
+
+// listenerEntry は、登録解除のためにリスナーと識別子を組にして保持します。
+type listenerEntry struct {
+	id int
+	fn Listener
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ Observable = (*List[int])(nil)
+
+// NewList は、指定された初期要素を持つ新しいListを生成します。
+func NewList[T any](items ...T) *List[T] {
+	l := &List[T]{}
+	l.items = append(l.items, items...)
+	return l
+}
+
+// Len はリストの要素数を返します。
+func (l *List[T]) Len() int {
+	return len(l.items)
+}
+
+// At は指定されたインデックスの要素を返します。
+func (l *List[T]) At(index int) T {
+	return l.items[index]
+}
+
+// Items はリストの要素のコピーを返します。
+func (l *List[T]) Items() []T {
+	items := make([]T, len(l.items))
+	copy(items, l.items)
+	return items
+}
+
+// Append はリストの末尾に要素を追加します。
+func (l *List[T]) Append(items ...T) {
+	l.Insert(len(l.items), items...)
+}
+
+// Insert は指定されたインデックスの位置に要素を挿入します。
+// インデックスは [0, Len()] の範囲に収められます。
+func (l *List[T]) Insert(index int, items ...T) {
+	if len(items) == 0 {
+		return
+	}
+	index = utils.Clamp(index, 0, len(l.items))
+	l.items = append(l.items[:index], append(append([]T(nil), items...), l.items[index:]...)...)
+	l.notify(Change{Kind: ChangeInsert, Index: index, Count: len(items)})
+}
+
+// RemoveAt は指定されたインデックスの要素を削除します。
+// 範囲外のインデックスが指定された場合は何もしません。
+func (l *List[T]) RemoveAt(index int) {
+	l.RemoveRange(index, 1)
+}
+
+// RemoveRange は指定されたインデックスから count 個の要素を削除します。
+func (l *List[T]) RemoveRange(index, count int) {
+	if index < 0 || index >= len(l.items) || count <= 0 {
+		return
+	}
+	count = min(count, len(l.items)-index)
+	l.items = append(l.items[:index], l.items[index+count:]...)
+	l.notify(Change{Kind: ChangeRemove, Index: index, Count: count})
+}
+
+// Move は from の位置の要素を to の位置へ移動します。
+func (l *List[T]) Move(from, to int) {
+	if from < 0 || from >= len(l.items) || from == to {
+		return
+	}
+	to = utils.Clamp(to, 0, len(l.items)-1)
+	if from == to {
+		return
+	}
+	item := l.items[from]
+	l.items = append(l.items[:from], l.items[from+1:]...)
+	l.items = append(l.items[:to], append([]T{item}, l.items[to:]...)...)
+	l.notify(Change{Kind: ChangeMove, Index: to, OldIndex: from})
+}
+
+// Set は指定されたインデックスの要素を置き換えます。
+func (l *List[T]) Set(index int, item T) {
+	if index < 0 || index >= len(l.items) {
+		return
+	}
+	l.items[index] = item
+	l.notify(Change{Kind: ChangeUpdate, Index: index})
+}
+
+// Replace はリストの内容全体を置き換えます。
+func (l *List[T]) Replace(items []T) {
+	l.items = append(l.items[:0:0], items...)
+	l.notify(Change{Kind: ChangeReset})
+}
+
+// Clear はリストのすべての要素を削除します。
+func (l *List[T]) Clear() {
+	l.Replace(nil)
+}
+
+// Subscribe はリスナーを登録し、登録を解除するための関数を返します。
+func (l *List[T]) Subscribe(fn Listener) (unsubscribe func()) {
+	return l.listeners.subscribe(fn)
+}
+
+// notify は登録されているすべてのリスナーに変更を通知します。
+func (l *List[T]) notify(c Change) {
+	l.listeners.notify(c)
+}
+
+// subscribe はリスナーを登録し、登録を解除するための関数を返します。
+func (s *listenerSet) subscribe(fn Listener) (unsubscribe func()) {
+	if fn == nil {
+		return func() {}
+	}
+	id := s.nextID
+	s.nextID++
+	s.entries = append(s.entries, listenerEntry{id: id, fn: fn})
+	return func() {
+		for i, entry := range s.entries {
+			if entry.id == id {
+				s.entries = append(s.entries[:i], s.entries[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// notify は登録されているすべてのリスナーに変更を通知します。
+func (s *listenerSet) notify(c Change) {
+	// リスナー内で購読解除が行われてもループが壊れないよう、スナップショットに対して反復します。
+	entries := append([]listenerEntry(nil), s.entries...)
+	for _, entry := range entries {
+		entry.fn(c)
+	}
+}
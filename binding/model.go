@@ -0,0 +1,102 @@
+package binding
+
+// ListModel は、ウィジェット(widget.List、widget.GridViewなど)に表示するデータの並びを提供するインターフェースです。
+// 要素数(Len)と要素(ItemAt)に加えて変更通知(Subscribe)を提供するため、ウィジェットはデータの変更を
+// 子ウィジェットの作り直しではなく、変更のあった要素だけの差分更新として反映できます。
+//
+// List[T] はこのインターフェースを実装しています。独自のデータ構造を表示する場合は、
+// Len、ItemAt、Subscribeを実装し、データを変更するたびに購読者へChangeを通知してください。
+type ListModel interface {
+	Observable
+	// ItemAt は、指定されたインデックスの要素を返します。
+	ItemAt(index int) any
+}
+
+// MovableListModel は、要素の移動を受け付けるListModelです。
+// 並べ替えが可能なウィジェット(widget.Listなど)は、ドラッグによる並べ替えをこのMoveを通じてモデルに反映します。
+type MovableListModel interface {
+	ListModel
+	// Move は、from番目の要素をto番目へ移動し、購読者にChangeMoveを通知します。
+	Move(from, to int)
+}
+
+// TableModel は、行と列からなる表形式のデータを提供するインターフェースです。
+// 行の並びと変更通知はListModelとして提供され、ItemAtは行の要素そのものを返します。
+type TableModel interface {
+	ListModel
+	// ColumnCount は、列の数を返します。
+	ColumnCount() int
+	// ColumnTitle は、指定された列の見出しを返します。
+	ColumnTitle(column int) string
+	// CellAt は、指定された行と列のセルの値を返します。
+	CellAt(row, column int) any
+}
+
+// Column は、ListTableの1つの列の定義です。Valueは、行の要素からセルの値を取り出します。
+type Column[T any] struct {
+	Title string
+	Value func(item T) any
+}
+
+// ListTable は、List[T] の各要素を1行とし、列の定義に従ってセルの値を取り出すTableModelです。
+// 行の追加や削除はListに対して行い、その変更はそのままListTableの購読者に通知されます。
+type ListTable[T any] struct {
+	rows    *List[T]
+	columns []Column[T]
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ ListModel = (*List[int])(nil)
+var _ MovableListModel = (*List[int])(nil)
+var _ TableModel = (*ListTable[int])(nil)
+
+// NewListTable は、rowsの要素を行とし、columnsを列とする新しいListTableを生成します。
+func NewListTable[T any](rows *List[T], columns ...Column[T]) *ListTable[T] {
+	return &ListTable[T]{rows: rows, columns: columns}
+}
+
+// ItemAt は、ListModelインターフェースの実装です。
+func (l *List[T]) ItemAt(index int) any {
+	return l.At(index)
+}
+
+// Rows は、行の要素を保持するListを返します。
+func (t *ListTable[T]) Rows() *List[T] {
+	return t.rows
+}
+
+// Len は、行の数を返します。
+func (t *ListTable[T]) Len() int {
+	return t.rows.Len()
+}
+
+// ItemAt は、指定された行の要素を返します。
+func (t *ListTable[T]) ItemAt(index int) any {
+	return t.rows.At(index)
+}
+
+// Subscribe は、行の変更を通知するリスナーを登録し、登録を解除するための関数を返します。
+func (t *ListTable[T]) Subscribe(fn Listener) (unsubscribe func()) {
+	return t.rows.Subscribe(fn)
+}
+
+// ColumnCount は、列の数を返します。
+func (t *ListTable[T]) ColumnCount() int {
+	return len(t.columns)
+}
+
+// ColumnTitle は、指定された列の見出しを返します。範囲外の場合は空文字列です。
+func (t *ListTable[T]) ColumnTitle(column int) string {
+	if column < 0 || column >= len(t.columns) {
+		return ""
+	}
+	return t.columns[column].Title
+}
+
+// CellAt は、指定された行と列のセルの値を返します。列が範囲外か、値を取り出す関数がない場合はnilです。
+func (t *ListTable[T]) CellAt(row, column int) any {
+	if column < 0 || column >= len(t.columns) || t.columns[column].Value == nil {
+		return nil
+	}
+	return t.columns[column].Value(t.rows.At(row))
+}
@@ -0,0 +1,387 @@
+package binding
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// sortFilterMaxMoves は、並び順の変更を個別の移動として通知する上限です。
+// これを超える移動が必要な場合は、ChangeResetとして通知します。
+const sortFilterMaxMoves = 256
+
+// SortFilterModel は、元のモデルの要素を絞り込み、並べ替えて見せるモデルです。
+// 元のモデルを変更せずに、検索による絞り込みや列の見出しによる並べ替えを実装するために使用します。
+//
+//	view := binding.NewSortFilterModel(contacts)
+//	list.SetModel(view, nil)
+//	view.SetFilterText("yamada") // 一致しない行だけが取り除かれます
+//
+// 絞り込みや並び順を変更したときと、元のモデルが変更されたときは、変化した要素だけが
+// 挿入・削除・移動として購読者に通知されるため、ウィジェットは選択などの状態を保ったまま差分更新されます。
+// 表示上のインデックスと元のモデルのインデックスは、SourceIndexとViewIndexで相互に変換できます。
+//
+// 元のモデルがTableModelの場合は、SortFilterModelもTableModelとして列とセルを提供し、
+// SortByColumnで列の値による並べ替えができます。
+//
+// NOTE: SortFilterModelは生成時から元のモデルを購読します。不要になったらCloseを呼び出してください。
+type SortFilterModel struct {
+	source      ListModel
+	table       TableModel
+	unsubscribe func()
+	// indices は、表示上の各要素に対応する元のモデルのインデックスです。
+	indices []int
+
+	filter     func(item any) bool
+	filterText string
+	less       func(a, b any) bool
+	// sortColumn は、並べ替えに使用する列です。列で並べ替えない場合は-1です。
+	sortColumn int
+	ascending  bool
+
+	listeners listenerSet
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ TableModel = (*SortFilterModel)(nil)
+
+// NewSortFilterModel は、sourceのすべての要素を元の順に見せる新しいSortFilterModelを生成します。
+func NewSortFilterModel(source ListModel) *SortFilterModel {
+	m := &SortFilterModel{source: source, sortColumn: -1, ascending: true}
+	m.table, _ = source.(TableModel)
+	m.indices = m.compute()
+	m.unsubscribe = source.Subscribe(m.handleSourceChange)
+	return m
+}
+
+// Source は、元のモデルを返します。
+func (m *SortFilterModel) Source() ListModel {
+	return m.source
+}
+
+// Close は、元のモデルの購読を解除します。以後、元のモデルの変更は反映されません。
+func (m *SortFilterModel) Close() {
+	if m.unsubscribe != nil {
+		m.unsubscribe()
+		m.unsubscribe = nil
+	}
+}
+
+// SetFilter は、表示する要素を選ぶ関数を設定します。nilを指定すると、この関数による絞り込みを解除します。
+func (m *SortFilterModel) SetFilter(filter func(item any) bool) {
+	m.filter = filter
+	m.refresh()
+}
+
+// SetFilterText は、文字列を含む要素だけを表示するように絞り込みます。大文字と小文字は区別しません。
+// 要素はfmt.Sprintで文字列にして比較します。元のモデルがTableModelの場合は、いずれかのセルに含まれていれば一致とみなします。
+// 空文字列を指定すると、文字列による絞り込みを解除します。SetFilterの関数と同時に指定した場合は、両方を満たす要素を表示します。
+func (m *SortFilterModel) SetFilterText(text string) {
+	text = strings.ToLower(text)
+	if m.filterText == text {
+		return
+	}
+	m.filterText = text
+	m.refresh()
+}
+
+// FilterText は、絞り込みに使用している文字列を返します。
+func (m *SortFilterModel) FilterText() string {
+	return m.filterText
+}
+
+// SetLess は、要素aを要素bより前に並べる場合にtrueを返す関数で並べ替えます。列による並べ替えは解除されます。
+// nilを指定すると、元のモデルの順に戻します。並べ替えは安定で、等しい要素は元のモデルの順に並びます。
+func (m *SortFilterModel) SetLess(less func(a, b any) bool) {
+	m.less = less
+	m.sortColumn = -1
+	m.refresh()
+}
+
+// SortByColumn は、元のモデル(TableModel)の列の値で並べ替えます。-1を指定すると、元のモデルの順に戻します。
+// 数値は数値として、それ以外は文字列として比較します。SetLessによる並べ替えは解除されます。
+func (m *SortFilterModel) SortByColumn(column int, ascending bool) {
+	if column < 0 {
+		column = -1
+	}
+	m.less = nil
+	m.sortColumn, m.ascending = column, ascending
+	m.refresh()
+}
+
+// SortColumn は、並べ替えに使用している列と、昇順かどうかを返します。列で並べ替えていない場合、列は-1です。
+func (m *SortFilterModel) SortColumn() (column int, ascending bool) {
+	return m.sortColumn, m.ascending
+}
+
+// SourceIndex は、表示上のインデックスに対応する元のモデルのインデックスを返します。範囲外の場合は-1です。
+func (m *SortFilterModel) SourceIndex(index int) int {
+	if index < 0 || index >= len(m.indices) {
+		return -1
+	}
+	return m.indices[index]
+}
+
+// ViewIndex は、元のモデルのインデックスに対応する表示上のインデックスを返します。表示されていない場合は-1です。
+func (m *SortFilterModel) ViewIndex(sourceIndex int) int {
+	return slices.Index(m.indices, sourceIndex)
+}
+
+// Len は、表示する要素の数を返します。
+func (m *SortFilterModel) Len() int {
+	return len(m.indices)
+}
+
+// ItemAt は、表示上のindex番目の要素を返します。
+func (m *SortFilterModel) ItemAt(index int) any {
+	return m.source.ItemAt(m.indices[index])
+}
+
+// Subscribe は、表示する要素の変更を通知するリスナーを登録し、登録を解除するための関数を返します。
+func (m *SortFilterModel) Subscribe(fn Listener) (unsubscribe func()) {
+	return m.listeners.subscribe(fn)
+}
+
+// ColumnCount は、元のモデルの列の数を返します。元のモデルがTableModelでない場合は0です。
+func (m *SortFilterModel) ColumnCount() int {
+	if m.table == nil {
+		return 0
+	}
+	return m.table.ColumnCount()
+}
+
+// ColumnTitle は、元のモデルの列の見出しを返します。元のモデルがTableModelでない場合は空文字列です。
+func (m *SortFilterModel) ColumnTitle(column int) string {
+	if m.table == nil {
+		return ""
+	}
+	return m.table.ColumnTitle(column)
+}
+
+// CellAt は、表示上のrow番目の行のセルの値を返します。元のモデルがTableModelでない場合はnilです。
+func (m *SortFilterModel) CellAt(row, column int) any {
+	if m.table == nil {
+		return nil
+	}
+	return m.table.CellAt(m.indices[row], column)
+}
+
+// accepts は、元のモデルのindex番目の要素を表示するかどうかを返します。
+func (m *SortFilterModel) accepts(index int) bool {
+	if m.filter != nil && !m.filter(m.source.ItemAt(index)) {
+		return false
+	}
+	if m.filterText == "" {
+		return true
+	}
+	if m.table == nil {
+		return containsFold(m.source.ItemAt(index), m.filterText)
+	}
+	for column := range m.table.ColumnCount() {
+		if containsFold(m.table.CellAt(index, column), m.filterText) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold は、値を文字列にしたものが小文字のtextを含むかどうかを、大文字と小文字を区別せずに返します。
+func containsFold(value any, text string) bool {
+	return strings.Contains(strings.ToLower(fmt.Sprint(value)), text)
+}
+
+// compare は、元のモデルのインデックスaとbの要素の順序を返します。並べ替えない場合はnilです。
+func (m *SortFilterModel) compare() func(a, b int) int {
+	switch {
+	case m.less != nil:
+		return func(a, b int) int {
+			itemA, itemB := m.source.ItemAt(a), m.source.ItemAt(b)
+			switch {
+			case m.less(itemA, itemB):
+				return -1
+			case m.less(itemB, itemA):
+				return 1
+			}
+			return 0
+		}
+	case m.sortColumn >= 0 && m.table != nil && m.sortColumn < m.table.ColumnCount():
+		return func(a, b int) int {
+			c := compareValues(m.table.CellAt(a, m.sortColumn), m.table.CellAt(b, m.sortColumn))
+			if !m.ascending {
+				c = -c
+			}
+			return c
+		}
+	}
+	return nil
+}
+
+// compareValues は、セルの値を比較します。数値同士は数値として、それ以外は文字列として比較し、nilは最小とみなします。
+func compareValues(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			return cmp.Compare(x, y)
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// toFloat は、数値型の値をfloat64に変換します。数値でない場合はfalseを返します。
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// compute は、絞り込みと並べ替えを適用した、表示する要素の元のモデルのインデックスを返します。
+func (m *SortFilterModel) compute() []int {
+	var indices []int
+	for i := range m.source.Len() {
+		if m.accepts(i) {
+			indices = append(indices, i)
+		}
+	}
+	if c := m.compare(); c != nil {
+		slices.SortStableFunc(indices, c)
+	}
+	return indices
+}
+
+// refresh は、絞り込みと並べ替えを適用し直し、変化を購読者に通知します。
+func (m *SortFilterModel) refresh() {
+	m.apply(m.compute())
+}
+
+// apply は、表示する要素をnextに置き換え、その差分を削除・挿入・移動として購読者に通知します。
+// 購読者が通知の中でItemAtなどを呼び出せるよう、通知のたびにindicesをその時点の状態に更新します。
+func (m *SortFilterModel) apply(next []int) {
+	keep := make(map[int]bool, len(next))
+	for _, s := range next {
+		keep[s] = true
+	}
+	// nextにない要素を、連続する範囲ごとに後ろから削除します。
+	for i := len(m.indices) - 1; i >= 0; i-- {
+		if keep[m.indices[i]] {
+			continue
+		}
+		start := i
+		for start > 0 && !keep[m.indices[start-1]] {
+			start--
+		}
+		m.indices = slices.Delete(m.indices, start, i+1)
+		m.listeners.notify(Change{Kind: ChangeRemove, Index: start, Count: i + 1 - start})
+		i = start
+	}
+
+	present := make(map[int]bool, len(m.indices))
+	for _, s := range m.indices {
+		present[s] = true
+	}
+	// 先頭から、nextの順になるよう新しい要素を挿入し、既存の要素を移動します。
+	moves := 0
+	for i := 0; i < len(next); {
+		if i < len(m.indices) && m.indices[i] == next[i] {
+			i++
+			continue
+		}
+		if !present[next[i]] {
+			end := i + 1
+			for end < len(next) && !present[next[end]] {
+				end++
+			}
+			m.indices = slices.Insert(m.indices, i, next[i:end]...)
+			m.listeners.notify(Change{Kind: ChangeInsert, Index: i, Count: end - i})
+			i = end
+			continue
+		}
+		if moves++; moves > sortFilterMaxMoves {
+			m.indices = next
+			m.listeners.notify(Change{Kind: ChangeReset})
+			return
+		}
+		from := i + slices.Index(m.indices[i:], next[i])
+		m.indices = slices.Insert(slices.Delete(m.indices, from, from+1), i, next[i])
+		m.listeners.notify(Change{Kind: ChangeMove, Index: i, OldIndex: from})
+		i++
+	}
+}
+
+// handleSourceChange は、元のモデルの変更を表示に反映します。
+// 既存の要素の元のインデックスを変更後の位置に読み替えてから、絞り込みと並べ替えを適用し直します。
+func (m *SortFilterModel) handleSourceChange(change Change) {
+	wasVisible := false
+	for i, s := range m.indices {
+		switch change.Kind {
+		case ChangeInsert:
+			if s >= change.Index {
+				m.indices[i] = s + change.Count
+			}
+		case ChangeRemove:
+			switch {
+			case s >= change.Index+change.Count:
+				m.indices[i] = s - change.Count
+			case s >= change.Index:
+				// 削除された要素は、applyで取り除かれるよう範囲外のインデックスにします。
+				m.indices[i] = -1
+			}
+		case ChangeMove:
+			switch {
+			case s == change.OldIndex:
+				m.indices[i] = change.Index
+			case change.OldIndex < s && s <= change.Index:
+				m.indices[i] = s - 1
+			case change.Index <= s && s < change.OldIndex:
+				m.indices[i] = s + 1
+			}
+		case ChangeUpdate:
+			if s == change.Index {
+				wasVisible = true
+			}
+		}
+	}
+	if change.Kind == ChangeReset {
+		m.indices = m.compute()
+		m.listeners.notify(Change{Kind: ChangeReset})
+		return
+	}
+	m.refresh()
+	// 置き換えられた要素が引き続き表示される場合は、その内容の変化を通知します。
+	if wasVisible {
+		if index := m.ViewIndex(change.Index); index >= 0 {
+			m.listeners.notify(Change{Kind: ChangeUpdate, Index: index})
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Package clock は、ライブラリが参照する現在時刻と、決定的モードを提供します。
+// イベントのタイムスタンプや編集履歴のまとめなど、時刻に依存する処理は time.Now の代わりにこのパッケージの Now を使用します。
+//
+// 決定的モードでは、Now は実際の時刻ではなく、固定の起点から NextFrame ごとに FrameInterval だけ進む仮想の時刻を返します。
+// また、ウィジェットはアニメーション(キャレットの点滅、並べ替えの移動、マーキーのスクロール、読み込み中のスピナーなど)を行わず、最終的な状態を直ちに表示します。
+// これにより、ゴールデンテストや記録したイベントの再生が、実行のたびに、またプラットフォームによらず同じツリーとピクセルを生成します。
+//
+// パフォーマンスカウンタ(stats)の所要時間は計測のための値であるため、決定的モードでも実際の時刻で計測されます。
+package clock
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// FrameInterval は、決定的モードで NextFrame が仮想の時刻を進める間隔です。60TPSの1フレームに相当します。
+const FrameInterval = time.Second / 60
+
+// Epoch は、決定的モードの仮想の時刻の起点です。
+var Epoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var (
+	deterministic atomic.Bool
+	// elapsed は、決定的モードで、Epochから進めた仮想の時間(ナノ秒)です。
+	elapsed atomic.Int64
+)
+
+// SetDeterministic は、決定的モードの有効・無効を切り替えます。
+// 有効にすると、仮想の時刻は Epoch に戻ります。
+func SetDeterministic(on bool) {
+	elapsed.Store(0)
+	deterministic.Store(on)
+}
+
+// IsDeterministic は、決定的モードが有効かどうかを返します。
+// アニメーションを行うウィジェットは、有効な間はアニメーションを省略して最終的な状態を表示します。
+func IsDeterministic() bool {
+	return deterministic.Load()
+}
+
+// Now は、現在時刻を返します。決定的モードでは、仮想の時刻を返します。
+func Now() time.Time {
+	if deterministic.Load() {
+		return Epoch.Add(time.Duration(elapsed.Load()))
+	}
+	return time.Now()
+}
+
+// Since は、tからの経過時間を返します。決定的モードでは、仮想の時刻からの経過時間を返します。
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
+
+// Advance は、決定的モードの仮想の時刻をdだけ進めます。
+// 記録したイベントを再生する際に、イベントの間隔を再現するために使用します。決定的モードでない場合は何もしません。
+func Advance(d time.Duration) {
+	if deterministic.Load() && d > 0 {
+		elapsed.Add(int64(d))
+	}
+}
+
+// NextFrame は、決定的モードの仮想の時刻を FrameInterval だけ進めます。
+// furoshiki.NextFrame から毎フレーム呼び出されます。
+func NextFrame() {
+	Advance(FrameInterval)
+}
@@ -0,0 +1,100 @@
+package component
+
+// このファイルは、スクリーンリーダーとの連携やアクセシビリティの自動検査のために、
+// ウィジェットに役割(Role)、ラベル、ヒントなどの意味情報を持たせる仕組みを提供します。
+// ツリー全体の意味情報は、a11yパッケージのExportで取り出せます。
+// フォーカスの移動などの出来事の通知は、accessibility_events.go を参照してください。
+
+// Role は、支援技術に伝えるウィジェットの役割です。
+type Role string
+
+const (
+	// RoleNone は、役割を持たないことを示します。ラベルもないウィジェットは、意味情報のツリーから省かれ、子要素だけが残ります。
+	RoleNone         Role = ""
+	RoleGroup        Role = "group"
+	RoleDialog       Role = "dialog"
+	RoleHeading      Role = "heading"
+	RoleText         Role = "text"
+	RoleButton       Role = "button"
+	RoleToggleButton Role = "togglebutton"
+	RoleCheckbox     Role = "checkbox"
+	RoleTextField    Role = "textfield"
+	RoleImage        Role = "image"
+	RoleList         Role = "list"
+	RoleListItem     Role = "listitem"
+	RoleGrid         Role = "grid"
+	RoleScrollArea   Role = "scrollarea"
+)
+
+// DefaultRoleProvider は、利用者が役割を設定しなかった場合に使用する、ウィジェットの種類ごとの役割を提供するインターフェースです。
+type DefaultRoleProvider interface {
+	DefaultAccessibleRole() Role
+}
+
+// DefaultLabelProvider は、利用者がラベルを設定しなかった場合に使用する、ウィジェットの内容から得たラベルを提供するインターフェースです。
+// 例えば、Labelは表示中のテキストを、TextInputはプレースホルダーをラベルとして提供します。
+type DefaultLabelProvider interface {
+	DefaultAccessibleLabel() string
+}
+
+// AccessibleValuer は、入力欄の値など、ラベルとは別に読み上げる値を持つウィジェットのインターフェースです。
+type AccessibleValuer interface {
+	AccessibleValue() string
+}
+
+// accessibility は、ウィジェットに設定されたアクセシビリティの情報です。
+type accessibility struct {
+	label string
+	role  Role
+	hint  string
+	// live は、ラベルが変わったときに支援技術へ通知する方法です。
+	live LiveRegion
+	// announced は、ライブリージョンとして最後に通知したラベルです。同じラベルを繰り返し通知しないために使用します。
+	announced string
+}
+
+// SetAccessibleLabel は、支援技術に伝えるウィジェットの名前を設定します。
+// アイコンだけのボタンなど、表示内容から名前が分からないウィジェットに設定してください。
+func (w *LayoutableWidget) SetAccessibleLabel(label string) {
+	w.a11y.label = label
+	w.NotifyLabelChanged()
+}
+
+// AccessibleLabel は、支援技術に伝えるウィジェットの名前を返します。
+// 設定されていない場合は、ウィジェットの内容から得たラベル(DefaultLabelProvider)を返します。
+func (w *LayoutableWidget) AccessibleLabel() string {
+	if w.a11y.label != "" {
+		return w.a11y.label
+	}
+	if p, ok := w.self.(DefaultLabelProvider); ok {
+		return p.DefaultAccessibleLabel()
+	}
+	return ""
+}
+
+// SetAccessibleRole は、支援技術に伝えるウィジェットの役割を設定します。RoleNoneを指定すると、種類ごとの既定の役割に戻ります。
+func (w *LayoutableWidget) SetAccessibleRole(role Role) {
+	w.a11y.role = role
+}
+
+// AccessibleRole は、支援技術に伝えるウィジェットの役割を返します。
+// 設定されていない場合は、ウィジェットの種類ごとの既定の役割(DefaultRoleProvider)を返します。
+func (w *LayoutableWidget) AccessibleRole() Role {
+	if w.a11y.role != RoleNone {
+		return w.a11y.role
+	}
+	if p, ok := w.self.(DefaultRoleProvider); ok {
+		return p.DefaultAccessibleRole()
+	}
+	return RoleNone
+}
+
+// SetAccessibleHint は、操作の結果など、名前を補足する説明を設定します(例: "設定画面を開きます")。
+func (w *LayoutableWidget) SetAccessibleHint(hint string) {
+	w.a11y.hint = hint
+}
+
+// AccessibleHint は、名前を補足する説明を返します。
+func (w *LayoutableWidget) AccessibleHint() string {
+	return w.a11y.hint
+}
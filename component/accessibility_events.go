@@ -0,0 +1,102 @@
+package component
+
+// このファイルは、読み上げなどの支援技術に伝えるべき出来事(フォーカスの移動、ダイアログの表示、ライブリージョンの更新)を
+// 通知する仕組みを提供します。通知を受け取って読み上げる文章を組み立てるのは、a11yパッケージのAnnouncerです。
+// NOTE: UIツリーの更新はメインループから単一のゴルーチンで行われる前提のため、同期処理は行いません。
+
+// AccessibilityEventKind は、支援技術に伝える出来事の種類です。
+type AccessibilityEventKind int
+
+const (
+	// AccessibilityFocusChanged は、フォーカスが別のウィジェットに移ったことを示します。
+	AccessibilityFocusChanged AccessibilityEventKind = iota
+	// AccessibilityDialogOpened は、役割がRoleDialogのウィジェットが表示されたことを示します。
+	AccessibilityDialogOpened
+	// AccessibilityLiveRegionChanged は、ライブリージョンに設定されたウィジェットのラベルが変わったことを示します。
+	AccessibilityLiveRegionChanged
+)
+
+// String は、出来事の種類の名前を返します。
+func (k AccessibilityEventKind) String() string {
+	switch k {
+	case AccessibilityFocusChanged:
+		return "FocusChanged"
+	case AccessibilityDialogOpened:
+		return "DialogOpened"
+	case AccessibilityLiveRegionChanged:
+		return "LiveRegionChanged"
+	default:
+		return "Unknown"
+	}
+}
+
+// LiveRegion は、ラベルが変わったときに支援技術へ通知する方法です。
+// スコアや残り時間、ステータスメッセージなど、フォーカスを移さずに変化を伝えたい表示に設定します。
+type LiveRegion int
+
+const (
+	// LiveOff は、ラベルの変化を通知しません。既定値です。
+	LiveOff LiveRegion = iota
+	// LivePolite は、読み上げ中の内容を遮らずに、区切りのよいところで変化を伝えます。
+	LivePolite
+	// LiveAssertive は、読み上げ中の内容を中断してでも、ただちに変化を伝えます。警告などに使用します。
+	LiveAssertive
+)
+
+// AccessibilityObserver は、支援技術に伝える出来事が起きたときに呼び出される関数です。
+type AccessibilityObserver func(kind AccessibilityEventKind, w Widget)
+
+var accessibilityObserver AccessibilityObserver
+
+// SetAccessibilityObserver は、支援技術に伝える出来事を受け取るオブザーバーを設定します。
+// nilを渡すと通知を停止します。オブザーバーは同時に1つだけ登録できます。
+// 通常は直接呼び出さず、a11y.SetAnnouncerを使用します。
+func SetAccessibilityObserver(observer AccessibilityObserver) {
+	accessibilityObserver = observer
+}
+
+// notifyAccessibility は、登録されているオブザーバーに出来事を通知します。オブザーバーが未登録の場合は何もしません。
+func notifyAccessibility(kind AccessibilityEventKind, w Widget) {
+	if accessibilityObserver != nil && w != nil {
+		accessibilityObserver(kind, w)
+	}
+}
+
+// NotifyDialogOpened は、root以下で最初に見つかった、役割がRoleDialogのウィジェットが表示されたことを通知します。
+// ui.Stageのレイヤーの表示など、ウィジェットのSetVisibleを経由せずにダイアログを表示する場合に呼び出します。
+// RoleDialogのウィジェットがない場合は何もしません。
+func NotifyDialogOpened(root Widget) {
+	if accessibilityObserver == nil || root == nil {
+		return
+	}
+	dialog := FindFirst(root, func(w Widget) bool {
+		a, ok := w.(Accessible)
+		return ok && a.AccessibleRole() == RoleDialog
+	})
+	notifyAccessibility(AccessibilityDialogOpened, dialog)
+}
+
+// SetAccessibleLive は、ウィジェットをライブリージョンにし、ラベルが変わったときに支援技術へ通知する方法を設定します。
+func (w *LayoutableWidget) SetAccessibleLive(live LiveRegion) {
+	w.a11y.live = live
+}
+
+// AccessibleLive は、ウィジェットのライブリージョンの設定を返します。
+func (w *LayoutableWidget) AccessibleLive() LiveRegion {
+	return w.a11y.live
+}
+
+// NotifyLabelChanged は、ウィジェットの表示内容が変わり、ラベルが変わった可能性があることを通知します。
+// ライブリージョンに設定されたウィジェットで、表示中かつラベルが前回の通知から変わっている場合にのみ通知します。
+// Labelなどのテキストを持つウィジェットは、テキストが変わったときに自動的に呼び出します。
+func (w *LayoutableWidget) NotifyLabelChanged() {
+	if w.a11y.live == LiveOff || accessibilityObserver == nil || !w.state.isVisible {
+		return
+	}
+	label := w.AccessibleLabel()
+	if label == w.a11y.announced {
+		return
+	}
+	w.a11y.announced = label
+	notifyAccessibility(AccessibilityLiveRegionChanged, w.self)
+}
@@ -0,0 +1,75 @@
+package component
+
+// MarginSide は、マージンの辺を表すビットフラグです。複数の辺は | で組み合わせます。
+type MarginSide uint8
+
+const (
+	MarginSideTop MarginSide = 1 << iota
+	MarginSideRight
+	MarginSideBottom
+	MarginSideLeft
+
+	// MarginSidesHorizontal は左右の辺、MarginSidesVertical は上下の辺です。
+	MarginSidesHorizontal = MarginSideLeft | MarginSideRight
+	MarginSidesVertical   = MarginSideTop | MarginSideBottom
+)
+
+// Has は、sidesのいずれかの辺が含まれているかどうかを返します。
+func (s MarginSide) Has(sides MarginSide) bool {
+	return s&sides != 0
+}
+
+// SetAutoMargins は、自動のマージンにする辺を設定します。0を渡すと解除します。
+// 自動のマージンは、FlexLayoutで余ったスペースを吸収します。主軸の辺では、Justifyの代わりに余ったスペースを
+// 自動のマージンの間で均等に分け、交差軸の辺では、AlignItemsの代わりにライン内の余ったスペースを吸収します。
+// 例えば、HStackの最後のアイテムの左を自動のマージンにすると、そのアイテムは右端に押し出されます。
+// スタイルのMarginの値は、自動のマージンに加えてそのまま適用されます。FlexLayout以外のレイアウトでは無視されます。
+func (w *LayoutableWidget) SetAutoMargins(sides MarginSide) {
+	if w.layout.autoMargins != sides {
+		w.layout.autoMargins = sides
+		w.MarkDirty(true)
+	}
+}
+
+// AutoMargins は、自動のマージンが設定された辺を返します。
+func (w *LayoutableWidget) AutoMargins() MarginSide {
+	return w.layout.autoMargins
+}
+
+// MarginTopAuto は、上のマージンを自動にします。VStackでは、このアイテムを下端へ押し出します。
+func (b *Builder[T, W]) MarginTopAuto() T {
+	return b.addAutoMargins(MarginSideTop)
+}
+
+// MarginRightAuto は、右のマージンを自動にします。HStackでは、後ろのアイテムを右端へ押し出します。
+func (b *Builder[T, W]) MarginRightAuto() T {
+	return b.addAutoMargins(MarginSideRight)
+}
+
+// MarginBottomAuto は、下のマージンを自動にします。VStackでは、後ろのアイテムを下端へ押し出します。
+func (b *Builder[T, W]) MarginBottomAuto() T {
+	return b.addAutoMargins(MarginSideBottom)
+}
+
+// MarginLeftAuto は、左のマージンを自動にします。HStackでは、このアイテムを右端へ押し出します。
+func (b *Builder[T, W]) MarginLeftAuto() T {
+	return b.addAutoMargins(MarginSideLeft)
+}
+
+// MarginXAuto は、左右のマージンを自動にします。HStackの余ったスペースや、VStackの幅の中で中央に配置されます。
+func (b *Builder[T, W]) MarginXAuto() T {
+	return b.addAutoMargins(MarginSidesHorizontal)
+}
+
+// MarginYAuto は、上下のマージンを自動にします。VStackの余ったスペースや、HStackの高さの中で中央に配置されます。
+func (b *Builder[T, W]) MarginYAuto() T {
+	return b.addAutoMargins(MarginSidesVertical)
+}
+
+// addAutoMargins は、設定済みの辺にsidesを加えて自動のマージンにします。
+func (b *Builder[T, W]) addAutoMargins(sides MarginSide) T {
+	if am, ok := any(b.Widget).(AutoMarginer); ok {
+		am.SetAutoMargins(am.AutoMargins() | sides)
+	}
+	return b.Self
+}
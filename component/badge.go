@@ -0,0 +1,187 @@
+package component
+
+import (
+	"furoshiki/style"
+	"furoshiki/theme"
+	"image"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// このファイルは、ウィジェットの角に未読件数などを表示する小さなバッジを提供します。
+// バッジはフォーカスリングと同様にウィジェット自身の描画キャッシュやシェーダーの外側に描画され、
+// ウィジェットの境界の外側にはみ出して表示できます。
+//
+// ルートのコンテナが描画先に直接描画しているウィジェットのバッジは、ルートの描画が終わるまで遅延して描画されるため、
+// 後から描画される兄弟の上に表示されます。クリップするコンテナやトランスフォームを持つコンテナの内側では、
+// 子はオフスクリーン画像に描画されるため、バッジはその場で描画され、コンテナの境界でクリップされます。
+// バッジは描画だけの装飾で、ヒットテストや支援技術には影響しません。件数を読み上げるにはAccessibleHintなどを併用してください。
+
+// maxBadgeCount は、SetBadgeCountで数字のまま表示する最大の件数です。これを超えると"99+"と表示します。
+const maxBadgeCount = 99
+
+// BadgePosition は、バッジを表示するウィジェットの角です。
+type BadgePosition int
+
+const (
+	// BadgeTopRight は、右上の角です(既定)。
+	BadgeTopRight BadgePosition = iota
+	// BadgeTopLeft は、左上の角です。
+	BadgeTopLeft
+	// BadgeBottomRight は、右下の角です。
+	BadgeBottomRight
+	// BadgeBottomLeft は、左下の角です。
+	BadgeBottomLeft
+)
+
+// badge は、ウィジェットに設定されたバッジです。textが空の場合、バッジは表示されません。
+type badge struct {
+	text     string
+	position BadgePosition
+}
+
+// pendingBadge は、ルートの描画が終わるまで描画を遅らせているバッジです。
+type pendingBadge struct {
+	text     string
+	position BadgePosition
+	bounds   image.Rectangle
+}
+
+var (
+	// badgeTarget は、進行中のバッジの描画パスの描画先です。パスが進行中でない場合はnilです。
+	badgeTarget *ebiten.Image
+	// pendingBadges は、badgeTargetに描画するために遅らせているバッジです。
+	pendingBadges []pendingBadge
+)
+
+// SetBadge は、ウィジェットのpositionの角にtextを表示するバッジを設定します。textが空の場合はバッジを取り除きます。
+func (w *LayoutableWidget) SetBadge(text string, position BadgePosition) {
+	if w.badge.text == text && w.badge.position == position {
+		return
+	}
+	w.badge = badge{text: text, position: position}
+	w.MarkDirty(false)
+}
+
+// SetBadgeCount は、件数を表示するバッジを設定します。countが0以下の場合はバッジを取り除き、
+// 99を超える場合は"99+"と表示します。
+func (w *LayoutableWidget) SetBadgeCount(count int, position BadgePosition) {
+	w.SetBadge(badgeCountText(count), position)
+}
+
+// ClearBadge は、バッジを取り除きます。
+func (w *LayoutableWidget) ClearBadge() {
+	w.SetBadge("", w.badge.position)
+}
+
+// Badge は、表示中のバッジの文字列と位置を返します。バッジがない場合、textは空文字列です。
+func (w *LayoutableWidget) Badge() (text string, position BadgePosition) {
+	return w.badge.text, w.badge.position
+}
+
+// badgeState は、このウィジェットのバッジを返します。
+func (w *LayoutableWidget) badgeState() *badge {
+	return &w.badge
+}
+
+// badgeOwner は、バッジを持つウィジェットを識別するための内部インターフェースです。
+type badgeOwner interface {
+	badgeState() *badge
+}
+
+// badgeCountText は、件数をバッジに表示する文字列にします。
+func badgeCountText(count int) string {
+	switch {
+	case count <= 0:
+		return ""
+	case count > maxBadgeCount:
+		return strconv.Itoa(maxBadgeCount) + "+"
+	default:
+		return strconv.Itoa(count)
+	}
+}
+
+// BeginBadgePass は、screenへのバッジの描画パスを開始し、開始した場合にtrueを返します。
+// パスの間にscreenへ直接描画されたウィジェットのバッジは、EndBadgePassまで描画が遅らされます。
+// 既にパスが進行中の場合は何もせずfalseを返します。ルートのコンテナが描画の前後に呼び出します。
+func BeginBadgePass(screen *ebiten.Image) bool {
+	if badgeTarget != nil || screen == nil {
+		return false
+	}
+	badgeTarget = screen
+	return true
+}
+
+// EndBadgePass は、遅らせていたバッジを描画し、バッジの描画パスを終了します。
+// BeginBadgePassがtrueを返した場合にだけ呼び出してください。
+func EndBadgePass() {
+	target := badgeTarget
+	badgeTarget = nil
+	if len(pendingBadges) == 0 {
+		return
+	}
+	FlushDraws()
+	for _, b := range pendingBadges {
+		drawBadgeAt(target, b.text, b.position, b.bounds)
+	}
+	FlushDraws()
+	clear(pendingBadges)
+	pendingBadges = pendingBadges[:0]
+}
+
+// drawBadge は、wにバッジが設定されていれば、その角にバッジを描画します。
+// 描画先がバッジの描画パスの描画先であれば、パスの終わりまで描画を遅らせます。
+func drawBadge(w Widget, info DrawInfo) {
+	owner, ok := w.(badgeOwner)
+	if !ok {
+		return
+	}
+	b := owner.badgeState()
+	if b.text == "" {
+		return
+	}
+	if is, ok := w.(InteractiveState); ok && (!is.IsVisible() || !is.HasBeenLaidOut()) {
+		return
+	}
+	bounds := widgetDrawBounds(w, info)
+	if bounds.Empty() {
+		return
+	}
+	if info.Screen == badgeTarget {
+		pendingBadges = append(pendingBadges, pendingBadge{text: b.text, position: b.position, bounds: bounds})
+		return
+	}
+	FlushDraws()
+	drawBadgeAt(info.Screen, b.text, b.position, bounds)
+}
+
+// drawBadgeAt は、boundsのpositionの角を中心として、テーマのBadgeのスタイルでバッジを描画します。
+// バッジの高さは文字の高さと上下の余白で決まり、幅は高さより狭くならないため、1文字のバッジは円になります。
+func drawBadgeAt(dst *ebiten.Image, text string, position BadgePosition, bounds image.Rectangle) {
+	t := theme.GetCurrent()
+	c := style.Resolve(t.Badge)
+	if c.Font == nil {
+		c.Font = t.DefaultFont
+	}
+	if c.Font == nil {
+		return
+	}
+	m := FaceMetrics(c.Font)
+	height := c.Padding.Top + (m.Ascent + m.Descent).Ceil() + c.Padding.Bottom
+	width := max(height, c.Padding.Left+MeasureString(c.Font, text).Ceil()+c.Padding.Right)
+	c.BorderRadius = float32(height) / 2
+
+	cx, cy := bounds.Max.X, bounds.Min.Y
+	switch position {
+	case BadgeTopLeft:
+		cx = bounds.Min.X
+	case BadgeBottomRight:
+		cy = bounds.Max.Y
+	case BadgeBottomLeft:
+		cx, cy = bounds.Min.X, bounds.Max.Y
+	}
+	x, y := cx-width/2, cy-height/2
+	DrawComputedBackground(dst, x, y, width, height, c)
+	DrawComputedText(dst, text, image.Rect(x, y, x+width, y+height), c, false)
+}
@@ -19,6 +19,8 @@ type LayoutableWidget struct {
 	// これにより、最小サイズ決定ロ-ジック（コンテンツ固有サイズ vs ユーザー設定サイズ）を
 	// LayoutableWidgetに集約し、TextWidgetのような具象ウィジェットでのコード重複を避けます。
 	contentMinSizeFunc func() (width, height int)
+	// measure は、contentMinSizeFuncやGetHeightForWidthの計測結果のキャッシュです。
+	measure measureCache
 
 	// --- Layout & Style ---
 	layout       layoutProperties
@@ -29,10 +31,26 @@ type LayoutableWidget struct {
 	// --- State ---
 	state widgetState
 
+	// --- Identity ---
+	identity identity
+	// a11y は、支援技術に伝える役割やラベルなどの情報です。
+	a11y accessibility
+
+	// --- Rendering ---
+	render renderCache
+	// hooks は、描画の前後に呼び出されるフックです。
+	hooks drawHooks
+	// effect は、描画結果に適用するシェーダーです。
+	effect shaderEffect
+	// badge は、ウィジェットの角に表示するバッジです。
+	badge badge
+
 	// --- Hierarchy & Events ---
 	hierarchy hierarchy
 	// NOTE: イベントハンドラを複数登録できるよう、型をハンドラのスライスに変更しました。
-	eventHandlers map[event.EventType][]event.EventHandler
+	// UPDATE: マップから、イベントタイプとハンドラの組のスライスに変更しました。
+	//         多くのウィジェットはハンドラを持たないか数種類しか持たないため、マップの割り当てを省けます。
+	eventHandlers []handlerEntry
 	// self は、このLayoutableWidgetを埋め込んでいる具象ウィジェット自身への参照です。
 	// これにより、HitTestのようなメソッドが、具体的な型（*Button, *Labelなど）を返すことができます。
 	self Widget
@@ -50,6 +68,14 @@ var _ InteractiveState = (*LayoutableWidget)(nil)
 var _ event.EventTarget = (*LayoutableWidget)(nil) // event.EventTargetも実装していることを明記
 var _ EventProcessor = (*LayoutableWidget)(nil)
 var _ AbsolutePositioner = (*LayoutableWidget)(nil)
+var _ Identifiable = (*LayoutableWidget)(nil)
+var _ RenderCacher = (*LayoutableWidget)(nil)
+var _ DrawHooker = (*LayoutableWidget)(nil)
+var _ Badger = (*LayoutableWidget)(nil)
+var _ Accessible = (*LayoutableWidget)(nil)
+var _ Focusable = (*LayoutableWidget)(nil)
+var _ MeasureInvalidator = (*LayoutableWidget)(nil)
+var _ AutoMarginer = (*LayoutableWidget)(nil)
 
 // position はウィジェットの位置情報を保持します
 type position struct {
@@ -68,6 +94,10 @@ type layoutProperties struct {
 	// layoutData は、特定のレイアウトシステムが必要とする追加情報を格納するための汎用フィールドです。
 	// 例えば、AdvancedGridLayoutはここにウィジェットの行、列、スパン情報を格納します。
 	layoutData any
+	// explicitWidth, explicitHeight は、ビルダーのSizeで幅と高さが明示的に指定されたかどうかです。
+	explicitWidth, explicitHeight bool
+	// autoMargins は、FlexLayoutの余ったスペースを吸収する自動のマージンが設定された辺です。
+	autoMargins MarginSide
 }
 
 // dirtyLevel はウィジェットのダーティ状態のレベルを示します。
@@ -91,6 +121,23 @@ type widgetState struct {
 	isVisible      bool
 	isDisabled     bool
 	hasBeenLaidOut bool // レイアウトが一度でも実行されたかを追跡するフラグ
+	isPaused       bool // 子孫の更新を一時停止しているかどうか
+	hideFocusRing  bool // フォーカスを持っている間に、フォーカスリングを描画しないかどうか
+	// subtreeDisabled は、SetEnabledRecursive(false)で自身と子孫を無効にしているかどうかです。
+	// isDisabled(SetDisabledで設定した自身の状態)とは独立して保持されるため、有効に戻すと元の状態に戻ります。
+	subtreeDisabled bool
+}
+
+// identity は、ツリー検索に使用されるウィジェットの識別情報を保持します
+type identity struct {
+	id   string
+	tags []string
+}
+
+// handlerEntry は、イベントタイプと、そのタイプに登録されたハンドラの組です
+type handlerEntry struct {
+	eventType event.EventType
+	handlers  []event.EventHandler
 }
 
 // hierarchy はウィジェットの階層構造情報を保持します
@@ -104,8 +151,7 @@ func NewLayoutableWidget() *LayoutableWidget {
 	// NOTE: コンストラクタ内でStyleManagerを初期化し、自身への参照を渡します。
 	// これにより、StyleManagerはスタイル変更時にこのウィジェットのMarkDirtyを呼び出せます。
 	w := &LayoutableWidget{
-		state:         widgetState{isVisible: true, hasBeenLaidOut: false, dirtyLevel: levelClean},
-		eventHandlers: make(map[event.EventType][]event.EventHandler),
+		state: widgetState{isVisible: true, hasBeenLaidOut: false, dirtyLevel: levelClean},
 	}
 	// NOTE: 非公開になったstyleManagerフィールドに設定します。
 	w.styleManager = NewStyleManager(w)
@@ -146,4 +192,10 @@ func (w *LayoutableWidget) SetStyleForState(state WidgetState, s style.Style) {
 // このメソッドは内部でキャッシュを利用するため、描画ループ内で効率的にスタイルを取得できます。
 func (w *LayoutableWidget) GetStyleForState(state WidgetState) style.Style {
 	return w.styleManager.GetStyleForState(state)
+}
+
+// ComputedStyleForState は、指定された状態に適用すべき最終的なスタイルを値型に解決して返します。
+// 結果はキャッシュされるため、描画ループから呼び出してもヒープ割り当ては発生しません。
+func (w *LayoutableWidget) ComputedStyleForState(state WidgetState) style.Computed {
+	return w.styleManager.ComputedForState(state)
 }
\ No newline at end of file
@@ -0,0 +1,310 @@
+package component
+
+import (
+	"furoshiki/style"
+	"unicode"
+)
+
+// このファイルは、アラビア語やヘブライ語などの右から左に書く文字(RTL)を含むテキストを表示するための、
+// 簡易的な双方向テキストの処理を提供します。
+// Unicodeの双方向アルゴリズム(UAX #9)のうち、埋め込みの制御文字や数字の区切り記号の扱いを省き、
+// 強い方向を持つ文字、数字、中立の文字の解決と、行内の並べ替え、括弧の鏡像化だけを行います。
+// テキストは論理順(入力された順)のまま保持・折り返しを行い、1行ずつ描画する直前に表示順へ並べ替えます。
+
+// bidiClass は、双方向テキストの処理における文字の分類です。
+type bidiClass uint8
+
+const (
+	bidiNeutral bidiClass = iota
+	bidiL                 // 左から右に書く文字
+	bidiR                 // 右から左に書く文字
+	bidiEN                // 数字
+)
+
+// classOf は、文字の双方向テキストの分類を返します。
+func classOf(r rune) bidiClass {
+	switch {
+	case unicode.IsDigit(r):
+		return bidiEN
+	case unicode.In(r, unicode.Hebrew, unicode.Arabic, unicode.Syriac, unicode.Thaana, unicode.Nko):
+		return bidiR
+	case unicode.IsLetter(r):
+		return bidiL
+	default:
+		return bidiNeutral
+	}
+}
+
+// IsRTL は、テキストの段落の方向が右から左かどうかを返します。
+// 方向は、最初に現れる強い方向を持つ文字(数字や記号を除く文字)で決まります。そのような文字がない場合はfalseです。
+func IsRTL(s string) bool {
+	for _, r := range s {
+		switch classOf(r) {
+		case bidiL:
+			return false
+		case bidiR:
+			return true
+		}
+	}
+	return false
+}
+
+// HasRTL は、テキストに右から左に書く文字が含まれるかどうかを返します。
+// 含まれない場合は並べ替えが不要なため、描画の際の処理を省くために使用します。
+func HasRTL(s string) bool {
+	for _, r := range s {
+		if classOf(r) == bidiR {
+			return true
+		}
+	}
+	return false
+}
+
+// BidiLevels は、論理順の文字列の各文字の埋め込みレベルを返します。
+// 偶数は左から右、奇数は右から左に表示されることを示します。rtlは段落の方向です。
+func BidiLevels(runes []rune, rtl bool) []uint8 {
+	base := bidiL
+	var baseLevel uint8
+	if rtl {
+		base, baseLevel = bidiR, 1
+	}
+	types := make([]bidiClass, len(runes))
+	lastStrong := base
+	for i, r := range runes {
+		t := classOf(r)
+		// 結合文字は直前の文字と同じ分類として扱います。
+		if i > 0 && unicode.Is(unicode.Mn, r) {
+			t = types[i-1]
+		}
+		switch t {
+		case bidiL, bidiR:
+			lastStrong = t
+		case bidiEN:
+			// 左から右の文字に続く数字は、左から右の文字として扱います(UAX #9 W7)。
+			if lastStrong == bidiL {
+				t = bidiL
+			}
+		}
+		types[i] = t
+	}
+
+	// 中立の文字の方向を決める際には、数字は右から左の文字として扱います。
+	strongOf := func(t bidiClass) bidiClass {
+		if t == bidiEN {
+			return bidiR
+		}
+		return t
+	}
+	resolveBracketPairs(runes, types, base, strongOf)
+
+	// 中立の文字は、前後の強い方向が同じであればその方向に、異なれば段落の方向になります(N1, N2)。
+	for i := 0; i < len(types); {
+		if types[i] != bidiNeutral {
+			i++
+			continue
+		}
+		j := i
+		for j < len(types) && types[j] == bidiNeutral {
+			j++
+		}
+		before, after := base, base
+		if i > 0 {
+			before = strongOf(types[i-1])
+		}
+		if j < len(types) {
+			after = strongOf(types[j])
+		}
+		resolved := base
+		if before == after {
+			resolved = before
+		}
+		for k := i; k < j; k++ {
+			types[k] = resolved
+		}
+		i = j
+	}
+
+	levels := make([]uint8, len(runes))
+	for i, t := range types {
+		switch {
+		case t == bidiEN:
+			// 方向の異なる文脈にある数字は、周囲より1つ上のレベルで左から右に表示します。
+			levels[i] = 2
+		case t == bidiR && !rtl:
+			levels[i] = 1
+		case t == bidiL && rtl:
+			levels[i] = 2
+		default:
+			levels[i] = baseLevel
+		}
+	}
+	// 行末の空白は段落の方向に戻します(L1)。
+	for i := len(runes) - 1; i >= 0 && unicode.IsSpace(runes[i]); i-- {
+		levels[i] = baseLevel
+	}
+	return levels
+}
+
+// resolveBracketPairs は、対になる括弧の方向を、括弧の内側と直前の文字の方向から決めます(N0)。
+// 内側に段落の方向の文字があれば段落の方向に、逆方向の文字だけがあれば、直前の文字も逆方向の場合に限り逆方向になります。
+// これにより、"(world)" のような括弧で囲まれた部分が、段落の方向に関わらず正しく対になって表示されます。
+func resolveBracketPairs(runes []rune, types []bidiClass, base bidiClass, strongOf func(bidiClass) bidiClass) {
+	var stack []int
+	for i, r := range runes {
+		if types[i] != bidiNeutral {
+			continue
+		}
+		switch r {
+		case '(', '[', '{':
+			stack = append(stack, i)
+			continue
+		case ')', ']', '}':
+		default:
+			continue
+		}
+		// 対応する開き括弧を探します。見つからない閉じ括弧は通常の中立の文字として扱います。
+		open := -1
+		for k := len(stack) - 1; k >= 0; k-- {
+			if mirrorRune(runes[stack[k]]) == r {
+				open = stack[k]
+				stack = stack[:k]
+				break
+			}
+		}
+		if open < 0 {
+			continue
+		}
+		var hasBase, hasOpposite bool
+		for _, t := range types[open+1 : i] {
+			switch s := strongOf(t); {
+			case s == base:
+				hasBase = true
+			case s != bidiNeutral:
+				hasOpposite = true
+			}
+		}
+		resolved := bidiNeutral
+		switch {
+		case hasBase:
+			resolved = base
+		case hasOpposite:
+			resolved = base
+			before := base
+			for k := open - 1; k >= 0; k-- {
+				if s := strongOf(types[k]); s != bidiNeutral {
+					before = s
+					break
+				}
+			}
+			if before != base {
+				resolved = before
+			}
+		}
+		if resolved != bidiNeutral {
+			types[open], types[i] = resolved, resolved
+		}
+	}
+}
+
+// VisualOrder は、埋め込みレベルから、表示順(左から右)に並べた論理順のインデックスを返します。
+func VisualOrder(levels []uint8) []int {
+	order := make([]int, len(levels))
+	var highest uint8
+	lowestOdd := uint8(255)
+	for i, l := range levels {
+		order[i] = i
+		highest = max(highest, l)
+		if l%2 == 1 {
+			lowestOdd = min(lowestOdd, l)
+		}
+	}
+	// 最も高いレベルから最も低い奇数のレベルまで、各レベル以上の連続した区間を反転します(L2)。
+	for level := highest; level >= lowestOdd && level > 0; level-- {
+		for i := 0; i < len(levels); {
+			if levels[order[i]] < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(levels) && levels[order[j]] >= level {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				order[a], order[b] = order[b], order[a]
+			}
+			i = j
+		}
+	}
+	return order
+}
+
+// VisualRunes は、論理順の文字列を表示順に並べ替え、右から左に表示される括弧を鏡像化して返します。
+// 戻り値のorderは、表示順のi番目の文字が論理順の何番目の文字かを示します。
+func VisualRunes(runes []rune, rtl bool) (visual []rune, order []int, levels []uint8) {
+	levels = BidiLevels(runes, rtl)
+	order = VisualOrder(levels)
+	visual = make([]rune, len(runes))
+	for i, logical := range order {
+		r := runes[logical]
+		if levels[logical]%2 == 1 {
+			r = mirrorRune(r)
+		}
+		visual[i] = r
+	}
+	return visual, order, levels
+}
+
+// VisualLine は、論理順の1行を表示順の文字列に並べ替えて返します。
+// 右から左に書く文字を含まない場合は、元の文字列をそのまま返します。
+func VisualLine(line string, rtl bool) string {
+	if !rtl && !HasRTL(line) {
+		return line
+	}
+	visual, _, _ := VisualRunes([]rune(line), rtl)
+	return string(visual)
+}
+
+// mirrorRune は、右から左に表示される場合に鏡像の字形を使う括弧などについて、対になる文字を返します。
+func mirrorRune(r rune) rune {
+	switch r {
+	case '(':
+		return ')'
+	case ')':
+		return '('
+	case '[':
+		return ']'
+	case ']':
+		return '['
+	case '{':
+		return '}'
+	case '}':
+		return '{'
+	case '<':
+		return '>'
+	case '>':
+		return '<'
+	case '«':
+		return '»'
+	case '»':
+		return '«'
+	}
+	return r
+}
+
+// ResolveTextAlign は、段落の方向に応じて、TextAlignStartとTextAlignEndを左揃えまたは右揃えに解決します。
+// それ以外の揃え位置はそのまま返します。
+func ResolveTextAlign(align style.TextAlignType, rtl bool) style.TextAlignType {
+	switch align {
+	case style.TextAlignStart:
+		if rtl {
+			return style.TextAlignRight
+		}
+		return style.TextAlignLeft
+	case style.TextAlignEnd:
+		if rtl {
+			return style.TextAlignLeft
+		}
+		return style.TextAlignRight
+	}
+	return align
+}
@@ -0,0 +1,146 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// DisplayNamer は、デバッグ表示やエラーメッセージで使用する表示名を持つウィジェットのインターフェースです。
+// 例えばContainerは、レイアウトに応じて "VStack" や "Grid" のような名前を返します。
+type DisplayNamer interface {
+	DisplayName() string
+}
+
+// maxLabelTextLength は、エラーパスに含めるテキストの最大文字数です。
+const maxLabelTextLength = 20
+
+// BuildError は、ウィジェットツリーのどこでビルドエラーが発生したかを示すパス付きのエラーです。
+// Pathはルート側から順に並んだ各ウィジェットのラベルです（例: ["VStack", "HStack[2]", "Button[0](text=Save)"]）。
+type BuildError struct {
+	Path []string
+	Err  error
+}
+
+// Error は "VStack > HStack[2] > Button[0](text=Save): size must be non-negative" の形式でエラーを返します。
+func (e *BuildError) Error() string {
+	return strings.Join(e.Path, " > ") + ": " + e.Err.Error()
+}
+
+// Unwrap は、元のエラーを返します。errors.Is(err, ErrInvalidSize) のような判定が可能です。
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
+
+// BuildErrors は、Buildで発生したすべてのBuildErrorをまとめたエラーです。
+type BuildErrors []*BuildError
+
+// Error は、各エラーを1行ずつ並べたレポートを返します。
+func (errs BuildErrors) Error() string {
+	lines := make([]string, len(errs))
+	for i, e := range errs {
+		lines[i] = e.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap は、含まれる各BuildErrorを返します。
+func (errs BuildErrors) Unwrap() []error {
+	result := make([]error, len(errs))
+	for i, e := range errs {
+		result[i] = e
+	}
+	return result
+}
+
+// WithChildIndex は、子ウィジェットのビルドエラーのパスの先頭（子自身のラベル）に、
+// 親コンテナ内でのインデックスを付与します。BuildErrors以外のエラーはそのまま返します。
+// コンテナ系のビルダーが子のビルドエラーを自身に追加する際に使用します。
+func WithChildIndex(err error, index int) error {
+	var errs BuildErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+	indexed := make(BuildErrors, len(errs))
+	for i, e := range errs {
+		path := append([]string(nil), e.Path...)
+		if len(path) > 0 {
+			path[0] = insertIndex(path[0], index)
+		}
+		indexed[i] = &BuildError{Path: path, Err: e.Err}
+	}
+	return indexed
+}
+
+// insertIndex は、"Button(text=Save)" のようなラベルの型名の直後に "[index]" を挿入します。
+func insertIndex(label string, index int) string {
+	name, detail, _ := strings.Cut(label, "(")
+	if detail != "" {
+		detail = "(" + detail
+	}
+	return fmt.Sprintf("%s[%d]%s", name, index, detail)
+}
+
+// newBuildErrors は、ビルダーに蓄積されたエラーを、widgetをパスの先頭とするBuildErrorsに変換します。
+// 子のビルドエラー(BuildErrors)は、パスの先頭にwidgetのラベルを追加して平坦化します。
+func newBuildErrors(widget Widget, errs []error) BuildErrors {
+	label := WidgetLabel(widget)
+	var result BuildErrors
+	for _, err := range errs {
+		var nested BuildErrors
+		if errors.As(err, &nested) {
+			for _, e := range nested {
+				result = append(result, &BuildError{Path: append([]string{label}, e.Path...), Err: e.Err})
+			}
+			continue
+		}
+		result = append(result, &BuildError{Path: []string{label}, Err: err})
+	}
+	return result
+}
+
+// WidgetLabel は、エラーパスやデバッグ表示に使用するウィジェットのラベルを返します。
+// 表示名（DisplayNamer）または型名に、IDやテキストを添えて "Button(id=save, text=Save)" のように表します。
+func WidgetLabel(w Widget) string {
+	name := WidgetName(w)
+	var details []string
+	if ident, ok := w.(Identifiable); ok && ident.GetID() != "" {
+		details = append(details, "id="+ident.GetID())
+	}
+	if t, ok := w.(interface{ Text() string }); ok && t.Text() != "" {
+		details = append(details, "text="+truncateLabelText(t.Text()))
+	}
+	if len(details) == 0 {
+		return name
+	}
+	return name + "(" + strings.Join(details, ", ") + ")"
+}
+
+// WidgetName は、ウィジェットの表示名を返します。
+// DisplayNamerを実装していればその名前を、そうでなければパッケージ修飾なしの具象型名を返します。
+func WidgetName(w Widget) string {
+	if dn, ok := w.(DisplayNamer); ok {
+		if name := dn.DisplayName(); name != "" {
+			return name
+		}
+	}
+	t := reflect.TypeOf(w)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return "<nil>"
+	}
+	return t.Name()
+}
+
+// truncateLabelText は、長いテキストを省略してラベルに収まる長さにします。
+func truncateLabelText(s string) string {
+	if utf8.RuneCountInString(s) <= maxLabelTextLength {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:maxLabelTextLength]) + "..."
+}
@@ -0,0 +1,76 @@
+package component
+
+import "strings"
+
+// WarningCode は、ビルド時の警告の種類を表す短い識別子です(例: "flex-in-absolute")。
+// 特定の種類の警告だけを無視したり、テストで検査したりするために使用します。
+type WarningCode string
+
+// BuildWarning は、ビルドは成功したものの、意図どおりに動作しない可能性が高い設定を示す警告です。
+// BuildErrorと異なり、Buildの結果をエラーにはしません。Pathの形式はBuildErrorと同じです。
+type BuildWarning struct {
+	Path    []string
+	Code    WarningCode
+	Message string
+}
+
+// String は "VStack > ZStack[1] > Button[0](text=OK): Flex has no effect ... [flex-in-absolute]" の形式で警告を返します。
+func (w *BuildWarning) String() string {
+	return strings.Join(w.Path, " > ") + ": " + w.Message + " [" + string(w.Code) + "]"
+}
+
+// WarningReporter は、ビルド時の警告を返すことができるビルダーのインターフェースです。
+// これは基底の `component.Builder` によって実装されます。
+type WarningReporter interface {
+	Warnings() []*BuildWarning
+}
+
+// AddWarning は、ビルダーのウィジェット自身に関する警告を追加します。
+func (b *Builder[T, W]) AddWarning(code WarningCode, message string) {
+	b.warnings = append(b.warnings, &BuildWarning{Code: code, Message: message})
+}
+
+// AddChildWarning は、indexの位置にある子childに関する警告を追加します。
+// コンテナ系のビルダーが、子との組み合わせの問題を報告する際に使用します。
+func (b *Builder[T, W]) AddChildWarning(index int, child Widget, code WarningCode, message string) {
+	label := insertIndex(WidgetLabel(child), index)
+	b.warnings = append(b.warnings, &BuildWarning{Path: []string{label}, Code: code, Message: message})
+}
+
+// AddChildWarnings は、indexの位置に追加した子のビルダーが報告した警告を、自身の警告として引き継ぎます。
+func (b *Builder[T, W]) AddChildWarnings(index int, warnings []*BuildWarning) {
+	for _, w := range warnings {
+		path := append([]string(nil), w.Path...)
+		if len(path) > 0 {
+			path[0] = insertIndex(path[0], index)
+		}
+		b.warnings = append(b.warnings, &BuildWarning{Path: path, Code: w.Code, Message: w.Message})
+	}
+}
+
+// Warnings は、このビルダーと、追加された子のビルダーが報告した警告を返します。
+// 各警告のパスの先頭は、このビルダーのウィジェットのラベルです。
+// 警告はBuildの結果に影響しないため、必要に応じてBuildの後に確認してログなどに出力します。
+func (b *Builder[T, W]) Warnings() []*BuildWarning {
+	if len(b.warnings) == 0 {
+		return nil
+	}
+	label := WidgetLabel(b.Widget)
+	result := make([]*BuildWarning, len(b.warnings))
+	for i, w := range b.warnings {
+		result[i] = &BuildWarning{Path: append([]string{label}, w.Path...), Code: w.Code, Message: w.Message}
+	}
+	return result
+}
+
+// HasExplicitSize は、ビルダーのSizeで幅と高さのそれぞれに正の値が指定されたかどうかを返します。
+// ウィジェットの既定の大きさ(ButtonのSetSizeなど)とは区別され、ビルド時の警告の判定に使用されます。
+func (w *LayoutableWidget) HasExplicitSize() (width, height bool) {
+	return w.layout.explicitWidth, w.layout.explicitHeight
+}
+
+// setExplicitSize は、ビルダーのSizeで大きさが明示的に指定されたことを記録します。
+func (w *LayoutableWidget) setExplicitSize(width, height bool) {
+	w.layout.explicitWidth = width
+	w.layout.explicitHeight = height
+}
@@ -7,6 +7,8 @@ import (
 	"furoshiki/style"
 	"image/color"
 	"reflect"
+
+	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // パッケージ全体で利用できるよう、共通エラーをエクスポートします。
@@ -16,6 +18,7 @@ var (
 	ErrInvalidSize          = errors.New("size must be non-negative")
 	ErrInvalidFlex          = errors.New("flex must be non-negative")
 	ErrInvalidBorderWidth   = errors.New("border width must be non-negative")
+	ErrInvalidInsets        = errors.New("insets must be non-negative")
 )
 
 // 【提案1対応】ジェネリクス型Wの制約を強化します。
@@ -29,6 +32,14 @@ type Buildable interface {
 	LayoutProperties
 	EventProcessor
 	AbsolutePositioner
+	Identifiable
+	RenderCacher
+	ShaderApplier
+	DrawHooker
+	Pausable
+	SubtreeDisabler
+	Badger
+	Accessible
 }
 
 // Builder は、すべてのウィジェットビルダーの汎用基底クラスです。
@@ -36,9 +47,10 @@ type Buildable interface {
 // T は具体的なビルダー型（例: *LabelBuilder）です。
 // W は構築中のウィジェット型（例: *Label）で、Buildableインターフェースを満たす必要があります。
 type Builder[T any, W Buildable] struct {
-	Widget W
-	errors []error
-	Self   T
+	Widget   W
+	errors   []error
+	warnings []*BuildWarning
+	Self     T
 }
 
 // Init は基底ビルダーを初期化します。具象ビルダーのコンストラクタから呼び出す必要があります。
@@ -70,6 +82,9 @@ func (b *Builder[T, W]) Size(width, height int) T {
 	} else {
 		// 【提案1対応】WはSizeSetterを実装していることが保証されています。
 		b.Widget.SetSize(width, height)
+		if es, ok := any(b.Widget).(interface{ setExplicitSize(width, height bool) }); ok {
+			es.setExplicitSize(width > 0, height > 0)
+		}
 	}
 	return b.Self
 }
@@ -147,8 +162,17 @@ func (b *Builder[T, W]) Margin(m int) T {
 	return b.MarginInsets(style.Insets{Top: m, Right: m, Bottom: m, Left: m})
 }
 
+// MarginXY は、左右にhorizontal、上下にverticalのマージン値を設定します。
+func (b *Builder[T, W]) MarginXY(horizontal, vertical int) T {
+	return b.MarginInsets(style.Insets{Top: vertical, Right: horizontal, Bottom: vertical, Left: horizontal})
+}
+
 // MarginInsets は各辺に個別のマージン値を設定します。
 func (b *Builder[T, W]) MarginInsets(i style.Insets) T {
+	if err := validateInsets(i); err != nil {
+		b.AddError(err)
+		return b.Self
+	}
 	return b.applyStyleProperty(func(s style.Style) style.Style {
 		s.Margin = style.PInsets(i)
 		return s
@@ -160,14 +184,31 @@ func (b *Builder[T, W]) Padding(p int) T {
 	return b.PaddingInsets(style.Insets{Top: p, Right: p, Bottom: p, Left: p})
 }
 
+// PaddingXY は、左右にhorizontal、上下にverticalのパディング値を設定します。
+func (b *Builder[T, W]) PaddingXY(horizontal, vertical int) T {
+	return b.PaddingInsets(style.Insets{Top: vertical, Right: horizontal, Bottom: vertical, Left: horizontal})
+}
+
 // PaddingInsets は各辺に個別のパディング値を設定します。
 func (b *Builder[T, W]) PaddingInsets(i style.Insets) T {
+	if err := validateInsets(i); err != nil {
+		b.AddError(err)
+		return b.Self
+	}
 	return b.applyStyleProperty(func(s style.Style) style.Style {
 		s.Padding = style.PInsets(i)
 		return s
 	})
 }
 
+// validateInsets は、マージンやパディングの各辺の値が有効かどうかを検証します。
+func validateInsets(i style.Insets) error {
+	if i.Top < 0 || i.Right < 0 || i.Bottom < 0 || i.Left < 0 {
+		return fmt.Errorf("%w, got %+v", ErrInvalidInsets, i)
+	}
+	return nil
+}
+
 // BorderRadius はウィジェットの角の半径を設定します。
 func (b *Builder[T, W]) BorderRadius(radius float32) T {
 	return b.applyStyleProperty(func(s style.Style) style.Style {
@@ -236,6 +277,134 @@ func (b *Builder[T, W]) AddOnMouseScroll(handler event.EventHandler) T {
 	return b.Self
 }
 
+// ID は、ウィジェットに識別子を設定します。
+// 設定したIDは ui.Find で検索でき、AssignTo で参照を保持しておく必要がなくなります。
+func (b *Builder[T, W]) ID(id string) T {
+	if id == "" {
+		b.AddError(errors.New("widget ID cannot be empty"))
+		return b.Self
+	}
+	b.Widget.SetID(id)
+	return b.Self
+}
+
+// Tag は、ウィジェットに1つ以上のタグを追加します。
+// 同じタグを持つウィジェットは ui.FindByTag でまとめて検索できます。
+func (b *Builder[T, W]) Tag(tags ...string) T {
+	b.Widget.AddTags(tags...)
+	return b.Self
+}
+
+// CacheRendering は、ウィジェットとその子孫の描画結果をオフスクリーン画像にキャッシュするかを設定します。
+// 静的なサブツリーは一度だけ描画され、いずれかの子孫がダーティになるまで画像の転送だけで描画されます。
+func (b *Builder[T, W]) CacheRendering(enabled bool) T {
+	b.Widget.SetCacheRendering(enabled)
+	return b.Self
+}
+
+// Shader は、ウィジェットとその子孫の描画結果をKageシェーダーを通して描画します。
+// uniformsは、シェーダーのユニフォーム変数の名前と値です。値は後からSetShaderUniformで更新できます。
+func (b *Builder[T, W]) Shader(shader *ebiten.Shader, uniforms map[string]any) T {
+	if shader == nil {
+		b.AddError(errors.New("shader cannot be nil"))
+		return b.Self
+	}
+	b.Widget.SetShader(shader, uniforms)
+	return b.Self
+}
+
+// Paused は、ウィジェットとその子孫の更新(アニメーションなど)を一時停止するかどうかを設定します。描画は続けられます。
+func (b *Builder[T, W]) Paused(paused bool) T {
+	b.Widget.SetPaused(paused)
+	return b.Self
+}
+
+// EnabledRecursive は、ウィジェットとその子孫をまとめて有効または無効にします。
+// falseを指定すると、サブツリー全体が無効状態のスタイルで描画され、操作を受け付けなくなります。
+func (b *Builder[T, W]) EnabledRecursive(enabled bool) T {
+	b.Widget.SetEnabledRecursive(enabled)
+	return b.Self
+}
+
+// AccessibleLabel は、支援技術に伝えるウィジェットの名前を設定します。
+// 省略した場合は、Labelのテキストなど、ウィジェットの内容から得た名前が使われます。
+func (b *Builder[T, W]) AccessibleLabel(label string) T {
+	b.Widget.SetAccessibleLabel(label)
+	return b.Self
+}
+
+// AccessibleRole は、支援技術に伝えるウィジェットの役割を設定します。
+// 省略した場合は、ウィジェットの種類ごとの既定の役割(Buttonならbuttonなど)が使われます。
+func (b *Builder[T, W]) AccessibleRole(role Role) T {
+	b.Widget.SetAccessibleRole(role)
+	return b.Self
+}
+
+// AccessibleHint は、名前を補足する説明を設定します。
+func (b *Builder[T, W]) AccessibleHint(hint string) T {
+	b.Widget.SetAccessibleHint(hint)
+	return b.Self
+}
+
+// AccessibleLive は、ウィジェットをライブリージョンにし、テキストが変わったときに支援技術へ通知するようにします。
+// 例: スコアの表示に AccessibleLive(component.LivePolite) を設定すると、スコアが変わるたびに読み上げられます。
+func (b *Builder[T, W]) AccessibleLive(live LiveRegion) T {
+	b.Widget.SetAccessibleLive(live)
+	return b.Self
+}
+
+// OnBeforeDraw は、ウィジェット本体を描画する直前に呼び出されるフックを追加します。
+// フックには描画情報と、描画先でのウィジェットの最終的な境界が渡されます。
+func (b *Builder[T, W]) OnBeforeDraw(hook DrawHook) T {
+	if hook == nil {
+		b.AddError(errors.New("draw hook cannot be nil"))
+		return b.Self
+	}
+	b.Widget.AddOnBeforeDraw(hook)
+	return b.Self
+}
+
+// OnAfterDraw は、ウィジェット本体（子孫を含む）を描画した直後に呼び出されるフックを追加します。
+// 選択状態の色付けやフォーカスの光彩など、ウィジェットの上に重ねる装飾の描画に使用します。
+func (b *Builder[T, W]) OnAfterDraw(hook DrawHook) T {
+	if hook == nil {
+		b.AddError(errors.New("draw hook cannot be nil"))
+		return b.Self
+	}
+	b.Widget.AddOnAfterDraw(hook)
+	return b.Self
+}
+
+// Badge は、ウィジェットのpositionの角にtextを表示するバッジを設定します。
+// バッジは兄弟のウィジェットの上に重ねて描画されます。値は後からSetBadgeで変更できます。
+func (b *Builder[T, W]) Badge(text string, position BadgePosition) T {
+	b.Widget.SetBadge(text, position)
+	return b.Self
+}
+
+// BadgeCount は、ウィジェットのpositionの角に件数を表示するバッジを設定します。
+// countが0以下の場合はバッジを表示せず、99を超える場合は"99+"と表示します。値は後からSetBadgeCountで変更できます。
+func (b *Builder[T, W]) BadgeCount(count int, position BadgePosition) T {
+	if count < 0 {
+		b.AddError(errors.New("badge count cannot be negative"))
+		return b.Self
+	}
+	b.Widget.SetBadgeCount(count, position)
+	return b.Self
+}
+
+// Ref は、ビルド中のウィジェットを指定された名前でレジストリに登録します。
+// AssignToと異なり、事前に型付きの変数を宣言しておく必要がありません。
+// 例: .Ref(refs, "detail.title") で登録し、後から refs.Label("detail.title") で取得します。
+func (b *Builder[T, W]) Ref(registry RefRegistry, name string) T {
+	if registry == nil {
+		b.AddError(errors.New("ref registry cannot be nil"))
+		return b.Self
+	}
+	b.AddError(registry.Register(name, b.Widget))
+	return b.Self
+}
+
 // AssignTo は、ビルド中のウィジェットインスタンスへのポインタを変数に代入します。
 // UIの宣言的な構築フローを中断することなく、後から操作したいウィジェットへの参照を
 // 安全に取得するために使用します。
@@ -274,13 +443,37 @@ func (b *Builder[T, W]) AssignTo(target any) T {
 	return b.Self
 }
 
+// Err は、これまでに蓄積されたエラーをパス付きのエラーとして返します。エラーがない場合はnilを返します。
+// Buildを呼び出さずにビルダーを使用する場合（ui.Editなど）に、エラーを取得するために使用します。
+func (b *Builder[T, W]) Err() error {
+	if len(b.errors) == 0 {
+		return nil
+	}
+	return newBuildErrors(b.Widget, b.errors)
+}
+
 // Build はウィジェットの構築を完了します。
 func (b *Builder[T, W]) Build() (W, error) {
 	if len(b.errors) > 0 {
 		var zero W
-		typeName := reflect.TypeOf(b.Widget).Elem().Name()
-		return zero, fmt.Errorf("%s build errors: %w", typeName, errors.Join(b.errors...))
+		// エラーにはツリー内の位置を示すパスが付与されます（例: "VStack > Button[1](text=Save): ..."）。
+		return zero, b.Err()
 	}
 	b.Widget.MarkDirty(true)
 	return b.Widget, nil
+}
+
+// MustBuild は、Buildを呼び出し、エラーがあればすべてのエラーのレポートと共にpanicします。
+// 手早く試作する場合など、ビルドエラーを個別に処理する必要がない場面で使用します。
+func (b *Builder[T, W]) MustBuild() W {
+	return MustBuild(b.Build())
+}
+
+// MustBuild は、ビルド結果にエラーがあればレポートと共にpanicし、なければウィジェットを返します。
+// Buildを独自に定義しているビルダーでも MustBuild(b.Build()) の形で使用できます。
+func MustBuild[W Widget](w W, err error) W {
+	if err != nil {
+		panic(fmt.Sprintf("furoshiki: build failed:\n%v", err))
+	}
+	return w
 }
\ No newline at end of file
@@ -30,3 +30,9 @@ type WidgetContainer interface {
 	// メソッドチェーンのための戻り値の型は、具象ビルダーの実装によって処理されます。
 	AddChild(child Widget)
 }
+
+// RefRegistry は、名前をキーにウィジェットへの参照を登録できるレジストリのための契約を定義します。
+// 基底の `component.Builder` の Ref メソッドが使用し、`ui.Refs` によって実装されます。
+type RefRegistry interface {
+	Register(name string, widget Widget) error
+}
@@ -0,0 +1,50 @@
+package component
+
+// RelayoutObserver は、ウィジェットがレイアウト計算を実行した直後に呼び出される関数です。
+// デバッグツールが「どのウィジェットが再レイアウトを引き起こしたか」を可視化するために使用します。
+type RelayoutObserver func(w Widget)
+
+var relayoutObserver RelayoutObserver
+
+// SetRelayoutObserver は、レイアウト計算の実行を通知するオブザーバーを設定します。
+// nilを渡すと通知を停止します。オブザーバーは同時に1つだけ登録できます。
+// NOTE: UIツリーの更新はメインループから単一のゴルーチンで行われる前提のため、同期処理は行いません。
+func SetRelayoutObserver(observer RelayoutObserver) {
+	relayoutObserver = observer
+}
+
+// NotifyRelayout は、レイアウト計算を実行したコンテナから呼び出され、
+// 登録されているオブザーバーに通知します。オブザーバーが未登録の場合は何もしません。
+func NotifyRelayout(w Widget) {
+	if relayoutObserver != nil {
+		relayoutObserver(w)
+	}
+}
+
+// RelayoutRequestObserver は、ウィジェットに再レイアウトが要求されたときに呼び出される関数です。
+// 親への伝播による要求では呼び出されず、MarkDirty(true)が直接呼び出されたウィジェットだけが渡されます。
+type RelayoutRequestObserver func(w Widget)
+
+// ResizeObserver は、ウィジェットの大きさが変わったときに、変更後の大きさとともに呼び出される関数です。
+type ResizeObserver func(w Widget, width, height int)
+
+var (
+	relayoutRequestObserver RelayoutRequestObserver
+	resizeObserver          ResizeObserver
+	// propagatingRelayout は、MarkDirtyが再レイアウトの要求を親へ伝播している最中かどうかです。
+	propagatingRelayout bool
+)
+
+// SetRelayoutRequestObserver は、再レイアウトの要求を通知するオブザーバーを設定します。
+// nilを渡すと通知を停止します。オブザーバーは同時に1つだけ登録できます。
+// 毎フレーム再レイアウトを要求し続けるウィジェットを見つけるために使用します。
+func SetRelayoutRequestObserver(observer RelayoutRequestObserver) {
+	relayoutRequestObserver = observer
+}
+
+// SetResizeObserver は、ウィジェットの大きさの変更を通知するオブザーバーを設定します。
+// nilを渡すと通知を停止します。オブザーバーは同時に1つだけ登録できます。
+// レイアウトの結果が2つの大きさの間で振動していないかを調べるために使用します。
+func SetResizeObserver(observer ResizeObserver) {
+	resizeObserver = observer
+}
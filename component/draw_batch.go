@@ -0,0 +1,126 @@
+package component
+
+import (
+	"furoshiki/stats"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// drawBatcher は、背景や境界線などの単色の三角形を複数のウィジェットにまたがって蓄積し、
+// 同じ描画先へのDrawTriangles呼び出しを1回にまとめます。
+// 大量の行を持つリストのように、同じ種類のウィジェットが多数並ぶ場合に描画命令の数を大きく削減します。
+//
+// 描画順序を保つため、バッチは次のタイミングでフラッシュされます。
+//   - 描画先の画像が変わったとき（オフスクリーン描画への切り替えなど）
+//   - テキストなど、バッチを経由しない描画を行う直前
+//   - ルートコンテナの描画が完了したとき
+//   - 頂点数またはインデックス数がEbitengineの上限に達したとき
+type drawBatcher struct {
+	dst      *ebiten.Image
+	vertices []ebiten.Vertex
+	indices  []uint16
+
+	// scratchVertices, scratchIndices は、パスから頂点を生成する際に再利用する作業用バッファです。
+	scratchVertices []ebiten.Vertex
+	scratchIndices  []uint16
+}
+
+var (
+	batcher         drawBatcher
+	batchingEnabled = true
+	batchTriOptions = &ebiten.DrawTrianglesOptions{AntiAlias: true}
+)
+
+// SetDrawBatchingEnabled は、背景と境界線の描画バッチ処理の有効・無効を切り替えます。
+// 無効にすると、図形ごとに即座にDrawTrianglesが発行されます（デバッグ用）。
+func SetDrawBatchingEnabled(enabled bool) {
+	FlushDraws()
+	batchingEnabled = enabled
+}
+
+// FlushDraws は、蓄積されている背景と境界線の描画命令を即座に発行します。
+// カスタムウィジェットがDrawメソッド内でEbitengineのAPIを直接使って描画する場合は、
+// 描画順序を保つために、その前にこの関数を呼び出してください。
+// ルートコンテナは描画の最後に自動的にこの関数を呼び出します。
+func FlushDraws() {
+	batcher.flush()
+}
+
+// flush は、蓄積された三角形を1回のDrawTrianglesで描画し、バッチを空にします。
+func (b *drawBatcher) flush() {
+	if len(b.indices) > 0 {
+		ensureWhitePixelImg()
+		b.dst.DrawTriangles(b.vertices, b.indices, whitePixelImg, batchTriOptions)
+		stats.AddDrawCalls(1)
+	}
+	b.dst = nil
+	b.vertices = b.vertices[:0]
+	b.indices = b.indices[:0]
+}
+
+// add は、図形の頂点とインデックスをバッチに追加します。
+// indicesは、verticesの先頭を0とするインデックスでなければなりません。
+func (b *drawBatcher) add(dst *ebiten.Image, vertices []ebiten.Vertex, indices []uint16) {
+	if len(vertices) == 0 || len(indices) == 0 {
+		return
+	}
+	ensureWhitePixelImg()
+	if !batchingEnabled {
+		dst.DrawTriangles(vertices, indices, whitePixelImg, batchTriOptions)
+		stats.AddDrawCalls(1)
+		return
+	}
+
+	if b.dst != dst ||
+		len(b.vertices)+len(vertices) > ebiten.MaxVertexCount ||
+		len(b.indices)+len(indices) > ebiten.MaxIndicesCount {
+		b.flush()
+	}
+	b.dst = dst
+
+	base := uint16(len(b.vertices))
+	b.vertices = append(b.vertices, vertices...)
+	for _, idx := range indices {
+		b.indices = append(b.indices, base+idx)
+	}
+}
+
+// DrawFilledPath は、パスで囲まれた図形を単色で塗りつぶす描画命令をバッチに追加します。
+func DrawFilledPath(dst *ebiten.Image, path *vector.Path, clr color.Color) {
+	if path == nil || clr == nil {
+		return
+	}
+	drawVectorPath(dst, path, clr, nil)
+}
+
+// DrawFilledCircle は、中心(cx, cy)、半径radiusの円を単色で塗りつぶす描画命令をバッチに追加します。
+func DrawFilledCircle(dst *ebiten.Image, cx, cy, radius float32, clr color.Color) {
+	if radius <= 0 || clr == nil {
+		return
+	}
+	drawVectorPath(dst, createCirclePath(cx, cy, radius), clr, nil)
+}
+
+// DrawFilledRect は、矩形を単色で塗りつぶす描画命令をバッチに追加します。
+// vector.DrawFilledRectと異なり、前後の背景描画とまとめて1回の描画命令として発行されます。
+func DrawFilledRect(dst *ebiten.Image, x, y, width, height float32, clr color.Color) {
+	if width <= 0 || height <= 0 || clr == nil {
+		return
+	}
+	cr, cg, cb, ca := colorToScale(clr)
+	if ca == 0 {
+		return
+	}
+
+	vertices := append(batcher.scratchVertices[:0],
+		ebiten.Vertex{DstX: x, DstY: y, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+		ebiten.Vertex{DstX: x + width, DstY: y, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+		ebiten.Vertex{DstX: x + width, DstY: y + height, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+		ebiten.Vertex{DstX: x, DstY: y + height, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+	)
+	indices := append(batcher.scratchIndices[:0], 0, 1, 2, 0, 2, 3)
+	batcher.add(dst, vertices, indices)
+	batcher.scratchVertices, batcher.scratchIndices = vertices, indices
+}
@@ -0,0 +1,61 @@
+package component
+
+import "image"
+
+// DrawHook は、ウィジェットの描画の直前または直後に呼び出される関数です。
+// boundsは、親から渡された描画オフセットを適用済みの、描画先画像上でのウィジェットの最終的な境界です。
+// 選択状態の色付け、フォーカスの光彩、デバッグ用の目印などを、
+// 既存ウィジェットのDrawをオーバーライドせずに重ねて描画するために使用します。
+type DrawHook func(info DrawInfo, bounds image.Rectangle)
+
+// drawHooks は、ウィジェットに登録された描画フックを保持します。
+type drawHooks struct {
+	before []DrawHook
+	after  []DrawHook
+}
+
+// AddOnBeforeDraw は、ウィジェット本体を描画する直前に呼び出されるフックを追加します。
+// ここで描画した内容はウィジェット本体の下に表示されます。
+func (w *LayoutableWidget) AddOnBeforeDraw(hook DrawHook) {
+	if hook != nil {
+		w.hooks.before = append(w.hooks.before, hook)
+	}
+}
+
+// AddOnAfterDraw は、ウィジェット本体（子孫を含む）を描画した直後に呼び出されるフックを追加します。
+// ここで描画した内容はウィジェット本体の上に重なって表示されます。
+func (w *LayoutableWidget) AddOnAfterDraw(hook DrawHook) {
+	if hook != nil {
+		w.hooks.after = append(w.hooks.after, hook)
+	}
+}
+
+// drawHookState は、このウィジェットの描画フックを返します。
+func (w *LayoutableWidget) drawHookState() *drawHooks {
+	return &w.hooks
+}
+
+// drawHookOwner は、描画フックを持つウィジェットを識別するための内部インターフェースです。
+type drawHookOwner interface {
+	drawHookState() *drawHooks
+}
+
+// runDrawHooks は、登録されたフックを順に呼び出します。
+// フックはebitenの描画APIを直接呼び出す可能性があるため、呼び出し前に保留中の描画を確定させます。
+func runDrawHooks(hooks []DrawHook, info DrawInfo, bounds image.Rectangle) {
+	if len(hooks) == 0 {
+		return
+	}
+	FlushDraws()
+	for _, hook := range hooks {
+		hook(info, bounds)
+	}
+}
+
+// widgetDrawBounds は、描画オフセットを適用したウィジェットの最終的な境界を返します。
+func widgetDrawBounds(w Widget, info DrawInfo) image.Rectangle {
+	x, y, width, height := widgetBounds(w)
+	x += info.OffsetX
+	y += info.OffsetY
+	return image.Rect(x, y, x+width, y+height)
+}
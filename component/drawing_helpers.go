@@ -1,6 +1,7 @@
 package component
 
 import (
+	"furoshiki/stats"
 	"furoshiki/style"
 	"furoshiki/utils" // UPDATE: utilsパッケージをインポート
 	"image"
@@ -31,17 +32,6 @@ func ensureWhitePixelImg() {
 
 // --- Drawing Helper ---
 
-// applyOpacity は元の色に不透明度を適用した新しい色を返します。
-// color.NRGBAModelに変換してアルファ値を操作することで、安全かつ意図通りに動作させます。
-func applyOpacity(c color.Color, opacity *float64) color.Color {
-	if c == nil || opacity == nil {
-		return c
-	}
-	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
-	nrgba.A = uint8(float64(nrgba.A) * (*opacity))
-	return nrgba
-}
-
 // colorToScale は color.Color を ebiten.Vertex で使用する float32 の RGBA スケール値([0, 1])に変換します。
 func colorToScale(clr color.Color) (float32, float32, float32, float32) {
 	if clr == nil {
@@ -84,18 +74,91 @@ func createRoundedRectPath(x, y, width, height, radius float32) *vector.Path {
 	return path
 }
 
+// createCirclePath は、中心(cx, cy)、半径radiusの円のパスを生成します。
+func createCirclePath(cx, cy, radius float32) *vector.Path {
+	path := &vector.Path{}
+	path.Arc(cx, cy, radius, 0, 2*math.Pi, vector.Clockwise)
+	path.Close()
+	return path
+}
+
+// maskedImageOptions は、画像を図形の形に切り抜いて描画する際のオプションです。
+var maskedImageOptions = &ebiten.DrawTrianglesOptions{AntiAlias: true, Filter: ebiten.FilterLinear}
+
+// DrawImageInPath は、画像をrectを覆うように縦横比を保って拡大縮小し、pathで囲まれた部分だけを描画します。
+// はみ出した部分は上下または左右が均等に切り取られます。円や角丸矩形など、矩形でない形に画像を切り抜くために使用します。
+// 画像をソースとするため背景のバッチにはまとめられず、呼び出しごとに1回の描画命令になります。
+func DrawImageInPath(dst, img *ebiten.Image, path *vector.Path, rect image.Rectangle) {
+	if img == nil || path == nil || rect.Empty() {
+		return
+	}
+	src := img.Bounds()
+	if src.Empty() {
+		return
+	}
+	scale := max(float32(rect.Dx())/float32(src.Dx()), float32(rect.Dy())/float32(src.Dy()))
+	originX := float32(rect.Min.X) + (float32(rect.Dx())-float32(src.Dx())*scale)/2
+	originY := float32(rect.Min.Y) + (float32(rect.Dy())-float32(src.Dy())*scale)/2
+
+	FlushDraws()
+	vertices, indices := path.AppendVerticesAndIndicesForFilling(batcher.scratchVertices[:0], batcher.scratchIndices[:0])
+	batcher.scratchVertices, batcher.scratchIndices = vertices, indices
+	if len(vertices) == 0 {
+		return
+	}
+	// 描画先の各頂点に、画像上で対応する位置を割り当てます。
+	for i := range vertices {
+		v := &vertices[i]
+		v.SrcX = float32(src.Min.X) + (v.DstX-originX)/scale
+		v.SrcY = float32(src.Min.Y) + (v.DstY-originY)/scale
+		v.ColorR, v.ColorG, v.ColorB, v.ColorA = 1, 1, 1, 1
+	}
+	dst.DrawTriangles(vertices, indices, img, maskedImageOptions)
+	stats.AddDrawCalls(1)
+}
+
+// DrawImageRounded は、画像をrectを覆うように拡大縮小し、半径radiusの角丸矩形の形に切り抜いて描画します。
+func DrawImageRounded(dst, img *ebiten.Image, rect image.Rectangle, radius float32) {
+	if rect.Empty() {
+		return
+	}
+	path := createRoundedRectPath(float32(rect.Min.X), float32(rect.Min.Y), float32(rect.Dx()), float32(rect.Dy()), radius)
+	DrawImageInPath(dst, img, path, rect)
+}
+
+// DrawImageCircle は、rectの中央に内接する円の形に画像を切り抜いて描画します。
+// 画像は円の外接正方形を覆うように拡大縮小されます。
+func DrawImageCircle(dst, img *ebiten.Image, rect image.Rectangle) {
+	square := centeredSquare(rect)
+	if square.Empty() {
+		return
+	}
+	r := float32(square.Dx()) / 2
+	DrawImageInPath(dst, img, createCirclePath(float32(square.Min.X)+r, float32(square.Min.Y)+r, r), square)
+}
+
+// centeredSquare は、rectの中央に置いた、rectに収まる最大の正方形を返します。
+func centeredSquare(rect image.Rectangle) image.Rectangle {
+	size := min(rect.Dx(), rect.Dy())
+	x := rect.Min.X + (rect.Dx()-size)/2
+	y := rect.Min.Y + (rect.Dy()-size)/2
+	return image.Rect(x, y, x+size, y+size)
+}
+
 // drawVectorPath は、vector.Pathを描画するための共通ヘルパー関数です。
 // strokeOptsがnilでない場合は線を描画し、nilの場合は図形を塗りつぶします。
 // これにより、背景と境界線の描画ロジックにおけるコードの重複を削減します。
-func drawVectorPath(dst *ebiten.Image, path *vector.Path, clr color.Color, triOpts *ebiten.DrawTrianglesOptions, strokeOpts *vector.StrokeOptions) {
+// UPDATE: 即座にDrawTrianglesを呼び出す代わりに、描画バッチに頂点を追加するように変更しました。
+func drawVectorPath(dst *ebiten.Image, path *vector.Path, clr color.Color, strokeOpts *vector.StrokeOptions) {
 	var vertices []ebiten.Vertex
 	var indices []uint16
 
 	if strokeOpts != nil {
-		vertices, indices = path.AppendVerticesAndIndicesForStroke(nil, nil, strokeOpts)
+		vertices, indices = path.AppendVerticesAndIndicesForStroke(batcher.scratchVertices[:0], batcher.scratchIndices[:0], strokeOpts)
 	} else {
-		vertices, indices = path.AppendVerticesAndIndicesForFilling(nil, nil)
+		vertices, indices = path.AppendVerticesAndIndicesForFilling(batcher.scratchVertices[:0], batcher.scratchIndices[:0])
 	}
+	batcher.scratchVertices, batcher.scratchIndices = vertices, indices
 
 	if len(vertices) == 0 {
 		return
@@ -106,93 +169,80 @@ func drawVectorPath(dst *ebiten.Image, path *vector.Path, clr color.Color, triOp
 		vertices[i].ColorR, vertices[i].ColorG, vertices[i].ColorB, vertices[i].ColorA = cr, cg, cb, ca
 	}
 
-	dst.DrawTriangles(vertices, indices, whitePixelImg, triOpts)
+	batcher.add(dst, vertices, indices)
 }
 
 // DrawStyledBackground は、指定されたスタイルでウィジェットの背景と境界線を描画します。
 // この関数は、描画ロジックを内部ヘルパー関数(drawBackground, drawBorder)に委譲することで、
 // コードの関心事を分離し、可読性を高めています。
+// NOTE: 描画ループから呼び出す場合は、キャッシュ済みの値型スタイルを受け取る
+//       DrawComputedBackground を使用してください。こちらは呼び出しのたびにスタイルを解決します。
 func DrawStyledBackground(dst *ebiten.Image, x, y, width, height int, s style.Style) {
+	DrawComputedBackground(dst, x, y, width, height, style.Resolve(s))
+}
+
+// DrawComputedBackground は、解決済みの値型スタイルでウィジェットの背景と境界線を描画します。
+// スタイルの参照を辿ったり不透明度を適用した色を生成したりしないため、ヒープ割り当てが発生しません。
+func DrawComputedBackground(dst *ebiten.Image, x, y, width, height int, c style.Computed) {
 	if width <= 0 || height <= 0 {
 		return
 	}
-	ensureWhitePixelImg()
 
 	fx, fy := float32(x), float32(y)
 	fw, fh := float32(width), float32(height)
 
-	drawTrianglesOptions := &ebiten.DrawTrianglesOptions{AntiAlias: true}
-
-	drawBackground(dst, fx, fy, fw, fh, s, drawTrianglesOptions)
-	drawBorder(dst, fx, fy, fw, fh, s, drawTrianglesOptions)
+	drawBackground(dst, fx, fy, fw, fh, c)
+	drawBorder(dst, fx, fy, fw, fh, c)
 }
 
 // drawBackground は、ウィジェットの背景を描画する内部ヘルパーです。
-func drawBackground(dst *ebiten.Image, x, y, width, height float32, s style.Style, opts *ebiten.DrawTrianglesOptions) {
-	bgColorPtr := s.Background
-	if bgColorPtr == nil || *bgColorPtr == color.Transparent {
+func drawBackground(dst *ebiten.Image, x, y, width, height float32, c style.Computed) {
+	if c.Background == nil {
 		return
 	}
 
-	bgColor := *bgColorPtr
-	if s.Opacity != nil {
-		bgColor = applyOpacity(bgColor, s.Opacity)
-	}
-
-	radius := float32(0)
-	if s.BorderRadius != nil {
-		radius = *s.BorderRadius
-	}
-
-	if radius > 0 {
+	if c.BorderRadius > 0 {
 		// パスを生成し、共通描画ヘルパーを呼び出します（塗りつぶしモード）。
-		path := createRoundedRectPath(x, y, width, height, radius)
-		drawVectorPath(dst, path, bgColor, opts, nil)
+		path := createRoundedRectPath(x, y, width, height, c.BorderRadius)
+		drawVectorPath(dst, path, c.Background, nil)
 	} else {
-		vector.DrawFilledRect(dst, x, y, width, height, bgColor, false)
+		DrawFilledRect(dst, x, y, width, height, c.Background)
 	}
 }
 
 // drawBorder は、ウィジェットの境界線を描画する内部ヘルパーです。
 // 常にパスベースの描画を使用することで、角丸でない矩形でも境界線が
 // クリッピング領域の内側に正しく描画されることを保証します。
-func drawBorder(dst *ebiten.Image, x, y, width, height float32, s style.Style, opts *ebiten.DrawTrianglesOptions) {
-	borderColorPtr := s.BorderColor
-	borderWidth := float32(0)
-	if s.BorderWidth != nil {
-		borderWidth = *s.BorderWidth
-	}
-	if borderColorPtr == nil || *borderColorPtr == color.Transparent || borderWidth <= 0 {
+func drawBorder(dst *ebiten.Image, x, y, width, height float32, c style.Computed) {
+	borderWidth := c.BorderWidth
+	if c.BorderColor == nil || borderWidth <= 0 {
 		return
 	}
 
-	borderColor := *borderColorPtr
-	if s.Opacity != nil {
-		borderColor = applyOpacity(borderColor, s.Opacity)
-	}
-
-	radius := float32(0)
-	if s.BorderRadius != nil {
-		radius = *s.BorderRadius
-	}
-
 	// 境界線のパスは、図形の中心に描画されるため、幅の半分だけ内側にオフセットさせます。
 	// これにより、`vector.StrokeRect`のように境界線の半分が外側にはみ出すのを防ぎ、
 	// クリッピングが有効なコンテナでも枠線が正しく描画されます。
 	halfBw := borderWidth / 2
-	insetPath := createRoundedRectPath(x+halfBw, y+halfBw, width-borderWidth, height-borderWidth, radius-halfBw)
+	insetPath := createRoundedRectPath(x+halfBw, y+halfBw, width-borderWidth, height-borderWidth, c.BorderRadius-halfBw)
 
 	// 線描画用のオプションを作成し、共通描画ヘルパーを呼び出します。
 	strokeOpts := &vector.StrokeOptions{Width: borderWidth, MiterLimit: 10}
-	drawVectorPath(dst, insetPath, borderColor, opts, strokeOpts)
+	drawVectorPath(dst, insetPath, c.BorderColor, strokeOpts)
 }
 
 // CalculateWrappedText は、指定された幅でテキストを折り返し、
 // 結果の行のスライスと、それらを描画するのに必要な合計高さを返します。
+// 折り返す位置はstyle.LineBreakAutoに従います。
 func CalculateWrappedText(f font.Face, textContent string, maxWidth int) ([]string, int) {
+	return CalculateWrappedTextWithBreak(f, textContent, maxWidth, style.LineBreakAuto)
+}
+
+// CalculateWrappedTextWithBreak は、modeで指定された改行位置の決め方に従ってテキストを折り返します。
+// 戻り値はCalculateWrappedTextと同じです。
+func CalculateWrappedTextWithBreak(f font.Face, textContent string, maxWidth int, mode style.LineBreakType) ([]string, int) {
 	if maxWidth <= 0 || textContent == "" {
 		if f != nil {
-			metrics := f.Metrics()
+			metrics := FaceMetrics(f)
 			return []string{textContent}, (metrics.Ascent + metrics.Descent).Ceil()
 		}
 		return []string{textContent}, 0
@@ -211,26 +261,41 @@ func CalculateWrappedText(f font.Face, textContent string, maxWidth int) ([]stri
 		return []string{}, 0
 	}
 
-	currentLine := words[0]
-	for _, word := range words[1:] {
+	if mode == style.LineBreakAuto && !HasUnspacedScript(textContent) {
+		// 空白で区切る言語のテキストは、単語の途中で改行しないため、単語単位で詰めます。
+		mode = style.LineBreakWord
+	}
+	currentLine := ""
+	for i, word := range words {
 		if word == "" {
 			// 連続したスペースを結合しようとすると、先頭に不要なスペースが入るため、
 			// currentLineにスペースを追加するだけにします。
 			currentLine += " "
 			continue
 		}
-		testLine := currentLine + " " + word
-		bounds := text.BoundString(f, testLine)
-		if bounds.Dx() > maxWidth {
-			lines = append(lines, currentLine)
-			currentLine = word
-		} else {
-			currentLine = testLine
+		// 単語を改行できる位置で分割し、分割した部分ごとに行へ詰めます。
+		// 単語の間には空白を入れ、単語の中の部分は空白を入れずにつなげます。
+		for j, unit := range LineBreakUnits(word, mode) {
+			if i == 0 && j == 0 {
+				currentLine = unit
+				continue
+			}
+			testLine := currentLine + unit
+			if j == 0 {
+				testLine = currentLine + " " + unit
+			}
+			bounds := BoundString(f, testLine)
+			if bounds.Dx() > maxWidth {
+				lines = append(lines, currentLine)
+				currentLine = unit
+			} else {
+				currentLine = testLine
+			}
 		}
 	}
 	lines = append(lines, currentLine)
 
-	metrics := f.Metrics()
+	metrics := FaceMetrics(f)
 	lineHeight := (metrics.Ascent + metrics.Descent).Ceil()
 	totalHeight := lineHeight * len(lines)
 
@@ -243,73 +308,126 @@ func DrawAlignedText(screen *ebiten.Image, textContent string, area image.Rectan
 	if textContent == "" || s.Font == nil || *s.Font == nil {
 		return
 	}
+	DrawComputedText(screen, textContent, area, style.Resolve(s), wrap)
+}
+
+// DrawComputedText は、解決済みの値型スタイルを使用して、矩形領域内にテキストを揃えて描画します。
+func DrawComputedText(screen *ebiten.Image, textContent string, area image.Rectangle, c style.Computed, wrap bool) {
+	if textContent == "" || c.Font == nil {
+		return
+	}
+
+	contentRect := contentRectOf(area, c.Padding)
+	if contentRect.Dx() <= 0 || contentRect.Dy() <= 0 {
+		return
+	}
+
+	if wrap {
+		lines, _ := CalculateWrappedTextWithBreak(c.Font, textContent, contentRect.Dx(), c.LineBreak)
+		drawLines(screen, lines, contentRect, c)
+		return
+	}
+	drawLine(screen, textContent, contentRect, c)
+}
 
-	padding := style.Insets{}
-	if s.Padding != nil {
-		padding = *s.Padding
+// DrawAlignedLines は、折り返し済みの行を指定された矩形領域内に揃えて描画します。
+// 折り返し結果をキャッシュしているウィジェットが、描画のたびに折り返しを再計算しないために使用します。
+func DrawAlignedLines(screen *ebiten.Image, lines []string, area image.Rectangle, c style.Computed) {
+	if len(lines) == 0 || c.Font == nil {
+		return
 	}
 
-	contentRect := image.Rect(
+	contentRect := contentRectOf(area, c.Padding)
+	if contentRect.Dx() <= 0 || contentRect.Dy() <= 0 {
+		return
+	}
+	drawLines(screen, lines, contentRect, c)
+}
+
+// contentRectOf は、領域からパディングを除いたコンテンツ領域を返します。
+func contentRectOf(area image.Rectangle, padding style.Insets) image.Rectangle {
+	return image.Rect(
 		area.Min.X+padding.Left,
 		area.Min.Y+padding.Top,
 		area.Max.X-padding.Right,
 		area.Max.Y-padding.Bottom,
 	)
-	if contentRect.Dx() <= 0 || contentRect.Dy() <= 0 {
+}
+
+// drawLine は、1行のテキストを描画します。
+// 折り返しのないテキストのために、行のスライスを割り当てずに済むよう分けています。
+func drawLine(screen *ebiten.Image, line string, contentRect image.Rectangle, c style.Computed) {
+	metrics := FaceMetrics(c.Font)
+	lineHeight := (metrics.Ascent + metrics.Descent).Ceil()
+	startY := alignedStartY(contentRect, lineHeight, metrics.Ascent.Ceil(), c.VerticalAlign)
+
+	// テキストはバッチを経由せずに描画されるため、先に蓄積された背景を描画して順序を保ちます。
+	FlushDraws()
+	rtl := IsRTL(line)
+	if drawTruncatedLine(screen, line, contentRect, startY, c, rtl) {
 		return
 	}
+	drawAlignedLine(screen, line, contentRect, startY, c, rtl)
+}
 
-	var lines []string
-	var totalTextHeight int
-	metrics := (*s.Font).Metrics()
+// drawLines は、コンテンツ領域内に行を揃えて描画する内部ヘルパーです。
+func drawLines(screen *ebiten.Image, lines []string, contentRect image.Rectangle, c style.Computed) {
+	metrics := FaceMetrics(c.Font)
 	lineHeight := (metrics.Ascent + metrics.Descent).Ceil()
+	totalTextHeight := lineHeight * len(lines)
+	startY := alignedStartY(contentRect, totalTextHeight, metrics.Ascent.Ceil(), c.VerticalAlign)
 
-	if wrap {
-		lines, totalTextHeight = CalculateWrappedText(*s.Font, textContent, contentRect.Dx())
-	} else {
-		lines = []string{textContent}
-		totalTextHeight = lineHeight
+	// テキストはバッチを経由せずに描画されるため、先に蓄積された背景を描画して順序を保ちます。
+	FlushDraws()
+
+	// 段落の方向は、折り返す前のテキスト全体の先頭の文字で決まるため、最初に方向が分かる行で判定します。
+	rtl := paragraphRTL(lines)
+	for i, line := range lines {
+		drawAlignedLine(screen, line, contentRect, startY+i*lineHeight, c, rtl)
 	}
+}
 
-	var startY int
-	verticalAlign := style.VerticalAlignMiddle
-	if s.VerticalAlign != nil {
-		verticalAlign = *s.VerticalAlign
+// paragraphRTL は、折り返された行からなる段落の方向が右から左かどうかを返します。
+func paragraphRTL(lines []string) bool {
+	for _, line := range lines {
+		for _, r := range line {
+			switch classOf(r) {
+			case bidiL:
+				return false
+			case bidiR:
+				return true
+			}
+		}
 	}
+	return false
+}
+
+// alignedStartY は、垂直方向の揃え位置に基づいて最初の行のベースラインのY座標を返します。
+func alignedStartY(contentRect image.Rectangle, totalTextHeight, ascent int, verticalAlign style.VerticalAlignType) int {
 	switch verticalAlign {
 	case style.VerticalAlignTop:
-		startY = contentRect.Min.Y + metrics.Ascent.Ceil()
+		return contentRect.Min.Y + ascent
 	case style.VerticalAlignBottom:
-		startY = contentRect.Max.Y - totalTextHeight + metrics.Ascent.Ceil()
+		return contentRect.Max.Y - totalTextHeight + ascent
 	default: // style.VerticalAlignMiddle
-		startY = contentRect.Min.Y + (contentRect.Dy()-totalTextHeight)/2 + metrics.Ascent.Ceil()
-	}
-
-	textAlign := style.TextAlignLeft
-	if s.TextAlign != nil {
-		textAlign = *s.TextAlign
-	}
-
-	textColor := color.Color(color.Black)
-	if s.TextColor != nil {
-		textColor = *s.TextColor
-	}
-	if s.Opacity != nil {
-		textColor = applyOpacity(textColor, s.Opacity)
+		return contentRect.Min.Y + (contentRect.Dy()-totalTextHeight)/2 + ascent
 	}
+}
 
-	for i, line := range lines {
-		bounds := text.BoundString(*s.Font, line)
-		var textX int
-		switch textAlign {
-		case style.TextAlignCenter:
-			textX = contentRect.Min.X + (contentRect.Dx()-bounds.Dx())/2
-		case style.TextAlignRight:
-			textX = contentRect.Max.X - bounds.Dx()
-		default: // style.TextAlignLeft
-			textX = contentRect.Min.X
-		}
-		textY := startY + i*lineHeight
-		text.Draw(screen, line, *s.Font, textX, textY, textColor)
+// drawAlignedLine は、水平方向の揃え位置に従って1行を描画します。
+// 論理順の行は、rtl(段落の方向)に従って表示順に並べ替えてから描画されます。
+func drawAlignedLine(screen *ebiten.Image, line string, contentRect image.Rectangle, textY int, c style.Computed, rtl bool) {
+	line = VisualLine(line, rtl)
+	bounds := BoundString(c.Font, line)
+	var textX int
+	switch ResolveTextAlign(c.TextAlign, rtl) {
+	case style.TextAlignCenter:
+		textX = contentRect.Min.X + (contentRect.Dx()-bounds.Dx())/2
+	case style.TextAlignRight:
+		textX = contentRect.Max.X - bounds.Dx()
+	default: // style.TextAlignLeft
+		textX = contentRect.Min.X
 	}
-}
+	text.Draw(screen, line, c.Font, textX, textY, c.TextColor)
+	stats.AddDrawCalls(1)
+}
\ No newline at end of file
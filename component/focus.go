@@ -0,0 +1,72 @@
+package component
+
+// このファイルは、キーボード入力を受け取るウィジェットを1つに決める、キーボードフォーカスの管理を提供します。
+// フォーカスはアプリケーション全体で1つです。UIの更新はゲームループ(単一のゴルーチン)から
+// 行われることを前提としているため、排他制御は行いません。
+
+// Focusable は、キーボードフォーカスを受け取れるウィジェットが実装するインターフェースです。
+// LayoutableWidgetを埋め込むすべてのウィジェットが実装しています。フォーカスを持っている間は、
+// StateFocusedのスタイルで描画され、周囲にフォーカスリングが描画されます(focus_ring.go を参照してください)。
+type Focusable interface {
+	Widget
+	// SetFocused は、フォーカスの取得または喪失をウィジェットに通知します。
+	// SetFocusなどのフォーカス管理関数から呼び出されるため、直接呼び出さないでください。
+	SetFocused(focused bool)
+	// IsFocused は、ウィジェットがフォーカスを持っているかどうかを返します。
+	IsFocused() bool
+}
+
+// focused は、現在フォーカスを持っているウィジェットです。
+var focused Focusable
+
+// SetFocus は、指定されたウィジェットにフォーカスを移します。
+// 以前にフォーカスを持っていたウィジェットには、喪失が通知されます。nilを渡すとフォーカスを解除します。
+func SetFocus(w Focusable) {
+	if focused == w {
+		return
+	}
+	prev := focused
+	focused = w
+	if prev != nil {
+		prev.SetFocused(false)
+	}
+	if w != nil {
+		w.SetFocused(true)
+		notifyAccessibility(AccessibilityFocusChanged, w)
+	}
+}
+
+// FocusedWidget は、現在フォーカスを持っているウィジェットを返します。ない場合はnilです。
+func FocusedWidget() Focusable {
+	return focused
+}
+
+// ClearFocus は、フォーカスを解除します。
+func ClearFocus() {
+	SetFocus(nil)
+}
+
+// SetFocused は、Focusableインターフェースの実装です。フォーカスの状態(StateFocused)のスタイルで描画し直すため、再描画を要求します。
+// フォーカスの有無は、SetFocusで設定されたウィジェットかどうかで判定されるため、このメソッドは状態を保持しません。
+// TextInputのように独自のフォーカス処理を持つウィジェットは、このメソッドをオーバーライドします。
+func (w *LayoutableWidget) SetFocused(focused bool) {
+	w.MarkDirty(false)
+}
+
+// IsFocused は、ウィジェットがフォーカスを持っているかどうかを返します。
+func (w *LayoutableWidget) IsFocused() bool {
+	return w.hasFocus()
+}
+
+// hasFocus は、このウィジェット(を埋め込む具象ウィジェット)がフォーカスを持っているかどうかを返します。
+func (w *LayoutableWidget) hasFocus() bool {
+	return focused != nil && w.self != nil && Widget(focused) == w.self
+}
+
+// Blur は、指定されたウィジェットがフォーカスを持っている場合にのみ、フォーカスを解除します。
+// ウィジェットが非表示になったときや、Cleanupの際に使用します。
+func Blur(w Focusable) {
+	if w != nil && focused == w {
+		SetFocus(nil)
+	}
+}
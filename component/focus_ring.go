@@ -0,0 +1,66 @@
+package component
+
+import (
+	"furoshiki/theme"
+
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// このファイルは、フォーカスを持っているウィジェットの周囲に描画するフォーカスリングを提供します。
+// キーボードやゲームパッドで操作する利用者が、現在の操作対象を見失わないようにするためのものです。
+// リングの色、太さ、ウィジェットからの距離は、テーマのFocusRingで設定します。
+
+// SetFocusRingVisible は、このウィジェットがフォーカスを持っている間に、フォーカスリングを描画するかどうかを設定します。
+// 既定では描画されます。StateFocusedのスタイルなどで独自にフォーカスを表示するウィジェットでは、falseにできます。
+func (w *LayoutableWidget) SetFocusRingVisible(visible bool) {
+	if w.state.hideFocusRing == !visible {
+		return
+	}
+	w.state.hideFocusRing = !visible
+	if w.hasFocus() {
+		w.MarkDirty(false)
+	}
+}
+
+// IsFocusRingVisible は、フォーカスを持っている間に、フォーカスリングを描画するかどうかを返します。
+func (w *LayoutableWidget) IsFocusRingVisible() bool {
+	return !w.state.hideFocusRing
+}
+
+// focusRingOwner は、フォーカスリングの表示設定を持つウィジェットを識別するためのインターフェースです。
+type focusRingOwner interface {
+	IsFocusRingVisible() bool
+}
+
+// drawFocusRing は、wがフォーカスを持っていれば、その周囲にフォーカスリングを描画します。
+// リングはウィジェット自身の描画キャッシュやシェーダーの外側に描画されるため、境界の外側にはみ出して表示できます。
+func drawFocusRing(w Widget, info DrawInfo) {
+	if focused == nil || Widget(focused) != w {
+		return
+	}
+	if owner, ok := w.(focusRingOwner); ok && !owner.IsFocusRingVisible() {
+		return
+	}
+	if is, ok := w.(InteractiveState); ok && (!is.IsVisible() || !is.HasBeenLaidOut()) {
+		return
+	}
+	ring := theme.GetCurrent().FocusRing
+	if ring.Width <= 0 || ring.Color == nil {
+		return
+	}
+	b := widgetDrawBounds(w, info)
+	if b.Empty() {
+		return
+	}
+	// 線の中心がウィジェットの境界からOffset+Width/2の位置になるように、矩形を広げます。
+	grow := ring.Offset + ring.Width/2
+	x := float32(b.Min.X) - grow
+	y := float32(b.Min.Y) - grow
+	width := float32(b.Dx()) + grow*2
+	height := float32(b.Dy()) + grow*2
+	if width <= 0 || height <= 0 {
+		return
+	}
+	FlushDraws()
+	vector.StrokeRect(info.Screen, x, y, width, height, ring.Width, ring.Color, true)
+}
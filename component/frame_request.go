@@ -0,0 +1,27 @@
+package component
+
+import "sync/atomic"
+
+// frameRequested は、前回TakeFrameRequestを呼び出してから、UIの更新と描画が要求されたかどうかを表します。
+// 最初のフレームは必ず描画するため、trueで始まります。
+// RequestFrameは任意のゴルーチンから呼び出されるため、アトミックに読み書きします。
+var frameRequested atomic.Bool
+
+func init() {
+	frameRequested.Store(true)
+}
+
+// RequestFrame は、次のフレームでUIの更新と描画が必要であることを通知します。
+// ウィジェットがダーティになると自動的に呼び出されます。ウィジェットの状態を変えずに
+// 表示が変わる場合(Canvasの描画内容が外部のデータに依存する場合など)は、明示的に呼び出してください。
+// デバウンスやアニメーションなど、Updateの中で時間を数える処理は、待機中は毎フレーム呼び出してください。
+// 任意のゴルーチンから呼び出せるため、非同期の処理の完了を通知するためにも使用できます。
+func RequestFrame() {
+	frameRequested.Store(true)
+}
+
+// TakeFrameRequest は、前回の呼び出し以降にフレームが要求されたかどうかを返し、要求をクリアします。
+// 省電力モード(furoshiki.SetLowPowerMode)が、更新と描画を省略できるかを判断するために使用します。
+func TakeFrameRequest() bool {
+	return frameRequested.Swap(false)
+}
@@ -49,6 +49,26 @@ type HeightForWider interface {
 	GetHeightForWidth(width int) int
 }
 
+// WidthForHeighter は、HeightForWiderの逆で、ウィジェットが特定の高さを与えられた場合に
+// 必要となる幅を計算できることを示すインターフェースです。
+// 縦長のツールバーのように、高さが制約される軸である場合に、折り返すテキストの幅を決めるために使用されます。
+// FlexLayoutの縦並び(DirectionColumn)は、高さが先に決まる子(Sizeで高さを指定した子、Flexを持つ子)の幅をこれで求めます。
+type WidthForHeighter interface {
+	GetWidthForHeight(height int) int
+}
+
+// AutoMarginer は、自動のマージン(余ったスペースを吸収するマージン)を設定できるウィジェットのインターフェースです。
+type AutoMarginer interface {
+	SetAutoMargins(sides MarginSide)
+	AutoMargins() MarginSide
+}
+
+// MeasureInvalidator は、計測結果のキャッシュを破棄できるウィジェットのインターフェースです。
+// 計測結果のキャッシュの約束事については、component/measure_cache.go を参照してください。
+type MeasureInvalidator interface {
+	InvalidateMeasure()
+}
+
 // ScrollBarWidget は、ScrollBarが実装すべきメソッドを定義します。
 // これにより、他のパッケージが具体的なScrollBar型に依存することなく、
 // このインターフェースを通じてScrollBarを操作できます。
@@ -94,6 +114,9 @@ type StyleGetterSetter interface {
 	// スタイルのディープコピーを生成しないメソッドを追加しました。
 	// 返されたスタイルは変更してはいけません。
 	ReadOnlyStyle() style.Style
+	// ComputedStyle は、基本スタイルを解決した値型スタイルを返します。
+	// 描画やレイアウトのホットパスでは、ReadOnlyStyleよりもこちらを優先してください。
+	ComputedStyle() style.Computed
 }
 
 // LayoutProperties はレイアウトプロパティを管理するためのインターフェースです
@@ -101,6 +124,8 @@ type LayoutProperties interface {
 	SetFlex(flex int)
 	GetFlex() int
 	SetLayoutBoundary(isBoundary bool)
+	// IsLayoutBoundary は、このウィジェットがレイアウト計算の境界であるかを返します。
+	IsLayoutBoundary() bool
 	// SetLayoutData は、このウィジェットにレイアウト固有のデータを設定します。
 	// 親コンテナのレイアウトシステム（例: AdvancedGridLayout）がこれを使用して、
 	// ウィジェットごとの配置情報（行、列、スパンなど）を管理します。
@@ -131,6 +156,26 @@ type InteractiveState interface {
 	CurrentState() WidgetState
 }
 
+// Pausable は、サブツリーの更新を一時停止できるウィジェットのインターフェースです。
+type Pausable interface {
+	SetPaused(paused bool)
+	IsPaused() bool
+}
+
+// SubtreeDisabler は、自身とその子孫をまとめて無効にできるウィジェットのインターフェースです。
+type SubtreeDisabler interface {
+	SetEnabledRecursive(enabled bool)
+	IsSubtreeDisabled() bool
+}
+
+// Badger は、角にバッジを表示できるウィジェットのインターフェースです。
+type Badger interface {
+	SetBadge(text string, position BadgePosition)
+	SetBadgeCount(count int, position BadgePosition)
+	ClearBadge()
+	Badge() (text string, position BadgePosition)
+}
+
 // EventProcessor はイベント処理のためのインターフェースです
 // NOTE: 以前の EventHandler から名称変更。
 type EventProcessor interface {
@@ -139,12 +184,66 @@ type EventProcessor interface {
 	HandleEvent(e *event.Event)
 }
 
+// MaxSizer は、FlexLayoutで伸長される際の最大サイズを持つウィジェットが実装するインターフェースです。
+// 0の軸は上限なしを意味します。
+type MaxSizer interface {
+	GetMaxSize() (width, height int)
+}
+
 // AbsolutePositioner は、AbsoluteLayout内で希望の相対位置を指定できるウィジェットが実装するインターフェースです。
 type AbsolutePositioner interface {
 	SetRequestedPosition(x, y int)
 	GetRequestedPosition() (x, y int)
 }
 
+// Identifiable は、構築後のウィジェットをIDやタグで検索できるようにするためのインターフェースです。
+// IDは "settings.volume" のようなドット区切りの文字列を想定していますが、形式は強制しません。
+type Identifiable interface {
+	SetID(id string)
+	GetID() string
+	AddTags(tags ...string)
+	HasTag(tag string) bool
+	GetTags() []string
+}
+
+// RenderCacher は、サブツリーの描画結果をオフスクリーン画像にキャッシュできるウィジェットのインターフェースです。
+type RenderCacher interface {
+	SetCacheRendering(enabled bool)
+	IsCacheRendering() bool
+	InvalidateRenderCache()
+}
+
+// Accessible は、支援技術に伝える役割、ラベル、ヒントを持つウィジェットのインターフェースです。
+type Accessible interface {
+	SetAccessibleLabel(label string)
+	AccessibleLabel() string
+	SetAccessibleRole(role Role)
+	AccessibleRole() Role
+	SetAccessibleHint(hint string)
+	AccessibleHint() string
+	SetAccessibleLive(live LiveRegion)
+	AccessibleLive() LiveRegion
+}
+
+// ShaderApplier は、サブツリーの描画結果にKageシェーダーを適用できるウィジェットのインターフェースです。
+type ShaderApplier interface {
+	SetShader(shader *ebiten.Shader, uniforms map[string]any)
+	Shader() *ebiten.Shader
+	SetShaderUniform(name string, value any)
+}
+
+// DrawHooker は、描画の前後にフックを登録できるウィジェットのインターフェースです。
+type DrawHooker interface {
+	AddOnBeforeDraw(hook DrawHook)
+	AddOnAfterDraw(hook DrawHook)
+}
+
+// PointTransformer は、子孫を座標変換して描画するウィジェット(座標変換を設定したContainerなど)が実装するインターフェースです。
+type PointTransformer interface {
+	// ToLocal は、親の座標系の点を子孫の座標系の点に変換します。座標変換がない場合はそのまま返します。
+	ToLocal(x, y int) (int, int)
+}
+
 // HitTester はヒットテストのためのインターフェースです
 type HitTester interface {
 	HitTest(x, y int) Widget
@@ -0,0 +1,115 @@
+package component
+
+import (
+	"furoshiki/style"
+	"unicode"
+	"unicode/utf8"
+)
+
+// このファイルは、日本語や中国語のように単語を空白で区切らない言語のテキストを折り返すための、
+// 文字単位の改行位置の判定を提供します。
+// 改行位置は書記素クラスタ(結合文字などを含めた、利用者から見た1文字)の境界に限られ、
+// 行頭に置けない文字(句読点や閉じ括弧など)と行末に置けない文字(開き括弧など)の前後では改行しません(禁則処理)。
+
+// noLineStart は、行頭に置かない文字です。
+const noLineStart = ",.!?:;)]}%'\"" +
+	"、。，．・：；？！‼⁇⁈⁉ー〜～…‥" +
+	"）］｝」』】〕〉》〙〗〟’”" +
+	"ゝゞ々〻ヽヾ" +
+	"ぁぃぅぇぉっゃゅょゎゕゖァィゥェォッャュョヮヵヶㇰㇱㇲㇳㇴㇵㇶㇷㇸㇹㇺㇻㇼㇽㇾㇿ"
+
+// noLineEnd は、行末に置かない文字です。
+const noLineEnd = "([{" +
+	"（［｛「『【〔〈《〘〖〝‘“"
+
+// isUnspaced は、分かち書きをしない文字(漢字、かな、全角の記号など)かどうかを返します。
+// これらの文字の前後は、空白がなくても改行できる位置として扱います。
+func isUnspaced(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Bopomofo) ||
+		(r >= 0x3000 && r <= 0x303F) || // CJKの記号と句読点
+		(r >= 0xFF00 && r <= 0xFFEF) || // 全角・半角形
+		r == 'ー'
+}
+
+// containsRune は、文字列setにrが含まれるかどうかを返します。
+func containsRune(set string, r rune) bool {
+	for _, c := range set {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
+
+// HasUnspacedScript は、テキストに分かち書きをしない文字(漢字、かなど)が含まれるかどうかを返します。
+// LineBreakAutoで、空白以外の位置でも折り返す必要があるかどうかの判定に使用します。
+func HasUnspacedScript(s string) bool {
+	for _, r := range s {
+		if isUnspaced(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// graphemes は、空白を含まない単語を書記素クラスタに分割します。
+// 結合文字、異体字セレクタ、およびゼロ幅接合子(ZWJ)でつながった文字は、直前の文字と同じクラスタに含めます。
+func graphemes(word string) []string {
+	var clusters []string
+	start := 0
+	joined := false
+	for i, r := range word {
+		if i == 0 {
+			continue
+		}
+		extends := unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+			(r >= 0xFE00 && r <= 0xFE0F) || // 異体字セレクタ
+			(r >= 0x1F3FB && r <= 0x1F3FF) || // 肌の色の修飾子
+			r == 0x200D
+		if !extends && !joined {
+			clusters = append(clusters, word[start:i])
+			start = i
+		}
+		joined = r == 0x200D
+	}
+	return append(clusters, word[start:])
+}
+
+// LineBreakUnits は、空白を含まない単語を、modeに従って途中で改行できる位置で分割します。
+// 分割された各部分の間では改行でき、部分の内側では改行しません。
+// LineBreakWordでは単語全体を1つの部分として返します。
+// LineBreakAutoでは、分かち書きをしない文字の前後でのみ分割します。
+// いずれの場合も、禁則処理の対象となる文字の前後では分割しません。
+func LineBreakUnits(word string, mode style.LineBreakType) []string {
+	if word == "" || mode == style.LineBreakWord {
+		return []string{word}
+	}
+	if mode == style.LineBreakAuto && !HasUnspacedScript(word) {
+		return []string{word}
+	}
+	clusters := graphemes(word)
+	units := make([]string, 0, len(clusters))
+	start := 0
+	offset := len(clusters[0])
+	for i := 1; i < len(clusters); i++ {
+		prev, _ := utf8.DecodeLastRuneInString(clusters[i-1])
+		next, _ := utf8.DecodeRuneInString(clusters[i])
+		if canBreakBetween(prev, next, mode) {
+			units = append(units, word[start:offset])
+			start = offset
+		}
+		offset += len(clusters[i])
+	}
+	return append(units, word[start:])
+}
+
+// canBreakBetween は、文字prevとnextの間で改行できるかどうかを返します。
+func canBreakBetween(prev, next rune, mode style.LineBreakType) bool {
+	if containsRune(noLineStart, next) || containsRune(noLineEnd, prev) {
+		return false
+	}
+	if mode == style.LineBreakCharacter {
+		return true
+	}
+	return isUnspaced(prev) || isUnspaced(next)
+}
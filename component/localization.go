@@ -0,0 +1,67 @@
+package component
+
+import (
+	"furoshiki/i18n"
+	"furoshiki/theme"
+
+	"golang.org/x/image/font"
+)
+
+// このファイルは、i18nのロケールが変わったときに、TextKeyでテキストを設定したウィジェットを更新する仕組みを提供します。
+// ウィジェットは新しいロケールでテキストを解決し直し、最小サイズを計測し直して再レイアウトを要求します。
+// テーマのLocaleFacesに新しいロケールのフェイスがあれば、フォントもそのフェイスに切り替わります。
+
+// Localizable は、ロケールの変更に合わせて表示を更新するウィジェットのインターフェースです。
+type Localizable interface {
+	// RefreshLocale は、現在のロケールでテキストとフォントを解決し直します。
+	RefreshLocale()
+}
+
+// localized は、ロケールの変更を通知するウィジェットの集合です。
+var localized = map[Localizable]struct{}{}
+
+func init() {
+	i18n.OnLocaleChange(func(string) { refreshLocalized() })
+}
+
+// RegisterLocalizable は、ロケールが変わったときにRefreshLocaleを呼び出すウィジェットを登録します。
+// TextKeyでテキストを設定したウィジェットは自動的に登録されます。登録したウィジェットは、Cleanupの際にUnregisterLocalizableで解除してください。
+func RegisterLocalizable(l Localizable) {
+	if l != nil {
+		localized[l] = struct{}{}
+	}
+}
+
+// UnregisterLocalizable は、RegisterLocalizableで登録したウィジェットの登録を解除します。
+func UnregisterLocalizable(l Localizable) {
+	delete(localized, l)
+}
+
+// refreshLocalized は、登録されたすべてのウィジェットの表示を現在のロケールで更新します。
+func refreshLocalized() {
+	targets := make([]Localizable, 0, len(localized))
+	for l := range localized {
+		targets = append(targets, l)
+	}
+	for _, l := range targets {
+		l.RefreshLocale()
+	}
+	RequestFrame()
+}
+
+// ApplyLocaleFace は、スタイルのフォントのうち、前回適用したフェイス(初回はテーマのDefaultFont)を、
+// 現在のロケールのフェイス(theme.FaceForLocale)に置き換え、適用したフェイスを返します。
+// 利用者が個別に設定したフォントは置き換えません。prevには前回この関数が返したフェイスを渡します。
+func (w *LayoutableWidget) ApplyLocaleFace(prev font.Face) font.Face {
+	t := theme.GetCurrent()
+	if prev == nil {
+		prev = t.DefaultFont
+	}
+	face := t.FaceForLocale(i18n.Locale())
+	if face == nil || face == prev {
+		return prev
+	}
+	w.invalidateMeasure()
+	w.styleManager.replaceFont(prev, face)
+	return face
+}
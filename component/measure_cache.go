@@ -0,0 +1,115 @@
+package component
+
+// このファイルは、ウィジェットの計測結果のキャッシュと、レイアウトを実装する側との間の約束事を定めます。
+//
+// ウィジェット側の約束:
+//   - GetMinSizeとGetHeightForWidthは、同じ入力(テキスト、スタイル、折り返しの設定など)に対して同じ結果を返し、
+//     ウィジェットの状態を変更しません。レイアウトは、同じパスの中で結果を再利用してよいものとして扱います。
+//   - 計測結果に影響する入力が変わったときは、InvalidateMeasureを呼び出します。キャッシュが破棄され、再レイアウトが要求されます。
+//     位置や大きさの変更は固有サイズに影響しないため、キャッシュを破棄しません。
+//   - 計測にコストがかかる独自のウィジェットは、CachedHeightForWidthとCachedWidthForHeightを使って、
+//     幅ごとの高さと高さごとの幅をキャッシュできます。
+//
+// レイアウト側の約束:
+//   - 1回のレイアウトのパスで、各子のGetMinSizeは1回だけ呼び出し、その結果をパスの中で使い回します。
+//   - GetHeightForWidthとGetWidthForHeightは、必要な制約ごとに呼び出してかまいません。同じ制約の結果はウィジェット側でキャッシュされています。
+//
+// 並列計測(layout.SetParallelMeasure)が有効な場合:
+//   - 兄弟の計測は別々のゴルーチンで同時に行われます。1つのウィジェットの計測とその計測キャッシュは、常に1つのゴルーチンからだけ使用されます。
+//   - フォントフェイスは共有されるため、テキストの計測はMeasureStringやFaceMetricsなどのロックを取る関数を通して行います(text_measure.go)。
+//   - それ以外の共有状態(パッケージ変数や、複数のウィジェットで共有するキャッシュなど)は保護されません。
+//     計測の中でこれらを書き換えるウィジェットがある場合は、並列計測を有効にしないでください。
+
+// measureCacheSize は、幅ごとの高さ(または高さごとの幅)の計測結果を保持するエントリ数です。
+// FlexLayoutやScrollViewLayoutは1回のレイアウトで数種類の幅に対して高さを問い合わせるため、
+// 少数のエントリを循環的に再利用します。
+const measureCacheSize = 4
+
+// measureCache は、ウィジェットの計測結果を制約（利用可能な幅）をキーとしてメモ化します。
+// テキストやスタイルなど、計測結果に影響する入力が変わったときだけ無効化されるため、
+// 変化していないウィジェットはレイアウトのたびに計測をやり直す必要がありません。
+//
+// NOTE: 位置やサイズの変更はコンテンツの固有サイズに影響しないため、キャッシュを無効化しません。
+type measureCache struct {
+	minValid       bool
+	minWidth       int
+	minHeight      int
+	heightForWidth measureEntries
+	widthForHeight measureEntries
+}
+
+// measureEntries は、制約(幅または高さ)ごとの計測結果を、少数のエントリを循環的に再利用して保持します。
+type measureEntries struct {
+	entries [measureCacheSize]measureEntry
+	next    int
+}
+
+// measureEntry は、特定の制約に対する計測結果です。
+type measureEntry struct {
+	valid      bool
+	constraint int
+	result     int
+}
+
+// get は、constraintに対する計測結果を返します。キャッシュにない場合はcomputeを呼び出して計測し、その結果を記録します。
+func (m *measureEntries) get(constraint int, compute func(constraint int) int) int {
+	for _, e := range m.entries {
+		if e.valid && e.constraint == constraint {
+			return e.result
+		}
+	}
+	result := compute(constraint)
+	m.entries[m.next] = measureEntry{valid: true, constraint: constraint, result: result}
+	m.next = (m.next + 1) % measureCacheSize
+	return result
+}
+
+// InvalidateMeasure は、計測結果のキャッシュを破棄し、再レイアウトを要求します。
+// 独自のウィジェットで、テキストや画像など、GetMinSizeやGetHeightForWidthの結果に影響する内容を変更したときに呼び出します。
+// 標準のウィジェットは、SetTextやSetStyleなどの中で自動的に呼び出します。
+func (w *LayoutableWidget) InvalidateMeasure() {
+	w.invalidateMeasure()
+	w.MarkDirty(true)
+}
+
+// invalidateMeasure は、再レイアウトを要求せずに計測結果のキャッシュを破棄します。
+// テキストやスタイルなど、コンテンツの固有サイズに影響する変更があったときに、ダーティ状態の設定とは別に呼び出されます。
+func (w *LayoutableWidget) invalidateMeasure() {
+	w.measure = measureCache{}
+}
+
+// SetContentMinSizeFunc は、GetMinSizeがキャッシュを介して使用する、コンテンツの最小サイズの計算を差し替えます。
+// テキストの計測方法を変える具象ウィジェット(フォントサイズを自動調整するLabelなど)が、
+// GetMinSizeをオーバーライドして毎回計測する代わりに使用します。キャッシュは破棄されます。
+func (w *LayoutableWidget) SetContentMinSizeFunc(compute func() (width, height int)) {
+	w.contentMinSizeFunc = compute
+	w.InvalidateMeasure()
+}
+
+// CachedHeightForWidth は、widthに対する高さをキャッシュを介して返します。キャッシュにない場合は、computeで計測して記録します。
+// HeightForWiderを実装する独自のウィジェットが、GetHeightForWidthの中で使用します。キャッシュはInvalidateMeasureで破棄されます。
+func (w *LayoutableWidget) CachedHeightForWidth(width int, compute func(width int) int) int {
+	return w.cachedHeightForWidth(width, compute)
+}
+
+// CachedWidthForHeight は、heightに対する幅をキャッシュを介して返します。キャッシュにない場合は、computeで計測して記録します。
+// WidthForHeighterを実装する独自のウィジェットが、GetWidthForHeightの中で使用します。キャッシュはInvalidateMeasureで破棄されます。
+func (w *LayoutableWidget) CachedWidthForHeight(height int, compute func(height int) int) int {
+	return w.measure.widthForHeight.get(height, compute)
+}
+
+// cachedContentMinSize は、contentMinSizeFuncの結果をキャッシュを介して返します。
+func (w *LayoutableWidget) cachedContentMinSize() (int, int) {
+	m := &w.measure
+	if !m.minValid {
+		m.minWidth, m.minHeight = w.contentMinSizeFunc()
+		m.minValid = true
+	}
+	return m.minWidth, m.minHeight
+}
+
+// cachedHeightForWidth は、指定された幅に対する高さの計測結果をキャッシュを介して返します。
+// キャッシュにない場合はcomputeを呼び出して計測し、その結果を記録します。
+func (w *LayoutableWidget) cachedHeightForWidth(width int, compute func(width int) int) int {
+	return w.measure.heightForWidth.get(width, compute)
+}
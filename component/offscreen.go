@@ -0,0 +1,93 @@
+package component
+
+import (
+	"cmp"
+	"furoshiki/stats"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// このファイルは、ライブラリが内部で保持するオフスクリーン画像(クリッピング、座標変換、シェーダー、描画キャッシュなど)の
+// 確保と解放を一元化し、その使用量を stats.OffscreenBytes として集計するための関数を提供します。
+// 使用量に予算を設定すると、予算を超えた時点で、最も長く描画されていない描画キャッシュから解放されます。
+
+// offscreenBytesPerPixel は、オフスクリーン画像の1ピクセルあたりの推定使用量(RGBA各8ビット)です。
+const offscreenBytesPerPixel = 4
+
+// offscreenBudget は、オフスクリーン画像の使用量の予算(バイト)です。0以下の場合は無制限です。
+var offscreenBudget int64
+
+// AllocateOffscreen は、width x height のオフスクリーン画像を確保し、確保回数と使用量を stats に記録します。
+// 確保した画像は、ReleaseOffscreen で解放してください。
+// 予算が設定されていて使用量が予算を超えた場合は、描画キャッシュを解放して使用量を減らします。
+func AllocateOffscreen(width, height int) *ebiten.Image {
+	img := ebiten.NewImage(width, height)
+	stats.AddOffscreenAllocation()
+	stats.AddOffscreenBytes(offscreenImageBytes(img))
+	enforceOffscreenBudget()
+	return img
+}
+
+// EnsureOffscreen は、imgが width x height であればそのまま返し、そうでなければimgを解放して新しい画像を確保して返します。
+// imgがnilの場合は、新しい画像を確保します。
+func EnsureOffscreen(img *ebiten.Image, width, height int) *ebiten.Image {
+	if img != nil && img.Bounds().Dx() == width && img.Bounds().Dy() == height {
+		return img
+	}
+	ReleaseOffscreen(img)
+	return AllocateOffscreen(width, height)
+}
+
+// ReleaseOffscreen は、AllocateOffscreen で確保した画像を解放し、使用量から差し引きます。imgがnilの場合は何もしません。
+func ReleaseOffscreen(img *ebiten.Image) {
+	if img == nil {
+		return
+	}
+	stats.AddOffscreenBytes(-offscreenImageBytes(img))
+	img.Deallocate()
+}
+
+// offscreenImageBytes は、画像の推定使用量を返します。
+func offscreenImageBytes(img *ebiten.Image) int64 {
+	b := img.Bounds()
+	return int64(b.Dx()) * int64(b.Dy()) * offscreenBytesPerPixel
+}
+
+// SetOffscreenBudget は、オフスクリーン画像の使用量の予算をバイト単位で設定します。0以下の場合は無制限です。
+// 使用量が予算を超えると、最も長く描画されていない描画キャッシュ(SetCacheRendering)から順に画像を解放します。
+// 解放されたキャッシュは、次に描画されるときに作り直されます。
+//
+// クリッピングや座標変換のための画像は毎フレームの描画に必要なため、解放の対象になりません。
+// そのため、使用量が予算を下回ることは保証されません。毎フレーム描画される描画キャッシュの合計より
+// 小さい予算を設定すると、キャッシュの解放と再生成が毎フレーム繰り返されるため注意してください。
+func SetOffscreenBudget(bytes int64) {
+	offscreenBudget = bytes
+	enforceOffscreenBudget()
+}
+
+// OffscreenBudget は、オフスクリーン画像の使用量の予算を返します。0以下の場合は無制限です。
+func OffscreenBudget() int64 {
+	return offscreenBudget
+}
+
+// enforceOffscreenBudget は、使用量が予算を超えている間、最も長く描画されていない描画キャッシュから解放します。
+// 描画中のキャッシュは、描画先の画像が失われないよう対象から外します。
+func enforceOffscreenBudget() {
+	if offscreenBudget <= 0 || stats.OffscreenBytes() <= offscreenBudget {
+		return
+	}
+	candidates := make([]*renderCache, 0, len(allocatedRenderCaches))
+	for rc := range allocatedRenderCaches {
+		if !rc.drawing {
+			candidates = append(candidates, rc)
+		}
+	}
+	slices.SortFunc(candidates, func(a, b *renderCache) int { return cmp.Compare(a.lastDrawn, b.lastDrawn) })
+	for _, rc := range candidates {
+		if stats.OffscreenBytes() <= offscreenBudget {
+			return
+		}
+		rc.release()
+	}
+}
@@ -0,0 +1,71 @@
+package component
+
+// activePauses は、一時停止しているウィジェットの数です。
+// 0の間は、IsInPausedSubtreeでの祖先の走査を省略します。
+var activePauses int
+
+// SetPaused は、このウィジェットとその子孫の更新を一時停止するかどうかを設定します。
+// 一時停止中も描画とイベントの処理は続きますが、子孫のUpdate(ラベルの流れる表示、カーソルの点滅、
+// ボタンの長押しの繰り返しなどのアニメーション)は呼び出されません。
+// ただし、大きさの変更などに応じてレイアウトを確定させるため、子孫のコンテナのUpdateは呼び出されます。
+// ゲームを一時停止している間のHUDなど、表示したまま動きを止めたいサブツリーに使用します。
+func (w *LayoutableWidget) SetPaused(paused bool) {
+	if w.state.isPaused == paused {
+		return
+	}
+	w.state.isPaused = paused
+	if paused {
+		activePauses++
+	} else {
+		activePauses--
+		// 停止中に保留されていた変化を反映させるため、再描画を要求します。
+		w.MarkDirty(false)
+	}
+}
+
+// IsPaused は、このウィジェット自身が一時停止しているかどうかを返します。
+// 祖先の一時停止も考慮する場合は、IsInPausedSubtreeを使用してください。
+func (w *LayoutableWidget) IsPaused() bool {
+	return w.state.isPaused
+}
+
+// IsInPausedSubtree は、wまたはその祖先のいずれかが一時停止しているかどうかを返します。
+func IsInPausedSubtree(w Widget) bool {
+	if activePauses == 0 {
+		return false
+	}
+	for w != nil {
+		if p, ok := w.(Pausable); ok && p.IsPaused() {
+			return true
+		}
+		parent := w.GetParent()
+		if parent == nil {
+			return false
+		}
+		w = parent
+	}
+	return false
+}
+
+// UpdateChildren は、子のUpdateを順に呼び出します。
+// parentが一時停止中のサブツリーに含まれる場合や、子自身が一時停止している場合は、
+// レイアウトを確定させるためにコンテナの子のUpdateだけを呼び出し、それ以外の子のUpdateは省略します。
+// 子を持つウィジェットは、子の更新にこの関数を使用してください。
+func UpdateChildren(parent Widget, children []Widget) {
+	paused := IsInPausedSubtree(parent)
+	for _, child := range children {
+		if _, ok := child.(Container); !ok && (paused || isPaused(child)) {
+			continue
+		}
+		child.Update()
+	}
+}
+
+// isPaused は、ウィジェット自身が一時停止しているかどうかを返します。
+func isPaused(w Widget) bool {
+	if activePauses == 0 {
+		return false
+	}
+	p, ok := w.(Pausable)
+	return ok && p.IsPaused()
+}
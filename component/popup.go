@@ -0,0 +1,72 @@
+package component
+
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// このファイルは、UIツリーの上に重ねて表示されるポップアップ(候補リスト、メニューなど)の管理を提供します。
+// ポップアップはUIツリーに属さないため、親のクリッピングや兄弟の描画順の影響を受けません。
+// フォーカスと同様にアプリケーション全体で1つの層を共有し、排他制御は行いません。
+//
+// アプリケーションは、ルートの描画後にDrawPopupsを呼び出し、ヒットテストにはルートの代わりにHitTestを使用します。
+// ポップアップのUpdateは呼び出されないため、必要な更新はポップアップを表示したウィジェットが行います。
+// ポップアップの位置は画面上の座標で指定します。
+
+// popups は、表示中のポップアップです。後に表示されたものほど手前に描画されます。
+var popups []Widget
+
+// ShowPopup は、ポップアップを最前面に表示します。既に表示中の場合は何もしません。
+func ShowPopup(w Widget) {
+	if w != nil && !slices.Contains(popups, w) {
+		popups = append(popups, w)
+	}
+}
+
+// HidePopup は、ポップアップを非表示にします。表示されていない場合は何もしません。
+func HidePopup(w Widget) {
+	if i := slices.Index(popups, w); i >= 0 {
+		popups = slices.Delete(popups, i, i+1)
+	}
+}
+
+// IsPopupShown は、ポップアップが表示中かどうかを返します。
+func IsPopupShown(w Widget) bool {
+	return slices.Contains(popups, w)
+}
+
+// HitTestPopups は、指定された座標にある最も手前のポップアップ(またはその子孫)を返します。ない場合はnilです。
+func HitTestPopups(x, y int) Widget {
+	for i := len(popups) - 1; i >= 0; i-- {
+		if hit := popups[i].HitTest(x, y); hit != nil {
+			return hit
+		}
+	}
+	return nil
+}
+
+// HitTest は、ポップアップを優先して、指定された座標にあるウィジェットを返します。
+// ポップアップに当たらない場合は、rootのHitTestの結果を返します。
+func HitTest(root Widget, x, y int) Widget {
+	if hit := HitTestPopups(x, y); hit != nil {
+		return hit
+	}
+	if root == nil {
+		return nil
+	}
+	return root.HitTest(x, y)
+}
+
+// DrawPopups は、表示中のポップアップを表示された順に描画します。ルートの描画後に呼び出してください。
+func DrawPopups(screen *ebiten.Image) {
+	if len(popups) == 0 {
+		return
+	}
+	info := DrawInfo{Screen: screen}
+	// 描画中にポップアップが閉じられても反復が乱れないように、複製してから描画します。
+	for _, p := range slices.Clone(popups) {
+		p.Draw(info)
+	}
+	FlushDraws()
+}
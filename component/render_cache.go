@@ -0,0 +1,210 @@
+package component
+
+import (
+	"furoshiki/stats"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// renderCache は、ウィジェットのサブツリーを一度だけオフスクリーン画像に描画し、
+// 以降のフレームではその画像を転送するだけで済ませるためのキャッシュ状態です。
+type renderCache struct {
+	enabled bool
+	valid   bool
+	image   *ebiten.Image
+	// lastDrawn は、最後に描画に使用されたときの renderCacheClock の値です。予算を超えた際の解放の順序に使用されます。
+	lastDrawn uint64
+	// drawing は、キャッシュ画像へ再描画している最中かどうかです。再描画中のキャッシュは解放の対象になりません。
+	drawing bool
+}
+
+// renderCacheOwner は、描画キャッシュを持つウィジェットを識別するための内部インターフェースです。
+// LayoutableWidgetを埋め込むすべてのウィジェットがこれを満たします。
+type renderCacheOwner interface {
+	renderCacheState() *renderCache
+}
+
+// activeRenderCaches は、描画キャッシュが有効になっているウィジェットの数です。
+// 0の間は、MarkDirty時の祖先へのキャッシュ無効化の走査を省略します。
+var activeRenderCaches int
+
+// allocatedRenderCaches は、画像を保持している描画キャッシュの集合です。予算を超えた際の解放の候補になります。
+var allocatedRenderCaches = make(map[*renderCache]struct{})
+
+// renderCacheClock は、描画キャッシュが描画に使用されるたびに進むカウンタです。
+var renderCacheClock uint64
+
+// renderCacheState は、このウィジェットの描画キャッシュの状態を返します。
+func (w *LayoutableWidget) renderCacheState() *renderCache {
+	return &w.render
+}
+
+// SetCacheRendering は、このウィジェットとその子孫の描画結果をオフスクリーン画像にキャッシュするかを設定します。
+// 有効にすると、サブツリー内のいずれかのウィジェットがダーティになるまで、
+// 毎フレームの描画はキャッシュ画像の転送1回だけになります。
+// 複雑ですがめったに変化しないパネル（インベントリの背景や装飾フレームなど）に適しています。
+//
+// キャッシュ画像はウィジェット自身の境界でクリップされるため、境界の外側にはみ出して
+// 描画される子要素は表示されなくなります。また、サブツリー内のウィジェットの位置が
+// 変わるとキャッシュは無効化されるため、スクロールし続ける内容には向きません。
+func (w *LayoutableWidget) SetCacheRendering(enabled bool) {
+	if w.render.enabled == enabled {
+		return
+	}
+	w.render.enabled = enabled
+	w.render.valid = false
+	if enabled {
+		activeRenderCaches++
+	} else {
+		activeRenderCaches--
+		w.releaseRenderCache()
+	}
+}
+
+// IsCacheRendering は、描画キャッシュが有効かどうかを返します。
+func (w *LayoutableWidget) IsCacheRendering() bool {
+	return w.render.enabled
+}
+
+// InvalidateRenderCache は、このウィジェットの描画キャッシュを無効化し、次の描画で再生成させます。
+func (w *LayoutableWidget) InvalidateRenderCache() {
+	w.render.valid = false
+}
+
+// invalidateRenderCaches は、このウィジェットと、描画キャッシュを持つすべての祖先のキャッシュを無効化します。
+func (w *LayoutableWidget) invalidateRenderCaches() {
+	if activeRenderCaches == 0 {
+		return
+	}
+	w.render.valid = false
+	w.invalidateAncestorRenderCaches()
+}
+
+// invalidateAncestorRenderCaches は、描画キャッシュを持つすべての祖先のキャッシュを無効化します。
+func (w *LayoutableWidget) invalidateAncestorRenderCaches() {
+	if activeRenderCaches == 0 {
+		return
+	}
+	for p := w.hierarchy.parent; p != nil; p = p.GetParent() {
+		if owner, ok := p.(renderCacheOwner); ok {
+			owner.renderCacheState().valid = false
+		}
+	}
+}
+
+// releaseRenderCache は、キャッシュ画像を解放します。
+func (w *LayoutableWidget) releaseRenderCache() {
+	w.render.release()
+}
+
+// release は、キャッシュ画像を解放し、キャッシュを無効にします。キャッシュが有効であれば、次の描画で作り直されます。
+func (rc *renderCache) release() {
+	if rc.image != nil {
+		ReleaseOffscreen(rc.image)
+		rc.image = nil
+		delete(allocatedRenderCaches, rc)
+	}
+	rc.valid = false
+}
+
+// DrawWidget は、描画キャッシュ、シェーダー、描画フック、フォーカスリング、バッジを考慮してウィジェットを描画します。
+// コンテナは子の描画に child.Draw を直接呼び出す代わりにこの関数を使用します。
+// いずれも持たないウィジェットの場合は、単に w.Draw(info) を呼び出します。
+func DrawWidget(w Widget, info DrawInfo) {
+	drawWidgetWithHooks(w, info)
+	drawFocusRing(w, info)
+	drawBadge(w, info)
+}
+
+// drawWidgetWithHooks は、描画フックを考慮してウィジェットを描画します。
+func drawWidgetWithHooks(w Widget, info DrawInfo) {
+	hooks, ok := w.(drawHookOwner)
+	if !ok {
+		drawWidgetContent(w, info)
+		return
+	}
+	h := hooks.drawHookState()
+	if len(h.before) == 0 && len(h.after) == 0 {
+		drawWidgetContent(w, info)
+		return
+	}
+	if is, ok := w.(InteractiveState); ok && (!is.IsVisible() || !is.HasBeenLaidOut()) {
+		return
+	}
+	// フックの描画は描画キャッシュの外側で行うため、装飾が変化してもキャッシュは無効化されません。
+	bounds := widgetDrawBounds(w, info)
+	runDrawHooks(h.before, info, bounds)
+	drawWidgetContent(w, info)
+	runDrawHooks(h.after, info, bounds)
+}
+
+// drawWidgetContent は、シェーダーが設定されていればシェーダーを通して、そうでなければ描画キャッシュを考慮してウィジェットを描画します。
+func drawWidgetContent(w Widget, info DrawInfo) {
+	if owner, ok := w.(shaderEffectOwner); ok {
+		if fx := owner.shaderEffectState(); fx.shader != nil {
+			drawWidgetShaded(w, info, fx)
+			return
+		}
+	}
+	drawWidgetCached(w, info)
+}
+
+// drawWidgetCached は、描画キャッシュが有効であればキャッシュ画像を使ってウィジェットを描画します。
+func drawWidgetCached(w Widget, info DrawInfo) {
+	owner, ok := w.(renderCacheOwner)
+	if !ok || !owner.renderCacheState().enabled {
+		w.Draw(info)
+		return
+	}
+	rc := owner.renderCacheState()
+
+	if is, ok := w.(InteractiveState); ok && (!is.IsVisible() || !is.HasBeenLaidOut()) {
+		return
+	}
+	x, y, width, height := widgetBounds(w)
+	if width <= 0 || height <= 0 {
+		return
+	}
+	img := updateRenderCache(w, rc, x, y, width, height)
+
+	FlushDraws()
+	opts := &ebiten.DrawImageOptions{}
+	opts.GeoM.Translate(float64(x+info.OffsetX), float64(y+info.OffsetY))
+	info.Screen.DrawImage(img, opts)
+	stats.AddDrawCalls(1)
+}
+
+// updateRenderCache は、キャッシュ画像を必要に応じて確保・再描画し、最新のキャッシュ画像を返します。
+func updateRenderCache(w Widget, rc *renderCache, x, y, width, height int) *ebiten.Image {
+	renderCacheClock++
+	rc.lastDrawn = renderCacheClock
+	if rc.image == nil || rc.image.Bounds().Dx() != width || rc.image.Bounds().Dy() != height {
+		// NOTE: 確保の前に集合から外しておき、予算の判定で確保中のキャッシュ自身が解放されないようにします。
+		rc.release()
+		rc.image = AllocateOffscreen(width, height)
+		allocatedRenderCaches[rc] = struct{}{}
+	}
+
+	if !rc.valid {
+		// キャッシュ画像への描画を始める前に、これまでの描画命令を確定させます。
+		FlushDraws()
+		rc.image.Clear()
+		rc.drawing = true
+		w.Draw(DrawInfo{Screen: rc.image, OffsetX: -x, OffsetY: -y})
+		rc.drawing = false
+		FlushDraws()
+		rc.valid = true
+	}
+	return rc.image
+}
+
+// widgetBounds は、ウィジェットの位置と大きさを返します。
+func widgetBounds(w Widget) (x, y, width, height int) {
+	if ps, ok := w.(PositionSetter); ok {
+		x, y = ps.GetPosition()
+	}
+	if ss, ok := w.(SizeSetter); ok {
+		width, height = ss.GetSize()
+	}
+	return x, y, width, height
+}
@@ -0,0 +1,100 @@
+package component
+
+import (
+	"furoshiki/stats"
+	"maps"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// shaderEffect は、ウィジェットの描画結果に適用するKageシェーダーと、その状態です。
+type shaderEffect struct {
+	shader   *ebiten.Shader
+	uniforms map[string]any
+	// image は、シェーダーに渡すためにサブツリーを描画するオフスクリーン画像です。
+	// 描画キャッシュが有効な場合は、キャッシュ画像をそのまま使うため確保しません。
+	image *ebiten.Image
+}
+
+// shaderEffectOwner は、シェーダーを適用できるウィジェットを識別するための内部インターフェースです。
+// LayoutableWidgetを埋め込むすべてのウィジェットがこれを満たします。
+type shaderEffectOwner interface {
+	shaderEffectState() *shaderEffect
+}
+
+// shaderEffectState は、このウィジェットのシェーダーの状態を返します。
+func (w *LayoutableWidget) shaderEffectState() *shaderEffect {
+	return &w.effect
+}
+
+// SetShader は、このウィジェットとその子孫の描画結果に適用するKageシェーダーを設定します。nilを指定すると解除します。
+// サブツリーはウィジェットの大きさのオフスクリーン画像に描画され、その画像をimageSrc0としてシェーダーで描画されます。
+// uniformsは、シェーダーのユニフォーム変数の名前と値です。
+// 無効状態のパネルのグレースケール化、ボタンの光沢、ディゾルブによる切り替えなどに使用します。
+//
+// 描画キャッシュと同様に、結果はウィジェット自身の境界でクリップされます。
+// 描画キャッシュが有効な場合は、キャッシュ画像にシェーダーを適用するため、追加のオフスクリーン画像は確保しません。
+// シェーダーは親コンテナから描画されるとき(DrawWidget経由)に適用されるため、Drawを直接呼び出すルートには適用されません。
+func (w *LayoutableWidget) SetShader(shader *ebiten.Shader, uniforms map[string]any) {
+	w.effect.shader = shader
+	w.effect.uniforms = maps.Clone(uniforms)
+	if shader == nil {
+		w.releaseShaderImage()
+	}
+	w.invalidateAncestorRenderCaches()
+}
+
+// Shader は、適用しているシェーダーを返します。設定されていない場合はnilです。
+func (w *LayoutableWidget) Shader() *ebiten.Shader {
+	return w.effect.shader
+}
+
+// SetShaderUniform は、シェーダーのユニフォーム変数の値を設定します。
+// ディゾルブの進行度や経過時間など、毎フレーム変化する値の更新に使用します。
+// サブツリー自体は変化しないため、このウィジェットの描画キャッシュは無効化されません。
+func (w *LayoutableWidget) SetShaderUniform(name string, value any) {
+	if w.effect.uniforms == nil {
+		w.effect.uniforms = make(map[string]any)
+	}
+	w.effect.uniforms[name] = value
+	w.invalidateAncestorRenderCaches()
+}
+
+// releaseShaderImage は、シェーダー用のオフスクリーン画像を解放します。
+func (w *LayoutableWidget) releaseShaderImage() {
+	if w.effect.image != nil {
+		ReleaseOffscreen(w.effect.image)
+		w.effect.image = nil
+	}
+}
+
+// drawWidgetShaded は、ウィジェットのサブツリーをオフスクリーン画像に描画し、シェーダーを通して描画先に描画します。
+func drawWidgetShaded(w Widget, info DrawInfo, fx *shaderEffect) {
+	if is, ok := w.(InteractiveState); ok && (!is.IsVisible() || !is.HasBeenLaidOut()) {
+		return
+	}
+	x, y, width, height := widgetBounds(w)
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	var src *ebiten.Image
+	if owner, ok := w.(renderCacheOwner); ok && owner.renderCacheState().enabled {
+		src = updateRenderCache(w, owner.renderCacheState(), x, y, width, height)
+	} else {
+		fx.image = EnsureOffscreen(fx.image, width, height)
+		// オフスクリーン画像への描画を始める前に、これまでの描画命令を確定させます。
+		FlushDraws()
+		fx.image.Clear()
+		w.Draw(DrawInfo{Screen: fx.image, OffsetX: -x, OffsetY: -y})
+		src = fx.image
+	}
+
+	FlushDraws()
+	opts := &ebiten.DrawRectShaderOptions{}
+	opts.GeoM.Translate(float64(x+info.OffsetX), float64(y+info.OffsetY))
+	opts.Images[0] = src
+	opts.Uniforms = fx.uniforms
+	info.Screen.DrawRectShader(width, height, fx.shader, opts)
+	stats.AddDrawCalls(1)
+}
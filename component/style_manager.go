@@ -1,6 +1,10 @@
 package component
 
-import "furoshiki/style"
+import (
+	"furoshiki/style"
+
+	"golang.org/x/image/font"
+)
 
 // StyleManager はウィジェットのスタイルを状態ベースで管理する責務を担います。
 // これにより、インタラクティブなウィジェットのスタイルロジックを共通化し、堅牢性を高めます。
@@ -8,16 +12,27 @@ import "furoshiki/style"
 type StyleManager struct {
 	baseStyle   style.Style
 	stateStyles map[WidgetState]style.Style
-	mergedCache map[WidgetState]style.Style
 	owner       DirtyManager // オーナーウィジェットのダーティ状態を更新するための参照
+
+	// UPDATE: マップによるキャッシュを、状態をインデックスとする固定長配列に変更しました。
+	//         描画ループでのマップ参照とキャッシュ再構築時の割り当てをなくします。
+	// baseComputed は、基本スタイルを解決した値型スタイルです。
+	baseComputed style.Computed
+	baseValid    bool
+	// merged, computed は、状態ごとのマージ済みスタイルと、それを解決した値型スタイルのキャッシュです。
+	merged   [numWidgetStates]style.Style
+	computed [numWidgetStates]style.Computed
+	valid    [numWidgetStates]bool
 }
 
+// numWidgetStates は、キャッシュ配列の大きさとなるWidgetStateの数です。
+const numWidgetStates = int(StateFocused) + 1
+
 // NewStyleManager は新しいStyleManagerインスタンスを生成します。
 // オーナーウィジェットへの参照を受け取り、スタイル変更時に自動でダーティフラグを立てられるようにします。
 func NewStyleManager(owner DirtyManager) *StyleManager {
 	return &StyleManager{
 		stateStyles: make(map[WidgetState]style.Style),
-		mergedCache: make(map[WidgetState]style.Style),
 		owner:       owner,
 	}
 }
@@ -52,7 +67,9 @@ func (sm *StyleManager) SetStyleForState(state WidgetState, s style.Style) {
 	mergedStyle := style.Merge(existingStateStyle, s)
 	sm.stateStyles[state] = mergedStyle
 
-	delete(sm.mergedCache, state) // 関連するキャッシュのみを破棄
+	if int(state) >= 0 && int(state) < numWidgetStates {
+		sm.valid[state] = false // 関連するキャッシュのみを破棄
+	}
 	sm.owner.MarkDirty(true)
 }
 
@@ -60,22 +77,75 @@ func (sm *StyleManager) SetStyleForState(state WidgetState, s style.Style) {
 // 最初に基本スタイルを適用し、その上に状態固有のスタイルをマージします。
 // 計算結果はキャッシュされ、パフォーマンスを向上させます。
 func (sm *StyleManager) GetStyleForState(state WidgetState) style.Style {
-	if merged, ok := sm.mergedCache[state]; ok {
-		return merged
+	if int(state) < 0 || int(state) >= numWidgetStates {
+		return sm.mergeForState(state)
+	}
+	sm.ensureState(state)
+	return sm.merged[state]
+}
+
+// ComputedForState は、指定された状態に適用すべき最終的なスタイルを値型に解決して返します。
+// 結果はキャッシュされるため、描画ループから毎フレーム呼び出してもヒープ割り当ては発生しません。
+func (sm *StyleManager) ComputedForState(state WidgetState) style.Computed {
+	if int(state) < 0 || int(state) >= numWidgetStates {
+		return style.Resolve(sm.mergeForState(state))
+	}
+	sm.ensureState(state)
+	return sm.computed[state]
+}
+
+// ComputedBase は、基本スタイルを値型に解決して返します。結果はキャッシュされます。
+func (sm *StyleManager) ComputedBase() style.Computed {
+	if !sm.baseValid {
+		sm.baseComputed = style.Resolve(sm.baseStyle)
+		sm.baseValid = true
+	}
+	return sm.baseComputed
+}
+
+// ensureState は、指定された状態のキャッシュが有効でなければ再計算します。
+func (sm *StyleManager) ensureState(state WidgetState) {
+	if sm.valid[state] {
+		return
 	}
+	sm.merged[state] = sm.mergeForState(state)
+	sm.computed[state] = style.Resolve(sm.merged[state])
+	sm.valid[state] = true
+}
 
+// mergeForState は、基本スタイルに状態固有のスタイルをマージした結果を返します。
+func (sm *StyleManager) mergeForState(state WidgetState) style.Style {
 	finalStyle := sm.baseStyle.DeepCopy()
 	if stateSpecificStyle, ok := sm.stateStyles[state]; ok {
 		finalStyle = style.Merge(finalStyle, stateSpecificStyle)
 	}
-
-	sm.mergedCache[state] = finalStyle
 	return finalStyle
 }
 
 // clearCache は全てのマージ済みスタイルキャッシュを破棄します。
 // 基本スタイルが変更された際に呼び出されます。
 func (sm *StyleManager) clearCache() {
-	// NOTE: [FIX] マップの型を正しく `map[WidgetState]style.Style` に修正しました。
-	sm.mergedCache = make(map[WidgetState]style.Style)
-}
\ No newline at end of file
+	sm.valid = [numWidgetStates]bool{}
+	sm.baseValid = false
+}
+
+// replaceFont は、基本スタイルと状態ごとのスタイルのうち、フォントがoldのものをnewに置き換えます。
+// スタイルのフォントのポインタはテーマなどと共有されている可能性があるため、値を書き換えずに新しいポインタを設定します。
+func (sm *StyleManager) replaceFont(old, new font.Face) {
+	changed := false
+	if sm.baseStyle.Font != nil && *sm.baseStyle.Font == old {
+		sm.baseStyle.Font = style.PFont(new)
+		changed = true
+	}
+	for state, s := range sm.stateStyles {
+		if s.Font != nil && *s.Font == old {
+			s.Font = style.PFont(new)
+			sm.stateStyles[state] = s
+			changed = true
+		}
+	}
+	if changed {
+		sm.clearCache()
+		sm.owner.MarkDirty(true)
+	}
+}
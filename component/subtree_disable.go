@@ -0,0 +1,62 @@
+package component
+
+// activeSubtreeDisables は、SetEnabledRecursive(false)でサブツリーを無効にしているウィジェットの数です。
+// 0の間は、IsInDisabledSubtreeでの祖先の走査を省略します。
+var activeSubtreeDisables int
+
+// SetEnabledRecursive は、このウィジェットとその子孫をまとめて有効または無効にします。
+// フォームやツールバーなど、サブツリー全体の操作を一時的に受け付けないようにするために使用します。
+// 無効にしたサブツリーのウィジェットは、IsDisabledがtrueを返し、無効状態のスタイルで描画され、ヒットテストの対象になりません。
+// 後から追加された子孫も無効になります。フォーカスを持っている子孫があれば、フォーカスは解除されます。
+//
+// 各ウィジェットのSetDisabledで設定した状態とは独立して管理されるため、有効に戻すと、それぞれのウィジェットは
+// 無効にする前の状態(SetDisabledで個別に無効にしていたウィジェットは無効のまま)に戻ります。
+func (w *LayoutableWidget) SetEnabledRecursive(enabled bool) {
+	if w.state.subtreeDisabled == !enabled {
+		return
+	}
+	w.state.subtreeDisabled = !enabled
+	if enabled {
+		activeSubtreeDisables--
+	} else {
+		activeSubtreeDisables++
+		if focused != nil && IsInDisabledSubtree(focused) {
+			SetFocus(nil)
+		}
+	}
+	if w.self == nil {
+		w.MarkDirty(false)
+		return
+	}
+	// 子孫の状態(CurrentState)が変わるため、サブツリー全体の再描画を要求します。
+	Walk(w.self, func(d Widget, _ int) WalkResult {
+		if dm, ok := d.(DirtyManager); ok {
+			dm.MarkDirty(false)
+		}
+		return WalkContinue
+	})
+}
+
+// IsSubtreeDisabled は、このウィジェットがSetEnabledRecursive(false)でサブツリーを無効にしているかどうかを返します。
+// 祖先による無効化も考慮する場合は、IsInDisabledSubtreeを使用してください。
+func (w *LayoutableWidget) IsSubtreeDisabled() bool {
+	return w.state.subtreeDisabled
+}
+
+// IsInDisabledSubtree は、wまたはその祖先のいずれかが、SetEnabledRecursive(false)でサブツリーを無効にしているかどうかを返します。
+func IsInDisabledSubtree(w Widget) bool {
+	if activeSubtreeDisables == 0 {
+		return false
+	}
+	for w != nil {
+		if d, ok := w.(SubtreeDisabler); ok && d.IsSubtreeDisabled() {
+			return true
+		}
+		parent := w.GetParent()
+		if parent == nil {
+			return false
+		}
+		w = parent
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package component
+
+import (
+	"image"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// このファイルは、フォントフェイスへのアクセスを直列化するテキスト計測関数を提供します。
+//
+// opentypeなどのfont.Faceは内部にバッファを持ち、複数のゴルーチンから同時に使用することはできません。
+// 並列計測(layout.SetParallelMeasure)が有効な場合、兄弟ウィジェットの計測が同時に実行されるため、
+// フェイスのメソッドを直接呼び出さず、これらの関数を通して呼び出してください。
+// ロックはすべてのフェイスで共有されるため、テキストの計測そのものは直列に行われます。
+
+// faceMu は、フォントフェイスへのアクセスを直列化するロックです。
+var faceMu sync.Mutex
+
+// MeasureString は、font.MeasureStringと同様に、sを描画したときの送り幅を返します。
+func MeasureString(f font.Face, s string) fixed.Int26_6 {
+	faceMu.Lock()
+	defer faceMu.Unlock()
+	return font.MeasureString(f, s)
+}
+
+// BoundString は、text.BoundStringと同様に、sを描画したときの外接矩形を返します。
+func BoundString(f font.Face, s string) image.Rectangle {
+	faceMu.Lock()
+	defer faceMu.Unlock()
+	return text.BoundString(f, s)
+}
+
+// FaceMetrics は、フェイスのメトリクスを返します。
+func FaceMetrics(f font.Face) font.Metrics {
+	faceMu.Lock()
+	defer faceMu.Unlock()
+	return f.Metrics()
+}
+
+// GlyphAdvance は、文字rの送り幅と、フェイスがその文字のグリフを持つかどうかを返します。
+func GlyphAdvance(f font.Face, r rune) (fixed.Int26_6, bool) {
+	faceMu.Lock()
+	defer faceMu.Unlock()
+	return f.GlyphAdvance(r)
+}
+
+// Kern は、文字r0とr1の間のカーニング量を返します。
+func Kern(f font.Face, r0, r1 rune) fixed.Int26_6 {
+	faceMu.Lock()
+	defer faceMu.Unlock()
+	return f.Kern(r0, r1)
+}
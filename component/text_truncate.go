@@ -0,0 +1,204 @@
+package component
+
+import (
+	"furoshiki/stats"
+	"furoshiki/style"
+	"image"
+	"image/color"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// Ellipsis は、切り詰めたテキストの省略部分に挿入される文字列です。
+// 多くのビットマップフォントが "…" (U+2026) のグリフを持たないため、ASCIIの3点を使用します。
+const Ellipsis = "..."
+
+// maxFadeWidth は、TextTruncateFadeで透明にしていく領域の最大幅です。
+const maxFadeWidth = 24
+
+// TruncateText は、テキストが maxWidth に収まるように指定された方法で切り詰めた文字列を返します。
+// 収まる場合や、切り詰めを行わない方法(None, Clip, Fade)の場合は元の文字列をそのまま返します。
+// 省略記号すら収まらない場合は空文字列を返します。
+func TruncateText(f font.Face, s string, maxWidth int, mode style.TextTruncateType) string {
+	if f == nil || s == "" {
+		return s
+	}
+	if mode != style.TextTruncateEllipsis && mode != style.TextTruncateMiddleEllipsis {
+		return s
+	}
+	if MeasureString(f, s).Ceil() <= maxWidth {
+		return s
+	}
+	available := maxWidth - MeasureString(f, Ellipsis).Ceil()
+	if available < 0 {
+		return ""
+	}
+
+	// 残す文字数を二分探索で求めます。幅は文字数に対して単調に増加します。
+	runes := utf8.RuneCountInString(s)
+	keep := func(n int) string {
+		if mode == style.TextTruncateMiddleEllipsis {
+			head := (n + 1) / 2
+			return runePrefix(s, head) + Ellipsis + runeSuffix(s, n-head)
+		}
+		return runePrefix(s, n) + Ellipsis
+	}
+	lo, hi := 0, runes
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if MeasureString(f, keep(mid)).Ceil() <= maxWidth {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return keep(lo)
+}
+
+// runePrefix は、先頭からn文字を返します。
+func runePrefix(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+// runeSuffix は、末尾からn文字を返します。
+func runeSuffix(s string, n int) string {
+	i := len(s)
+	for ; n > 0 && i > 0; n-- {
+		_, size := utf8.DecodeLastRuneInString(s[:i])
+		i -= size
+	}
+	return s[i:]
+}
+
+// truncatedMinWidth は、切り詰めが有効なテキストのコンテンツの最小幅を返します。
+// 省略記号を使う方法では省略記号の幅、切り取る方法では0になります。
+func truncatedMinWidth(f font.Face, mode style.TextTruncateType) int {
+	switch mode {
+	case style.TextTruncateEllipsis, style.TextTruncateMiddleEllipsis:
+		return MeasureString(f, Ellipsis).Ceil()
+	default:
+		return 0
+	}
+}
+
+// drawTruncatedLine は、コンテンツ領域に収まらない1行を、スタイルの切り詰め方法に従って描画します。
+// 収まる場合やTextTruncateNoneの場合はfalseを返し、呼び出し側が通常の描画を行います。
+// 右から左に書く段落(rtl)では、切り取りやフェードは左端側で行われます。
+func drawTruncatedLine(screen *ebiten.Image, line string, contentRect image.Rectangle, textY int, c style.Computed, rtl bool) bool {
+	if c.TextTruncate == style.TextTruncateNone || MeasureString(c.Font, line).Ceil() <= contentRect.Dx() {
+		return false
+	}
+	switch c.TextTruncate {
+	case style.TextTruncateEllipsis, style.TextTruncateMiddleEllipsis:
+		drawAlignedLine(screen, TruncateText(c.Font, line, contentRect.Dx(), c.TextTruncate), contentRect, textY, c, rtl)
+	case style.TextTruncateClip:
+		// 収まらないテキストは、揃え位置に関わらず先頭が見えるように、段落の先頭側に揃えて描画します。
+		clipped := screen.SubImage(contentRect).(*ebiten.Image)
+		visual := VisualLine(line, rtl)
+		x := contentRect.Min.X
+		if rtl {
+			x = contentRect.Max.X - MeasureString(c.Font, visual).Ceil()
+		}
+		text.Draw(clipped, visual, c.Font, x, textY, c.TextColor)
+		stats.AddDrawCalls(1)
+	case style.TextTruncateFade:
+		drawFadedLine(screen.SubImage(contentRect).(*ebiten.Image), line, contentRect, textY, c, rtl)
+	default:
+		return false
+	}
+	return true
+}
+
+// drawFadedLine は、コンテンツ領域の末尾側(左から右に書く段落では右端)に向かって徐々に透明になるように1行を描画します。
+// 透明にならない部分はまとめて描画し、フェード領域にかかる文字だけを1文字ずつ不透明度を変えて描画します。
+func drawFadedLine(dst *ebiten.Image, line string, contentRect image.Rectangle, textY int, c style.Computed, rtl bool) {
+	if rtl {
+		drawFadedLineRTL(dst, line, contentRect, textY, c)
+		return
+	}
+	line = VisualLine(line, false)
+	fadeWidth := min(maxFadeWidth, contentRect.Dx()/3)
+	fadeStart := contentRect.Max.X - fadeWidth
+	x := contentRect.Min.X
+	prev := rune(-1)
+	solidEnd := 0
+	for i, r := range line {
+		adv, _ := GlyphAdvance(c.Font, r)
+		if prev >= 0 {
+			x += Kern(c.Font, prev, r).Round()
+		}
+		if x+adv.Round() > fadeStart {
+			break
+		}
+		x += adv.Round()
+		solidEnd = i + utf8.RuneLen(r)
+		prev = r
+	}
+	if solidEnd > 0 {
+		text.Draw(dst, line[:solidEnd], c.Font, contentRect.Min.X, textY, c.TextColor)
+		stats.AddDrawCalls(1)
+	}
+
+	base := color.NRGBAModel.Convert(c.TextColor).(color.NRGBA)
+	for _, r := range line[solidEnd:] {
+		if x >= contentRect.Max.X {
+			break
+		}
+		adv, _ := GlyphAdvance(c.Font, r)
+		// 文字の中心がフェード領域のどこにあるかで不透明度を決めます。
+		center := x + adv.Round()/2
+		alpha := float64(contentRect.Max.X-center) / float64(max(1, fadeWidth))
+		text.Draw(dst, string(r), c.Font, x, textY, fadedColor(base, alpha))
+		stats.AddDrawCalls(1)
+		x += adv.Round()
+	}
+}
+
+// drawFadedLineRTL は、右から左に書く段落の1行を、右端に揃え、左端に向かって徐々に透明になるように描画します。
+// 表示順の末尾(右端)から文字を配置していき、フェード領域にかかる文字だけを1文字ずつ描画します。
+func drawFadedLineRTL(dst *ebiten.Image, line string, contentRect image.Rectangle, textY int, c style.Computed) {
+	visual := []rune(VisualLine(line, true))
+	fadeWidth := min(maxFadeWidth, contentRect.Dx()/3)
+	fadeEnd := contentRect.Min.X + fadeWidth
+	x := contentRect.Max.X
+	solidStart := len(visual)
+	for i := len(visual) - 1; i >= 0; i-- {
+		adv, _ := GlyphAdvance(c.Font, visual[i])
+		if x-adv.Round() < fadeEnd {
+			break
+		}
+		x -= adv.Round()
+		solidStart = i
+	}
+	if solidStart < len(visual) {
+		text.Draw(dst, string(visual[solidStart:]), c.Font, x, textY, c.TextColor)
+		stats.AddDrawCalls(1)
+	}
+
+	base := color.NRGBAModel.Convert(c.TextColor).(color.NRGBA)
+	for i := solidStart - 1; i >= 0 && x > contentRect.Min.X; i-- {
+		adv, _ := GlyphAdvance(c.Font, visual[i])
+		x -= adv.Round()
+		center := x + adv.Round()/2
+		alpha := float64(center-contentRect.Min.X) / float64(max(1, fadeWidth))
+		text.Draw(dst, string(visual[i]), c.Font, x, textY, fadedColor(base, alpha))
+		stats.AddDrawCalls(1)
+	}
+}
+
+// fadedColor は、不透明度をalpha(0から1に丸められます)倍にした色を返します。
+func fadedColor(base color.NRGBA, alpha float64) color.NRGBA {
+	alpha = max(0, min(1, alpha))
+	faded := base
+	faded.A = uint8(float64(base.A) * alpha)
+	return faded
+}
@@ -1,11 +1,12 @@
 package component
 
 import (
+	"furoshiki/i18n"
 	"furoshiki/style"
 	"furoshiki/utils" // UPDATE: utilsパッケージをインポート
 	"image"
 
-	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
 )
 
 // --- TextWidget ---
@@ -15,10 +16,31 @@ type TextWidget struct {
 	*LayoutableWidget
 	text     string
 	wrapText bool // テキストを折り返すかどうか
+	// textKey, textParams は、テキストをi18nのカタログから解決する場合のキーとパラメータです。
+	textKey    string
+	textParams i18n.Params
+	// localeFace は、ロケールに合わせて最後に適用したフェイスです。
+	localeFace font.Face
+
+	// wrapCache は、直近の折り返し計算の結果です。
+	// 計測(GetHeightForWidth)と描画で同じ幅の折り返しを何度も再計算しないために使用します。
+	wrapCache wrappedTextCache
+}
+
+// wrappedTextCache は、(テキスト, 幅, フォント, 改行位置の決め方)の組に対する折り返し結果を保持します。
+type wrappedTextCache struct {
+	valid  bool
+	text   string
+	width  int
+	face   font.Face
+	mode   style.LineBreakType
+	lines  []string
+	height int
 }
 
 // コンパイル時にインターフェースの実装を検証します。
 var _ HeightForWider = (*TextWidget)(nil)
+var _ WidthForHeighter = (*TextWidget)(nil)
 
 // NewTextWidget は新しいTextWidgetを生成します。
 func NewTextWidget(text string) *TextWidget {
@@ -41,12 +63,63 @@ func (t *TextWidget) Text() string {
 	return t.text
 }
 
+// DefaultAccessibleRole は、DefaultRoleProviderインターフェースの実装です。テキストを表示するウィジェットはtextの役割を持ちます。
+func (t *TextWidget) DefaultAccessibleRole() Role {
+	return RoleText
+}
+
+// DefaultAccessibleLabel は、DefaultLabelProviderインターフェースの実装です。表示中のテキストを名前として返します。
+func (t *TextWidget) DefaultAccessibleLabel() string {
+	return t.text
+}
+
 // SetText はウィジェットのテキストを設定し、ダーティフラグを立てます。
+// SetTextKeyで設定したキーは解除されます。
 func (t *TextWidget) SetText(text string) {
+	if t.textKey != "" {
+		t.textKey, t.textParams = "", nil
+		UnregisterLocalizable(t)
+	}
+	t.setText(text)
+}
+
+// SetTextKey は、i18nのカタログのキーを設定し、現在のロケールで解決したテキストを表示します。
+// paramsは、メッセージの {name} に埋め込むパラメータです。不要な場合はnilを指定します。
+// ロケールが変わると、テキストとフォントは自動的に解決し直され、再レイアウトされます。
+func (t *TextWidget) SetTextKey(key string, params i18n.Params) {
+	t.textKey, t.textParams = key, params
+	RegisterLocalizable(t)
+	t.RefreshLocale()
+}
+
+// RefreshLocale は、Localizableインターフェースの実装です。現在のロケールでテキストとフォントを解決し直します。
+func (t *TextWidget) RefreshLocale() {
+	if t.textKey == "" {
+		return
+	}
+	t.localeFace = t.ApplyLocaleFace(t.localeFace)
+	t.setText(i18n.T(t.textKey, t.textParams))
+}
+
+// Cleanup は、ロケールの変更の通知を解除してから、ウィジェットのリソースを解放します。
+func (t *TextWidget) Cleanup() {
+	UnregisterLocalizable(t)
+	t.LayoutableWidget.Cleanup()
+}
+
+// TextKey は、SetTextKeyで設定したキーを返します。テキストを直接設定している場合は空文字列です。
+func (t *TextWidget) TextKey() string {
+	return t.textKey
+}
+
+// setText は、キーの設定を変えずにテキストを設定します。
+func (t *TextWidget) setText(text string) {
 	if t.text != text {
 		t.text = text
+		t.invalidateMeasure()
 		// テキスト変更は最小サイズに影響し、レイアウトが変わる可能性があるため再レイアウトを要求します。
 		t.MarkDirty(true)
+		t.NotifyLabelChanged()
 	}
 }
 
@@ -54,20 +127,31 @@ func (t *TextWidget) SetText(text string) {
 func (t *TextWidget) SetWrapText(wrap bool) {
 	if t.wrapText != wrap {
 		t.wrapText = wrap
+		t.invalidateMeasure()
 		// 折り返し設定の変更はレイアウトに影響するため、再レイアウトを要求します。
 		t.MarkDirty(true)
 	}
 }
 
+// IsWrapText は、テキストを折り返す設定かどうかを返します。
+func (t *TextWidget) IsWrapText() bool {
+	return t.wrapText
+}
+
 // GetHeightForWidth は、HeightForWiderインターフェースの実装です。
 // 指定された幅に基づいて、テキストを折り返した場合に必要となる高さを計算します。
 func (t *TextWidget) GetHeightForWidth(width int) int {
 	if !t.wrapText {
 		// 折り返しが無効な場合、通常の最小高さを返します。
-		_, h := t.calculateContentMinSize()
+		_, h := t.cachedContentMinSize()
 		return h
 	}
+	// 同じ幅に対する計測結果はテキストやスタイルが変わるまで再利用します。
+	return t.cachedHeightForWidth(width, t.measureHeightForWidth)
+}
 
+// measureHeightForWidth は、指定された幅でテキストを折り返した場合の高さを実際に計測します。
+func (t *TextWidget) measureHeightForWidth(width int) int {
 	s := t.ReadOnlyStyle()
 	if t.text == "" || s.Font == nil || *s.Font == nil {
 		return 0
@@ -80,20 +164,93 @@ func (t *TextWidget) GetHeightForWidth(width int) int {
 
 	contentWidth := width - padding.Left - padding.Right
 	if contentWidth <= 0 {
-		_, h := t.calculateContentMinSize() // 幅がない場合は1行の高さを返す
+		_, h := t.cachedContentMinSize() // 幅がない場合は1行の高さを返す
 		return h
 	}
 
-	// drawing_helpers.goの公開関数を呼び出します。
-	_, requiredHeight := CalculateWrappedText(*s.Font, t.text, contentWidth)
+	_, requiredHeight := t.wrappedLines(*s.Font, contentWidth, lineBreakOf(s))
 	return requiredHeight + padding.Top + padding.Bottom
 }
 
+// GetWidthForHeight は、WidthForHeighterインターフェースの実装です。
+// 指定された高さに収まるようにテキストを折り返した場合の、最も狭い幅を返します。
+// 折り返しが無効な場合は、通常の最小幅を返します。
+func (t *TextWidget) GetWidthForHeight(height int) int {
+	if !t.wrapText {
+		w, _ := t.cachedContentMinSize()
+		return w
+	}
+	return t.CachedWidthForHeight(height, t.measureWidthForHeight)
+}
+
+// measureWidthForHeight は、高さheightに収まる最も狭い幅を、最も長い単語の幅と1行に並べた幅の間で二分探索して求めます。
+// 1行に並べても収まらない場合は、1行に並べた幅を返します。
+func (t *TextWidget) measureWidthForHeight(height int) int {
+	minWidth, _ := t.cachedContentMinSize()
+	s := t.ReadOnlyStyle()
+	if t.text == "" || s.Font == nil || *s.Font == nil {
+		return minWidth
+	}
+	padding := style.Insets{}
+	if s.Padding != nil {
+		padding = *s.Padding
+	}
+	f, mode := *s.Font, lineBreakOf(s)
+	// NOTE: 探索中の折り返しは描画用のキャッシュ(wrappedLines)を経由せず、描画時の折り返し結果を上書きしないようにします。
+	fits := func(width int) bool {
+		_, h := CalculateWrappedTextWithBreak(f, t.text, width-padding.Left-padding.Right, mode)
+		return h+padding.Top+padding.Bottom <= height
+	}
+	lo := minWidth
+	hi := max(lo, BoundString(f, t.text).Dx()+padding.Left+padding.Right)
+	if !fits(hi) {
+		return hi
+	}
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if fits(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// wrappedLines は、指定された幅とフォントでテキストを折り返した結果を返します。
+// 直前の呼び出しと同じ(テキスト, 幅, フォント, 改行位置の決め方)の組であれば、キャッシュされた結果を再利用します。
+// 返されたスライスは次の呼び出しまでの間だけ有効で、変更してはいけません。
+func (t *TextWidget) wrappedLines(f font.Face, contentWidth int, mode style.LineBreakType) ([]string, int) {
+	c := &t.wrapCache
+	if c.valid && c.text == t.text && c.width == contentWidth && c.face == f && c.mode == mode {
+		return c.lines, c.height
+	}
+	// drawing_helpers.goの公開関数を呼び出します。
+	lines, height := CalculateWrappedTextWithBreak(f, t.text, contentWidth, mode)
+	*c = wrappedTextCache{
+		valid:  true,
+		text:   t.text,
+		width:  contentWidth,
+		face:   f,
+		mode:   mode,
+		lines:  lines,
+		height: height,
+	}
+	return lines, height
+}
+
 // UPDATE: DrawWithStyleのシグネチャをDrawInfoを受け取るように変更
 // DrawWithStyleは、指定されたスタイルを用いてウィジェットの背景とテキストを描画する共通ロジックです。
 // 通常のDrawメソッドと分離することで、Buttonのように状態に応じてスタイルを切り替える必要のある
 // 具象ウィジェットが、描画ロジックを再利用しやすくなります。
+// NOTE: 描画ループでは、キャッシュ済みの値型スタイルを受け取るDrawWithComputedStyleを使用してください。
 func (t *TextWidget) DrawWithStyle(info DrawInfo, styleToUse style.Style) {
+	t.DrawWithComputedStyle(info, style.Resolve(styleToUse))
+}
+
+// DrawWithComputedStyle は、解決済みの値型スタイルを用いてウィジェットの背景とテキストを描画します。
+// StyleManagerがキャッシュした値型スタイルを渡すことで、描画時のヒープ割り当てをなくします。
+func (t *TextWidget) DrawWithComputedStyle(info DrawInfo, c style.Computed) {
 	// IsVisible() に加えてレイアウト済みかもチェックします。
 	// これにより、ウィジェットがUIツリーに追加されてから最初のレイアウト計算が完了するまでの1フレーム間、
 	// 意図せず (0,0) 座標に描画されてしまうのを防ぎます。
@@ -108,20 +265,35 @@ func (t *TextWidget) DrawWithStyle(info DrawInfo, styleToUse style.Style) {
 	finalX := x + info.OffsetX
 	finalY := y + info.OffsetY
 
-	DrawStyledBackground(info.Screen, finalX, finalY, width, height, styleToUse)
-	// 描画ヘルパーに折り返し情報を渡します。
+	DrawComputedBackground(info.Screen, finalX, finalY, width, height, c)
 	finalRect := image.Rect(finalX, finalY, finalX+width, finalY+height)
-	DrawAlignedText(info.Screen, t.text, finalRect, styleToUse, t.wrapText)
+	if !t.wrapText || c.Font == nil {
+		DrawComputedText(info.Screen, t.text, finalRect, c, t.wrapText)
+		return
+	}
+
+	// 折り返しが有効な場合は、計測時にキャッシュされた折り返し結果を再利用して描画します。
+	contentWidth := width - c.Padding.Left - c.Padding.Right
+	if t.text == "" || contentWidth <= 0 {
+		return
+	}
+	lines, _ := t.wrappedLines(c.Font, contentWidth, c.LineBreak)
+	DrawAlignedLines(info.Screen, lines, finalRect, c)
 }
 
 // UPDATE: DrawメソッドのシグネチャをDrawInfoを受け取るように変更
 // Draw はTextWidgetを描画します。
-// このメソッドは、ウィジェット自身の現在のスタイルを使用して、共通の描画ロジック(DrawWithStyle)を呼び出します。
+// このメソッドは、ウィジェット自身の現在のスタイルを使用して、共通の描画ロジック(DrawWithComputedStyle)を呼び出します。
 func (t *TextWidget) Draw(info DrawInfo) {
-	// NOTE: パフォーマンス向上のため、ディープコピーを行うGetStyle()の代わりに
-	//       シャローコピーを行うReadOnlyStyle()を使用します。
-	currentStyle := t.ReadOnlyStyle()
-	t.DrawWithStyle(info, currentStyle)
+	// NOTE: パフォーマンス向上のため、キャッシュ済みの値型スタイルを使用します。
+	//       スタイルのコピーや不透明度を適用した色の生成が毎フレーム発生しません。
+	t.DrawWithComputedStyle(info, t.ComputedStyle())
+}
+
+// ContentMinSize は、現在のテキストとスタイルに基づくコンテンツの最小サイズを、キャッシュを介さずに計算します。
+// SetContentMinSizeFuncで計算を差し替える具象ウィジェットが、標準の計算結果を利用するために使用します。
+func (t *TextWidget) ContentMinSize() (int, int) {
+	return t.calculateContentMinSize()
 }
 
 // calculateContentMinSize は、現在のテキストとスタイルに基づいてコンテンツが表示されるべき最小サイズを計算します。
@@ -137,30 +309,46 @@ func (t *TextWidget) calculateContentMinSize() (int, int) {
 		padding = *s.Padding
 	}
 
-	metrics := (*s.Font).Metrics()
+	metrics := FaceMetrics(*s.Font)
 	contentMinHeight := (metrics.Ascent + metrics.Descent).Ceil() + padding.Top + padding.Bottom
 
 	if t.wrapText {
 		// 折り返しが有効な場合、最小幅は最も長い単語の幅になります。
+		// 日本語などの文字の間で改行できるテキストでは、改行できる位置で分割した部分のうち最も長いものの幅になります。
 		longestWord := ""
 		// UPDATE: strings.Fieldsから、ライブラリで共通化されたutils.SplitIntoWordsに変更。
 		// これにより、単語分割のロジックが一貫します。
 		words := utils.SplitIntoWords(t.text)
+		mode := lineBreakOf(s)
 		for _, word := range words {
-			if len(word) > len(longestWord) {
-				longestWord = word
+			for _, unit := range LineBreakUnits(word, mode) {
+				if len(unit) > len(longestWord) {
+					longestWord = unit
+				}
 			}
 		}
 		if longestWord == "" {
 			longestWord = t.text // 空白を含まない長い単一の単語の場合
 		}
-		bounds := text.BoundString(*s.Font, longestWord)
+		bounds := BoundString(*s.Font, longestWord)
 		contentMinWidth := bounds.Dx() + padding.Left + padding.Right
 		return contentMinWidth, contentMinHeight
+	} else if s.TextTruncate != nil && *s.TextTruncate != style.TextTruncateNone {
+		// 切り詰めが有効な場合、テキストは幅に合わせて省略されるため、最小幅は省略記号の幅(または0)になります。
+		contentMinWidth := truncatedMinWidth(*s.Font, *s.TextTruncate) + padding.Left + padding.Right
+		return contentMinWidth, contentMinHeight
 	} else {
 		// 折り返しが無効な場合、最小幅はテキスト全体の幅になります。
-		bounds := text.BoundString(*s.Font, t.text)
+		bounds := BoundString(*s.Font, t.text)
 		contentMinWidth := bounds.Dx() + padding.Left + padding.Right
 		return contentMinWidth, contentMinHeight
 	}
 }
+
+// lineBreakOf は、スタイルに設定された改行位置の決め方を返します。未設定の場合はLineBreakAutoです。
+func lineBreakOf(s style.Style) style.LineBreakType {
+	if s.LineBreak != nil {
+		return *s.LineBreak
+	}
+	return style.LineBreakAuto
+}
@@ -0,0 +1,89 @@
+package component
+
+// WalkResult は、Walkの訪問関数が走査をどのように続けるかを指示する値です。
+type WalkResult int
+
+const (
+	// WalkContinue は、このウィジェットの子孫の走査を続けることを示します。
+	WalkContinue WalkResult = iota
+	// WalkSkipChildren は、このウィジェットの子孫を飛ばし、次の兄弟から走査を続けることを示します。
+	WalkSkipChildren
+	// WalkStop は、走査全体をただちに終了することを示します。
+	WalkStop
+)
+
+// Walk は、rootを起点にウィジェットツリーを深さ優先（親が先、子は描画順）で走査し、
+// 各ウィジェットに対してvisitを呼び出します。depthはrootを0とした深さです。
+//
+// 子要素はContainerインターフェースのGetChildrenから取得します。ScrollViewのように
+// 内部コンテナを持つウィジェットはGetChildrenを内部コンテナに委譲しているため、
+// スクロール領域の内容も通常のコンテナと同じように走査されます。
+// 走査中にツリーを変更した場合の動作は未定義です。
+func Walk(root Widget, visit func(w Widget, depth int) WalkResult) {
+	if root == nil || visit == nil {
+		return
+	}
+	walk(root, 0, visit)
+}
+
+// walk は、Walkの再帰部分です。走査を終了すべき場合はfalseを返します。
+func walk(w Widget, depth int, visit func(w Widget, depth int) WalkResult) bool {
+	switch visit(w, depth) {
+	case WalkStop:
+		return false
+	case WalkSkipChildren:
+		return true
+	}
+	if c, ok := w.(Container); ok {
+		for _, child := range c.GetChildren() {
+			if child == nil {
+				continue
+			}
+			if !walk(child, depth+1, visit) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// FindFirst は、root以下のツリーを深さ優先で走査し、predicateを満たす最初のウィジェットを返します。
+// 見つからない場合はnilを返します。
+func FindFirst(root Widget, predicate func(Widget) bool) Widget {
+	var found Widget
+	Walk(root, func(w Widget, _ int) WalkResult {
+		if predicate(w) {
+			found = w
+			return WalkStop
+		}
+		return WalkContinue
+	})
+	return found
+}
+
+// FindAll は、root以下のツリーからpredicateを満たすウィジェットをすべて、深さ優先の順で返します。
+func FindAll(root Widget, predicate func(Widget) bool) []Widget {
+	var result []Widget
+	Walk(root, func(w Widget, _ int) WalkResult {
+		if predicate(w) {
+			result = append(result, w)
+		}
+		return WalkContinue
+	})
+	return result
+}
+
+// TranslateTree は、rootとその子孫の位置を(dx, dy)だけ移動します。
+// コンテナは自身の位置が変わっても子を配置し直さないため、再レイアウトせずにサブツリー全体を動かす場合に使用します。
+func TranslateTree(root Widget, dx, dy int) {
+	if dx == 0 && dy == 0 {
+		return
+	}
+	Walk(root, func(w Widget, _ int) WalkResult {
+		if ps, ok := w.(PositionSetter); ok {
+			x, y := ps.GetPosition()
+			ps.SetPosition(x+dx, y+dy)
+		}
+		return WalkContinue
+	})
+}
@@ -11,47 +11,73 @@ import (
 // NOTE: 複数のハンドラを登録できるように、内部実装がスライスベースに変更されました。
 // 同じイベントタイプに対して複数回呼び出すと、ハンドラが順に追加されます。
 func (w *LayoutableWidget) AddEventHandler(eventType event.EventType, handler event.EventHandler) {
-	if w.eventHandlers == nil {
-		// NOTE: eventHandlersの型が map[event.EventType]event.EventHandler から
-		//       map[event.EventType][]event.EventHandler に変更されました。
-		w.eventHandlers = make(map[event.EventType][]event.EventHandler)
-	}
 	// 指定されたイベントタイプのハンドラスライスに、新しいハンドラを追加します。
-	w.eventHandlers[eventType] = append(w.eventHandlers[eventType], handler)
+	for i := range w.eventHandlers {
+		if w.eventHandlers[i].eventType == eventType {
+			w.eventHandlers[i].handlers = append(w.eventHandlers[i].handlers, handler)
+			return
+		}
+	}
+	w.eventHandlers = append(w.eventHandlers, handlerEntry{eventType: eventType, handlers: []event.EventHandler{handler}})
 }
 
 // RemoveEventHandler は、指定されたイベントタイプのイベントハンドラをすべて削除します。
 func (w *LayoutableWidget) RemoveEventHandler(eventType event.EventType) {
-	if w.eventHandlers != nil {
-		delete(w.eventHandlers, eventType)
+	for i := range w.eventHandlers {
+		if w.eventHandlers[i].eventType == eventType {
+			w.eventHandlers = append(w.eventHandlers[:i], w.eventHandlers[i+1:]...)
+			return
+		}
 	}
 }
 
+// handlersFor は、指定されたイベントタイプに登録されたハンドラを返します。
+func (w *LayoutableWidget) handlersFor(eventType event.EventType) []event.EventHandler {
+	for i := range w.eventHandlers {
+		if w.eventHandlers[i].eventType == eventType {
+			return w.eventHandlers[i].handlers
+		}
+	}
+	return nil
+}
+
 // HandleEvent は、ディスパッチャから渡されたイベントを処理します。
 // このメソッドはイベントバブリングを実装します。まず自身のハンドラを呼び出し、
 // イベントがまだ処理されていない（e.Handled == false）場合、親ウィジェットの
 // HandleEventメソッドを再帰的に呼び出します。
 func (w *LayoutableWidget) HandleEvent(e *event.Event) {
+	// ディスパッチャのトレーサーやブレークポイントのために、イベントが届いたことを通知します。
+	var target event.EventTarget
+	if e != nil && e.Debugging() {
+		target = w.eventTarget()
+		e.NotifyDelivered(target)
+	}
 	// NOTE: 複数のハンドラを順に実行するようにロジックが更新されました。
-	if handlers, exists := w.eventHandlers[e.Type]; exists {
+	if handlers := w.handlersFor(e.Type); len(handlers) > 0 {
 		// 登録されているすべてのハンドラをループ処理します。
-		for _, handler := range handlers {
+		for i, handler := range handlers {
 			// イベントが既に処理済みの場合、後続のハンドラの実行をスキップします。
 			if e.Handled {
 				break
 			}
 			// イベントハンドラ内でパニックが発生してもアプリケーションがクラッシュしないように保護します。
 			func() {
+				result, panicked := event.Propagate, true
 				defer func() {
 					if r := recover(); r != nil {
 						log.Printf("Recovered from panic in event handler: %v\n%s", r, debug.Stack())
 					}
+					if target != nil {
+						e.NotifyHandler(target, i, result, panicked)
+					}
 				}()
 				// NOTE: このハンドラ呼び出しを個別に保護することで、特定のハンドラがパニックを起こしても、
 				//       同じイベントに登録された他のハンドラの実行が継続されます。
 				//       これは、UIの堅牢性を高めるための意図的な設計です。
 				// 【提案1対応】ハンドラの戻り値をチェックし、イベントの伝播を停止するか判断します。
-				if handler(e) == event.StopPropagation {
+				result = handler(e)
+				panicked = false
+				if result == event.StopPropagation {
 					e.Handled = true
 				}
 			}()
@@ -68,6 +94,30 @@ func (w *LayoutableWidget) HandleEvent(e *event.Event) {
 	}
 }
 
+// eventTarget は、イベントの対象としての具象ウィジェットを返します。
+// 具象ウィジェットが設定されていない場合は、LayoutableWidget自身を返します。
+func (w *LayoutableWidget) eventTarget() event.EventTarget {
+	if t, ok := w.self.(event.EventTarget); ok {
+		return t
+	}
+	return w
+}
+
+// LocalPoint は、画面座標を、このウィジェットの位置と同じ座標系の座標に変換します。
+// 座標変換を持つ祖先(PointTransformer)があれば、外側の祖先から順に変換を適用します。
+func (w *LayoutableWidget) LocalPoint(x, y int) (int, int) {
+	var transformers []PointTransformer
+	for p := w.hierarchy.parent; p != nil; p = p.GetParent() {
+		if t, ok := p.(PointTransformer); ok {
+			transformers = append(transformers, t)
+		}
+	}
+	for i := len(transformers) - 1; i >= 0; i-- {
+		x, y = transformers[i].ToLocal(x, y)
+	}
+	return x, y
+}
+
 // HitTest は、指定された座標がウィジェットの領域内にあるかを判定します。
 // 戻り値として、初期化時に設定された具象ウィジェットへの参照(w.self)を返します。
 // これにより、ButtonやLabelなどの具象ウィジェット側でこのメソッドをオーバーライドする必要がなくなります。
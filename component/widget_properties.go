@@ -34,6 +34,9 @@ func (w *LayoutableWidget) SetSize(width, height int) {
 		w.size.width = width
 		w.size.height = height
 		w.MarkDirty(true) // サイズ変更は再レイアウトが必要
+		if resizeObserver != nil && w.self != nil {
+			resizeObserver(w.self, width, height)
+		}
 	}
 }
 
@@ -62,7 +65,8 @@ func (w *LayoutableWidget) GetMinSize() (width, height int) {
 	userMinWidth, userMinHeight := w.minSize.width, w.minSize.height
 
 	if w.contentMinSizeFunc != nil {
-		contentMinWidth, contentMinHeight := w.contentMinSizeFunc()
+		// NOTE: コンテンツの固有サイズは入力が変わらない限り一定なため、キャッシュを介して取得します。
+		contentMinWidth, contentMinHeight := w.cachedContentMinSize()
 		// ローカルのmax関数を削除し、Go 1.21+ で利用可能な組み込みのmax関数を使用します。
 		finalMinWidth := max(contentMinWidth, userMinWidth)
 		finalMinHeight := max(contentMinHeight, userMinHeight)
@@ -72,6 +76,13 @@ func (w *LayoutableWidget) GetMinSize() (width, height int) {
 	return userMinWidth, userMinHeight
 }
 
+// UserMinSize は、ユーザーが明示的に設定した最小サイズを返します。
+// GetMinSizeと異なり、コンテンツから計算される最小サイズは含みません。
+// GetMinSizeをオーバーライドする具象ウィジェットが、独自のコンテンツサイズと組み合わせるために使用します。
+func (w *LayoutableWidget) UserMinSize() (int, int) {
+	return w.minSize.width, w.minSize.height
+}
+
 // SetRequestedPosition は、レイアウトに対する希望の相対位置を設定します。
 // このメソッドは、親コンテナが `AbsoluteLayout` (主に `ui.ZStack` で作成) を
 // 使用している場合にのみ有効です。
@@ -92,6 +103,8 @@ func (w *LayoutableWidget) GetRequestedPosition() (int, int) {
 // NOTE: 内部のStyleManagerを介してスタイルが管理され、変更が検知された場合にのみ
 // ダーティフラグが自動的に設定されます。
 func (w *LayoutableWidget) SetStyle(s style.Style) {
+	// フォントやパディングの変更は計測結果に影響するため、計測キャッシュを破棄します。
+	w.invalidateMeasure()
 	// NOTE: カプセル化されたstyleManagerのメソッドを呼び出します。
 	w.styleManager.SetBaseStyle(s)
 }
@@ -112,6 +125,12 @@ func (w *LayoutableWidget) ReadOnlyStyle() style.Style {
 	return w.styleManager.ReadOnlyBaseStyle()
 }
 
+// ComputedStyle は、ウィジェットの基本スタイルを値型に解決して返します。
+// 結果はキャッシュされるため、描画やレイアウトのホットパスで使用してもヒープ割り当ては発生しません。
+func (w *LayoutableWidget) ComputedStyle() style.Computed {
+	return w.styleManager.ComputedBase()
+}
+
 // SetFlex はFlexLayoutにおけるウィジェットの伸縮係数を設定します。
 func (w *LayoutableWidget) SetFlex(flex int) {
 	if flex < 0 {
@@ -137,6 +156,11 @@ func (w *LayoutableWidget) SetLayoutBoundary(isBoundary bool) {
 	}
 }
 
+// IsLayoutBoundary は、このウィジェットがレイアウト計算の境界であるかを返します。
+func (w *LayoutableWidget) IsLayoutBoundary() bool {
+	return w.layout.relayoutBoundary
+}
+
 // SetParent はウィジェットの親コンテナを設定します。
 func (w *LayoutableWidget) SetParent(parent Container) {
 	w.hierarchy.parent = parent
@@ -156,4 +180,39 @@ func (w *LayoutableWidget) SetLayoutData(data any) {
 // GetLayoutData はウィジェットからレイアウト固有のデータを取得します。
 func (w *LayoutableWidget) GetLayoutData() any {
 	return w.layout.layoutData
-}
\ No newline at end of file
+}
+
+// SetID はウィジェットの識別子を設定します。
+// IDはレイアウトや描画には影響しないため、ダーティフラグは立てません。
+func (w *LayoutableWidget) SetID(id string) {
+	w.identity.id = id
+}
+
+// GetID はウィジェットの識別子を返します。
+func (w *LayoutableWidget) GetID() string {
+	return w.identity.id
+}
+
+// AddTags はウィジェットにタグを追加します。既に付与されているタグは無視されます。
+func (w *LayoutableWidget) AddTags(tags ...string) {
+	for _, tag := range tags {
+		if tag != "" && !w.HasTag(tag) {
+			w.identity.tags = append(w.identity.tags, tag)
+		}
+	}
+}
+
+// HasTag はウィジェットが指定されたタグを持っているかを返します。
+func (w *LayoutableWidget) HasTag(tag string) bool {
+	for _, t := range w.identity.tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTags はウィジェットに付与されたタグのコピーを返します。
+func (w *LayoutableWidget) GetTags() []string {
+	return append([]string(nil), w.identity.tags...)
+}
@@ -1,5 +1,7 @@
 package component
 
+import "furoshiki/stats"
+
 // WidgetState は、ウィジェットが取りうるインタラクティブな状態を定義します。
 type WidgetState int
 
@@ -12,8 +14,28 @@ const (
 	StatePressed
 	// StateDisabled は、ウィジェットが無効化され、ユーザー入力を受け付けない状態です。
 	StateDisabled
+	// StateFocused は、ウィジェットがキーボードフォーカスを持っている状態です。
+	StateFocused
 )
 
+// String は、状態の名前を返します。デバッグ表示やログ出力に使用されます。
+func (s WidgetState) String() string {
+	switch s {
+	case StateNormal:
+		return "Normal"
+	case StateHovered:
+		return "Hovered"
+	case StatePressed:
+		return "Pressed"
+	case StateDisabled:
+		return "Disabled"
+	case StateFocused:
+		return "Focused"
+	default:
+		return "Unknown"
+	}
+}
+
 // Update はウィジェットの状態を更新します。
 // この基本実装は、具象ウィジェット（Button, Labelなど）で利用されます。
 // Container型は自身のUpdateメソッドでこれをオーバーライドして、子の更新やレイアウト処理を行います。
@@ -31,20 +53,30 @@ func (w *LayoutableWidget) Draw(info DrawInfo) {
 	}
 	// NOTE: StyleManagerから現在の状態に合ったスタイルを取得します。
 	//       カプセル化のために、LayoutableWidgetに新設したラッパーメソッドを経由します。
-	styleToUse := w.GetStyleForState(w.CurrentState())
+	// UPDATE: キャッシュ済みの値型スタイルを使用し、描画時のヒープ割り当てをなくします。
+	styleToUse := w.ComputedStyleForState(w.CurrentState())
 
 	// UPDATE: 親から渡されたオフセットを描画座標に適用
 	// これにより、クリッピング描画などで座標を一時的に書き換える必要がなくなります。
 	finalX := w.position.x + info.OffsetX
 	finalY := w.position.y + info.OffsetY
 
-	DrawStyledBackground(info.Screen, finalX, finalY, w.size.width, w.size.height, styleToUse)
+	DrawComputedBackground(info.Screen, finalX, finalY, w.size.width, w.size.height, styleToUse)
 }
 
 // MarkDirty はウィジェットの状態が変更されたことをマークします。
 // relayoutがtrueの場合、より高いダーティレベル(levelRelayoutDirty)が設定され、
 // 親コンテナにも再レイアウトが必要であることが伝播されます。
 func (w *LayoutableWidget) MarkDirty(relayout bool) {
+	// 描画キャッシュは、ダーティレベルに関わらず変更があるたびに無効化する必要があるため、
+	// 下の早期リターンより前に処理します。省電力モードのためのフレームの要求も同様です。
+	w.invalidateRenderCaches()
+	RequestFrame()
+	stats.AddDirtyRequest(relayout)
+	if relayout && relayoutRequestObserver != nil && !propagatingRelayout && w.self != nil {
+		relayoutRequestObserver(w.self)
+	}
+
 	requestedLevel := levelRedrawDirty
 	if relayout {
 		requestedLevel = levelRelayoutDirty
@@ -61,7 +93,10 @@ func (w *LayoutableWidget) MarkDirty(relayout bool) {
 
 	// 親が存在し、かつ自身がレイアウト境界でなく、再レイアウトが必要な場合のみ伝播します。
 	if w.hierarchy.parent != nil && !w.layout.relayoutBoundary && relayout {
+		outer := propagatingRelayout
+		propagatingRelayout = true
 		w.hierarchy.parent.MarkDirty(true)
+		propagatingRelayout = outer
 	}
 }
 
@@ -107,13 +142,17 @@ func (w *LayoutableWidget) IsPressed() bool {
 }
 
 // CurrentState はウィジェットの現在のインタラクティブな状態を返します。
+// 優先順位は Disabled, Pressed, Focused, Hovered, Normal の順です。
 func (w *LayoutableWidget) CurrentState() WidgetState {
-	if w.state.isDisabled {
+	if w.IsDisabled() {
 		return StateDisabled
 	}
 	if w.state.isPressed {
 		return StatePressed
 	}
+	if w.hasFocus() {
+		return StateFocused
+	}
 	if w.state.isHovered {
 		return StateHovered
 	}
@@ -129,8 +168,12 @@ func (w *LayoutableWidget) SetDisabled(disabled bool) {
 }
 
 // IsDisabled はウィジェットが無効状態かどうかを返します。
+// SetDisabledで無効にした場合に加え、自身または祖先がSetEnabledRecursive(false)でサブツリーを無効にしている場合もtrueを返します。
 func (w *LayoutableWidget) IsDisabled() bool {
-	return w.state.isDisabled
+	if w.state.isDisabled || w.state.subtreeDisabled {
+		return true
+	}
+	return w.self != nil && IsInDisabledSubtree(w.self)
 }
 
 // SetVisible はウィジェットの可視性を設定します。
@@ -138,6 +181,9 @@ func (w *LayoutableWidget) SetVisible(visible bool) {
 	if w.state.isVisible != visible {
 		w.state.isVisible = visible
 		w.MarkDirty(true) // 表示状態の変更はレイアウトに影響
+		if visible && w.AccessibleRole() == RoleDialog {
+			notifyAccessibility(AccessibilityDialogOpened, w.self)
+		}
 	}
 }
 
@@ -153,6 +199,26 @@ func (w *LayoutableWidget) HasBeenLaidOut() bool {
 
 // Cleanup は、コンポーネントが不要になったときにリソースを解放するためのメソッドです。
 func (w *LayoutableWidget) Cleanup() {
+	if w.render.enabled {
+		w.render.enabled = false
+		activeRenderCaches--
+	}
+	if w.state.isPaused {
+		w.state.isPaused = false
+		activePauses--
+	}
+	if w.state.subtreeDisabled {
+		w.state.subtreeDisabled = false
+		activeSubtreeDisables--
+	}
+	w.releaseRenderCache()
+	w.releaseShaderImage()
+	w.effect = shaderEffect{}
 	w.eventHandlers = nil
+	w.hooks = drawHooks{}
 	w.hierarchy.parent = nil
+	if w.hasFocus() {
+		// 破棄されたウィジェットにフォーカスが残らないようにします。
+		focused = nil
+	}
 }
\ No newline at end of file
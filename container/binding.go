@@ -0,0 +1,106 @@
+package container
+
+import (
+	"furoshiki/binding"
+	"furoshiki/component"
+)
+
+// BindChildren は、監視可能なリストとコンテナの子要素を同期させます。
+// リストに要素が挿入・削除・移動されるたびに、コンテナの子要素も差分更新されます。
+// 既存のウィジェットは可能な限り再利用され、変更のあった要素に対応するウィジェットのみが
+// factory によって生成（または破棄）されます。
+// factory はリスト内のインデックスを受け取り、その要素を表示するウィジェットを返します。
+// update は、要素が置き換えられたとき(ChangeUpdate)やリスト全体が置き換えられたとき(ChangeReset)に、
+// 既存のウィジェットとその新しいインデックスを受け取り、表示内容をその場で更新します。
+// ウィジェットを作り直さないため、フォーカスやスクロール位置などの状態が保たれます。
+// update が nil の場合は、これらの変更でも factory によってウィジェットを作り直します。
+//
+// NOTE: バインドされたコンテナの子要素はリストによって管理されます。
+// バインド時点で存在していた子要素は破棄され、以後 AddChild などで子を直接操作すると
+// リストとの対応が崩れるため避けてください。
+// 戻り値の関数を呼び出すと購読が解除されます。コンテナのCleanup時にも自動的に解除されます。
+// OverflowScrollでScrollViewに包まれたコンテナでは、スクロールされる内部のコンテナの子要素を同期します(ContentContainer)。
+func (c *Container) BindChildren(list binding.Observable, factory func(index int) component.Widget, update func(child component.Widget, index int)) (unbind func()) {
+	if content := c.ContentContainer(); content != c {
+		return content.BindChildren(list, factory, update)
+	}
+	c.unbindChildren()
+	if list == nil || factory == nil {
+		return func() {}
+	}
+
+	c.BatchUpdate(func() {
+		c.clearChildrenWithCleanup()
+		for i := 0; i < list.Len(); i++ {
+			c.InsertChildAt(i, factory(i))
+		}
+	})
+
+	unsubscribe := list.Subscribe(func(change binding.Change) {
+		c.applyListChange(list, factory, update, change)
+	})
+	c.unbind = unsubscribe
+	c.MarkDirty(true)
+	return c.unbindChildren
+}
+
+// applyListChange は、リストの変更内容を子要素に反映します。
+func (c *Container) applyListChange(list binding.Observable, factory func(index int) component.Widget, update func(child component.Widget, index int), change binding.Change) {
+	// 複数の子が変化する場合でも、再レイアウトの要求は最後に1回だけ行います。
+	c.BeginUpdate()
+	defer c.EndUpdate()
+
+	switch change.Kind {
+	case binding.ChangeInsert:
+		for k := 0; k < change.Count; k++ {
+			c.InsertChildAt(change.Index+k, factory(change.Index+k))
+		}
+	case binding.ChangeRemove:
+		for k := 0; k < change.Count; k++ {
+			if change.Index < len(c.children) {
+				c.RemoveChild(c.children[change.Index])
+			}
+		}
+	case binding.ChangeMove:
+		if change.OldIndex < len(c.children) {
+			c.MoveChild(c.children[change.OldIndex], change.Index)
+		}
+	case binding.ChangeUpdate:
+		if change.Index >= len(c.children) {
+			break
+		}
+		if update != nil {
+			update(c.children[change.Index], change.Index)
+		} else {
+			c.ReplaceChild(c.children[change.Index], factory(change.Index))
+		}
+	case binding.ChangeReset:
+		if update == nil {
+			c.clearChildrenWithCleanup()
+			for i := 0; i < list.Len(); i++ {
+				c.InsertChildAt(i, factory(i))
+			}
+			break
+		}
+		// 新しいリストにもインデックスが存在する子は再利用し、余った子だけを破棄します。
+		n := list.Len()
+		for len(c.children) > n {
+			c.RemoveChild(c.children[len(c.children)-1])
+		}
+		for i, child := range c.children {
+			update(child, i)
+		}
+		for i := len(c.children); i < n; i++ {
+			c.InsertChildAt(i, factory(i))
+		}
+	}
+	c.MarkDirty(true)
+}
+
+// unbindChildren は、現在のリストバインディングがあれば購読を解除します。
+func (c *Container) unbindChildren() {
+	if c.unbind != nil {
+		c.unbind()
+		c.unbind = nil
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"furoshiki/component"
 	"furoshiki/layout"
+	"furoshiki/stats"
+	"furoshiki/utils"
 	"log"
 	"runtime/debug"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -25,12 +28,32 @@ type Container struct {
 	warned   bool // サイズ警告を一度だけ出すためのフラグ
 
 	clipsChildren  bool          // 子要素をクリッピングするかどうか
+	overflow       Overflow      // 子要素がはみ出した場合の扱い
 	offscreenImage *ebiten.Image // クリッピング描画用のオフスクリーンバッファ
+
+	unbind func() // BindChildrenによるリスト購読を解除する関数
+
+	// updateHooks は、毎フレームのUpdateの最初(レイアウトの前)に呼び出される関数です。
+	updateHooks []func()
+
+	// transform は、子孫に適用する座標変換です。nilの場合は座標変換を行いません。
+	transform *Transform
+	// trackTransform は、毎フレームのUpdateで座標変換を返す関数です。
+	trackTransform func() Transform
+	// transformImage は、座標変換を適用して描画するためのオフスクリーンバッファです。
+	transformImage *ebiten.Image
+
+	// updateDepth は、BeginUpdateのネストの深さです。0より大きい間はMarkDirtyの伝播を保留します。
+	updateDepth int
+	// pendingDirty, pendingRelayout は、更新の保留中に要求されたダーティ状態です。
+	pendingDirty    bool
+	pendingRelayout bool
 }
 
 // コンパイル時にインターフェースの実装を検証します。
 var _ component.Container = (*Container)(nil)
 var _ layout.Container = (*Container)(nil)
+var _ component.PointTransformer = (*Container)(nil)
 
 // NewContainer は、ビルダーを使わずに新しいContainerインスタンスを生成します。
 // NOTE: 内部のInit呼び出しが失敗する可能性があるため、コンストラクタはerrorを返すように変更されました。
@@ -78,13 +101,27 @@ func (c *Container) Update() {
 	}
 
 	c.checkSizeWarning()
+	for _, hook := range c.updateHooks {
+		hook()
+	}
+	c.updateTransform()
 
 	if c.IsDirty() {
 		if c.NeedsRelayout() {
 			if c.layout != nil {
+				stats.AddLayoutPass()
+				component.NotifyRelayout(c)
+				var start time.Time
+				if stats.Enabled() {
+					start = time.Now()
+				}
 				// NOTE: レイアウト計算がエラーを返すように変更されたため、ここでハンドリングします。
 				//       以前のpanic/recoverモデルから移行し、より予測可能なエラー処理を実現します。
-				if err := c.layout.Layout(c); err != nil {
+				err := c.layout.Layout(c)
+				if !start.IsZero() {
+					stats.AddLayoutTime(time.Since(start))
+				}
+				if err != nil {
 					// レイアウト計算中にエラーが発生した場合、ログに出力します。
 					// これにより、開発者はレイアウトに関する問題を早期に発見できます。
 					log.Printf("Error during layout calculation: %v\n%s", err, debug.Stack())
@@ -94,11 +131,68 @@ func (c *Container) Update() {
 		c.ClearDirty()
 	}
 
-	for _, child := range c.children {
-		child.Update()
+	component.UpdateChildren(c, c.children)
+}
+
+// AddOnUpdate は、毎フレームのUpdateの最初に呼び出される関数を追加します。
+// 関数はレイアウトの前に呼び出されるため、子の表示状態などの変更は同じフレームのレイアウトに反映されます。
+// 画面の大きさに応じた表示の切り替え(ui.When)など、フレームごとに条件を確認する処理に使用します。
+func (c *Container) AddOnUpdate(fn func()) {
+	if fn != nil {
+		c.updateHooks = append(c.updateHooks, fn)
 	}
 }
 
+// MarkDirty はコンテナの状態が変更されたことをマークします。
+// BeginUpdateとEndUpdateの間（またはBatchUpdateの実行中）は、要求を記録するだけで伝播を保留し、
+// 最後のEndUpdateでまとめて1回だけ処理します。
+func (c *Container) MarkDirty(relayout bool) {
+	if c.updateDepth > 0 {
+		c.pendingDirty = true
+		c.pendingRelayout = c.pendingRelayout || relayout
+		return
+	}
+	c.LayoutableWidget.MarkDirty(relayout)
+}
+
+// BeginUpdate は、子要素の一括変更を開始します。
+// EndUpdateが呼び出されるまで、子の追加・削除などによるダーティ状態の伝播を保留します。
+// 呼び出しはネストでき、最も外側のEndUpdateで保留中の変更が反映されます。
+func (c *Container) BeginUpdate() {
+	c.updateDepth++
+}
+
+// EndUpdate は、BeginUpdateで開始した一括変更を終了します。
+// 最も外側の呼び出しであれば、保留されていたダーティ状態を1回だけ伝播させ、再レイアウトを要求します。
+func (c *Container) EndUpdate() {
+	if c.updateDepth == 0 {
+		return
+	}
+	c.updateDepth--
+	if c.updateDepth > 0 || !c.pendingDirty {
+		return
+	}
+	relayout := c.pendingRelayout
+	c.pendingDirty, c.pendingRelayout = false, false
+	c.LayoutableWidget.MarkDirty(relayout)
+}
+
+// BatchUpdate は、fnの実行中に行われた子要素の変更をまとめ、最後に1回だけ再レイアウトを要求します。
+// 数百のアイテムを持つリストを構築する場合などに、ツリー全体へのダーティ伝播の繰り返しを防ぎます。
+//
+// 使用例:
+//
+//	list.BatchUpdate(func() {
+//		for _, item := range items {
+//			list.AddChild(newRow(item))
+//		}
+//	})
+func (c *Container) BatchUpdate(fn func()) {
+	c.BeginUpdate()
+	defer c.EndUpdate()
+	fn()
+}
+
 // checkSizeWarning はコンテナのサイズに関する警告を出力します。
 func (c *Container) checkSizeWarning() {
 	if c.warned {
@@ -119,12 +213,27 @@ func (c *Container) Draw(info component.DrawInfo) {
 	if !c.IsVisible() {
 		return
 	}
+	// ルートのコンテナは、子孫のバッジを兄弟の上に表示するため、描画の終わりまでバッジの描画を遅らせます。
+	badgePass := c.GetParent() == nil && component.BeginBadgePass(info.Screen)
 
-	if c.clipsChildren {
+	if c.transform != nil {
+		c.drawWithTransform(info)
+	} else if c.clipsChildren {
 		c.drawWithClipping(info)
 	} else {
 		c.drawWithoutClipping(info)
 	}
+	if c.overflow == OverflowEllipsis && c.hasOverflowingChild() {
+		c.drawEllipsisMarker(info)
+	}
+
+	// ルートコンテナの描画が完了したら、バッチに残っている背景と境界線を描画します。
+	if c.GetParent() == nil {
+		component.FlushDraws()
+	}
+	if badgePass {
+		component.EndBadgePass()
+	}
 }
 
 // UPDATE: drawWithoutClippingのシグネチャをDrawInfoを受け取るように変更
@@ -132,15 +241,16 @@ func (c *Container) Draw(info component.DrawInfo) {
 func (c *Container) drawWithoutClipping(info component.DrawInfo) {
 	x, y := c.GetPosition()
 	width, height := c.GetSize()
-	// NOTE: パフォーマンス向上のためReadOnlyStyle()を使用します。
+	// NOTE: パフォーマンス向上のため、キャッシュ済みの値型スタイル(ComputedStyle)を使用します。
 	// UPDATE: 親から渡されたオフセットを描画座標に適用
 	finalX := x + info.OffsetX
 	finalY := y + info.OffsetY
-	component.DrawStyledBackground(info.Screen, finalX, finalY, width, height, c.ReadOnlyStyle())
+	component.DrawComputedBackground(info.Screen, finalX, finalY, width, height, c.ComputedStyle())
 
 	for _, child := range c.children {
 		// UPDATE: 子の描画にもオフセット情報を伝播
-		child.Draw(info)
+		// NOTE: 描画キャッシュを考慮するため、DrawWidget経由で描画します。
+		component.DrawWidget(child, info)
 	}
 }
 
@@ -155,17 +265,14 @@ func (c *Container) drawWithClipping(info component.DrawInfo) {
 	}
 
 	// オフスクリーン画像の準備
-	if c.offscreenImage == nil || c.offscreenImage.Bounds().Dx() != containerWidth || c.offscreenImage.Bounds().Dy() != containerHeight {
-		if c.offscreenImage != nil {
-			c.offscreenImage.Deallocate()
-		}
-		c.offscreenImage = ebiten.NewImage(containerWidth, containerHeight)
-	}
+	c.offscreenImage = component.EnsureOffscreen(c.offscreenImage, containerWidth, containerHeight)
+	// オフスクリーン画像への描画を始める前に、これまでの描画命令を確定させます。
+	component.FlushDraws()
 	c.offscreenImage.Clear()
 
 	// コンテナ自身の背景をオフスクリーン画像に描画(オフセットは(0,0))
-	// NOTE: パフォーマンス向上のためReadOnlyStyle()を使用します。
-	component.DrawStyledBackground(c.offscreenImage, 0, 0, containerWidth, containerHeight, c.ReadOnlyStyle())
+	// NOTE: パフォーマンス向上のため、キャッシュ済みの値型スタイル(ComputedStyle)を使用します。
+	component.DrawComputedBackground(c.offscreenImage, 0, 0, containerWidth, containerHeight, c.ComputedStyle())
 
 	// コンテナ自身がScrollerインターフェースを実装しているかチェック
 	var scrollOffsetX, scrollOffsetY int
@@ -186,7 +293,7 @@ func (c *Container) drawWithClipping(info component.DrawInfo) {
 	// 子要素をオフスクリーン画像に描画
 	for _, child := range c.children {
 		// オフセットされた座標でオフスクリーン画像に描画
-		child.Draw(childDrawInfo)
+		component.DrawWidget(child, childDrawInfo)
 	}
 
 	// 完成したオフスクリーン画像をスクリーンに描画
@@ -195,7 +302,9 @@ func (c *Container) drawWithClipping(info component.DrawInfo) {
 	finalX := float64(containerX + info.OffsetX)
 	finalY := float64(containerY + info.OffsetY)
 	opts.GeoM.Translate(finalX, finalY)
+	component.FlushDraws()
 	info.Screen.DrawImage(c.offscreenImage, opts)
+	stats.AddDrawCalls(1)
 }
 
 // HitTest は、指定された座標がコンテナまたはその子のいずれかにヒットするかをテストします。
@@ -203,7 +312,19 @@ func (c *Container) HitTest(x, y int) component.Widget {
 	if !c.IsVisible() {
 		return nil
 	}
-	// 描画順とは逆に、最前面の子からヒットテストします。
+	if c.transform != nil {
+		return c.hitTestTransformed(x, y)
+	}
+	if target := c.hitTestChildren(x, y); target != nil {
+		return target
+	}
+	// どの子にもヒットしなかった場合、コンテナ自身をテストします。
+	// LayoutableWidget.HitTestは、ヒットした場合にコンテナ自身(c)を返します。
+	return c.LayoutableWidget.HitTest(x, y)
+}
+
+// hitTestChildren は、描画順とは逆に、最前面の子からヒットテストします。
+func (c *Container) hitTestChildren(x, y int) component.Widget {
 	for i := len(c.children) - 1; i >= 0; i-- {
 		child := c.children[i]
 		// 【提案1対応】型アサーションの追加: IsVisibleはInteractiveStateインターフェースが持つため、
@@ -219,22 +340,26 @@ func (c *Container) HitTest(x, y int) component.Widget {
 			return target
 		}
 	}
-	// どの子にもヒットしなかった場合、コンテナ自身をテストします。
-	// LayoutableWidget.HitTestは、ヒットした場合にコンテナ自身(c)を返します。
-	return c.LayoutableWidget.HitTest(x, y)
+	return nil
 }
 
 // Cleanup は、コンテナとすべての子ウィジェットのリソースを解放します。
 func (c *Container) Cleanup() {
+	c.unbindChildren()
+	c.updateHooks = nil
 	for _, child := range c.children {
 		child.Cleanup()
 	}
 	c.children = nil
 
 	if c.offscreenImage != nil {
-		c.offscreenImage.Deallocate()
+		component.ReleaseOffscreen(c.offscreenImage)
 		c.offscreenImage = nil
 	}
+	if c.transformImage != nil {
+		component.ReleaseOffscreen(c.transformImage)
+		c.transformImage = nil
+	}
 
 	c.LayoutableWidget.Cleanup()
 }
@@ -278,6 +403,126 @@ func (c *Container) RemoveChild(child component.Widget) {
 	}
 }
 
+// ClearChildren は、すべての子ウィジェットを切り離し、それぞれのCleanupを呼び出してリソースを解放します。
+// 「パネルの内容を作り直す」処理で、子のスライスを変更しながら反復する必要がなくなります。
+// BindChildrenによるリストとのバインドがある場合は、その購読も解除されます。
+func (c *Container) ClearChildren() {
+	c.unbindChildren()
+	c.clearChildrenWithCleanup()
+}
+
+// clearChildrenWithCleanup は、すべての子ウィジェットを切り離してリソースを解放する内部ヘルパーです。
+func (c *Container) clearChildrenWithCleanup() {
+	for _, child := range c.children {
+		child.SetParent(nil)
+		child.Cleanup()
+	}
+	// 切り離したウィジェットへの参照が基底配列に残らないようにしてから長さを0にします。
+	clear(c.children)
+	c.children = c.children[:0]
+	c.MarkDirty(true)
+}
+
+// InsertChildAt は、指定されたインデックスの位置に子ウィジェットを挿入します。
+// インデックスが範囲外の場合は、先頭または末尾に丸められます。
+// 子が既に別のコンテナ（またはこのコンテナ）に属している場合は、先にそこから切り離されます。
+func (c *Container) InsertChildAt(index int, child component.Widget) {
+	if child == nil {
+		return
+	}
+	if oldParent := child.GetParent(); oldParent != nil {
+		if container, ok := oldParent.(*Container); ok {
+			container.detachChild(child)
+		}
+	}
+	index = utils.Clamp(index, 0, len(c.children))
+	child.SetParent(c)
+	c.children = append(c.children, nil)
+	copy(c.children[index+1:], c.children[index:])
+	c.children[index] = child
+	c.MarkDirty(true)
+}
+
+// MoveChild は、既存の子ウィジェットを指定されたインデックスへ移動します。
+// ウィジェットは破棄・再生成されず、状態を保ったまま並び順だけが変わります。
+// インデックスが範囲外の場合は先頭または末尾に丸められます。
+// childがこのコンテナの子でない場合はfalseを返します。
+func (c *Container) MoveChild(child component.Widget, newIndex int) bool {
+	from := c.IndexOfChild(child)
+	if from < 0 {
+		return false
+	}
+	newIndex = utils.Clamp(newIndex, 0, len(c.children)-1)
+	if from == newIndex {
+		return true
+	}
+	// 移動元と移動先の間の要素を1つずつずらし、空いた位置に子を置きます。
+	if from < newIndex {
+		copy(c.children[from:newIndex], c.children[from+1:newIndex+1])
+	} else {
+		copy(c.children[newIndex+1:from+1], c.children[newIndex:from])
+	}
+	c.children[newIndex] = child
+	c.MarkDirty(true)
+	return true
+}
+
+// ReplaceChild は、子ウィジェットoldChildをnewChildに置き換えます。
+// newChildはoldChildと同じ位置に配置されるため、RemoveChildとAddChildの組み合わせと異なり
+// 並び順が変わりません。oldChildはRemoveChildと同様にCleanupされます。
+// oldChildがこのコンテナの子でない場合はfalseを返します。
+func (c *Container) ReplaceChild(oldChild, newChild component.Widget) bool {
+	if newChild == nil || oldChild == newChild {
+		return false
+	}
+	index := c.IndexOfChild(oldChild)
+	if index < 0 {
+		return false
+	}
+	// newChildが既にこのコンテナの子である場合、その切り離しによってインデックスがずれるため、
+	// 先に切り離してからoldChildの位置を求め直します。
+	if oldParent := newChild.GetParent(); oldParent != nil {
+		if container, ok := oldParent.(*Container); ok {
+			container.detachChild(newChild)
+		}
+	}
+	index = c.IndexOfChild(oldChild)
+
+	oldChild.SetParent(nil)
+	oldChild.Cleanup()
+	newChild.SetParent(c)
+	c.children[index] = newChild
+	c.MarkDirty(true)
+	return true
+}
+
+// SwapChildren は、2つの子ウィジェットの位置を入れ替えます。
+// どちらかがこのコンテナの子でない場合はfalseを返します。
+func (c *Container) SwapChildren(a, b component.Widget) bool {
+	i, j := c.IndexOfChild(a), c.IndexOfChild(b)
+	if i < 0 || j < 0 {
+		return false
+	}
+	if i != j {
+		c.children[i], c.children[j] = c.children[j], c.children[i]
+		c.MarkDirty(true)
+	}
+	return true
+}
+
+// IndexOfChild は、子ウィジェットのインデックスを返します。子でない場合は-1を返します。
+func (c *Container) IndexOfChild(child component.Widget) int {
+	if child == nil {
+		return -1
+	}
+	for i, current := range c.children {
+		if current == child {
+			return i
+		}
+	}
+	return -1
+}
+
 // GetChildren はコンテナが保持するすべての子ウィジェットのスライスを返します。
 func (c *Container) GetChildren() []component.Widget {
 	return c.children
@@ -296,4 +541,23 @@ func (c *Container) GetPadding() layout.Insets {
 		}
 	}
 	return layout.Insets{}
-}
\ No newline at end of file
+}
+
+// DisplayName は、デバッグ表示やビルドエラーのパスに使用する表示名を返します。
+// ui パッケージのビルダー名に合わせ、レイアウトの種類から "VStack" や "Grid" のような名前を決定します。
+func (c *Container) DisplayName() string {
+	switch l := c.layout.(type) {
+	case *layout.FlexLayout:
+		if l.Direction == layout.DirectionRow {
+			return "HStack"
+		}
+		return "VStack"
+	case *layout.GridLayout:
+		return "Grid"
+	case *layout.AdvancedGridLayout:
+		return "AdvancedGrid"
+	case *layout.AbsoluteLayout:
+		return "ZStack"
+	}
+	return "Container"
+}
@@ -2,6 +2,7 @@ package container
 
 import (
 	"errors"
+	"furoshiki/binding"
 	"furoshiki/component"
 	"furoshiki/layout"
 )
@@ -58,6 +59,24 @@ func (b *ContainerBuilder) AddChildren(children ...component.Widget) *ContainerB
 	return b
 }
 
+// BindChildren binds the container's children to an observable list.
+// The container is diff-updated as items are inserted, removed, or moved;
+// factory is called with the list index of each item that needs a new widget.
+// update, if non-nil, refreshes an existing widget in place when its item is
+// replaced or the list is reset, so the widget keeps its state.
+func (b *ContainerBuilder) BindChildren(list binding.Observable, factory func(index int) component.Widget, update func(child component.Widget, index int)) *ContainerBuilder {
+	if list == nil {
+		b.AddError(errors.New("bound list cannot be nil"))
+		return b
+	}
+	if factory == nil {
+		b.AddError(errors.New("child factory cannot be nil"))
+		return b
+	}
+	b.Widget.BindChildren(list, factory, update)
+	return b
+}
+
 // SetLayoutBoundary はコンテナをレイアウト境界として設定します。
 func (b *ContainerBuilder) SetLayoutBoundary(isBoundary bool) *ContainerBuilder {
 	b.Widget.SetLayoutBoundary(isBoundary)
@@ -70,6 +89,14 @@ func (b *ContainerBuilder) SetClipsChildren(clips bool) *ContainerBuilder {
 	return b
 }
 
+// SetOverflow は、子要素がはみ出した場合の扱いを設定します。
+// NOTE: このビルダーはOverflowScrollでもScrollViewへの包み込みを行わず、クリップのみを行います。
+//       スクロールさせる場合は ui パッケージのビルダーの Overflow を使用してください。
+func (b *ContainerBuilder) SetOverflow(o Overflow) *ContainerBuilder {
+	b.Widget.SetOverflow(o)
+	return b
+}
+
 // Build はコンテナの構築を完了します。
 func (b *ContainerBuilder) Build() (*Container, error) {
 	return b.Builder.Build()
@@ -0,0 +1,107 @@
+package container
+
+import (
+	"furoshiki/component"
+	"image"
+)
+
+// Overflow は、子要素がコンテナのコンテンツ領域からはみ出した場合の扱いを定義します。
+type Overflow int
+
+const (
+	// OverflowVisible は、はみ出した子要素をそのまま描画します（デフォルト）。
+	OverflowVisible Overflow = iota
+	// OverflowHidden は、はみ出した部分をコンテナの境界でクリップします。
+	OverflowHidden
+	// OverflowScroll は、コンテンツをScrollViewで包み、スクロールして表示できるようにします。
+	// ScrollViewへの包み込みはui パッケージのビルダーがBuild時に行います。
+	// コンテナ単体ではOverflowHiddenと同様にクリップします。
+	OverflowScroll
+	// OverflowEllipsis は、はみ出した部分をクリップし、内容が省略されていることを示す印を右下に描画します。
+	OverflowEllipsis
+)
+
+// ellipsisDotSize, ellipsisDotGap は、省略記号として描画する点の大きさと間隔です。
+const (
+	ellipsisDotSize = 2
+	ellipsisDotGap  = 2
+)
+
+// SetOverflow は、子要素がはみ出した場合の扱いを設定します。
+// OverflowVisible以外のモードでは、子要素はコンテナの境界でクリップされます。
+//
+// コンテナの最小サイズは子要素から計算されないため、どのモードでも
+// はみ出した子要素が親コンテナを押し広げることはありません。
+func (c *Container) SetOverflow(o Overflow) {
+	if c.overflow == o {
+		return
+	}
+	c.overflow = o
+	c.SetClipsChildren(o != OverflowVisible)
+	c.MarkDirty(false)
+}
+
+// GetOverflow は、現在のはみ出しの扱いを返します。
+func (c *Container) GetOverflow() Overflow {
+	return c.overflow
+}
+
+// ContentContainer は、子要素が実際に置かれているコンテナを返します。
+// uiパッケージのビルダーがOverflowScrollのコンテナをScrollViewで包んだ場合は、スクロールされる内部のコンテナを、
+// それ以外の場合はコンテナ自身を返します。構築後に子要素を追加・同期する処理はこのコンテナに対して行います。
+func (c *Container) ContentContainer() *Container {
+	if c.overflow != OverflowScroll || len(c.children) != 1 {
+		return c
+	}
+	sv, ok := c.children[0].(interface{ GetContentContainer() component.Widget })
+	if !ok {
+		return c
+	}
+	if content, ok := sv.GetContentContainer().(*Container); ok && content != nil {
+		return content
+	}
+	return c
+}
+
+// hasOverflowingChild は、表示中の子要素のいずれかがコンテンツ領域からはみ出しているかを返します。
+func (c *Container) hasOverflowingChild() bool {
+	x, y := c.GetPosition()
+	width, height := c.GetSize()
+	padding := c.GetPadding()
+	content := image.Rect(x+padding.Left, y+padding.Top, x+width-padding.Right, y+height-padding.Bottom)
+
+	for _, child := range c.children {
+		if is, ok := child.(component.InteractiveState); ok && !is.IsVisible() {
+			continue
+		}
+		var cx, cy, cw, ch int
+		if ps, ok := child.(component.PositionSetter); ok {
+			cx, cy = ps.GetPosition()
+		}
+		if ss, ok := child.(component.SizeSetter); ok {
+			cw, ch = ss.GetSize()
+		}
+		if cx+cw > content.Max.X || cy+ch > content.Max.Y || cx < content.Min.X || cy < content.Min.Y {
+			return true
+		}
+	}
+	return false
+}
+
+// drawEllipsisMarker は、コンテンツ領域の右下に省略を示す3つの点を描画します。
+func (c *Container) drawEllipsisMarker(info component.DrawInfo) {
+	x, y := c.GetPosition()
+	width, height := c.GetSize()
+	padding := c.GetPadding()
+	clr := c.ComputedStyle().TextColor
+
+	markerWidth := 3*ellipsisDotSize + 2*ellipsisDotGap
+	right := x + info.OffsetX + width - max(padding.Right, ellipsisDotSize)
+	bottom := y + info.OffsetY + height - max(padding.Bottom, ellipsisDotSize)
+	startX := right - markerWidth
+	dotY := bottom - ellipsisDotSize
+	for i := 0; i < 3; i++ {
+		dotX := startX + i*(ellipsisDotSize+ellipsisDotGap)
+		component.DrawFilledRect(info.Screen, float32(dotX), float32(dotY), ellipsisDotSize, ellipsisDotSize, clr)
+	}
+}
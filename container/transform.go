@@ -0,0 +1,181 @@
+package container
+
+import (
+	"furoshiki/component"
+	"furoshiki/stats"
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// このファイルは、コンテナの子孫全体に座標変換(平行移動と拡大縮小)を適用する仕組みを提供します。
+// ゲームのカメラに合わせて、建物の名前や体力バーなどのUIのレイヤー全体を動かす場合に使用します。
+//
+//	labels.TrackTransform(func() container.Transform {
+//		return container.Transform{X: camera.X, Y: camera.Y, Zoom: camera.Zoom}
+//	})
+//
+// 子孫のウィジェットは、レイアウトによって通常どおり配置されます(この座標系を「ローカル座標」と呼びます)。
+// 描画時には、ローカル座標の点(X, Y)がコンテナの左上に来るように平行移動し、Zoom倍に拡大して描画します。
+// はみ出した部分は、コンテナの境界でクリップされます。
+// ヒットテストとイベントの座標(event.Event.X, Y)は、逆変換によってローカル座標に変換されます。
+//
+// NOTE: ebiten.CursorPositionを直接参照するウィジェット(Listのドラッグ、スクロールバーのドラッグなど)の
+//       ドラッグ量は画面座標のままのため、拡大縮小している間は実際の移動量とずれます。
+
+// Transform は、コンテナの子孫に適用する座標変換です。
+type Transform struct {
+	// X, Y は、コンテナの左上に表示するローカル座標の点の、コンテナの位置からのずれです。カメラの位置に相当します。
+	X, Y float64
+	// Zoom は、拡大率です。0以下の場合は1として扱います。
+	Zoom float64
+}
+
+// zoom は、有効な拡大率を返します。
+func (t Transform) zoom() float64 {
+	if t.Zoom <= 0 {
+		return 1
+	}
+	return t.Zoom
+}
+
+// SetTransform は、子孫に適用する座標変換を設定します。TrackTransformで設定した関数は解除されます。
+func (c *Container) SetTransform(t Transform) {
+	c.trackTransform = nil
+	c.setTransform(t)
+}
+
+// setTransform は、座標変換を更新し、変化があれば再描画を要求します。
+func (c *Container) setTransform(t Transform) {
+	t.Zoom = t.zoom()
+	if c.transform != nil && *c.transform == t {
+		return
+	}
+	c.transform = &t
+	c.MarkDirty(false)
+}
+
+// Transform は、子孫に適用している座標変換を返します。設定されていない場合、okはfalseです。
+func (c *Container) Transform() (t Transform, ok bool) {
+	if c.transform == nil {
+		return Transform{Zoom: 1}, false
+	}
+	return *c.transform, true
+}
+
+// ClearTransform は、座標変換を解除します。TrackTransformで設定した関数も解除されます。
+func (c *Container) ClearTransform() {
+	c.trackTransform = nil
+	if c.transform == nil {
+		return
+	}
+	c.transform = nil
+	if c.transformImage != nil {
+		component.ReleaseOffscreen(c.transformImage)
+		c.transformImage = nil
+	}
+	c.MarkDirty(false)
+}
+
+// TrackTransform は、座標変換を毎フレームのUpdateで返す関数を設定します。ゲームのカメラへの追従に使用します。
+func (c *Container) TrackTransform(fn func() Transform) {
+	c.trackTransform = fn
+	if fn != nil {
+		c.setTransform(fn())
+	}
+}
+
+// ToLocal は、親の座標系の点を、子孫のローカル座標に変換します。座標変換がない場合はそのまま返します。
+// component.PointTransformerインターフェースの実装です。
+func (c *Container) ToLocal(x, y int) (int, int) {
+	if c.transform == nil {
+		return x, y
+	}
+	cx, cy := c.GetPosition()
+	t := *c.transform
+	lx := float64(cx) + float64(x-cx)/t.Zoom + t.X
+	ly := float64(cy) + float64(y-cy)/t.Zoom + t.Y
+	return int(math.Floor(lx)), int(math.Floor(ly))
+}
+
+// ToScreen は、子孫のローカル座標の点を、親の座標系の点に変換します。ToLocalの逆変換です。
+// ローカル座標で配置したウィジェットの画面上の位置を求める場合に使用します。
+func (c *Container) ToScreen(x, y int) (int, int) {
+	if c.transform == nil {
+		return x, y
+	}
+	cx, cy := c.GetPosition()
+	t := *c.transform
+	sx := float64(cx) + (float64(x-cx)-t.X)*t.Zoom
+	sy := float64(cy) + (float64(y-cy)-t.Y)*t.Zoom
+	return int(math.Round(sx)), int(math.Round(sy))
+}
+
+// updateTransform は、TrackTransformで設定された関数から座標変換を更新します。
+func (c *Container) updateTransform() {
+	if c.trackTransform != nil {
+		c.setTransform(c.trackTransform())
+	}
+}
+
+// drawWithTransform は、子孫をオフスクリーン画像にローカル座標で描画し、座標変換を適用して画面に描画します。
+func (c *Container) drawWithTransform(info component.DrawInfo) {
+	x, y := c.GetPosition()
+	width, height := c.GetSize()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	finalX, finalY := x+info.OffsetX, y+info.OffsetY
+	// コンテナ自身の背景は、座標変換を適用せずに描画します。
+	component.DrawComputedBackground(info.Screen, finalX, finalY, width, height, c.ComputedStyle())
+
+	t := *c.transform
+	// 拡大率が1未満の場合は、コンテナより広い範囲のローカル座標が表示されます。
+	// 端数の平行移動を描画時に行うため、1ピクセル余分に確保します。
+	imageW := int(math.Ceil(float64(width)/t.Zoom)) + 1
+	imageH := int(math.Ceil(float64(height)/t.Zoom)) + 1
+	c.transformImage = component.EnsureOffscreen(c.transformImage, imageW, imageH)
+	// オフスクリーン画像への描画を始める前に、これまでの描画命令を確定させます。
+	component.FlushDraws()
+	c.transformImage.Clear()
+
+	// 平行移動の整数部分は描画オフセットで、端数はGeoMで適用します。
+	originX, originY := math.Floor(t.X), math.Floor(t.Y)
+	childDrawInfo := component.DrawInfo{
+		Screen:  c.transformImage,
+		OffsetX: -(x + int(originX)),
+		OffsetY: -(y + int(originY)),
+	}
+	for _, child := range c.children {
+		component.DrawWidget(child, childDrawInfo)
+	}
+
+	opts := &ebiten.DrawImageOptions{}
+	opts.GeoM.Translate(originX-t.X, originY-t.Y)
+	opts.GeoM.Scale(t.Zoom, t.Zoom)
+	opts.GeoM.Translate(float64(finalX), float64(finalY))
+	if t.Zoom != 1 {
+		opts.Filter = ebiten.FilterLinear
+	}
+	component.FlushDraws()
+	// コンテナの境界の外には描画しません。
+	dst := info.Screen.SubImage(image.Rect(finalX, finalY, finalX+width, finalY+height)).(*ebiten.Image)
+	dst.DrawImage(c.transformImage, opts)
+	stats.AddDrawCalls(1)
+}
+
+// hitTestTransformed は、座標変換を持つコンテナのヒットテストを行います。
+// コンテナの境界内の点だけを、ローカル座標に変換してから子孫に対してテストします。
+func (c *Container) hitTestTransformed(x, y int) component.Widget {
+	cx, cy := c.GetPosition()
+	w, h := c.GetSize()
+	if !image.Pt(x, y).In(image.Rect(cx, cy, cx+w, cy+h)) {
+		return nil
+	}
+	lx, ly := c.ToLocal(x, y)
+	if target := c.hitTestChildren(lx, ly); target != nil {
+		return target
+	}
+	return c.LayoutableWidget.HitTest(x, y)
+}
@@ -0,0 +1,172 @@
+package devtools
+
+import (
+	"fmt"
+	"furoshiki/component"
+	"furoshiki/style"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// relayoutFlashFrames は、再レイアウトを実行したウィジェットをハイライトし続けるフレーム数です。
+const relayoutFlashFrames = 20
+
+var (
+	boundsOutlineColor   = color.RGBA{R: 0, G: 200, B: 255, A: 160}
+	paddingOutlineColor  = color.RGBA{R: 120, G: 220, B: 100, A: 140}
+	boundaryOutlineColor = color.RGBA{R: 255, G: 0, B: 255, A: 220}
+	relayoutFlashColor   = color.RGBA{R: 255, G: 60, B: 40, A: 255}
+)
+
+// DebugOverlay は、UIツリー全体のレイアウト状況を可視化するデバッグ用オーバーレイです。
+// 有効な間は、すべてのウィジェットの境界とパディングボックスの輪郭、レイアウト境界を描画し、
+// 直近のフレームでレイアウト計算を実行したウィジェットを赤く点滅させます。
+// 想定外の再レイアウトの連鎖（relayout storm）を診断するのに役立ちます。
+//
+// 操作方法:
+//   - ToggleKey (デフォルト: F11) でオーバーレイの有効・無効を切り替えます。
+//
+// 再レイアウトの検出には component.SetRelayoutObserver を使用するため、
+// 同時に有効にできるDebugOverlayは1つだけです。
+type DebugOverlay struct {
+	// ToggleKey は、オーバーレイの有効・無効を切り替えるキーです。
+	ToggleKey ebiten.Key
+	// ShowBounds は、ウィジェットの境界の輪郭を描画するかどうかです。
+	ShowBounds bool
+	// ShowPadding は、ボーダーとパディングを除いたコンテンツ領域の輪郭を描画するかどうかです。
+	ShowPadding bool
+	// ShowLayoutBoundaries は、レイアウト境界に設定されたウィジェットを強調表示するかどうかです。
+	ShowLayoutBoundaries bool
+	// ShowRelayouts は、レイアウト計算を実行したウィジェットを点滅させるかどうかです。
+	ShowRelayouts bool
+
+	root    component.Widget
+	enabled bool
+	// flashes は、再レイアウトを実行したウィジェットと、残りのハイライトフレーム数の対応です。
+	flashes map[component.Widget]int
+	// relayoutsThisFrame は、直前のUpdateから現在までに発生したレイアウト計算の回数です。
+	relayoutsThisFrame int
+	relayoutsLastFrame int
+}
+
+// NewDebugOverlay は、指定されたルートウィジェット以下を可視化するDebugOverlayを生成します。
+func NewDebugOverlay(root component.Widget) *DebugOverlay {
+	return &DebugOverlay{
+		ToggleKey:            ebiten.KeyF11,
+		ShowBounds:           true,
+		ShowPadding:          true,
+		ShowLayoutBoundaries: true,
+		ShowRelayouts:        true,
+		root:                 root,
+		flashes:              make(map[component.Widget]int),
+	}
+}
+
+// SetRoot は、可視化の対象となるルートウィジェットを変更します。
+func (o *DebugOverlay) SetRoot(root component.Widget) {
+	o.root = root
+	clear(o.flashes)
+}
+
+// Enabled は、オーバーレイが有効かどうかを返します。
+func (o *DebugOverlay) Enabled() bool {
+	return o.enabled
+}
+
+// SetEnabled は、オーバーレイの有効・無効を設定します。
+// 有効な間だけ再レイアウトのオブザーバーを登録するため、無効時のオーバーヘッドはありません。
+func (o *DebugOverlay) SetEnabled(enabled bool) {
+	if o.enabled == enabled {
+		return
+	}
+	o.enabled = enabled
+	if enabled {
+		component.SetRelayoutObserver(o.recordRelayout)
+	} else {
+		component.SetRelayoutObserver(nil)
+		clear(o.flashes)
+	}
+}
+
+// recordRelayout は、レイアウト計算を実行したウィジェットをハイライト対象として記録します。
+func (o *DebugOverlay) recordRelayout(w component.Widget) {
+	o.flashes[w] = relayoutFlashFrames
+	o.relayoutsThisFrame++
+}
+
+// Update は、トグルキーの入力を処理し、ハイライトの残りフレーム数を進めます。
+// UIツリーのUpdateより前に、毎フレーム1回呼び出してください。
+func (o *DebugOverlay) Update() {
+	if inpututil.IsKeyJustPressed(o.ToggleKey) {
+		o.SetEnabled(!o.enabled)
+	}
+	if !o.enabled {
+		return
+	}
+
+	o.relayoutsLastFrame = o.relayoutsThisFrame
+	o.relayoutsThisFrame = 0
+	for w, remaining := range o.flashes {
+		if remaining <= 1 {
+			delete(o.flashes, w)
+		} else {
+			o.flashes[w] = remaining - 1
+		}
+	}
+}
+
+// Draw は、オーバーレイを描画します。UIツリーの描画後に呼び出してください。
+func (o *DebugOverlay) Draw(screen *ebiten.Image) {
+	if !o.enabled || o.root == nil {
+		return
+	}
+	o.drawNode(screen, o.root)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("relayouts: %d", o.relayoutsLastFrame), panelMargin, screen.Bounds().Dy()-debugLineHeight-panelMargin)
+}
+
+// drawNode は、ウィジェットとその子孫の輪郭とハイライトを再帰的に描画します。
+func (o *DebugOverlay) drawNode(screen *ebiten.Image, w component.Widget) {
+	if is, ok := w.(component.InteractiveState); ok && !is.IsVisible() {
+		return
+	}
+
+	bounds := widgetBounds(w)
+	if o.ShowRelayouts {
+		if remaining, ok := o.flashes[w]; ok {
+			// 残りフレーム数に応じてフェードアウトさせます。
+			flash := relayoutFlashColor
+			flash.A = uint8(int(relayoutFlashColor.A) * remaining / relayoutFlashFrames)
+			fillAlpha := flash.A / 3
+			fillRect(screen, bounds, color.RGBA{R: fillAlpha, A: fillAlpha})
+			strokeRect(screen, bounds, 2, flash)
+		}
+	}
+	if o.ShowBounds {
+		strokeRect(screen, bounds, 1, boundsOutlineColor)
+	}
+	if o.ShowPadding {
+		s := widgetStyle(w)
+		content := shrink(bounds, insetsOf(s.Padding))
+		if s.BorderWidth != nil {
+			bw := int(*s.BorderWidth)
+			content = shrink(content, style.Insets{Top: bw, Right: bw, Bottom: bw, Left: bw})
+		}
+		if content != bounds {
+			strokeRect(screen, content, 1, paddingOutlineColor)
+		}
+	}
+	if o.ShowLayoutBoundaries {
+		if lp, ok := w.(component.LayoutProperties); ok && lp.IsLayoutBoundary() {
+			strokeRect(screen, bounds, 2, boundaryOutlineColor)
+		}
+	}
+
+	if c, ok := w.(component.Container); ok {
+		for _, child := range c.GetChildren() {
+			o.drawNode(screen, child)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package devtools
+
+import (
+	"fmt"
+	"furoshiki/component"
+	"furoshiki/style"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// このファイルは、デバッグツール群が共通で使用するウィジェット情報の取得ヘルパーを提供します。
+
+// widgetBounds は、ウィジェットの絶対座標での境界矩形を返します。
+func widgetBounds(w component.Widget) image.Rectangle {
+	var x, y, width, height int
+	if ps, ok := w.(component.PositionSetter); ok {
+		x, y = ps.GetPosition()
+	}
+	if ss, ok := w.(component.SizeSetter); ok {
+		width, height = ss.GetSize()
+	}
+	return image.Rect(x, y, x+width, y+height)
+}
+
+// widgetStyle は、ウィジェットの基本スタイルをコピーせずに返します。
+func widgetStyle(w component.Widget) style.Style {
+	if sg, ok := w.(component.StyleGetterSetter); ok {
+		return sg.ReadOnlyStyle()
+	}
+	return style.Style{}
+}
+
+// insetsOf は、nilの可能性があるInsetsポインタを値に変換します。
+func insetsOf(i *style.Insets) style.Insets {
+	if i == nil {
+		return style.Insets{}
+	}
+	return *i
+}
+
+// shrink は、矩形を指定されたInsetsの分だけ内側に縮めます。
+func shrink(r image.Rectangle, i style.Insets) image.Rectangle {
+	return image.Rect(r.Min.X+i.Left, r.Min.Y+i.Top, r.Max.X-i.Right, r.Max.Y-i.Bottom)
+}
+
+// grow は、矩形を指定されたInsetsの分だけ外側に広げます。
+func grow(r image.Rectangle, i style.Insets) image.Rectangle {
+	return image.Rect(r.Min.X-i.Left, r.Min.Y-i.Top, r.Max.X+i.Right, r.Max.Y+i.Bottom)
+}
+
+// typeName は、ウィジェットの表示名を返します（例: "Button", "VStack"）。
+func typeName(w component.Widget) string {
+	if w == nil {
+		return "<nil>"
+	}
+	return component.WidgetName(w)
+}
+
+// nodeLabel は、ツリーパスの一要素として表示するウィジェットのラベルを返します。
+// IDが設定されている場合は "Button#save"、そうでない場合は親の中でのインデックスを使って
+// "Button[2]" のように表します。
+func nodeLabel(w component.Widget) string {
+	name := typeName(w)
+	if ident, ok := w.(component.Identifiable); ok && ident.GetID() != "" {
+		return name + "#" + ident.GetID()
+	}
+	if parent := w.GetParent(); parent != nil {
+		for i, sibling := range parent.GetChildren() {
+			if sibling == w {
+				return fmt.Sprintf("%s[%d]", name, i)
+			}
+		}
+	}
+	return name
+}
+
+// ancestry は、ルートから指定されたウィジェットまでの経路を返します。
+func ancestry(w component.Widget) []component.Widget {
+	var path []component.Widget
+	for current := w; current != nil; {
+		path = append(path, current)
+		parent := current.GetParent()
+		if parent == nil {
+			break
+		}
+		current = parent
+	}
+	// ルートが先頭になるように反転します。
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// treePath は、ウィジェットのツリーパスを "Container > Container[1] > Button[2]" の形式で返します。
+func treePath(w component.Widget) string {
+	path := ancestry(w)
+	labels := make([]string, len(path))
+	for i, node := range path {
+		labels[i] = nodeLabel(node)
+	}
+	return strings.Join(labels, " > ")
+}
+
+// dirtyState は、ウィジェットのダーティ状態を文字列で返します。
+func dirtyState(w component.Widget) string {
+	switch {
+	case w.NeedsRelayout():
+		return "relayout"
+	case w.IsDirty():
+		return "redraw"
+	default:
+		return "clean"
+	}
+}
+
+// colorString は、色を "#rrggbbaa" 形式の文字列に変換します。
+func colorString(c *color.Color) string {
+	if c == nil || *c == nil {
+		return "-"
+	}
+	nrgba := color.NRGBAModel.Convert(*c).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x%02x", nrgba.R, nrgba.G, nrgba.B, nrgba.A)
+}
+
+// insetsString は、Insetsを "上 右 下 左" の順の文字列に変換します。
+func insetsString(i style.Insets) string {
+	return fmt.Sprintf("%d %d %d %d", i.Top, i.Right, i.Bottom, i.Left)
+}
+
+// describe は、ウィジェットの詳細情報を表示用の行のスライスとして返します。
+func describe(w component.Widget) []string {
+	bounds := widgetBounds(w)
+	s := widgetStyle(w)
+
+	lines := []string{
+		"type:    " + typeName(w),
+		fmt.Sprintf("bounds:  (%d,%d) %dx%d", bounds.Min.X, bounds.Min.Y, bounds.Dx(), bounds.Dy()),
+	}
+	if ident, ok := w.(component.Identifiable); ok {
+		if id := ident.GetID(); id != "" {
+			lines = append(lines, "id:      "+id)
+		}
+		if tags := ident.GetTags(); len(tags) > 0 {
+			lines = append(lines, "tags:    "+strings.Join(tags, ", "))
+		}
+	}
+	if mss, ok := w.(component.MinSizeSetter); ok {
+		minW, minH := mss.GetMinSize()
+		lines = append(lines, fmt.Sprintf("minSize: %dx%d", minW, minH))
+	}
+	if lp, ok := w.(component.LayoutProperties); ok {
+		lines = append(lines, fmt.Sprintf("flex:    %d boundary=%t", lp.GetFlex(), lp.IsLayoutBoundary()))
+	}
+	lines = append(lines,
+		"padding: "+insetsString(insetsOf(s.Padding)),
+		"margin:  "+insetsString(insetsOf(s.Margin)),
+		"bg:      "+colorString(s.Background),
+		"border:  "+colorString(s.BorderColor),
+		"dirty:   "+dirtyState(w),
+	)
+	if is, ok := w.(component.InteractiveState); ok {
+		lines = append(lines, fmt.Sprintf("state:   %s visible=%t", is.CurrentState(), is.IsVisible()))
+	}
+	if c, ok := w.(component.Container); ok {
+		lines = append(lines, fmt.Sprintf("children: %d", len(c.GetChildren())))
+	}
+	return lines
+}
@@ -0,0 +1,71 @@
+package devtools
+
+import (
+	"fmt"
+	"furoshiki/component"
+	"furoshiki/event"
+	"io"
+	"strings"
+)
+
+// TraceEvents は、dがディスパッチしたイベントを、1イベント1行でwに書き出すトレースを開始します。
+// 各行には、イベントの種類と位置、ヒットしたウィジェットのツリーパス、実行されたハンドラと結果、イベントを消費したウィジェットが含まれます。
+// typesを指定した場合は、その種類のイベントだけを書き出します。トレースを終了するには d.SetTracer(nil) を呼び出します。
+//
+//	devtools.TraceEvents(event.GetDispatcher(), os.Stderr, event.EventClick, event.MouseDown, event.MouseUp)
+//
+// 出力の例:
+//
+//	Click (412,37) hit: Container > Container[0] > Button[9] ran: Button[9]#0=propagate Container[0]#0=stop handled by: Container[0]
+//
+// ハンドラは"ウィジェット#登録順"で表し、パニックしたハンドラには"!"が付きます。書き込みのエラーは無視されます。
+func TraceEvents(d *event.Dispatcher, w io.Writer, types ...event.EventType) {
+	d.SetTracer(func(t *event.Trace) {
+		fmt.Fprintln(w, formatTrace(t))
+	}, types...)
+}
+
+// formatTrace は、イベントの記録を1行の文字列にします。
+func formatTrace(t *event.Trace) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%d,%d) hit: %s", t.Event.Type, t.Event.X, t.Event.Y, targetPath(t.Event.Target))
+	b.WriteString(" ran:")
+	if len(t.Handlers) == 0 {
+		b.WriteString(" -")
+	}
+	for _, h := range t.Handlers {
+		result := "propagate"
+		if h.Result == event.StopPropagation {
+			result = "stop"
+		}
+		if h.Panicked {
+			result = "!panic"
+		}
+		fmt.Fprintf(&b, " %s#%d=%s", targetLabel(h.Target), h.Index, result)
+	}
+	if by := t.HandledBy(); by != nil {
+		b.WriteString(" handled by: " + targetLabel(by))
+	} else {
+		b.WriteString(" unhandled")
+	}
+	return b.String()
+}
+
+// targetPath は、イベントの対象がウィジェットであればツリーパスを、そうでなければ型名を返します。
+func targetPath(t event.EventTarget) string {
+	if w, ok := t.(component.Widget); ok {
+		return treePath(w)
+	}
+	return targetLabel(t)
+}
+
+// targetLabel は、イベントの対象を表示するための短いラベルを返します。
+func targetLabel(t event.EventTarget) string {
+	if w, ok := t.(component.Widget); ok {
+		return nodeLabel(w)
+	}
+	if t == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%T", t)
+}
@@ -0,0 +1,437 @@
+package devtools
+
+import (
+	"fmt"
+	"furoshiki/component"
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	// debugCharWidth, debugLineHeight は、ebitenutil.DebugPrintAt が使用する組み込みフォントの寸法です。
+	debugCharWidth  = 6
+	debugLineHeight = 16
+	panelPadding    = 6
+	panelMargin     = 8
+)
+
+var (
+	marginBoxColor  = color.RGBA{R: 246, G: 178, B: 107, A: 110}
+	borderBoxColor  = color.RGBA{R: 111, G: 168, B: 220, A: 110}
+	paddingBoxColor = color.RGBA{R: 147, G: 196, B: 125, A: 110}
+	selectionColor  = color.RGBA{R: 255, G: 64, B: 129, A: 255}
+	panelColor      = color.RGBA{R: 20, G: 20, B: 28, A: 225}
+	pathHoverColor  = color.RGBA{R: 90, G: 90, B: 120, A: 255}
+)
+
+// Inspector は、ブラウザの開発者ツールの要素ピッカーのように、実行中のUIツリーを調べるための
+// デバッグ用オーバーレイです。有効な間は、カーソル下のウィジェットをハイライトし、
+// その境界・パディング・マージン、型、スタイル、ダーティ状態、ツリーパスを表示します。
+//
+// 操作方法:
+//   - ToggleKey (デフォルト: F12) でインスペクタの有効・無効を切り替えます。
+//   - 左クリックでカーソル下のウィジェットを選択（固定）します。
+//   - パネル内のツリーパスの要素をクリックすると、その祖先ウィジェットを選択します。
+//   - ↑ で親、↓ で最初の子、←/→ で兄弟ウィジェットへ選択を移動します。Esc で選択を解除します。
+//   - 選択中は、パネルのプロパティ(大きさ、パディング、色、テキストなど)の行をクリックすると値を編集できます。
+//     Enter で適用し、Esc で取り消します。数値のプロパティは、行の上でマウスホイールを回して増減できます。
+//     変更はすぐにウィジェットに適用され、再レイアウトの結果を確認できます。
+//
+// 使用例:
+//
+//	func (g *Game) Update() error {
+//		if !g.inspector.Update() {
+//			// インスペクタが入力を消費しなかった場合のみ、UIにイベントを配送します。
+//			dispatcher.Dispatch(target, cx, cy)
+//		}
+//		...
+//	}
+//	func (g *Game) Draw(screen *ebiten.Image) {
+//		g.root.Draw(...)
+//		g.inspector.Draw(screen)
+//	}
+type Inspector struct {
+	// ToggleKey は、インスペクタの有効・無効を切り替えるキーです。
+	ToggleKey ebiten.Key
+
+	root     component.Widget
+	enabled  bool
+	hovered  component.Widget
+	selected component.Widget
+
+	// 以下は直前のDrawで計算されたパネルのレイアウト情報で、Update時のクリック判定に使用します。
+	panelRect image.Rectangle
+	pathRects []image.Rectangle
+	pathNodes []component.Widget
+	editRects []image.Rectangle
+	editProps []*editorProperty
+
+	// 以下はプロパティエディタの状態です。編集は選択中のウィジェットに対してのみ行えます。
+	editing    *editorProperty
+	editBuffer []rune
+	editError  string
+}
+
+// NewInspector は、指定されたルートウィジェット以下を調べるInspectorを生成します。
+func NewInspector(root component.Widget) *Inspector {
+	return &Inspector{
+		ToggleKey: ebiten.KeyF12,
+		root:      root,
+	}
+}
+
+// SetRoot は、調査対象のルートウィジェットを変更し、現在の選択を解除します。
+func (in *Inspector) SetRoot(root component.Widget) {
+	in.root = root
+	in.hovered = nil
+	in.selected = nil
+	in.cancelEdit()
+}
+
+// Enabled は、インスペクタが有効かどうかを返します。
+func (in *Inspector) Enabled() bool {
+	return in.enabled
+}
+
+// SetEnabled は、インスペクタの有効・無効を設定します。
+func (in *Inspector) SetEnabled(enabled bool) {
+	in.enabled = enabled
+	if !enabled {
+		in.hovered = nil
+		in.selected = nil
+		in.cancelEdit()
+	}
+}
+
+// Selected は、現在選択（固定）されているウィジェットを返します。
+func (in *Inspector) Selected() component.Widget {
+	return in.selected
+}
+
+// Update は、インスペクタの入力を処理します。
+// インスペクタが有効でマウス入力を消費した場合はtrueを返します。
+// その場合、アプリケーションはUIへのイベント配送を省略するべきです。
+func (in *Inspector) Update() bool {
+	if inpututil.IsKeyJustPressed(in.ToggleKey) {
+		in.SetEnabled(!in.enabled)
+	}
+	if !in.enabled || in.root == nil {
+		return false
+	}
+
+	cx, cy := ebiten.CursorPosition()
+	cursor := image.Pt(cx, cy)
+
+	// 選択中のウィジェットがツリーから取り除かれていた場合は、選択を解除します。
+	if in.selected != nil && !in.isAttached(in.selected) {
+		in.selected = nil
+	}
+
+	previous := in.selected
+	if cursor.In(in.panelRect) {
+		// パネル上ではウィジェットのホバーを更新せず、ツリーパスとプロパティの行の操作のみを受け付けます。
+		in.handlePanelInput(cursor)
+	} else {
+		in.hovered = in.root.HitTest(cx, cy)
+		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+			in.selected = in.hovered
+		}
+	}
+
+	if in.editing != nil {
+		in.handleEditKeys()
+	} else {
+		in.handleNavigationKeys()
+	}
+	if in.selected != previous {
+		in.cancelEdit()
+	}
+	return true
+}
+
+// handlePanelInput は、パネル上でのクリックとマウスホイールを処理します。
+func (in *Inspector) handlePanelInput(cursor image.Point) {
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		for i, r := range in.pathRects {
+			if cursor.In(r) {
+				in.selected = in.pathNodes[i]
+				return
+			}
+		}
+		for i, r := range in.editRects {
+			if cursor.In(r) {
+				in.beginEdit(in.editProps[i])
+				return
+			}
+		}
+	}
+	_, dy := ebiten.Wheel()
+	if dy == 0 || in.selected == nil {
+		return
+	}
+	for i, r := range in.editRects {
+		if prop := in.editProps[i]; cursor.In(r) && prop.step != nil && prop != in.editing {
+			delta := 1
+			if dy < 0 {
+				delta = -1
+			}
+			prop.step(in.selected, delta)
+			in.editError = ""
+			return
+		}
+	}
+}
+
+// beginEdit は、選択中のウィジェットのプロパティの編集を開始します。入力欄は現在の値で初期化されます。
+func (in *Inspector) beginEdit(prop *editorProperty) {
+	if in.selected == nil {
+		return
+	}
+	value, ok := prop.get(in.selected)
+	if !ok {
+		return
+	}
+	in.editing = prop
+	in.editBuffer = append(in.editBuffer[:0], []rune(value)...)
+	in.editError = ""
+	// 入力した文字がフォーカス中のテキスト入力にも届かないよう、UIのフォーカスを外します。
+	component.ClearFocus()
+}
+
+// cancelEdit は、進行中の編集を取り消します。
+func (in *Inspector) cancelEdit() {
+	in.editing = nil
+	in.editBuffer = in.editBuffer[:0]
+	in.editError = ""
+}
+
+// handleEditKeys は、編集中の入力欄へのキー入力を処理します。
+func (in *Inspector) handleEditKeys() {
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		in.cancelEdit()
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter):
+		if err := in.editing.set(in.selected, strings.TrimSpace(string(in.editBuffer))); err != nil {
+			in.editError = err.Error()
+			return
+		}
+		in.cancelEdit()
+	case inpututil.IsKeyJustPressed(ebiten.KeyBackspace):
+		if len(in.editBuffer) > 0 {
+			in.editBuffer = in.editBuffer[:len(in.editBuffer)-1]
+		}
+	default:
+		in.editBuffer = ebiten.AppendInputChars(in.editBuffer)
+	}
+}
+
+// handleNavigationKeys は、キーボードによる階層移動を処理します。
+func (in *Inspector) handleNavigationKeys() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		in.selected = nil
+		return
+	}
+	current := in.selected
+	if current == nil {
+		return
+	}
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowUp):
+		if parent := current.GetParent(); parent != nil {
+			in.selected = parent
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowDown):
+		if c, ok := current.(component.Container); ok && len(c.GetChildren()) > 0 {
+			in.selected = c.GetChildren()[0]
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft):
+		in.selected = in.sibling(current, -1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowRight):
+		in.selected = in.sibling(current, 1)
+	}
+}
+
+// sibling は、指定されたウィジェットからdelta個離れた兄弟ウィジェットを返します。
+// 範囲外の場合は元のウィジェットを返します。
+func (in *Inspector) sibling(w component.Widget, delta int) component.Widget {
+	parent := w.GetParent()
+	if parent == nil {
+		return w
+	}
+	siblings := parent.GetChildren()
+	for i, s := range siblings {
+		if s == w {
+			if j := i + delta; j >= 0 && j < len(siblings) {
+				return siblings[j]
+			}
+			break
+		}
+	}
+	return w
+}
+
+// isAttached は、ウィジェットが現在のルートに接続されているかを返します。
+func (in *Inspector) isAttached(w component.Widget) bool {
+	path := ancestry(w)
+	return len(path) > 0 && path[0] == in.root
+}
+
+// target は、情報を表示する対象のウィジェットを返します。選択中のウィジェットが優先されます。
+func (in *Inspector) target() component.Widget {
+	if in.selected != nil {
+		return in.selected
+	}
+	return in.hovered
+}
+
+// Draw は、ハイライトと情報パネルを描画します。UIツリーの描画後に呼び出してください。
+func (in *Inspector) Draw(screen *ebiten.Image) {
+	in.panelRect = image.Rectangle{}
+	in.pathRects = in.pathRects[:0]
+	in.pathNodes = in.pathNodes[:0]
+	in.editRects = in.editRects[:0]
+	in.editProps = in.editProps[:0]
+	if !in.enabled {
+		return
+	}
+
+	target := in.target()
+	if target == nil {
+		ebitenutil.DebugPrintAt(screen, "Inspector: hover a widget (click to select)", panelMargin, panelMargin)
+		return
+	}
+
+	in.drawBoxModel(screen, target)
+	in.drawPanel(screen, target)
+}
+
+// drawBoxModel は、マージン・境界・パディングの各ボックスを半透明で塗り分けて描画します。
+func (in *Inspector) drawBoxModel(screen *ebiten.Image, w component.Widget) {
+	s := widgetStyle(w)
+	border := widgetBounds(w)
+	margin := grow(border, insetsOf(s.Margin))
+	content := shrink(border, insetsOf(s.Padding))
+
+	fillRect(screen, margin, marginBoxColor)
+	fillRect(screen, border, borderBoxColor)
+	fillRect(screen, content, paddingBoxColor)
+	strokeRect(screen, border, 1, selectionColor)
+}
+
+// drawPanel は、ウィジェットの詳細情報とクリック可能なツリーパスを含むパネルを描画します。
+// 選択中のウィジェットの場合は、編集できるプロパティの行も描画します。
+func (in *Inspector) drawPanel(screen *ebiten.Image, w component.Widget) {
+	lines := describe(w)
+	path := ancestry(w)
+	editLines, editProps := in.propertyLines(w)
+
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(line)*debugCharWidth)
+	}
+	for _, node := range path {
+		width = max(width, (len(nodeLabel(node))+2)*debugCharWidth)
+	}
+	for _, line := range editLines {
+		width = max(width, len(line)*debugCharWidth)
+	}
+	height := (len(lines) + len(path) + 1 + len(editLines)) * debugLineHeight
+
+	// パネルは、調査対象のウィジェットと重ならないよう画面の反対側の隅に配置します。
+	screenBounds := screen.Bounds()
+	bounds := widgetBounds(w)
+	x := screenBounds.Max.X - width - panelPadding*2 - panelMargin
+	if bounds.Min.X+bounds.Dx()/2 > screenBounds.Dx()/2 {
+		x = panelMargin
+	}
+	y := panelMargin
+	in.panelRect = image.Rect(x, y, x+width+panelPadding*2, y+height+panelPadding*2)
+	fillRect(screen, in.panelRect, panelColor)
+
+	textX, textY := x+panelPadding, y+panelPadding
+	for _, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, textX, textY)
+		textY += debugLineHeight
+	}
+
+	ebitenutil.DebugPrintAt(screen, "path:", textX, textY)
+	textY += debugLineHeight
+	cx, cy := ebiten.CursorPosition()
+	for _, node := range path {
+		label := "> " + nodeLabel(node)
+		r := image.Rect(textX, textY, textX+len(label)*debugCharWidth, textY+debugLineHeight)
+		if image.Pt(cx, cy).In(r) {
+			fillRect(screen, r, pathHoverColor)
+		}
+		if node == w {
+			strokeRect(screen, r, 1, selectionColor)
+		}
+		ebitenutil.DebugPrintAt(screen, label, textX, textY)
+		in.pathRects = append(in.pathRects, r)
+		in.pathNodes = append(in.pathNodes, node)
+		textY += debugLineHeight
+	}
+
+	// editLinesは、見出し、editPropsに対応する行、(あれば)エラーの順に並んでいます。
+	for i, line := range editLines {
+		if row := i - 1; row >= 0 && row < len(editProps) {
+			r := image.Rect(textX, textY, textX+width, textY+debugLineHeight)
+			if editProps[row] == in.editing {
+				strokeRect(screen, r, 1, selectionColor)
+			} else if image.Pt(cx, cy).In(r) {
+				fillRect(screen, r, pathHoverColor)
+			}
+			in.editRects = append(in.editRects, r)
+			in.editProps = append(in.editProps, editProps[row])
+		}
+		ebitenutil.DebugPrintAt(screen, line, textX, textY)
+		textY += debugLineHeight
+	}
+}
+
+// propertyLines は、選択中のウィジェットの編集できるプロパティを表示する行と、各行に対応するプロパティを返します。
+// 選択中でないウィジェット(ホバー中のウィジェット)の場合は、選択を促す1行だけを返します。
+func (in *Inspector) propertyLines(w component.Widget) ([]string, []*editorProperty) {
+	if w != in.selected {
+		return []string{"(click to select and edit)"}, nil
+	}
+	lines := []string{"edit: click a value, wheel to adjust"}
+	var props []*editorProperty
+	for _, prop := range editorProperties {
+		value, ok := prop.get(w)
+		if !ok {
+			continue
+		}
+		if prop == in.editing {
+			value = string(in.editBuffer) + "_"
+		}
+		lines = append(lines, fmt.Sprintf("  %-8s %s", prop.name+":", value))
+		props = append(props, prop)
+	}
+	if in.editError != "" {
+		lines = append(lines, "  ! "+in.editError)
+	}
+	return lines, props
+}
+
+// fillRect は、矩形を指定された色で塗りつぶします。
+func fillRect(dst *ebiten.Image, r image.Rectangle, clr color.Color) {
+	if r.Dx() <= 0 || r.Dy() <= 0 {
+		return
+	}
+	vector.DrawFilledRect(dst, float32(r.Min.X), float32(r.Min.Y), float32(r.Dx()), float32(r.Dy()), clr, false)
+}
+
+// strokeRect は、矩形の輪郭を指定された幅と色で描画します。
+func strokeRect(dst *ebiten.Image, r image.Rectangle, width float32, clr color.Color) {
+	if r.Dx() <= 0 || r.Dy() <= 0 {
+		return
+	}
+	vector.StrokeRect(dst, float32(r.Min.X), float32(r.Min.Y), float32(r.Dx()), float32(r.Dy()), width, clr, false)
+}
@@ -0,0 +1,209 @@
+package devtools
+
+import (
+	"fmt"
+	"furoshiki/component"
+	"image"
+	"log"
+	"time"
+)
+
+const (
+	defaultStormFrames       = 30
+	defaultOscillationFrames = 6
+	defaultWarnInterval      = 5 * time.Second
+)
+
+// LayoutWatchdog は、レイアウトが落ち着かない状態を検出して警告するデバッグ用の診断です。
+// 次の2つの状態を検出し、原因のウィジェットのツリーパスとともに報告します。
+//
+//   - 再レイアウトの嵐(relayout storm): 同じウィジェットが、連続するフレームで毎回再レイアウトを要求し続けている。
+//     計測(GetMinSizeなど)やUpdateの中で、レイアウトに影響する状態を変更している場合に起こります。
+//   - レイアウトの振動: ウィジェットの大きさが、フレームごとに2つの値の間を交互に行き来している。
+//     折り返しの有無でスクロールバーの表示が切り替わる、といった循環する依存がある場合に起こります。
+//
+// 警告はウィジェットごとにWarnIntervalに1回までに制限され、その間に抑制した回数が次の警告に添えられます。
+//
+//	g.watchdog = devtools.NewLayoutWatchdog()
+//	g.watchdog.SetEnabled(true)
+//	...
+//	func (g *Game) Update() error {
+//		g.watchdog.Update()
+//		...
+//	}
+//
+// 検出には component.SetRelayoutRequestObserver と component.SetResizeObserver を使用するため、
+// 同時に有効にできるLayoutWatchdogは1つだけです。
+type LayoutWatchdog struct {
+	// StormFrames は、再レイアウトの嵐と見なす、連続して再レイアウトを要求したフレーム数です。
+	StormFrames int
+	// OscillationFrames は、振動と見なす、大きさが2つの値の間を交互に行き来したフレーム数です。
+	OscillationFrames int
+	// WarnInterval は、同じウィジェットについて警告を出す最短の間隔です。
+	WarnInterval time.Duration
+	// Warn は、警告を出力する関数です。既定では log.Print で出力します。
+	Warn func(message string)
+
+	enabled bool
+	// frame は、Updateが呼び出された回数に基づくフレーム番号です。記録の0を「未記録」として扱うため、1から始まります。
+	frame   uint64
+	records map[component.Widget]*layoutRecord
+	ignored map[component.Widget]bool
+}
+
+// layoutRecord は、1つのウィジェットについて記録している直近のレイアウトの履歴です。
+type layoutRecord struct {
+	// requestFrame は、最後に再レイアウトを要求したフレームです。
+	requestFrame uint64
+	// requestRun は、連続して再レイアウトを要求したフレーム数です。
+	requestRun int
+
+	// resizeFrame は、最後に大きさが変わったフレームです。resizedは、そのフレームでの最後の大きさです。
+	resizeFrame uint64
+	resized     image.Point
+	// sizeFrame は、historyに最後に記録したフレームです。history[1]が最新の大きさです。
+	sizeFrame uint64
+	history   [2]image.Point
+	// swingRun は、大きさが2フレーム前の値に戻る変化が連続したフレーム数です。
+	swingRun int
+
+	lastWarned time.Time
+	suppressed int
+}
+
+// NewLayoutWatchdog は、既定のしきい値を持つ無効なLayoutWatchdogを生成します。
+func NewLayoutWatchdog() *LayoutWatchdog {
+	return &LayoutWatchdog{
+		StormFrames:       defaultStormFrames,
+		OscillationFrames: defaultOscillationFrames,
+		WarnInterval:      defaultWarnInterval,
+		Warn:              func(message string) { log.Print(message) },
+		frame:             1,
+		records:           make(map[component.Widget]*layoutRecord),
+		ignored:           make(map[component.Widget]bool),
+	}
+}
+
+// Ignore は、指定したウィジェットを診断の対象から外します。
+// フレーム統計の表示のように、毎フレーム内容が変わることが意図されているウィジェットに使用します。
+func (wd *LayoutWatchdog) Ignore(widgets ...component.Widget) {
+	for _, w := range widgets {
+		wd.ignored[w] = true
+		delete(wd.records, w)
+	}
+}
+
+// Enabled は、診断が有効かどうかを返します。
+func (wd *LayoutWatchdog) Enabled() bool {
+	return wd.enabled
+}
+
+// SetEnabled は、診断の有効・無効を設定します。
+// 有効な間だけオブザーバーを登録するため、無効時のオーバーヘッドはありません。
+func (wd *LayoutWatchdog) SetEnabled(enabled bool) {
+	if wd.enabled == enabled {
+		return
+	}
+	wd.enabled = enabled
+	if enabled {
+		component.SetRelayoutRequestObserver(wd.recordRequest)
+		component.SetResizeObserver(wd.recordResize)
+	} else {
+		component.SetRelayoutRequestObserver(nil)
+		component.SetResizeObserver(nil)
+		clear(wd.records)
+	}
+}
+
+// record は、ウィジェットの記録を返します。ない場合は作成します。
+func (wd *LayoutWatchdog) record(w component.Widget) *layoutRecord {
+	r, ok := wd.records[w]
+	if !ok {
+		r = &layoutRecord{}
+		wd.records[w] = r
+	}
+	return r
+}
+
+// recordRequest は、再レイアウトの要求を記録し、連続したフレーム数がしきい値に達していれば警告します。
+func (wd *LayoutWatchdog) recordRequest(w component.Widget) {
+	if wd.ignored[w] {
+		return
+	}
+	r := wd.record(w)
+	switch {
+	case r.requestRun > 0 && r.requestFrame == wd.frame:
+		return
+	case r.requestRun > 0 && r.requestFrame+1 == wd.frame:
+		r.requestRun++
+	default:
+		r.requestRun = 1
+	}
+	r.requestFrame = wd.frame
+	if r.requestRun >= wd.StormFrames {
+		wd.warn(w, r, fmt.Sprintf("requested relayout in %d consecutive frames; check for measurement or Update code that changes layout state every frame", r.requestRun))
+	}
+}
+
+// recordResize は、フレーム内での最後の大きさを記録します。振動の判定は、フレームの終わり(Update)で行います。
+func (wd *LayoutWatchdog) recordResize(w component.Widget, width, height int) {
+	if wd.ignored[w] {
+		return
+	}
+	r := wd.record(w)
+	r.resizeFrame = wd.frame
+	r.resized = image.Pt(width, height)
+}
+
+// Update は、直前のフレームの大きさの変化から振動を判定し、次のフレームに進みます。
+// 古い記録はここで取り除かれます。UIツリーのUpdateより前に、毎フレーム1回呼び出してください。
+func (wd *LayoutWatchdog) Update() {
+	if !wd.enabled {
+		return
+	}
+	for w, r := range wd.records {
+		if r.resizeFrame == wd.frame && r.sizeFrame != wd.frame {
+			wd.checkOscillation(w, r)
+		}
+		last := max(r.requestFrame, r.sizeFrame)
+		if last+2 < wd.frame && time.Since(r.lastWarned) >= wd.WarnInterval {
+			delete(wd.records, w)
+		}
+	}
+	wd.frame++
+}
+
+// checkOscillation は、フレームの最後の大きさを履歴に加え、2つの大きさを交互に行き来していれば警告します。
+func (wd *LayoutWatchdog) checkOscillation(w component.Widget, r *layoutRecord) {
+	size := r.resized
+	consecutive := r.sizeFrame+1 == wd.frame
+	if consecutive && size == r.history[0] && size != r.history[1] {
+		r.swingRun++
+	} else {
+		r.swingRun = 0
+	}
+	r.history[0], r.history[1] = r.history[1], size
+	r.sizeFrame = wd.frame
+	if r.swingRun >= wd.OscillationFrames {
+		wd.warn(w, r, fmt.Sprintf("layout oscillates between %dx%d and %dx%d for %d frames; check for a cyclic dependency between its size and its content",
+			r.history[0].X, r.history[0].Y, r.history[1].X, r.history[1].Y, r.swingRun))
+	}
+}
+
+// warn は、WarnIntervalの制限に従って、ウィジェットのツリーパスを添えた警告を出力します。
+func (wd *LayoutWatchdog) warn(w component.Widget, r *layoutRecord, message string) {
+	now := time.Now()
+	if !r.lastWarned.IsZero() && now.Sub(r.lastWarned) < wd.WarnInterval {
+		r.suppressed++
+		return
+	}
+	text := "layout watchdog: " + treePath(w) + " " + message
+	if r.suppressed > 0 {
+		text += fmt.Sprintf(" (%d similar warnings suppressed)", r.suppressed)
+	}
+	r.lastWarned = now
+	r.suppressed = 0
+	if wd.Warn != nil {
+		wd.Warn(text)
+	}
+}
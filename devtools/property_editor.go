@@ -0,0 +1,297 @@
+package devtools
+
+import (
+	"errors"
+	"fmt"
+	"furoshiki/component"
+	"furoshiki/style"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// このファイルは、Inspectorのパネルで選択中のウィジェットのプロパティを実行中に書き換えるための定義を提供します。
+// 値はパネルに表示される文字列の形式のまま入力し、適用するとウィジェットが再レイアウト・再描画されます。
+
+// editorProperty は、プロパティエディタで編集できる1つのプロパティです。
+type editorProperty struct {
+	name string
+	// get は、ウィジェットの現在の値を表示用の文字列で返します。ウィジェットがプロパティを持たない場合はfalseを返します。
+	get func(w component.Widget) (string, bool)
+	// set は、入力された文字列を解釈してウィジェットに適用します。
+	set func(w component.Widget, value string) error
+	// step は、マウスホイールによる値の増減です。数値でないプロパティではnilです。
+	step func(w component.Widget, delta int)
+}
+
+// editorProperties は、プロパティエディタに表示するプロパティの一覧です。
+var editorProperties = []*editorProperty{
+	{
+		name: "size",
+		get: func(w component.Widget) (string, bool) {
+			ss, ok := w.(component.SizeSetter)
+			if !ok {
+				return "", false
+			}
+			width, height := ss.GetSize()
+			return fmt.Sprintf("%dx%d", width, height), true
+		},
+		set: func(w component.Widget, value string) error {
+			width, height, err := parseSize(value)
+			if err != nil {
+				return err
+			}
+			w.(component.SizeSetter).SetSize(width, height)
+			return nil
+		},
+		step: func(w component.Widget, delta int) {
+			ss := w.(component.SizeSetter)
+			width, height := ss.GetSize()
+			ss.SetSize(max(0, width+delta), max(0, height+delta))
+		},
+	},
+	{
+		name: "minSize",
+		get: func(w component.Widget) (string, bool) {
+			mss, ok := w.(component.MinSizeSetter)
+			if !ok {
+				return "", false
+			}
+			width, height := mss.GetMinSize()
+			return fmt.Sprintf("%dx%d", width, height), true
+		},
+		set: func(w component.Widget, value string) error {
+			width, height, err := parseSize(value)
+			if err != nil {
+				return err
+			}
+			w.(component.MinSizeSetter).SetMinSize(width, height)
+			return nil
+		},
+	},
+	{
+		name: "flex",
+		get: func(w component.Widget) (string, bool) {
+			lp, ok := w.(component.LayoutProperties)
+			if !ok {
+				return "", false
+			}
+			return strconv.Itoa(lp.GetFlex()), true
+		},
+		set: func(w component.Widget, value string) error {
+			flex, err := parseNonNegative(value)
+			if err != nil {
+				return err
+			}
+			w.(component.LayoutProperties).SetFlex(flex)
+			return nil
+		},
+		step: func(w component.Widget, delta int) {
+			lp := w.(component.LayoutProperties)
+			lp.SetFlex(max(0, lp.GetFlex()+delta))
+		},
+	},
+	insetsProperty("padding", func(s *style.Style) **style.Insets { return &s.Padding }),
+	insetsProperty("margin", func(s *style.Style) **style.Insets { return &s.Margin }),
+	colorProperty("bg", func(s *style.Style) **color.Color { return &s.Background }),
+	colorProperty("text", func(s *style.Style) **color.Color { return &s.TextColor }),
+	colorProperty("border", func(s *style.Style) **color.Color { return &s.BorderColor }),
+	floatProperty("borderW", func(s *style.Style) **float32 { return &s.BorderWidth }),
+	floatProperty("radius", func(s *style.Style) **float32 { return &s.BorderRadius }),
+	{
+		name: "label",
+		get: func(w component.Widget) (string, bool) {
+			t, ok := w.(textProperty)
+			if !ok {
+				return "", false
+			}
+			return strconv.Quote(t.Text()), true
+		},
+		set: func(w component.Widget, value string) error {
+			// 引用符で囲まれていれば、エスケープシーケンス(\nなど)を解釈します。
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				value = unquoted
+			}
+			w.(textProperty).SetText(value)
+			return nil
+		},
+	},
+}
+
+// textProperty は、テキストを持つウィジェット(Label、Buttonなど)が満たすインターフェースです。
+type textProperty interface {
+	Text() string
+	SetText(string)
+}
+
+// editStyle は、ウィジェットの基本スタイルのコピーをfnで変更して設定し直します。
+// スタイルを持たないウィジェットの場合は何もしません。
+func editStyle(w component.Widget, fn func(s *style.Style)) {
+	sg, ok := w.(component.StyleGetterSetter)
+	if !ok {
+		return
+	}
+	s := sg.GetStyle()
+	fn(&s)
+	sg.SetStyle(s)
+	// パディングやマージンの変更を、同じフレームのレイアウトに反映させます。
+	w.MarkDirty(true)
+}
+
+// insetsProperty は、スタイルのInsetsのプロパティを編集するプロパティを返します。
+// 値は"上 右 下 左"の順の4つの数値、またはすべての辺に同じ値を使う1つの数値です。ホイールではすべての辺を増減します。
+func insetsProperty(name string, field func(s *style.Style) **style.Insets) *editorProperty {
+	return &editorProperty{
+		name: name,
+		get: func(w component.Widget) (string, bool) {
+			if _, ok := w.(component.StyleGetterSetter); !ok {
+				return "", false
+			}
+			s := widgetStyle(w)
+			return insetsString(insetsOf(*field(&s))), true
+		},
+		set: func(w component.Widget, value string) error {
+			insets, err := parseInsets(value)
+			if err != nil {
+				return err
+			}
+			editStyle(w, func(s *style.Style) { *field(s) = style.PInsets(insets) })
+			return nil
+		},
+		step: func(w component.Widget, delta int) {
+			editStyle(w, func(s *style.Style) {
+				i := insetsOf(*field(s))
+				i = style.Insets{Top: max(0, i.Top+delta), Right: max(0, i.Right+delta), Bottom: max(0, i.Bottom+delta), Left: max(0, i.Left+delta)}
+				*field(s) = style.PInsets(i)
+			})
+		},
+	}
+}
+
+// colorProperty は、スタイルの色のプロパティを編集するプロパティを返します。
+// 値は"#rgb"、"#rrggbb"、"#rrggbbaa"のいずれかの形式です。"-"を入力すると設定を取り除きます。
+func colorProperty(name string, field func(s *style.Style) **color.Color) *editorProperty {
+	return &editorProperty{
+		name: name,
+		get: func(w component.Widget) (string, bool) {
+			if _, ok := w.(component.StyleGetterSetter); !ok {
+				return "", false
+			}
+			s := widgetStyle(w)
+			return colorString(*field(&s)), true
+		},
+		set: func(w component.Widget, value string) error {
+			if value == "-" {
+				editStyle(w, func(s *style.Style) { *field(s) = nil })
+				return nil
+			}
+			c, err := parseHexColor(value)
+			if err != nil {
+				return err
+			}
+			editStyle(w, func(s *style.Style) { *field(s) = style.PColor(c) })
+			return nil
+		},
+	}
+}
+
+// floatProperty は、スタイルの数値のプロパティ(境界線の幅、角の半径)を編集するプロパティを返します。
+func floatProperty(name string, field func(s *style.Style) **float32) *editorProperty {
+	return &editorProperty{
+		name: name,
+		get: func(w component.Widget) (string, bool) {
+			if _, ok := w.(component.StyleGetterSetter); !ok {
+				return "", false
+			}
+			s := widgetStyle(w)
+			if v := *field(&s); v != nil {
+				return strconv.FormatFloat(float64(*v), 'g', -1, 32), true
+			}
+			return "0", true
+		},
+		set: func(w component.Widget, value string) error {
+			v, err := strconv.ParseFloat(value, 32)
+			if err != nil || v < 0 {
+				return fmt.Errorf("%q is not a non-negative number", value)
+			}
+			editStyle(w, func(s *style.Style) { *field(s) = style.PFloat32(float32(v)) })
+			return nil
+		},
+		step: func(w component.Widget, delta int) {
+			editStyle(w, func(s *style.Style) {
+				var v float32
+				if p := *field(s); p != nil {
+					v = *p
+				}
+				*field(s) = style.PFloat32(max(0, v+float32(delta)))
+			})
+		},
+	}
+}
+
+// parseSize は、"幅x高さ"の形式の文字列を解釈します。
+func parseSize(value string) (width, height int, err error) {
+	ws, hs, ok := strings.Cut(value, "x")
+	if !ok {
+		return 0, 0, fmt.Errorf("%q is not in the form WIDTHxHEIGHT", value)
+	}
+	if width, err = parseNonNegative(ws); err != nil {
+		return 0, 0, err
+	}
+	if height, err = parseNonNegative(hs); err != nil {
+		return 0, 0, err
+	}
+	return width, height, nil
+}
+
+// parseInsets は、1つまたは"上 右 下 左"の順の4つの数値を解釈します。
+func parseInsets(value string) (style.Insets, error) {
+	fields := strings.Fields(value)
+	values := make([]int, len(fields))
+	for i, f := range fields {
+		v, err := parseNonNegative(f)
+		if err != nil {
+			return style.Insets{}, err
+		}
+		values[i] = v
+	}
+	switch len(values) {
+	case 1:
+		return style.Insets{Top: values[0], Right: values[0], Bottom: values[0], Left: values[0]}, nil
+	case 4:
+		return style.Insets{Top: values[0], Right: values[1], Bottom: values[2], Left: values[3]}, nil
+	default:
+		return style.Insets{}, errors.New("insets must be 1 or 4 numbers (top right bottom left)")
+	}
+}
+
+// parseNonNegative は、0以上の整数を解釈します。
+func parseNonNegative(value string) (int, error) {
+	v, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("%q is not a non-negative integer", value)
+	}
+	return v, nil
+}
+
+// parseHexColor は、"#rgb"、"#rrggbb"、"#rrggbbaa"の形式の文字列を色に変換します。
+func parseHexColor(value string) (color.Color, error) {
+	hex, ok := strings.CutPrefix(value, "#")
+	if !ok {
+		return nil, errors.New("color must start with '#'")
+	}
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return nil, errors.New("color must have 3, 6 or 8 hex digits")
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a hex color", value)
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
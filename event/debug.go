@@ -0,0 +1,166 @@
+package event
+
+import "slices"
+
+// このファイルは、クリックが意図しないウィジェットに届くといった問題を調べるための、Dispatcherのデバッグ用のフックを提供します。
+// トレーサーを設定すると、ディスパッチしたイベントごとに、イベントが届いた対象(バブリングの経路)と、
+// 実行されたハンドラとその結果が記録されて渡されます。ブレークポイントは、指定した種類のイベントが指定した対象に
+// 届いたときに、その対象のハンドラより先に呼び出される関数です。
+//
+// どちらも、イベントを処理する側(HandleEventの実装)がNotifyDeliveredとNotifyHandlerを呼び出すことで機能します。
+// LayoutableWidgetを埋め込むウィジェットは自動的に対応します。
+
+// HandlerRun は、トレースされた1回のハンドラの実行です。
+type HandlerRun struct {
+	// Target は、ハンドラを登録していた対象です。
+	Target EventTarget
+	// Index は、対象に登録されたハンドラのうち、何番目(0から)のハンドラかを表します。
+	Index int
+	// Result は、ハンドラの戻り値です。パニックした場合はPropagateです。
+	Result Propagation
+	// Panicked は、ハンドラがパニックしたかどうかです。
+	Panicked bool
+}
+
+// Trace は、1回のディスパッチでイベントが辿った経路の記録です。
+type Trace struct {
+	// Event は、ディスパッチを終えた時点のイベントです。Handledは、いずれかのハンドラが伝播を止めたかどうかを表します。
+	Event Event
+	// Path は、イベントが届いた対象を、届いた順(ヒットした対象から祖先へ)に並べたものです。
+	Path []EventTarget
+	// Handlers は、実行されたハンドラを実行順に並べたものです。
+	Handlers []HandlerRun
+}
+
+// HandledBy は、伝播を止めたハンドラを登録していた対象を返します。どのハンドラも伝播を止めなかった場合はnilです。
+func (t *Trace) HandledBy() EventTarget {
+	for i := len(t.Handlers) - 1; i >= 0; i-- {
+		if t.Handlers[i].Result == StopPropagation {
+			return t.Handlers[i].Target
+		}
+	}
+	return nil
+}
+
+// TraceFunc は、ディスパッチしたイベントの記録を受け取る関数です。
+// Traceはディスパッチャが再利用するため、関数の実行中だけ有効です。
+// 関数はディスパッチャのロックを保持したまま呼び出されるため、その中で同じDispatcherのメソッドを呼び出さないでください。
+type TraceFunc func(t *Trace)
+
+// breakpoint は、AddBreakpointで登録されたブレークポイントです。
+type breakpoint struct {
+	eventType EventType
+	target    EventTarget
+	fn        func(e *Event)
+}
+
+// dispatchDebug は、Dispatcherのデバッグ用のフックの状態です。
+type dispatchDebug struct {
+	tracer      TraceFunc
+	traceTypes  []EventType
+	breakpoints []*breakpoint
+	// trace は、進行中のディスパッチの記録です。ディスパッチのたびに再利用されます。
+	trace Trace
+	// tracing は、進行中のディスパッチを記録しているかどうかです。
+	tracing bool
+}
+
+// active は、いずれかのフックが設定されているかどうかを返します。
+func (dd *dispatchDebug) active() bool {
+	return dd.tracer != nil || len(dd.breakpoints) > 0
+}
+
+// SetTracer は、ディスパッチしたイベントごとにfnを呼び出すトレーサーを設定します。nilを渡すとトレースを終了します。
+// typesを指定した場合は、その種類のイベントだけを記録します。毎フレーム発生するMouseMoveを除く場合などに使用します。
+//
+//	d.SetTracer(func(t *event.Trace) { log.Println(t.Event.Type, len(t.Path)) }, event.EventClick, event.MouseDown)
+func (d *Dispatcher) SetTracer(fn TraceFunc, types ...EventType) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.debug.tracer = fn
+	d.debug.traceTypes = slices.Clone(types)
+}
+
+// AddBreakpoint は、eventTypeのイベントがtargetに届いたとき、targetのハンドラより先にfnを呼び出すブレークポイントを登録します。
+// targetがnilの場合は、その種類のイベントが届くすべての対象で呼び出されます。
+// fnの中にデバッガのブレークポイントを置く(またはruntime.Breakpointを呼び出す)と、そのイベントを処理する直前で実行を止められます。
+// fnは、TraceFuncと同様にディスパッチャのロックを保持したまま呼び出されます。
+// 戻り値の関数を呼び出すと、ブレークポイントを取り除きます。
+func (d *Dispatcher) AddBreakpoint(eventType EventType, target EventTarget, fn func(e *Event)) (remove func()) {
+	if fn == nil {
+		return func() {}
+	}
+	bp := &breakpoint{eventType: eventType, target: target, fn: fn}
+	d.mutex.Lock()
+	d.debug.breakpoints = append(d.debug.breakpoints, bp)
+	d.mutex.Unlock()
+	return func() {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+		if i := slices.Index(d.debug.breakpoints, bp); i >= 0 {
+			d.debug.breakpoints = slices.Delete(d.debug.breakpoints, i, i+1)
+		}
+	}
+}
+
+// ClearBreakpoints は、登録されたすべてのブレークポイントを取り除きます。
+func (d *Dispatcher) ClearBreakpoints() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.debug.breakpoints = nil
+}
+
+// deliver は、イベントを対象に渡し、トレース中であれば記録をトレーサーに渡します。
+func (d *Dispatcher) deliver(target EventTarget, e *Event) {
+	dd := &d.debug
+	if !dd.active() {
+		target.HandleEvent(e)
+		return
+	}
+	dd.tracing = dd.tracer != nil && (len(dd.traceTypes) == 0 || slices.Contains(dd.traceTypes, e.Type))
+	if dd.tracing {
+		clear(dd.trace.Path)
+		clear(dd.trace.Handlers)
+		dd.trace.Path = dd.trace.Path[:0]
+		dd.trace.Handlers = dd.trace.Handlers[:0]
+	}
+	e.debug = dd
+	target.HandleEvent(e)
+	e.debug = nil
+	if dd.tracing {
+		dd.tracing = false
+		dd.trace.Event = *e
+		dd.tracer(&dd.trace)
+	}
+}
+
+// NotifyDelivered は、イベントがtargetに届いたことをデバッグ用のフックに通知し、一致するブレークポイントを呼び出します。
+// HandleEventの実装が、自身のハンドラを呼び出す前に呼び出します。フックが設定されていない場合は何もしません。
+func (e *Event) NotifyDelivered(target EventTarget) {
+	dd := e.debug
+	if dd == nil {
+		return
+	}
+	if dd.tracing {
+		dd.trace.Path = append(dd.trace.Path, target)
+	}
+	for _, bp := range dd.breakpoints {
+		if bp.eventType == e.Type && (bp.target == nil || bp.target == target) {
+			bp.fn(e)
+		}
+	}
+}
+
+// NotifyHandler は、targetに登録されたindex番目のハンドラを実行した結果をデバッグ用のフックに通知します。
+// フックが設定されていない場合は何もしません。
+func (e *Event) NotifyHandler(target EventTarget, index int, result Propagation, panicked bool) {
+	if dd := e.debug; dd != nil && dd.tracing {
+		dd.trace.Handlers = append(dd.trace.Handlers, HandlerRun{Target: target, Index: index, Result: result, Panicked: panicked})
+	}
+}
+
+// Debugging は、イベントにデバッグ用のフックが設定されているかどうかを返します。
+// NotifyDeliveredやNotifyHandlerに渡す値の準備を、フックがない場合に省略するために使用します。
+func (e *Event) Debugging() bool {
+	return e.debug != nil
+}
@@ -1,6 +1,8 @@
 package event
 
 import (
+	"furoshiki/clock"
+	"furoshiki/stats"
 	"sync"
 	"time"
 
@@ -13,6 +15,16 @@ type Dispatcher struct {
 	hoveredComponent EventTarget
 	pressedComponent EventTarget
 	mutex            sync.Mutex
+
+	// eventBuffer は、ディスパッチのたびに再利用されるイベントです。
+	// イベントごとにEventを割り当てる代わりにこのバッファを再初期化して渡すことで、
+	// 定常状態のフレームでのヒープ割り当てをなくします。
+	// NOTE: そのため、ハンドラは受け取った*Eventをハンドラの呼び出し後まで保持してはいけません。
+	//       必要な場合は値としてコピーしてください。
+	eventBuffer Event
+
+	// debug は、トレーサーとブレークポイントの状態です。詳細は debug.go を参照してください。
+	debug dispatchDebug
 }
 
 var (
@@ -28,91 +40,127 @@ func GetDispatcher() *Dispatcher {
 	return instance
 }
 
+// NewDispatcher は、シングルトンとは独立したホバー・押下状態を持つ新しいDispatcherを生成します。
+// テクスチャに描画するUIや分割画面のビューポートなど、画面のUIとは別にポインタを扱うUIのルートごとに使用します。
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{}
+}
+
+// Pointer は、1フレーム分のポインタの入力状態です。
+// DispatchPointerに渡すことで、マウス以外の入力(ゲーム内のモニターへの照準など)をUIのポインタとして扱えます。
+type Pointer struct {
+	// X, Y は、ヒットテストに使用したのと同じ座標系でのポインタの位置です。
+	X, Y int
+	// JustPressed, JustReleased は、このフレームで左ボタンに相当する入力が押された、または離されたかどうかです。
+	JustPressed, JustReleased bool
+	// WheelX, WheelY は、このフレームのホイールのスクロール量です。
+	WheelX, WheelY float64
+}
+
+// mousePointer は、マウスの入力状態から、指定された位置のPointerを生成します。
+func mousePointer(cx, cy int) Pointer {
+	wheelX, wheelY := ebiten.Wheel()
+	return Pointer{
+		X:            cx,
+		Y:            cy,
+		JustPressed:  inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft),
+		JustReleased: inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft),
+		WheelX:       wheelX,
+		WheelY:       wheelY,
+	}
+}
+
 // Dispatch は、マウスイベントを処理し、適切なイベントをコンポーネントに発行します。
 // このメソッドは、アプリケーションのメインUpdateループから毎フレーム呼び出されることを想定しています。
 // 【提案1対応】循環参照を解消するため、引数の型をcomponent.WidgetからEventTargetに戻しました。
 // これにより、eventパッケージはcomponentパッケージに依存しなくなります。
 // 呼び出し側(main.goなど)は、HitTestの結果をEventTargetに型アサーションしてから渡す必要があります。
 func (d *Dispatcher) Dispatch(target EventTarget, cx, cy int) {
+	d.DispatchPointer(target, mousePointer(cx, cy))
+}
+
+// DispatchPointer は、マウスの代わりに指定されたポインタの入力状態でイベントを発行します。
+// targetは、p.X, p.Yでヒットテストした結果です。
+func (d *Dispatcher) DispatchPointer(target EventTarget, p Pointer) {
+	cx, cy := p.X, p.Y
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
+	if stats.Enabled() {
+		start := time.Now()
+		defer func() { stats.AddEventDispatchTime(time.Since(start)) }()
+	}
+
 	// 1. ホバー状態の更新 (MouseEnter, MouseLeave)
 	if target != d.hoveredComponent {
 		if d.hoveredComponent != nil {
 			d.hoveredComponent.SetHovered(false)
-			d.hoveredComponent.HandleEvent(&Event{Type: MouseLeave, Target: d.hoveredComponent, X: cx, Y: cy})
+			d.deliver(d.hoveredComponent, d.newEvent(MouseLeave, d.hoveredComponent, cx, cy))
 		}
 		if target != nil {
 			target.SetHovered(true)
-			target.HandleEvent(&Event{Type: MouseEnter, Target: target, X: cx, Y: cy})
+			d.deliver(target, d.newEvent(MouseEnter, target, cx, cy))
 		}
 		d.hoveredComponent = target
 	}
 
 	// 2. マウス移動イベント (MouseMove)
 	if d.hoveredComponent != nil {
-		d.hoveredComponent.HandleEvent(&Event{Type: MouseMove, Target: d.hoveredComponent, X: cx, Y: cy})
+		d.deliver(d.hoveredComponent, d.newEvent(MouseMove, d.hoveredComponent, cx, cy))
 	}
 
 	// 3. マウスボタン押下イベント (MouseDown)
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+	if p.JustPressed {
 		if d.hoveredComponent != nil {
 			d.pressedComponent = d.hoveredComponent
 			d.pressedComponent.SetPressed(true)
-			d.pressedComponent.HandleEvent(&Event{
-				Type:        MouseDown,
-				Target:      d.pressedComponent,
-				X:           cx,
-				Y:           cy,
-				Timestamp:   time.Now().UnixNano(),
-				MouseButton: ebiten.MouseButtonLeft,
-			})
+			e := d.newEvent(MouseDown, d.pressedComponent, cx, cy)
+			e.Timestamp = clock.Now().UnixNano()
+			e.MouseButton = ebiten.MouseButtonLeft
+			d.deliver(d.pressedComponent, e)
 		}
 	}
 
 	// 4. マウスボタン解放イベント (MouseUp and Click)
-	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+	if p.JustReleased {
 		if d.pressedComponent != nil {
 			d.pressedComponent.SetPressed(false)
 
 			// MouseUpイベントは、最初に「押された」コンポーネントに送ります。
-			d.pressedComponent.HandleEvent(&Event{
-				Type:        MouseUp,
-				Target:      d.pressedComponent,
-				X:           cx,
-				Y:           cy,
-				Timestamp:   time.Now().UnixNano(),
-				MouseButton: ebiten.MouseButtonLeft,
-			})
+			e := d.newEvent(MouseUp, d.pressedComponent, cx, cy)
+			e.Timestamp = clock.Now().UnixNano()
+			e.MouseButton = ebiten.MouseButtonLeft
+			d.deliver(d.pressedComponent, e)
 
 			// クリックが成立するのは、押したコンポーネントと離したコンポーネントが同じ場合のみです。
 			if d.pressedComponent == d.hoveredComponent {
-				d.pressedComponent.HandleEvent(&Event{
-					Type:        EventClick,
-					Target:      d.pressedComponent,
-					X:           cx,
-					Y:           cy,
-					Timestamp:   time.Now().UnixNano(),
-					MouseButton: ebiten.MouseButtonLeft,
-				})
+				e := d.newEvent(EventClick, d.pressedComponent, cx, cy)
+				e.Timestamp = clock.Now().UnixNano()
+				e.MouseButton = ebiten.MouseButtonLeft
+				d.deliver(d.pressedComponent, e)
 			}
 		}
 		d.pressedComponent = nil
 	}
 
 	// 5. マウスホイールイベント (MouseScroll)
-	wheelX, wheelY := ebiten.Wheel()
-	if (wheelX != 0 || wheelY != 0) && d.hoveredComponent != nil {
-		d.hoveredComponent.HandleEvent(&Event{
-			Type:    MouseScroll,
-			Target:  d.hoveredComponent,
-			X:       cx,
-			Y:       cy,
-			ScrollX: wheelX,
-			ScrollY: wheelY,
-		})
+	if (p.WheelX != 0 || p.WheelY != 0) && d.hoveredComponent != nil {
+		e := d.newEvent(MouseScroll, d.hoveredComponent, cx, cy)
+		e.ScrollX = p.WheelX
+		e.ScrollY = p.WheelY
+		d.deliver(d.hoveredComponent, e)
+	}
+}
+
+// newEvent は、再利用バッファを指定された内容で初期化し、そのポインタを返します。
+// 前回のディスパッチで設定されたフィールド（Handledなど）はすべてクリアされます。
+// 対象がPointLocalizerを実装している場合、座標は対象の座標系に変換されます。
+func (d *Dispatcher) newEvent(eventType EventType, target EventTarget, x, y int) *Event {
+	if pl, ok := target.(PointLocalizer); ok {
+		x, y = pl.LocalPoint(x, y)
 	}
+	d.eventBuffer = Event{Type: eventType, Target: target, X: x, Y: y}
+	return &d.eventBuffer
 }
 
 // Reset は、ディスパッチャの内部状態をリセットします。
@@ -1,6 +1,10 @@
 package event
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
 
 // EventType はUIイベントの種類（クリック、マウスオーバーなど）を定義します。
 type EventType int
@@ -15,6 +19,28 @@ const (
 	MouseScroll
 )
 
+// String は、イベントの種類の名前を返します(例: "Click", "MouseDown")。
+func (t EventType) String() string {
+	switch t {
+	case EventClick:
+		return "Click"
+	case MouseEnter:
+		return "MouseEnter"
+	case MouseLeave:
+		return "MouseLeave"
+	case MouseMove:
+		return "MouseMove"
+	case MouseDown:
+		return "MouseDown"
+	case MouseUp:
+		return "MouseUp"
+	case MouseScroll:
+		return "MouseScroll"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+}
+
 // 【提案1対応】イベントの伝播を制御するための型を定義します。
 // これにより、ハンドラの戻り値でイベントをバブリングさせるか停止させるかを明示的に示せます。
 type Propagation int
@@ -38,11 +64,16 @@ type Event struct {
 	// これがtrueに設定されると、イベントの親ウィジェットへの伝播（バブリング）が停止します。
 	// 【提案1対応】このフィールドは主に内部で使われ、ハンドラの戻り値によって制御されるようになります。
 	Handled bool
+
+	// debug は、ディスパッチャのデバッグ用のフックです。フックが設定されていない場合はnilです。
+	debug *dispatchDebug
 }
 
 // EventHandler は、特定のイベントタイプに応答するための関数シグネチャーです。
 // 【提案1対応】戻り値としてPropagation型を返すようにシグネチャが変更されました。
 // これにより、ハンドラは副作用なしにイベントの伝播を制御できます。
+// NOTE: Dispatcherはイベントオブジェクトを再利用するため、渡された*Eventはハンドラの実行中だけ有効です。
+//       イベントの内容を後で参照する場合は、値としてコピーしてください。
 type EventHandler func(e *Event) Propagation
 
 // EventTargetは、Dispatcherがイベントをディスパッチするためにウィジェットが満たすべき最低限の振る舞いを定義するインターフェースです。
@@ -53,4 +84,11 @@ type EventTarget interface {
 	SetHovered(bool)
 	SetPressed(bool)
 	HandleEvent(e *Event)
+}
+
+// PointLocalizer は、画面座標を自身の座標系に変換できるイベントの対象が実装するインターフェースです。
+// Dispatcherは、対象がこれを実装している場合、イベントのX, Yを変換後の座標で設定します。
+// 座標変換(カメラの平行移動や拡大縮小)を持つコンテナの子孫が、自身の位置と同じ座標系でポインタの位置を受け取るために使用します。
+type PointLocalizer interface {
+	LocalPoint(x, y int) (int, int)
 }
\ No newline at end of file
@@ -1,21 +1,37 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"furoshiki"
+	"furoshiki/a11y"
+	"furoshiki/binding"
 	"furoshiki/component"
 	"furoshiki/container"
+	"furoshiki/devtools"
 	"furoshiki/event"
+	"furoshiki/i18n"
 	"furoshiki/layout"
 	"furoshiki/style"
 	"furoshiki/theme"
 	"furoshiki/ui"
 	"furoshiki/widget"
+	"image"
 	"image/color"
 	"log"
+	"math"
+	"os"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font"
 	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
 )
 
 const (
@@ -23,11 +39,36 @@ const (
 	screenHeight = 600
 )
 
+// highContrast は、ハイコントラストのテーマでデモを起動するかどうかです。
+var highContrast = flag.Bool("high-contrast", false, "use the built-in high-contrast theme")
+
+// grayscaleShaderSource は、Amountの割合で色をグレースケールに近づけるKageシェーダーです。
+const grayscaleShaderSource = `//kage:unit pixels
+
+package main
+
+var Amount float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	c := imageSrc0At(srcPos)
+	y := dot(c.rgb, vec3(0.299, 0.587, 0.114))
+	return vec4(mix(c.rgb, vec3(y), Amount), c.a)
+}
+`
+
 // Game はEbitenのゲーム構造体を保持します。
 type Game struct {
 	root        component.Container
+	stage       *ui.Stage      // メインのUIとダイアログを重ねるレイヤーの管理
+	dialog      *ui.Layer      // "About"ボタンで表示するモーダルなダイアログのレイヤー
+	fileDialog  *ui.FileDialog // "Files"ボタンで表示する、カレントディレクトリのファイルを開くダイアログ
 	contentArea *container.Container
 	currentDemo component.Widget
+	inspector   *devtools.Inspector    // F12で切り替えるUIインスペクタ
+	overlay     *devtools.DebugOverlay // F11で切り替えるレイアウトのデバッグ表示
+	tracing     bool                   // F6で切り替えるイベントのトレースが有効かどうか
+	statsView   *widget.StatsView
+	watchdog    *devtools.LayoutWatchdog
 }
 
 // NewGame は新しいGameインスタンスを作成し、UIを構築します。
@@ -36,12 +77,30 @@ func NewGame() *Game {
 
 	// --- テーマとフォントの初期設定 ---
 	appTheme := theme.GetCurrent()
+	if *highContrast {
+		appTheme = theme.NewHighContrastTheme()
+	}
 	appTheme.SetDefaultFont(basicfont.Face7x13)
+	// Label.AutoFitが選択するフェイスとして、複数のサイズのGoフォントを登録します。
+	appTheme.Faces = newDemoFaces()
 	theme.SetCurrent(appTheme)
+	// 文字色と背景色のコントラストがWCAGのレベルAAに満たないテーマのスタイルを報告します。
+	for _, issue := range theme.CheckContrast(appTheme, theme.ContrastAA) {
+		log.Printf("contrast: %s", issue)
+	}
+
+	// --- UIの文字列のカタログ ---
+	// TextKeyで指定したキーは、現在のロケールのカタログから解決されます。
+	registerDemoMessages()
+
+	// --- フレーム統計の計測を有効化 ---
+	furoshiki.EnableStats(true)
+	// 描画キャッシュなどのオフスクリーン画像の使用量を64MiBまでに抑えます
+	furoshiki.SetOffscreenBudget(64 << 20)
 
 	// --- UIの全体構造を構築 ---
-	// root変数は不要なため、_で破棄します
-	_, err := ui.VStack(func(b *ui.FlexBuilder) {
+	// root変数は不要なため、_で破棄します。ビルダーはビルド時の警告を確認するために保持します
+	rootBuilder := ui.VStack(func(b *ui.FlexBuilder) {
 		b.Size(screenWidth, screenHeight).
 			BackgroundColor(appTheme.BackgroundColor).
 			Padding(10).
@@ -84,6 +143,24 @@ func NewGame() *Game {
 					return event.Propagate
 				})
 			})
+			b.Button(func(btn *widget.ButtonBuilder) {
+				btn.Text("Form").Flex(1).AddOnClick(func(e *event.Event) event.Propagation {
+					g.switchToDemo(g.createFormDemo)
+					return event.Propagate
+				})
+			})
+			b.Button(func(btn *widget.ButtonBuilder) {
+				btn.Text("Sections").Flex(1).AddOnClick(func(e *event.Event) event.Propagation {
+					g.switchToDemo(g.createSectionedListDemo)
+					return event.Propagate
+				})
+			})
+			b.Button(func(btn *widget.ButtonBuilder) {
+				btn.Text("Grid View").Flex(1).AddOnClick(func(e *event.Event) event.Propagation {
+					g.switchToDemo(g.createGridViewDemo)
+					return event.Propagate
+				})
+			})
 			b.Button(func(btn *widget.ButtonBuilder) {
 				btn.Text("ZStack Layout").Flex(1).AddOnClick(func(e *event.Event) event.Propagation {
 					g.switchToDemo(g.createZStackDemo)
@@ -96,6 +173,47 @@ func NewGame() *Game {
 					return event.Propagate
 				})
 			})
+			b.Button(func(btn *widget.ButtonBuilder) {
+				btn.Text("Safe Area").Flex(1).AddOnClick(func(e *event.Event) event.Propagation {
+					g.switchToDemo(g.createSafeAreaDemo)
+					return event.Propagate
+				})
+			})
+			// 選んだファイルの数を、ボタンの右上のバッジに表示します。
+			var filesButton *widget.Button
+			var opened int
+			b.Button(func(btn *widget.ButtonBuilder) {
+				btn.Text("Files").Flex(1).AssignTo(&filesButton).AddOnClick(func(e *event.Event) event.Propagation {
+					err := g.fileDialog.Show(func(p string, ok bool) {
+						if ok {
+							log.Printf("file dialog: selected %q", p)
+							opened++
+							filesButton.SetBadgeCount(opened, component.BadgeTopRight)
+						}
+					})
+					if err != nil {
+						log.Printf("file dialog: %v", err)
+					}
+					return event.Propagate
+				})
+			})
+			// 表示言語の切り替え。TextKeyで設定したテキストは、ロケールの変更に合わせて自動的に更新され、再レイアウトされます。
+			b.Button(func(btn *widget.ButtonBuilder) {
+				btn.TextKey("nav.language").Flex(1).AddOnClick(func(e *event.Event) event.Propagation {
+					if i18n.Locale() == "es" {
+						i18n.SetLocale("en")
+					} else {
+						i18n.SetLocale("es")
+					}
+					return event.Propagate
+				})
+			})
+			b.Button(func(btn *widget.ButtonBuilder) {
+				btn.TextKey("nav.about").Flex(1).AddOnClick(func(e *event.Event) event.Propagation {
+					g.dialog.SetVisible(true)
+					return event.Propagate
+				})
+			})
 		})
 
 		// --- 2. デモ表示エリア ---
@@ -104,15 +222,78 @@ func NewGame() *Game {
 					AssignTo(&g.contentArea)
 		})
 
-	}).Build()
+		// --- 3. フレーム統計 ---
+		b.StatsView(func(s *widget.StatsViewBuilder) {
+			s.Size(0, 20).AssignTo(&g.statsView)
+		})
+
+	})
+	_, err := rootBuilder.Build()
 
 	if err != nil {
 		log.Fatalf("UI build failed: %v", err)
 	}
+	// ビルドは成功しても意図どおりに動作しない可能性が高い設定を、警告としてログに出力します
+	for _, w := range rootBuilder.Warnings() {
+		log.Printf("build warning: %s", w)
+	}
 
 	// 初期表示のデモを設定
 	g.switchToDemo(g.createFlexLayoutDemo)
 
+	// --- レイヤーの構成 ---
+	// メインのUIの上に、モーダルなダイアログのレイヤーを重ねます。ダイアログの表示中は、メインのUIにイベントが届きません。
+	dialogRoot, err := g.createAboutDialog()
+	if err != nil {
+		log.Fatalf("UI build failed: %v", err)
+	}
+	g.stage = ui.NewStage()
+	if _, err := g.stage.AddLayer("main", g.root); err != nil {
+		log.Fatal(err)
+	}
+	if g.dialog, err = g.stage.AddLayer("dialog", dialogRoot); err != nil {
+		log.Fatal(err)
+	}
+	g.dialog.SetModal(true)
+	g.dialog.SetVisible(false)
+	// ファイルダイアログは、自身をモーダルなレイヤーとしてStageに追加します。
+	// ここではカレントディレクトリを閲覧しますが、embed.FSなど任意のfs.FSを渡せます。
+	g.fileDialog, err = ui.NewFileDialog(g.stage, "file-dialog", ui.FileDialogOptions{FS: os.DirFS("."), Extensions: []string{".go", ".md"}})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// FPSやウィジェットの数、フレーム統計を画面の左上に重ねて表示するHUD (F3で切り替え)。
+	// HUDはヒットテストに当たらないため、最も手前のレイヤーに置いても下のUIの操作を妨げません。
+	var layerRoots []component.Widget
+	for _, l := range g.stage.Layers() {
+		layerRoots = append(layerRoots, l.Root())
+	}
+	hud, err := widget.NewDebugHUDBuilder().Roots(layerRoots...).Build()
+	if err != nil {
+		log.Fatal(err)
+	}
+	hud.SetPosition(10, 50)
+	if _, err := g.stage.AddLayer("hud", hud); err != nil {
+		log.Fatal(err)
+	}
+
+	// フォーカスの移動、ダイアログの表示、ライブリージョンの更新で読み上げる文章をログに出力します。
+	// 実際のゲームでは、ここで音声合成やスクリーンリーダーとの連携を行うバックエンドを設定します。
+	a11y.SetAnnouncer(a11y.AnnouncerFunc(func(a a11y.Announcement) {
+		log.Printf("[a11y] %s", a.Text)
+	}))
+
+	// デバッグ用のUIインスペクタ (F12で切り替え)
+	g.inspector = devtools.NewInspector(g.root)
+	// 境界と再レイアウトを可視化するデバッグオーバーレイ (F11で切り替え)
+	g.overlay = devtools.NewDebugOverlay(g.root)
+	// 毎フレーム再レイアウトを要求し続けるウィジェットや、大きさが振動するウィジェットをログに警告します。
+	// フレーム統計は毎フレーム表示が変わるため、対象から外します。
+	g.watchdog = devtools.NewLayoutWatchdog()
+	g.watchdog.Ignore(g.statsView)
+	g.watchdog.SetEnabled(true)
+
 	return g
 }
 
@@ -122,12 +303,7 @@ func (g *Game) switchToDemo(demoCreator func() (component.Widget, error)) {
 		return
 	}
 
-	// 古いデモが存在すれば削除
-	if g.currentDemo != nil {
-		g.contentArea.RemoveChild(g.currentDemo) // RemoveChildはCleanupも呼び出す
-	}
-
-	// 新しいデモを生成して追加
+	// 新しいデモを生成
 	newDemo, err := demoCreator()
 	if err != nil {
 		log.Printf("Failed to create demo: %v", err)
@@ -140,52 +316,168 @@ func (g *Game) switchToDemo(demoCreator func() (component.Widget, error)) {
 		newDemo = errorLabel
 	}
 
+	// 古いデモが存在すれば、同じ位置で新しいデモに置き換えます (ReplaceChildは古いデモのCleanupも呼び出す)
+	if g.currentDemo == nil || !g.contentArea.ReplaceChild(g.currentDemo, newDemo) {
+		g.contentArea.AddChild(newDemo)
+	}
 	g.currentDemo = newDemo
-	g.contentArea.AddChild(g.currentDemo)
 }
 
 // Update はゲームの状態を更新します。
 func (g *Game) Update() error {
-	cx, cy := ebiten.CursorPosition()
-	dispatcher := event.GetDispatcher()
-
-	// 【提案1対応】HitTestはcomponent.Widgetを返します。
-	// Dispatcherはevent.EventTargetを期待するため、型アサーションで変換します。
-	hittableWidget := g.root.HitTest(cx, cy)
-	var target event.EventTarget
-	if hittableWidget != nil {
-		// ヒットしたウィジェットがEventTargetインターフェースを実装しているか確認します。
-		// 実装していれば、イベントディスパッチの対象となります。
-		if et, ok := hittableWidget.(event.EventTarget); ok {
-			target = et
+	// 前フレームのカウンタを確定させ、新しいフレームの計測を開始します。
+	furoshiki.NextFrame()
+	// F10で省電力モードを切り替えます。有効な間は、入力も変化もないフレームの更新と描画を省略します。
+	// フレーム統計は毎フレーム表示が変わり続けるため、省電力モードの間は一時停止します。
+	if inpututil.IsKeyJustPressed(ebiten.KeyF10) {
+		furoshiki.SetLowPowerMode(!furoshiki.LowPowerMode())
+		g.statsView.SetPaused(furoshiki.LowPowerMode())
+	}
+	// F9で、表示中のレイヤーごとに、UIの意味情報のツリー(役割、名前、状態、境界)をログに出力します。
+	if inpututil.IsKeyJustPressed(ebiten.KeyF9) {
+		for _, l := range g.stage.Layers() {
+			if l.IsVisible() {
+				log.Printf("semantics tree of layer %q:\n%s", l.Name(), a11y.Export(l.Root()))
+			}
+		}
+	}
+	// F6で、クリックとボタンの押下・解放が届いたウィジェットと、実行されたハンドラを標準エラー出力に書き出すトレースを切り替えます。
+	if inpututil.IsKeyJustPressed(ebiten.KeyF6) {
+		g.tracing = !g.tracing
+		if g.tracing {
+			devtools.TraceEvents(event.GetDispatcher(), os.Stderr, event.EventClick, event.MouseDown, event.MouseUp)
+		} else {
+			event.GetDispatcher().SetTracer(nil)
 		}
 	}
-	dispatcher.Dispatch(target, cx, cy)
+	// F7で、デモ表示エリアのレイアウト計算(計測と配置)を標準エラー出力に書き出すトレースを切り替えます。
+	if inpututil.IsKeyJustPressed(ebiten.KeyF7) {
+		if layout.IsTracing() {
+			layout.SetTracer(nil, nil)
+		} else {
+			layout.SetTracer(g.contentArea, layout.LogTracer(os.Stderr))
+			g.contentArea.MarkDirty(true)
+		}
+	}
+	// F8で、表示中のレイヤーごとに、ウィジェットツリーのレイアウトの詳細を標準エラー出力に書き出します。
+	if inpututil.IsKeyJustPressed(ebiten.KeyF8) {
+		for _, l := range g.stage.Layers() {
+			if l.IsVisible() {
+				fmt.Fprintf(os.Stderr, "widget tree of layer %q:\n", l.Name())
+				if err := furoshiki.DumpTree(l.Root(), os.Stderr); err != nil {
+					log.Printf("dump tree: %v", err)
+				}
+			}
+		}
+	}
+	if !furoshiki.ShouldUpdate() {
+		return nil
+	}
+	g.overlay.Update()
+	g.watchdog.Update()
 
-	g.root.Update()
+	// インスペクタが有効な間は、マウス入力をUIに配送しません。
+	// Stageは、ポップアップと手前のレイヤーを優先してヒットテストし、イベントを配送してから各レイヤーを更新します。
+	g.stage.SetInputEnabled(!g.inspector.Update())
+	g.stage.Update()
 	return nil
 }
 
 // Draw はゲームを描画します。
 func (g *Game) Draw(screen *ebiten.Image) {
-	screen.Fill(color.RGBA{50, 50, 50, 255})
-	// UPDATE: 新しいDrawメソッドのシグネチャに合わせてDrawInfoを生成して渡します。
-	// ルートウィジェットの描画オフセットは(0, 0)です。
-	drawInfo := component.DrawInfo{
-		Screen:  screen,
-		OffsetX: 0,
-		OffsetY: 0,
+	if !furoshiki.ShouldDraw() {
+		return
 	}
-	g.root.Draw(drawInfo)
+	screen.Fill(color.RGBA{50, 50, 50, 255})
+	// レイヤーを奥から順に描画し、最後にポップアップを描画します。
+	g.stage.Draw(screen)
+	g.overlay.Draw(screen)
+	g.inspector.Draw(screen)
+}
+
+// createAboutDialog は、画面全体を暗くしてパネルを中央に表示するダイアログのレイヤーのルートを生成します。
+func (g *Game) createAboutDialog() (component.Widget, error) {
+	return ui.ZStack(func(b *ui.ZStackBuilder) {
+		b.Size(screenWidth, screenHeight).
+			BackgroundColor(color.RGBA{A: 140}).
+			ContentAlign(layout.AlignCenter, layout.AlignCenter)
+		b.VStack(func(b *ui.FlexBuilder) {
+			b.Size(320, 120).Padding(10).Gap(10).
+				BackgroundColor(color.White).Border(1, color.Gray{Y: 100}).
+				AccessibleRole(component.RoleDialog).AccessibleLabel("About").
+				AlignItems(layout.AlignCenter)
+			b.Label(func(l *widget.LabelBuilder) {
+				l.TextKey("about.body", i18n.Params{"name": "Furoshiki"}).WrapText(true).Size(300, 0)
+			})
+			b.Spacer()
+			b.Button(func(btn *widget.ButtonBuilder) {
+				btn.TextKey("about.close").Size(80, 28).AccessibleHint("Closes the About dialog").AddOnClick(func(e *event.Event) event.Propagation {
+					g.dialog.SetVisible(false)
+					return event.Propagate
+				})
+			})
+		})
+	}).Build()
 }
 
 // Layout はEbitenにゲームの画面サイズを伝えます。
+// ウィンドウの大きさに合わせてすべてのレイヤーのルートを再レイアウトします。
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return screenWidth, screenHeight
+	g.stage.Resize(outsideWidth, outsideHeight)
+	return outsideWidth, outsideHeight
+}
+
+// registerDemoMessages は、デモで使用するUIの文字列のカタログを登録します。
+func registerDemoMessages() {
+	// デモのフォント(basicfont)で表示できるよう、英語とスペイン語のカタログを用意します。
+	i18n.Register("en", i18n.Messages{
+		"nav.about":    "About",
+		"nav.language": "Español",
+		"about.body":   "{name} demo. This dialog is a modal layer on a ui.Stage.",
+		"about.close":  "Close",
+	})
+	i18n.Register("es", i18n.Messages{
+		"nav.about":    "Acerca de",
+		"nav.language": "English",
+		"about.body":   "Demostración de {name}. Este diálogo es una capa modal sobre un ui.Stage.",
+		"about.close":  "Cerrar",
+	})
+}
+
+// demoIcon は、アイコン付きボタンやマークアップの[icon=...]タグのデモで使用する単色の画像です。
+var demoIcon = newDemoIcon(12, color.RGBA{R: 200, G: 80, B: 40, A: 255})
+
+// newDemoIcon は、指定された大きさと色で塗りつぶした正方形の画像を生成します。
+func newDemoIcon(size int, c color.Color) *ebiten.Image {
+	img := ebiten.NewImage(size, size)
+	img.Fill(c)
+	return img
+}
+
+// newDemoFaces は、Goフォントから8ptから40ptまでのフェイスを生成します。
+func newDemoFaces() theme.FaceSet {
+	ttf, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var faces []theme.SizedFace
+	for size := 8.0; size <= 40; size += 4 {
+		face, err := opentype.NewFace(ttf, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			log.Fatal(err)
+		}
+		faces = append(faces, theme.SizedFace{Size: size, Face: face})
+	}
+	return theme.NewFaceSet(faces...)
 }
 
 func main() {
+	flag.Parse()
+	widget.SetDefaultMarkupStyle(&widget.MarkupStyle{
+		Icons: map[string]*ebiten.Image{"sword": demoIcon},
+	})
 	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 	ebiten.SetWindowTitle("Furoshiki UI Demo")
 	if err := ebiten.RunGame(NewGame()); err != nil {
 		log.Fatal(err)
@@ -208,6 +500,107 @@ func (g *Game) createFlexLayoutDemo() (component.Widget, error) {
 			b.Button(func(btn *widget.ButtonBuilder) { btn.Text("Button A").Size(80, 0) })
 			b.Button(func(btn *widget.ButtonBuilder) { btn.Text("Button B").Size(100, 40) })
 			b.Button(func(btn *widget.ButtonBuilder) { btn.Text("Button C").Size(60, 60) })
+			b.Button(func(btn *widget.ButtonBuilder) { btn.Text("Icon").Icon(demoIcon).Size(0, 40) })
+			b.Button(func(btn *widget.ButtonBuilder) {
+				btn.Text("Top").Icon(demoIcon).IconPlacement(widget.IconTop).Size(0, 60)
+			})
+		})
+
+		// --- レスポンシブ表示のデモ ---
+		// ウィンドウの幅が700px未満になると、説明のラベルを短い表記に切り替えます。
+		b.When(ui.WidthAtLeast(700), func(b *ui.FlexBuilder) {
+			b.Label(func(l *widget.LabelBuilder) {
+				l.Text("Wide window: resize below 700px to switch to the compact layout")
+			})
+		})
+		b.When(ui.WidthBelow(700), func(b *ui.FlexBuilder) {
+			b.Label(func(l *widget.LabelBuilder) { l.Text("Compact layout") })
+		})
+
+		// --- ToggleButtonとButtonGroupのデモ (セグメントコントロール) ---
+		b.Label(func(l *widget.LabelBuilder) { l.Text("Segmented control (ToggleButton + ButtonGroup)") })
+		group := widget.NewButtonGroup()
+		b.HStack(func(b *ui.FlexBuilder) {
+			b.Size(0, 40).Gap(2)
+			for i, name := range []string{"Day", "Week", "Month"} {
+				b.ToggleButton(func(t *widget.ToggleButtonBuilder) {
+					t.Text(name).Size(70, 0).Group(group).Selected(i == 0)
+				})
+			}
+		})
+
+		// --- TextInputのデモ ---
+		b.Label(func(l *widget.LabelBuilder) {
+			l.Text("TextInput (placeholder + clear button, masked with reveal button)")
+		})
+		b.HStack(func(b *ui.FlexBuilder) {
+			b.Size(0, 28).Gap(5)
+			b.TextInput(func(t *widget.TextInputBuilder) { t.Placeholder("Player name").ClearButton(true).Size(160, 28) })
+			b.TextInput(func(t *widget.TextInputBuilder) {
+				t.Value("secret").Masked(true).RevealButton(true).Size(200, 28).
+					OnSubmit(func(value string) { log.Printf("Submitted password of length %d", len(value)) })
+			})
+		})
+
+		// --- 入力値の検証のデモ ---
+		b.Label(func(l *widget.LabelBuilder) { l.Text("Validation (required, 1-99)") })
+		b.HStack(func(b *ui.FlexBuilder) {
+			b.Size(0, 44).Gap(5)
+			b.TextInput(func(t *widget.TextInputBuilder) {
+				t.Value("42").Size(200, 44).Validate(widget.Required("Enter a level"), widget.NumberRange(1, 99))
+			})
+		})
+
+		// --- Autocompleteのデモ ---
+		b.Label(func(l *widget.LabelBuilder) { l.Text("Autocomplete (type a fruit, arrows + Enter)") })
+		b.HStack(func(b *ui.FlexBuilder) {
+			b.Size(0, 28).Gap(5)
+			b.TextInput(func(t *widget.TextInputBuilder) {
+				t.Placeholder("Fruit").Size(200, 28).Suggestions(suggestFruits)
+			})
+		})
+
+		// --- SearchBoxのデモ ---
+		b.Label(func(l *widget.LabelBuilder) { l.Text("SearchBox (debounced, matches highlighted)") })
+		var searchResults *widget.RichLabel
+		highlight := widget.Span{Background: color.RGBA{255, 220, 80, 255}}
+		b.HStack(func(b *ui.FlexBuilder) {
+			b.Size(0, 28).Gap(5)
+			b.SearchBox(func(s *widget.SearchBoxBuilder) {
+				s.Size(180, 28).OnQueryChanged(func(query string) {
+					var spans []widget.Span
+					for _, fruit := range demoFruits {
+						if query != "" && !strings.Contains(strings.ToLower(fruit), strings.ToLower(query)) {
+							continue
+						}
+						spans = append(spans, widget.HighlightMatches(fruit, query, highlight)...)
+						spans = append(spans, widget.Span{Text: " "})
+					}
+					searchResults.SetSpans(spans...)
+				})
+			})
+			b.RichLabel(func(r *widget.RichLabelBuilder) {
+				r.Text(strings.Join(demoFruits, " ")).Flex(1).AssignTo(&searchResults)
+			})
+		})
+
+		// --- 入力マスクのデモ ---
+		b.Label(func(l *widget.LabelBuilder) { l.Text("Input masks (date, phone, hex color)") })
+		b.HStack(func(b *ui.FlexBuilder) {
+			b.Size(0, 28).Gap(5)
+			b.TextInput(func(t *widget.TextInputBuilder) { t.Mask(widget.MaskDate).Placeholder("MM/DD/YYYY").Size(120, 28) })
+			b.TextInput(func(t *widget.TextInputBuilder) { t.Mask(widget.MaskPhone).Placeholder("Phone").Size(140, 28) })
+			b.TextInput(func(t *widget.TextInputBuilder) { t.Mask(widget.MaskHexColor).Value("1E90FF").Size(100, 28) })
+		})
+
+		// --- NumberInputのデモ ---
+		b.Label(func(l *widget.LabelBuilder) { l.Text("NumberInput (0-1,000,000, step 250, arrows/wheel)") })
+		b.HStack(func(b *ui.FlexBuilder) {
+			b.Size(0, 28).Gap(5)
+			b.NumberInput(func(n *widget.NumberInputBuilder) {
+				n.Range(0, 1000000).Step(250).Decimals(2).Grouping(true).Value(12500).Size(160, 28).
+					OnValueChange(func(v float64) { log.Printf("Number changed: %.2f", v) })
+			})
 		})
 
 		// --- Spacerのデモ ---
@@ -220,6 +613,52 @@ func (g *Game) createFlexLayoutDemo() (component.Widget, error) {
 			b.Button(func(btn *widget.ButtonBuilder) { btn.Text("Right") })
 		})
 
+		// --- シェーダーのデモ ---
+		// パネル全体をグレースケールのシェーダーで描画します。ボタンでグレースケールの度合いを切り替えます。
+		b.Label(func(l *widget.LabelBuilder) { l.Text("Shader (grayscale panel, click to toggle)") })
+		if shader, err := ebiten.NewShader([]byte(grayscaleShaderSource)); err != nil {
+			log.Printf("Failed to compile grayscale shader: %v", err)
+		} else {
+			var panel *container.Container
+			gray := true
+			b.HStack(func(b *ui.FlexBuilder) {
+				b.Size(0, 40).Padding(5).Gap(5).Border(1, color.Gray{Y: 150}).
+					BackgroundColor(color.RGBA{R: 230, G: 240, B: 255, A: 255}).
+					Shader(shader, map[string]any{"Amount": float32(1)}).
+					AssignTo(&panel)
+				b.Button(func(btn *widget.ButtonBuilder) {
+					btn.Text("Toggle").AddOnClick(func(e *event.Event) event.Propagation {
+						gray = !gray
+						amount := float32(0)
+						if gray {
+							amount = 1
+						}
+						panel.SetShaderUniform("Amount", amount)
+						return event.Propagate
+					})
+				})
+				b.Label(func(l *widget.LabelBuilder) {
+					l.Text("Colorful label").TextColor(color.RGBA{R: 200, G: 40, B: 40, A: 255})
+				})
+			})
+		}
+
+		// --- Canvasのデモ ---
+		// 描画関数で波形を描きます。Canvasは要求した大きさでレイアウトに配置されます。
+		b.Label(func(l *widget.LabelBuilder) { l.Text("Canvas (custom draw callback)") })
+		b.Canvas(func(c *widget.CanvasBuilder) {
+			c.PreferredSize(300, 40).Border(1, color.Gray{Y: 150}).
+				OnDraw(func(info component.DrawInfo, bounds image.Rectangle) {
+					mid := float32(bounds.Min.Y + bounds.Dy()/2)
+					amp := float32(bounds.Dy()/2 - 4)
+					for x := bounds.Min.X + 1; x < bounds.Max.X; x++ {
+						t0 := float64(x-1-bounds.Min.X) / 20
+						t1 := float64(x-bounds.Min.X) / 20
+						vector.StrokeLine(info.Screen, float32(x-1), mid-amp*float32(math.Sin(t0)), float32(x), mid-amp*float32(math.Sin(t1)), 1, color.RGBA{R: 40, G: 120, B: 200, A: 255}, true)
+					}
+				})
+		})
+
 		// --- VStackのデモ ---
 		b.Label(func(l *widget.LabelBuilder) { l.Text("VStack (AlignItems: AlignStretch)") })
 		b.VStack(func(b *ui.FlexBuilder) {
@@ -256,7 +695,8 @@ func (g *Game) createFlexWrapDemo() (component.Widget, error) {
 		// --- AlignContent のデモ ---
 		b.Label(func(l *widget.LabelBuilder) { l.Text("HStack with Wrap(true) and AlignContent(AlignCenter)") })
 		b.HStack(func(b *ui.FlexBuilder) {
-			b.Flex(1).Padding(5).Gap(8).Border(1, color.Gray{Y: 150}).
+			// 横の間隔(4)を詰め、折り返したライン間(12)を広く取る
+			b.Flex(1).Padding(5).Gap(4, 12).Border(1, color.Gray{Y: 150}).
 				Wrap(true).                      // 折り返しを有効にする
 				AlignContent(layout.AlignCenter) // 折り返したライン全体をコンテナの中央に配置
 
@@ -286,6 +726,65 @@ func (g *Game) createWrapTextDemo() (component.Widget, error) {
 				Padding(5)
 		})
 
+		b.Label(func(l *widget.LabelBuilder) {
+			l.Text("RichLabel with colored and underlined spans")
+		})
+		b.RichLabel(func(r *widget.RichLabelBuilder) {
+			r.Span(widget.Span{Text: "The blade deals "}).
+				Span(widget.Span{Text: "+12 fire damage", Color: color.RGBA{R: 220, G: 60, A: 255}}).
+				Span(widget.Span{Text: " and has a "}).
+				Span(widget.Span{Text: "5% chance", Underline: true, Background: color.RGBA{R: 255, G: 240, B: 150, A: 255}}).
+				Span(widget.Span{Text: " to stun. Spans wrap together with the surrounding text."}).
+				WrapText(true).
+				Size(400, 0). // 幅を固定し、高さはレイアウトに任せる
+				Border(1, color.Gray{Y: 150}).
+				Padding(5)
+		})
+		b.RichLabel(func(r *widget.RichLabelBuilder) {
+			r.Markup("Markup: [color=#dc3c00]crit![/color] [u]underlined[/u] [bg=#fff096]highlight[/bg] [[escaped] [icon=sword] +12").
+				WrapText(true).
+				Size(400, 0).
+				Padding(5)
+		})
+
+		b.HStack(func(b *ui.FlexBuilder) {
+			b.Size(0, 30).Gap(5)
+			truncations := []style.TextTruncateType{style.TextTruncateEllipsis, style.TextTruncateMiddleEllipsis, style.TextTruncateFade}
+			for _, mode := range truncations {
+				b.Label(func(l *widget.LabelBuilder) {
+					l.Text("C:/Users/player/Documents/MyGame/saves/slot1.sav").
+						Truncate(mode).
+						Size(150, 30).
+						Border(1, color.Gray{Y: 150})
+				})
+			}
+		})
+
+		b.HStack(func(b *ui.FlexBuilder) {
+			b.Size(0, 30).Gap(5)
+			b.Label(func(l *widget.LabelBuilder) {
+				l.Text("Now playing: A Very Long Song Title That Does Not Fit").
+					Marquee(widget.MarqueeLoop, 40).
+					Size(200, 30).
+					Border(1, color.Gray{Y: 150})
+			})
+			b.Label(func(l *widget.LabelBuilder) {
+				l.Text("Legendary Sword of the Forgotten Kingdom").
+					Marquee(widget.MarqueeBounce, 30).
+					Size(200, 30).
+					Border(1, color.Gray{Y: 150})
+			})
+		})
+
+		b.HStack(func(b *ui.FlexBuilder) {
+			b.Size(0, 60).Gap(5)
+			for _, width := range []int{60, 120, 240} {
+				b.Label(func(l *widget.LabelBuilder) {
+					l.Text("12,345").AutoFit(8, 40).Size(width, 60).Border(1, color.Gray{Y: 150})
+				})
+			}
+		})
+
 		b.Label(func(l *widget.LabelBuilder) {
 			l.Text("Stretched Label with WrapText(true) and VerticalAlignTop")
 		})
@@ -366,6 +865,133 @@ func (g *Game) createAdvancedGridLayoutDemo() (component.Widget, error) {
 	}).Build()
 }
 
+// createFormDemo はFormのデモ用ウィジェットを生成します。
+func (g *Game) createFormDemo() (component.Widget, error) {
+	return ui.VStack(func(b *ui.FlexBuilder) {
+		b.Flex(1).Padding(10).Gap(10).Border(1, color.Gray{Y: 100})
+
+		b.Label(func(l *widget.LabelBuilder) {
+			l.Text("Form (submit is enabled once all fields are valid, Enter also submits)")
+		})
+		// 画像のないAvatarは、名前の頭文字と、名前ごとに決まる色で表示されます。
+		b.HStack(func(b *ui.FlexBuilder) {
+			b.Size(0, 40).Gap(8)
+			b.Avatar(func(a *widget.AvatarBuilder) { a.Name("Ada Lovelace").Status(widget.AvatarOnline) })
+			b.Avatar(func(a *widget.AvatarBuilder) { a.Name("Alan Turing").Status(widget.AvatarAway) })
+			b.Avatar(func(a *widget.AvatarBuilder) {
+				a.Name("Grace Hopper").Shape(widget.AvatarRoundedRect).Status(widget.AvatarBusy)
+			})
+		})
+		b.Form(func(f *ui.FormBuilder) {
+			f.Size(420, 0)
+			f.Section("Profile")
+			f.TextField("name", "Name", func(t *widget.TextInputBuilder) {
+				t.Placeholder("Player name").Validate(widget.Required("Enter a name"))
+			})
+			f.TextField("email", "Email", func(t *widget.TextInputBuilder) {
+				t.Placeholder("name@example.com").
+					Validate(widget.Required(""), widget.MatchRegexp(demoEmailPattern, "Invalid email address"))
+			})
+			f.NumberField("level", "Level", func(n *widget.NumberInputBuilder) { n.Range(1, 99).Decimals(0).Value(1) })
+			f.Section("Options")
+			f.ToggleField("news", "Newsletter", func(t *widget.ToggleButtonBuilder) { t.Text("Subscribe") })
+			f.Submit("Save", nil)
+			f.OnSubmit(func(v ui.FormValues) {
+				log.Printf("Form submitted: name=%q email=%q level=%g news=%t",
+					v.String("name"), v.String("email"), v.Float("level"), v.Bool("news"))
+			})
+		})
+		// タグの編集欄。Enterか","で入力を確定するとチップになり、"x"かBackspaceで取り除けます。
+		b.ChipInput(func(c *widget.ChipInputBuilder) {
+			c.Size(420, 28).Placeholder("Tags").Values("rpg", "fantasy").MaxChips(6).
+				Suggestions(func(q string) []string {
+					var matches []string
+					for _, tag := range []string{"action", "adventure", "fantasy", "puzzle", "rpg", "sci-fi", "strategy"} {
+						if strings.HasPrefix(tag, strings.ToLower(q)) {
+							matches = append(matches, tag)
+						}
+					}
+					return matches
+				}).
+				OnValuesChanged(func(tags []string) { log.Printf("Tags: %v", tags) })
+		})
+	}).Build()
+}
+
+// createSectionedListDemo はSectionedListのデモ用ウィジェットを生成します。
+func (g *Game) createSectionedListDemo() (component.Widget, error) {
+	return ui.VStack(func(b *ui.FlexBuilder) {
+		b.Flex(1).Padding(10).Gap(10).Border(1, color.Gray{Y: 100})
+
+		b.Label(func(l *widget.LabelBuilder) {
+			l.Text("SectionedList (click a header to collapse it, headers stick to the top while scrolling)")
+		})
+		b.SectionedList(func(s *widget.SectionedListBuilder) {
+			s.Size(300, 0).Flex(1).Border(1, color.Gray{Y: 200}).
+				StickyHeaders(true).
+				Section("Weapons", "Short Sword", "Long Sword", "Battle Axe", "Spear", "Longbow", "Crossbow").
+				Section("Armor", "Leather Cap", "Iron Helm", "Chain Mail", "Plate Armor", "Wooden Shield").
+				Section("Potions", "Healing Potion", "Mana Potion", "Antidote", "Elixir").
+				Section("Materials", "Iron Ore", "Silver Ore", "Oak Log", "Leather", "Silk Thread", "Dragon Scale").
+				Collapsed(3, true).
+				OnSelectionChanged(func(path widget.IndexPath) {
+					if path.IsValid() {
+						log.Printf("Selected: section %d, item %d", path.Section, path.Item)
+					}
+				}).
+				OnSectionToggled(func(section int, collapsed bool) {
+					log.Printf("Section %d collapsed: %t", section, collapsed)
+				})
+		})
+	}).Build()
+}
+
+// createGridViewDemo はGridViewのデモ用ウィジェットを生成します。
+// 10,000個のセルを持ちますが、生成されるセルのウィジェットは表示範囲に収まる分だけです。
+// セルの数はbinding.Listに追従し、ダブルクリックしたセルはリストから取り除かれます。
+func (g *Game) createGridViewDemo() (component.Widget, error) {
+	items := make([]int, 10000)
+	for i := range items {
+		items[i] = i + 1
+	}
+	numbers := binding.NewList(items...)
+	// 検索欄の文字列による絞り込みは、numbersを変更せずにSortFilterModelで行います。
+	view := binding.NewSortFilterModel(numbers)
+	return ui.VStack(func(b *ui.FlexBuilder) {
+		b.Flex(1).Padding(10).Gap(10).Border(1, color.Gray{Y: 100})
+
+		b.Label(func(l *widget.LabelBuilder) {
+			l.Text("GridView with 10,000 recycled cells (resize the window to change the column count, double-click to remove)")
+		})
+		b.SearchBox(func(s *widget.SearchBoxBuilder) {
+			s.Placeholder("Filter numbers").Size(200, 28).
+				OnQueryChanged(view.SetFilterText)
+		})
+		b.GridView(func(gv *widget.GridViewBuilder) {
+			gv.Flex(1).Padding(4).Border(1, color.Gray{Y: 200}).
+				CellSize(56, 40).
+				Gap(4).
+				Cells(0,
+					func() component.Widget {
+						return widget.NewLabelBuilder().
+							TextAlign(style.TextAlignCenter).
+							VerticalAlign(style.VerticalAlignMiddle).
+							BackgroundColor(color.Gray{Y: 235}).
+							MustBuild()
+					},
+					func(cell component.Widget, index int) {
+						cell.(*widget.Label).SetText(fmt.Sprint(view.ItemAt(index)))
+					}).
+				Model(view).
+				OnSelectionChanged(func(index int) { log.Printf("Grid cell selected: %d", index) }).
+				OnCellActivated(func(index int) {
+					log.Printf("Grid cell removed: %v", view.ItemAt(index))
+					numbers.RemoveAt(view.SourceIndex(index))
+				})
+		})
+	}).Build()
+}
+
 // createZStackDemo はZStack (AbsoluteLayout) のデモ用ウィジェットを生成します。
 func (g *Game) createZStackDemo() (component.Widget, error) {
 	return ui.ZStack(func(b *ui.ZStackBuilder) {
@@ -409,44 +1035,179 @@ func (g *Game) createZStackDemo() (component.Widget, error) {
 				AbsolutePosition(480, 200).
 				WrapText(true) // 折り返しを有効化
 		})
+
+		// ワールド座標に追従する名札 (WorldAnchor)。ワールドの原点はZStackの左上で、目標は円を描いて移動します。
+		var stack *container.Container
+		var angle float64
+		b.AssignTo(&stack)
+		b.WorldAnchor(
+			func(x, y float64) (float64, float64, bool) {
+				sx, sy := stack.GetPosition()
+				return float64(sx) + x, float64(sy) + y, true
+			},
+			func() (float64, float64) {
+				angle += 0.02
+				return 560 + 80*math.Cos(angle), 120 + 50*math.Sin(angle)
+			},
+			ui.From(widget.NewLabelBuilder().
+				Text("Scout").
+				BackgroundColor(color.RGBA{R: 40, G: 40, B: 40, A: 200}).
+				TextColor(color.White)),
+		)
+
+		// カメラに追従するレイヤー (Transform)。中の建物は通常どおり配置され、レイヤー全体が平行移動と拡大縮小で描画されます。
+		// ヒットテストは逆変換されるため、拡大中でもボタンをクリックできます。
+		var t float64
+		b.ZStack(func(layer *ui.ZStackBuilder) {
+			layer.Size(200, 120).AbsolutePosition(480, 280).Border(1, color.Gray{Y: 100}).
+				TrackTransform(func() container.Transform {
+					t += 0.01
+					return container.Transform{X: 20 * math.Sin(t), Y: 10 * math.Cos(t), Zoom: 1.25 + 0.25*math.Sin(t*0.7)}
+				})
+			layer.Button(func(btn *widget.ButtonBuilder) {
+				btn.Text("Town Hall").
+					Size(90, 30).
+					AbsolutePosition(20, 20).
+					AddOnClick(func(e *event.Event) event.Propagation {
+						log.Printf("Town Hall clicked at local (%d, %d)", e.X, e.Y)
+						return event.Propagate
+					})
+			})
+			layer.Label(func(l *widget.LabelBuilder) {
+				l.Text("Barracks").
+					Size(80, 24).
+					BackgroundColor(color.RGBA{R: 120, G: 90, B: 60, A: 255}).
+					TextColor(color.White).
+					AbsolutePosition(90, 70)
+			})
+		})
+	}).Build()
+}
+
+// createSafeAreaDemo はSafeAreaRootのデモ用ウィジェットを生成します。
+// ゲームの画面を16:9(ボタンで4:3に切り替え)で表示し、余った部分を帯にします。
+// 左側のノッチとテレビのオーバースキャンを想定した安全領域を設け、HUDはその隅に固定します。
+func (g *Game) createSafeAreaDemo() (component.Widget, error) {
+	game, err := ui.ZStack(func(b *ui.ZStackBuilder) {
+		b.BackgroundColor(color.RGBA{R: 40, G: 90, B: 60, A: 255}).
+			ContentAlign(layout.AlignCenter, layout.AlignCenter)
+		b.Label(func(l *widget.LabelBuilder) {
+			l.Text("Game view").TextColor(color.White)
+		})
 	}).Build()
+	if err != nil {
+		return nil, err
+	}
+	root, err := ui.NewSafeAreaRoot(game)
+	if err != nil {
+		return nil, err
+	}
+	root.SetFlex(1)
+	root.SetStyle(style.Style{Background: style.PColor(color.Black)})
+	root.SetSafeArea(layout.Insets{Left: 24})
+	root.SetOverscan(0.03)
+	root.SetAspectRatio(16, 9)
+
+	score, err := widget.NewLabelBuilder().Text("Score: 1200").TextColor(color.White).Build()
+	if err != nil {
+		return nil, err
+	}
+	root.AddAnchored(score, ui.AnchorTopLeft, 8, 8)
+
+	// 表示する縦横比をボタンで切り替えます。
+	var aspect *widget.Button
+	wide := true
+	aspect, err = widget.NewButtonBuilder().Text("16:9").Size(60, 24).
+		AddOnClick(func(e *event.Event) event.Propagation {
+			wide = !wide
+			if wide {
+				root.SetAspectRatio(16, 9)
+				aspect.SetText("16:9")
+			} else {
+				root.SetAspectRatio(4, 3)
+				aspect.SetText("4:3")
+			}
+			return event.Propagate
+		}).Build()
+	if err != nil {
+		return nil, err
+	}
+	root.AddAnchored(aspect, ui.AnchorTopRight, 8, 8)
+
+	hint, err := widget.NewLabelBuilder().Text("HUD stays in the safe-area corners").TextColor(color.White).Build()
+	if err != nil {
+		return nil, err
+	}
+	root.AddAnchored(hint, ui.AnchorBottom, 0, 8)
+	return root, nil
 }
 
 // createScrollViewDemo はScrollViewのデモ用ウィジェットを生成します。
 func (g *Game) createScrollViewDemo() (component.Widget, error) {
-	var detailTitleLabel *widget.Label
-	var detailInfoLabel *widget.Label
+	// 詳細表示用のラベルは名前で登録し、クリックハンドラから参照します。
+	refs := ui.NewRefs()
 
 	return ui.HStack(func(b *ui.FlexBuilder) {
 		b.Flex(1).Gap(10)
 
-		// 左ペイン：スクロールビュー
-		b.ScrollView(func(sv *widget.ScrollViewBuilder) {
-			sv.Size(250, 0).Flex(1).Border(1, color.Gray{Y: 200})
-
-			content, _ := ui.VStack(func(b *ui.FlexBuilder) {
-				b.Padding(8).Gap(5)
-
-				for i := 1; i <= 50; i++ {
-					itemNumber := i
-					b.Button(func(btn *widget.ButtonBuilder) {
-						btn.Text(fmt.Sprintf("Item %d", itemNumber)).
-							Size(0, 30). // 幅は親に合わせる
-							AddOnClick(func(e *event.Event) event.Propagation {
-								log.Printf("Clicked: Item %d", itemNumber)
-								if detailTitleLabel != nil {
-									detailTitleLabel.SetText(fmt.Sprintf("Details for Item %d", itemNumber))
-								}
-								if detailInfoLabel != nil {
-									detailInfoLabel.SetText(fmt.Sprintf("Here you would see more detailed information about item number %d. This text is updated dynamically when you select an item from the list. It can be quite long, so text wrapping is essential here.", itemNumber))
-								}
-								return event.Propagate
-							})
-					})
-				}
-			}).Build()
-
-			sv.Content(content)
+		// 左ペイン：選択可能なリスト (ScrollViewの上に構築されています)
+		// Ctrl+クリックで選択を切り替え、Shift+クリックで範囲選択、Ctrl+Aですべて選択できます。
+		// クリックでフォーカスした後は、矢印キーなどで移動し、Enterで確定できます。
+		// 末尾付近までスクロールすると、項目を25個ずつ追加で読み込みます。
+		const pageSize, maxItems = 25, 200
+		page := func(start int) []string {
+			items := make([]string, pageSize)
+			for i := range items {
+				items[i] = fmt.Sprintf("Item %d", start+i+1)
+			}
+			return items
+		}
+		items := page(0)
+		var list *widget.List
+		b.List(func(l *widget.ListBuilder) {
+			l.Size(250, 0).Flex(1).Border(1, color.Gray{Y: 200}).
+				Items(items...).
+				SelectionMode(widget.SelectionMultiple).
+				Reorderable(true).
+				DragHandles(true).
+				AssignTo(&list).
+				OnActivate(func(index int) { log.Printf("Activated: Item %d", index+1) }).
+				OnReorder(func(from, to int) { log.Printf("Reordered: %d -> %d", from, to) }).
+				RowActions(
+					widget.ListAction{ID: "edit", Text: "Edit"},
+					widget.ListAction{ID: "delete", Text: "Delete", Color: color.RGBA{R: 200, G: 40, B: 40, A: 255}},
+				).
+				OnRowAction(func(index int, action string) {
+					log.Printf("Row action %q on item %d", action, index)
+					if action == "delete" {
+						list.RemoveItem(index)
+					}
+				}).
+				OnNearEndItems(5, func() {
+					if n := list.Len(); n < maxItems {
+						log.Printf("Loading items %d-%d", n+1, n+pageSize)
+						if err := list.AppendItems(page(n)); err != nil {
+							log.Printf("Failed to load items: %v", err)
+						}
+					}
+				}).
+				OnSelectionChanged(func(index int) {
+					if index < 0 {
+						return
+					}
+					itemNumber := index + 1
+					log.Printf("Selected: Item %d", itemNumber)
+					if detailTitleLabel := refs.Label("detail.title"); detailTitleLabel != nil {
+						if n := len(list.SelectedIndices()); n > 1 {
+							detailTitleLabel.SetText(fmt.Sprintf("Details for Item %d (%d selected)", itemNumber, n))
+						} else {
+							detailTitleLabel.SetText(fmt.Sprintf("Details for Item %d", itemNumber))
+						}
+					}
+					if detailInfoLabel := refs.Label("detail.info"); detailInfoLabel != nil {
+						detailInfoLabel.SetText(fmt.Sprintf("Here you would see more detailed information about item number %d. This text is updated dynamically when you select an item from the list. It can be quite long, so text wrapping is essential here.", itemNumber))
+					}
+				})
 		})
 
 		// 右ペイン：詳細表示エリア
@@ -458,7 +1219,9 @@ func (g *Game) createScrollViewDemo() (component.Widget, error) {
 					Size(0, 30).
 					TextColor(color.White).
 					BackgroundColor(theme.GetCurrent().PrimaryColor).
-					AssignTo(&detailTitleLabel)
+					// 選択した項目が変わるたびに、見出しの内容を支援技術に伝えます。
+					AccessibleLive(component.LivePolite).
+					Ref(refs, "detail.title")
 			})
 
 			b.Label(func(l *widget.LabelBuilder) {
@@ -467,8 +1230,25 @@ func (g *Game) createScrollViewDemo() (component.Widget, error) {
 					TextAlign(style.TextAlignLeft).
 					VerticalAlign(style.VerticalAlignTop).
 					WrapText(true). // 詳細テキストの折り返しを有効化
-					AssignTo(&detailInfoLabel)
+					Ref(refs, "detail.info")
 			})
 		})
 	}).Build()
-}
\ No newline at end of file
+}
+
+// demoFruits は、Autocompleteのデモで候補として使用する単語です。
+var demoFruits = []string{"Apple", "Apricot", "Avocado", "Banana", "Blackberry", "Blueberry", "Cherry", "Coconut", "Grape", "Grapefruit", "Lemon", "Lime", "Mango", "Melon", "Orange", "Peach", "Pear", "Pineapple", "Plum", "Strawberry"}
+
+// demoEmailPattern は、Formのデモで使用する簡易的なメールアドレスの形式です。
+var demoEmailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// suggestFruits は、入力された文字列で始まる果物の名前を返します。
+func suggestFruits(query string) []string {
+	var matches []string
+	for _, fruit := range demoFruits {
+		if strings.HasPrefix(strings.ToLower(fruit), strings.ToLower(query)) {
+			matches = append(matches, fruit)
+		}
+	}
+	return matches
+}
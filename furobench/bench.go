@@ -0,0 +1,114 @@
+package furobench
+
+import (
+	"furoshiki/component"
+	"furoshiki/container"
+	"furoshiki/stats"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Run は、各ツリーについて Measure、Arrange、Draw をサブベンチマーク("<ツリー名>/Measure"など)として実行します。
+func Run(b *testing.B, trees ...*Tree) {
+	for _, t := range trees {
+		b.Run(t.Name, func(b *testing.B) {
+			b.Run("Measure", func(b *testing.B) { Measure(b, t) })
+			b.Run("Arrange", func(b *testing.B) { Arrange(b, t) })
+			b.Run("Draw", func(b *testing.B) { Draw(b, t) })
+		})
+	}
+}
+
+// Measure は、すべてのウィジェットの計測結果のキャッシュを破棄した状態からのレイアウトを繰り返し計測します。
+// テキストの変更やテーマの切り替えの直後のように、ツリー全体を計測し直す場合の性能に相当します。
+// キャッシュの破棄にかかる時間は計測に含まれません。
+func Measure(b *testing.B, t *Tree) {
+	root, widgets := setup(b, t)
+	runMeasured(b, func() {
+		for range b.N {
+			b.StopTimer()
+			for _, w := range widgets {
+				if mi, ok := w.(component.MeasureInvalidator); ok {
+					mi.InvalidateMeasure()
+				}
+				w.MarkDirty(true)
+			}
+			b.StartTimer()
+			root.Update()
+		}
+	})
+}
+
+// Arrange は、計測結果のキャッシュが有効な状態で、すべてのコンテナの再レイアウトを繰り返し計測します。
+// ウィンドウのリサイズのように、内容は変わらずに配置だけをやり直す場合の性能に相当します。
+func Arrange(b *testing.B, t *Tree) {
+	root, widgets := setup(b, t)
+	containers := make([]component.Widget, 0, len(widgets))
+	for _, w := range widgets {
+		if _, ok := w.(component.Container); ok {
+			containers = append(containers, w)
+		}
+	}
+	runMeasured(b, func() {
+		for range b.N {
+			b.StopTimer()
+			for _, c := range containers {
+				c.MarkDirty(true)
+			}
+			b.StartTimer()
+			root.Update()
+		}
+	})
+}
+
+// Draw は、レイアウトが確定したツリーのオフスクリーン画像への描画を繰り返し計測します。
+// 描画命令はフレームの終わりにGPUへ送られるため、計測されるのは描画命令の発行と描画ヘルパーのバッチ処理にかかる時間です。
+func Draw(b *testing.B, t *Tree) {
+	root, _ := setup(b, t)
+	screen := ebiten.NewImage(t.Width, t.Height)
+	defer screen.Deallocate()
+	runMeasured(b, func() {
+		for range b.N {
+			screen.Clear()
+			component.DrawWidget(root, component.DrawInfo{Screen: screen})
+			component.FlushDraws()
+		}
+	})
+}
+
+// setup は、ツリーを構築してレイアウトを確定させ、ルートとすべてのウィジェットを返します。
+func setup(b *testing.B, t *Tree) (*container.Container, []component.Widget) {
+	b.Helper()
+	root, err := t.Build()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(root.Cleanup)
+	root.Update()
+
+	var widgets []component.Widget
+	component.Walk(root, func(w component.Widget, depth int) component.WalkResult {
+		widgets = append(widgets, w)
+		return component.WalkContinue
+	})
+	b.ReportMetric(float64(len(widgets)), "widgets")
+	return root, widgets
+}
+
+// runMeasured は、パフォーマンスカウンタを有効にしてfnを実行し、1回あたりのカウンタの値を報告します。
+func runMeasured(b *testing.B, fn func()) {
+	enabled := stats.Enabled()
+	stats.SetEnabled(true)
+	defer stats.SetEnabled(enabled)
+
+	stats.NextFrame()
+	b.ResetTimer()
+	fn()
+	b.StopTimer()
+	s := stats.Current()
+	n := float64(b.N)
+	b.ReportMetric(float64(s.WidgetsMeasured)/n, "measured/op")
+	b.ReportMetric(float64(s.WidgetsArranged)/n, "arranged/op")
+	b.ReportMetric(float64(s.DrawCalls)/n, "draws/op")
+}
@@ -0,0 +1,123 @@
+// Package furobench は、レイアウトと描画の性能を計測するためのベンチマーク用のツリーと、その計測関数を提供します。
+// 大きさを指定して生成できる典型的なツリー(折り返すHStackに並んだ多数のボタン、深い入れ子、大きなグリッド)に対して、
+// 計測(Measure)、配置(Arrange)、描画(Draw)をそれぞれ繰り返し実行し、FlexLayoutや描画ヘルパーへの変更の効果を数値で比較できるようにします。
+//
+// 描画の計測にはEbitengineのゲームループが動作している必要があるため、furotestと同様に TestMain から
+// furotest.MainWithRunLoop を呼び出してください。
+//
+//	func TestMain(m *testing.M) {
+//		furotest.MainWithRunLoop(m)
+//	}
+//
+//	func BenchmarkLayouts(b *testing.B) {
+//		furobench.Run(b, furobench.DefaultTrees()...)
+//	}
+//
+// 結果は go test -bench . -benchmem で確認できます。標準の ns/op などに加えて、
+// 1回あたりに計測・配置されたウィジェットの数(measured/op, arranged/op)と描画命令の数(draws/op)も報告されます。
+package furobench
+
+import (
+	"fmt"
+	"furoshiki/container"
+	"furoshiki/ui"
+	"furoshiki/widget"
+)
+
+const (
+	// defaultWidth, defaultHeight は、組み込みのツリーのルートの大きさです。
+	defaultWidth  = 1280
+	defaultHeight = 720
+)
+
+// Tree は、ベンチマークの対象とするウィジェットツリーの定義です。
+// ツリーは計測関数の中でベンチマークごとに新しく構築されるため、同じTreeを複数のベンチマークで使用できます。
+type Tree struct {
+	// Name は、サブベンチマークの名前に使われるツリーの名前です。
+	Name string
+	// Width, Height は、ルートに設定する大きさと、描画先の画像の大きさです。
+	Width, Height int
+	build         func() (*container.Container, error)
+}
+
+// NewTree は、buildが構築するツリーをベンチマークの対象とするTreeを生成します。
+// 組み込みのツリー以外の、アプリケーション固有の画面を計測する場合に使用します。
+func NewTree(name string, width, height int, build func() (*container.Container, error)) *Tree {
+	return &Tree{Name: name, Width: width, Height: height, build: build}
+}
+
+// Build は、ツリーを新しく構築し、ルートの位置と大きさを設定して返します。
+func (t *Tree) Build() (*container.Container, error) {
+	root, err := t.build()
+	if err != nil {
+		return nil, fmt.Errorf("furobench: failed to build %s: %w", t.Name, err)
+	}
+	root.SetPosition(0, 0)
+	root.SetSize(t.Width, t.Height)
+	return root, nil
+}
+
+// WrappedButtons は、折り返しが有効なHStackにn個のボタンを並べたツリーを返します。
+// FlexLayoutの折り返しと、多数の兄弟要素の計測の性能を計測します。
+func WrappedButtons(n int) *Tree {
+	return NewTree(fmt.Sprintf("WrappedButtons%d", n), defaultWidth, defaultHeight, func() (*container.Container, error) {
+		return ui.HStack(func(b *ui.FlexBuilder) {
+			b.Wrap(true).Gap(4).Padding(8)
+			for i := range n {
+				b.Button(func(b *widget.ButtonBuilder) {
+					b.Text(fmt.Sprintf("Button %d", i))
+				})
+			}
+		}).Build()
+	})
+}
+
+// DeepNesting は、depth段に入れ子にしたVStackの各段にラベルを置いたツリーを返します。
+// 再レイアウトの伝播と、入れ子になったコンテナの計測の性能を計測します。
+func DeepNesting(depth int) *Tree {
+	return NewTree(fmt.Sprintf("DeepNesting%d", depth), defaultWidth, defaultHeight, func() (*container.Container, error) {
+		return ui.VStack(func(b *ui.FlexBuilder) {
+			nest(b, depth)
+		}).Build()
+	})
+}
+
+// nest は、bにラベルと、残りの段数の入れ子のVStackを追加します。
+func nest(b *ui.FlexBuilder, depth int) {
+	b.Padding(1).Gap(1)
+	b.Label(func(b *widget.LabelBuilder) {
+		b.Text(fmt.Sprintf("Level %d", depth))
+	})
+	if depth > 1 {
+		b.VStack(func(b *ui.FlexBuilder) {
+			b.Flex(1)
+			nest(b, depth-1)
+		})
+	}
+}
+
+// BigGrid は、rows行cols列のGridLayoutの各セルにラベルを置いたツリーを返します。
+// 大量の子を持つコンテナの配置と描画の性能を計測します。
+func BigGrid(rows, cols int) *Tree {
+	return NewTree(fmt.Sprintf("BigGrid%dx%d", rows, cols), defaultWidth, defaultHeight, func() (*container.Container, error) {
+		return ui.Grid(func(b *ui.GridBuilder) {
+			b.Columns(cols).Rows(rows).HorizontalGap(1).VerticalGap(1)
+			for i := range rows * cols {
+				b.Label(func(b *widget.LabelBuilder) {
+					b.Text(fmt.Sprintf("%d", i))
+				})
+			}
+		}).Build()
+	})
+}
+
+// DefaultTrees は、組み込みのツリーを代表的な大きさで生成して返します。
+func DefaultTrees() []*Tree {
+	return []*Tree{
+		WrappedButtons(100),
+		WrappedButtons(1000),
+		DeepNesting(32),
+		DeepNesting(128),
+		BigGrid(30, 30),
+	}
+}
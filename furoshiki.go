@@ -0,0 +1,43 @@
+// Package furoshiki は、ライブラリ全体に関わる横断的な機能（統計情報など）への入り口を提供します。
+// UIの構築には ui, widget パッケージを、個々の機能にはそれぞれのパッケージを使用してください。
+package furoshiki
+
+import (
+	"furoshiki/clock"
+	"furoshiki/component"
+	"furoshiki/stats"
+)
+
+// EnableStats は、パフォーマンスカウンタの収集を有効または無効にします。
+// 無効な間はカウンタが加算されず、Stats はゼロ値に近い結果を返します。
+func EnableStats(on bool) {
+	stats.SetEnabled(on)
+}
+
+// NextFrame は、フレームの区切りをライブラリに通知します。
+// 統計情報や決定的モードを使用する場合は、ebiten.Game の Update の先頭で毎フレーム呼び出してください。
+func NextFrame() {
+	stats.NextFrame()
+	clock.NextFrame()
+}
+
+// SetDeterministic は、実行のたびに結果が変わる要因を取り除く決定的モードを有効または無効にします。
+// 有効な間は、イベントのタイムスタンプなどに使われる時刻が、NextFrameごとに一定の間隔で進む仮想の時刻になり、
+// キャレットの点滅などのアニメーションは行われません。ゴールデンテストや、記録したイベントの再生に使用します。
+// 詳しくは clock パッケージを参照してください。
+func SetDeterministic(on bool) {
+	clock.SetDeterministic(on)
+}
+
+// Stats は、直前に完了したフレームのパフォーマンスカウンタを返します。
+// レイアウト計算の回数、計測・配置されたウィジェット数、描画命令数、
+// オフスクリーン画像の確保数と使用量、イベントディスパッチ時間が含まれます。
+func Stats() stats.FrameStats {
+	return stats.LastFrame()
+}
+
+// SetOffscreenBudget は、ライブラリが保持するオフスクリーン画像の使用量の予算をバイト単位で設定します。0以下の場合は無制限です。
+// 予算を超えると、最も長く描画されていない描画キャッシュから解放されます。詳しくは component.SetOffscreenBudget を参照してください。
+func SetOffscreenBudget(bytes int64) {
+	component.SetOffscreenBudget(bytes)
+}
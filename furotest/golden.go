@@ -0,0 +1,151 @@
+package furotest
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// UpdateGoldenEnv は、設定されているとAssertGoldenがゴールデン画像を比較せずに上書きする環境変数の名前です。
+// 例: FUROSHIKI_UPDATE_GOLDEN=1 go test ./...
+const UpdateGoldenEnv = "FUROSHIKI_UPDATE_GOLDEN"
+
+// ErrSizeMismatch は、比較する2つの画像のサイズが異なる場合に返されます。
+var ErrSizeMismatch = errors.New("image sizes differ")
+
+// CompareOptions は、画像比較の許容範囲を指定します。
+type CompareOptions struct {
+	// Tolerance は、ピクセルを「一致」とみなすチャンネルごとの最大差分(0-255)です。
+	// アンチエイリアスの微小な差異を吸収するために使用します。
+	Tolerance uint8
+	// MaxDiffPixels は、許容される不一致ピクセル数の上限です。
+	MaxDiffPixels int
+}
+
+// DiffResult は、画像比較の結果を保持します。
+type DiffResult struct {
+	// DiffPixels は、許容範囲を超えて異なっていたピクセルの数です。
+	DiffPixels int
+	// MaxDelta は、観測されたチャンネルごとの差分の最大値です。
+	MaxDelta uint8
+	// DiffImage は、不一致ピクセルを赤で、一致ピクセルを薄く表示した差分画像です。
+	DiffImage *image.RGBA
+}
+
+// Passed は、比較結果が指定された許容範囲に収まっているかを返します。
+func (r DiffResult) Passed(opts CompareOptions) bool {
+	return r.DiffPixels <= opts.MaxDiffPixels
+}
+
+// Compare は、2つの画像をピクセル単位で比較します。
+// 画像のサイズが異なる場合は ErrSizeMismatch を返します。
+func Compare(got, want image.Image, opts CompareOptions) (DiffResult, error) {
+	gb, wb := got.Bounds(), want.Bounds()
+	if gb.Dx() != wb.Dx() || gb.Dy() != wb.Dy() {
+		return DiffResult{}, fmt.Errorf("%w: got %dx%d, want %dx%d", ErrSizeMismatch, gb.Dx(), gb.Dy(), wb.Dx(), wb.Dy())
+	}
+
+	result := DiffResult{DiffImage: image.NewRGBA(image.Rect(0, 0, gb.Dx(), gb.Dy()))}
+	for y := 0; y < gb.Dy(); y++ {
+		for x := 0; x < gb.Dx(); x++ {
+			g := color.NRGBAModel.Convert(got.At(gb.Min.X+x, gb.Min.Y+y)).(color.NRGBA)
+			w := color.NRGBAModel.Convert(want.At(wb.Min.X+x, wb.Min.Y+y)).(color.NRGBA)
+			delta := max(absDiff(g.R, w.R), absDiff(g.G, w.G), absDiff(g.B, w.B), absDiff(g.A, w.A))
+			result.MaxDelta = max(result.MaxDelta, delta)
+			if delta > opts.Tolerance {
+				result.DiffPixels++
+				result.DiffImage.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				// 一致したピクセルは、差分箇所が目立つようにグレースケールで薄く表示します。
+				gray := uint8((uint16(g.R) + uint16(g.G) + uint16(g.B)) / 3 / 4)
+				result.DiffImage.Set(x, y, color.RGBA{R: gray, G: gray, B: gray, A: 255})
+			}
+		}
+	}
+	return result, nil
+}
+
+// SavePNG は、画像をPNG形式で指定されたパスに保存します。必要に応じてディレクトリを作成します。
+// *ebiten.Image を渡す場合は、ゲームループが動作している必要があります。
+func SavePNG(img image.Image, path string) error {
+	if ebitenImg, ok := img.(*ebiten.Image); ok {
+		img = ToRGBA(ebitenImg)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadPNG は、指定されたパスからPNG画像を読み込みます。
+func LoadPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+// AssertGolden は、画像を goldenPath に保存されたゴールデン画像と比較し、
+// 許容範囲を超える差異があればテストを失敗させます。
+// 失敗時には、調査用に "<name>.actual.png" と "<name>.diff.png" をゴールデン画像の隣に書き出します。
+// 環境変数 UpdateGoldenEnv が設定されている場合、またはゴールデン画像が存在しない場合は、
+// 比較を行わずにゴールデン画像を（再）生成します。
+func AssertGolden(tb testing.TB, img image.Image, goldenPath string, opts CompareOptions) {
+	tb.Helper()
+	if ebitenImg, ok := img.(*ebiten.Image); ok {
+		img = ToRGBA(ebitenImg)
+	}
+
+	_, statErr := os.Stat(goldenPath)
+	if os.Getenv(UpdateGoldenEnv) != "" || errors.Is(statErr, os.ErrNotExist) {
+		if err := SavePNG(img, goldenPath); err != nil {
+			tb.Fatalf("furotest: failed to write golden %s: %v", goldenPath, err)
+		}
+		tb.Logf("furotest: wrote golden %s", goldenPath)
+		return
+	}
+
+	want, err := LoadPNG(goldenPath)
+	if err != nil {
+		tb.Fatalf("furotest: failed to load golden %s: %v", goldenPath, err)
+	}
+
+	result, err := Compare(img, want, opts)
+	base := strings.TrimSuffix(goldenPath, filepath.Ext(goldenPath))
+	if err != nil {
+		_ = SavePNG(img, base+".actual.png")
+		tb.Fatalf("furotest: %s: %v", goldenPath, err)
+	}
+	if !result.Passed(opts) {
+		_ = SavePNG(img, base+".actual.png")
+		_ = SavePNG(result.DiffImage, base+".diff.png")
+		tb.Errorf("furotest: %s: %d pixels differ (max channel delta %d, tolerance %d, allowed %d)",
+			goldenPath, result.DiffPixels, result.MaxDelta, opts.Tolerance, opts.MaxDiffPixels)
+	}
+}
+
+// absDiff は、2つのuint8値の差の絶対値を返します。
+func absDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
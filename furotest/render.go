@@ -0,0 +1,119 @@
+// Package furotest は、Furoshikiで構築したUIのテストを支援するユーティリティを提供します。
+// ウィジェットツリーをオフスクリーン画像に描画し、PNGとして保存済みのゴールデン画像と
+// ピクセル単位で比較することで、レイアウトやスタイルの回帰を自動的に検出できます。
+//
+// Ebitengineの画像のピクセルを読み出すにはゲームループが動作している必要があるため、
+// テストパッケージの TestMain から MainWithRunLoop を呼び出してください。
+//
+//	func TestMain(m *testing.M) {
+//		furotest.MainWithRunLoop(m)
+//	}
+//
+//	func TestSettingsPanel(t *testing.T) {
+//		furotest.UseDeterministic(t)
+//		root, _ := ui.VStack(...).Build()
+//		img := furotest.Render(root, 400, 300)
+//		furotest.AssertGolden(t, img, "testdata/settings_panel.png", furotest.CompareOptions{Tolerance: 2})
+//	}
+package furotest
+
+import (
+	"furoshiki/clock"
+	"furoshiki/component"
+	"image"
+	"os"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// maxSettlePasses は、Renderがレイアウトの収束を待つために実行するUpdateの最大回数です。
+// ScrollViewのように、一度のUpdateでは最終的な配置が確定しないウィジェットに対応するためです。
+const maxSettlePasses = 8
+
+// Render は、ウィジェットツリーを width x height のオフスクリーン画像に描画して返します。
+// ルートウィジェットのサイズが未設定(0x0)の場合は、画像と同じサイズが設定されます。
+// 描画前に、ダーティ状態が解消されるまで（最大 maxSettlePasses 回）Updateを繰り返し、
+// レイアウトを確定させます。
+func Render(root component.Widget, width, height int) *ebiten.Image {
+	if ps, ok := root.(component.PositionSetter); ok {
+		ps.SetPosition(0, 0)
+	}
+	if ss, ok := root.(component.SizeSetter); ok {
+		if w, h := ss.GetSize(); w == 0 && h == 0 {
+			ss.SetSize(width, height)
+		}
+	}
+	settle(root)
+
+	// 実際のフレームやSnapshotと同じ結果になるように、描画キャッシュ、シェーダー、描画フック、バッジを考慮して描画します。
+	img := ebiten.NewImage(width, height)
+	component.FlushDraws()
+	component.DrawWidget(root, component.DrawInfo{Screen: img})
+	component.FlushDraws()
+	return img
+}
+
+// UseDeterministic は、テストの間だけ決定的モード(clock.SetDeterministic)を有効にし、テストの終了時に元に戻します。
+// 仮想の時刻は起点に戻るため、同じ操作を行うテストは、実行のたびに同じタイムスタンプのイベントと同じ描画結果を得ます。
+func UseDeterministic(tb testing.TB) {
+	tb.Helper()
+	prev := clock.IsDeterministic()
+	clock.SetDeterministic(true)
+	tb.Cleanup(func() { clock.SetDeterministic(prev) })
+}
+
+// settle は、ルートのレイアウトが収束するまでUpdateを繰り返します。
+func settle(root component.Widget) {
+	for i := 0; i < maxSettlePasses; i++ {
+		root.Update()
+		if !root.NeedsRelayout() {
+			return
+		}
+	}
+}
+
+// ToRGBA は、Ebitengineの画像のピクセルを読み出して *image.RGBA に変換します。
+// ゲームループが動作している必要があります（MainWithRunLoop を参照）。
+func ToRGBA(img *ebiten.Image) *image.RGBA {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	img.ReadPixels(rgba.Pix)
+	return rgba
+}
+
+// runLoopGame は、テストの実行中にゲームループを回し続けるための最小限のebiten.Gameです。
+type runLoopGame struct {
+	done chan struct{}
+}
+
+func (g *runLoopGame) Update() error {
+	select {
+	case <-g.done:
+		return ebiten.Termination
+	default:
+		return nil
+	}
+}
+
+func (g *runLoopGame) Draw(screen *ebiten.Image) {}
+
+func (g *runLoopGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return 1, 1
+}
+
+// MainWithRunLoop は、Ebitengineのゲームループを動作させた状態でテストを実行し、
+// その終了コードでプロセスを終了します。TestMain から呼び出してください。
+func MainWithRunLoop(m *testing.M) {
+	g := &runLoopGame{done: make(chan struct{})}
+	code := 0
+	go func() {
+		code = m.Run()
+		close(g.done)
+	}()
+	ebiten.SetWindowSize(1, 1)
+	if err := ebiten.RunGameWithOptions(g, &ebiten.RunGameOptions{InitUnfocused: true}); err != nil {
+		panic(err)
+	}
+	os.Exit(code)
+}
@@ -0,0 +1,215 @@
+// Package i18n は、UIの文字列をロケールごとのメッセージカタログから解決する機能を提供します。
+//
+//	i18n.Register("en", i18n.Messages{"menu.start": "Start", "score": "Score: {points}"})
+//	i18n.Register("ja", i18n.Messages{"menu.start": "スタート", "score": "スコア: {points}"})
+//	i18n.SetLocale("ja")
+//
+//	i18n.T("menu.start")                           // "スタート"
+//	i18n.T("score", i18n.Params{"points": 1200})   // "スコア: 1200"
+//	widget.NewLabelBuilder().TextKey("menu.start") // 現在のロケールで解決したテキストを表示します
+//
+// メッセージは、現在のロケール、その言語部分("ja-JP"なら"ja")、フォールバックのロケールの順に探します。
+// どのカタログにも見つからない場合はキーそのものを返すため、翻訳漏れを画面上で見つけられます。
+// UIの更新はゲームループ(単一のゴルーチン)から行われることを前提としていますが、
+// カタログの登録を読み込み用のゴルーチンから行えるよう、内部の状態はミューテックスで保護しています。
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Messages は、キーとメッセージの組です。メッセージには {name} の形式でパラメータを埋め込めます。
+type Messages map[string]string
+
+// Params は、メッセージに埋め込むパラメータの名前と値です。値はfmt.Sprintの形式で文字列に変換されます。
+type Params map[string]any
+
+// DefaultFallbackLocale は、フォールバックのロケールの初期値です。
+const DefaultFallbackLocale = "en"
+
+var (
+	mutex    sync.RWMutex
+	catalogs = map[string]Messages{}
+	locale   = DefaultFallbackLocale
+	fallback = DefaultFallbackLocale
+	// listeners は、ロケールが変わったときに呼び出される関数です。
+	listeners []func(locale string)
+)
+
+// Register は、localeのカタログにメッセージを追加します。同じキーがすでにある場合は上書きします。
+func Register(locale string, messages Messages) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	c, ok := catalogs[locale]
+	if !ok {
+		c = make(Messages, len(messages))
+		catalogs[locale] = c
+	}
+	for k, v := range messages {
+		c[k] = v
+	}
+}
+
+// LoadJSON は、JSONで記述されたメッセージをlocaleのカタログに追加します。
+// 入れ子のオブジェクトは、キーをドットで連結して平坦化されます({"menu": {"start": "Start"}} は "menu.start" になります)。
+func LoadJSON(locale string, data []byte) error {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("i18n: failed to parse messages for %q: %w", locale, err)
+	}
+	messages := Messages{}
+	if err := flatten("", raw, messages); err != nil {
+		return fmt.Errorf("i18n: invalid messages for %q: %w", locale, err)
+	}
+	Register(locale, messages)
+	return nil
+}
+
+// flatten は、入れ子のオブジェクトをドットで連結したキーのメッセージに変換します。
+func flatten(prefix string, raw map[string]any, dst Messages) error {
+	for k, v := range raw {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch v := v.(type) {
+		case string:
+			dst[key] = v
+		case map[string]any:
+			if err := flatten(key, v, dst); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("value of %q must be a string or an object", key)
+		}
+	}
+	return nil
+}
+
+// SetLocale は、現在のロケール("en", "ja-JP"など)を設定します。
+// ロケールが変わった場合は、OnLocaleChangeで登録された関数を呼び出します。
+// TextKeyでテキストを設定したウィジェットは、これによって新しいロケールのテキストに更新され、再レイアウトされます。
+func SetLocale(l string) {
+	mutex.Lock()
+	if locale == l {
+		mutex.Unlock()
+		return
+	}
+	locale = l
+	fns := slices.Clone(listeners)
+	mutex.Unlock()
+	for _, fn := range fns {
+		fn(l)
+	}
+}
+
+// OnLocaleChange は、ロケールが変わったときに呼び出される関数を登録します。
+// 関数は、SetLocaleを呼び出したゴルーチンで、新しいロケールを引数に呼び出されます。
+func OnLocaleChange(fn func(locale string)) {
+	if fn == nil {
+		return
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	listeners = append(listeners, fn)
+}
+
+// Locale は、現在のロケールを返します。
+func Locale() string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return locale
+}
+
+// SetFallbackLocale は、現在のロケールにメッセージがない場合に使用するロケールを設定します。
+func SetFallbackLocale(l string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	fallback = l
+}
+
+// Locales は、カタログが登録されているロケールを返します。順序は不定です。
+func Locales() []string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	locales := make([]string, 0, len(catalogs))
+	for l := range catalogs {
+		locales = append(locales, l)
+	}
+	return locales
+}
+
+// Has は、現在のロケールまたはフォールバックのロケールに、keyのメッセージがあるかどうかを返します。
+func Has(key string) bool {
+	_, ok := lookup(key)
+	return ok
+}
+
+// T は、keyのメッセージを現在のロケールで解決し、paramsを埋め込んで返します。
+// メッセージが見つからない場合はkeyを返します。
+func T(key string, params ...Params) string {
+	msg, ok := lookup(key)
+	if !ok {
+		msg = key
+	}
+	for _, p := range params {
+		msg = interpolate(msg, p)
+	}
+	return msg
+}
+
+// lookup は、現在のロケール、その言語部分、フォールバックのロケールの順にメッセージを探します。
+func lookup(key string) (string, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	for _, l := range candidates(locale, fallback) {
+		if msg, ok := catalogs[l][key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// candidates は、メッセージを探すロケールを優先順に返します。
+func candidates(locale, fallback string) []string {
+	list := []string{locale}
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		list = append(list, locale[:i])
+	}
+	if fallback != "" && fallback != locale {
+		list = append(list, fallback)
+	}
+	return list
+}
+
+// interpolate は、msgの中の {name} をparamsの値で置き換えます。paramsにない名前はそのまま残します。
+func interpolate(msg string, params Params) string {
+	if len(params) == 0 || !strings.Contains(msg, "{") {
+		return msg
+	}
+	var sb strings.Builder
+	for {
+		start := strings.IndexByte(msg, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(msg[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		sb.WriteString(msg[:start])
+		if v, ok := params[msg[start+1:end]]; ok {
+			fmt.Fprint(&sb, v)
+		} else {
+			sb.WriteString(msg[start : end+1])
+		}
+		msg = msg[end+1:]
+	}
+	sb.WriteString(msg)
+	return sb.String()
+}
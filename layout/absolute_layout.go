@@ -1,15 +1,32 @@
 package layout
 
-import "furoshiki/component"
+import (
+	"furoshiki/component"
+	"furoshiki/stats"
+)
 
 // AbsoluteLayout は、子要素をコンテナ内の指定された相対座標に基づいて配置します。
-type AbsoluteLayout struct{}
+type AbsoluteLayout struct {
+	// ContentAlign は、子要素群全体（各子要素の要求位置と大きさを包む矩形）をコンテナ内のどこに寄せるかを指定します。
+	ContentAlign ContentAlignment
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ ContentAligner = (*AbsoluteLayout)(nil)
+
+// SetContentAlignment は、子要素群全体の揃え位置を設定します。
+func (l *AbsoluteLayout) SetContentAlignment(a ContentAlignment) { l.ContentAlign = a }
+
+// GetContentAlignment は、子要素群全体の揃え位置を返します。
+func (l *AbsoluteLayout) GetContentAlignment() ContentAlignment { return l.ContentAlign }
 
 // Layout は AbsoluteLayout のレイアウトロジックを実装します。
 // NOTE: Layoutインターフェースの変更に伴い、errorを返すようにシグネチャが更新されました。
 func (l *AbsoluteLayout) Layout(container Container) error {
+	traceLayout(container)
 	containerX, containerY := container.GetPosition()
 	padding := container.GetPadding()
+	offsetX, offsetY := l.contentOffset(container)
 
 	for _, child := range container.GetChildren() {
 		// 【提案1】型アサーションの追加: 可視状態はWidgetインターフェースではなく
@@ -29,14 +46,41 @@ func (l *AbsoluteLayout) Layout(container Container) error {
 			requestedX, requestedY = pr.GetRequestedPosition()
 		}
 
-		finalX := containerX + padding.Left + requestedX
-		finalY := containerY + padding.Top + requestedY
+		finalX := containerX + padding.Left + offsetX + requestedX
+		finalY := containerY + padding.Top + offsetY + requestedY
 
 		// 【提案1】型アサーションの追加: SetPositionはPositionSetterインターフェースが持つため、
 		// 型アサーションを行い、実装しているウィジェットのみ位置を設定します。
 		if ps, ok := child.(component.PositionSetter); ok {
 			ps.SetPosition(finalX, finalY)
 		}
+		traceArrange(child)
+		stats.AddArranged(1)
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// contentOffset は、ContentAlignに基づいて子要素群全体に加えるオフセットを計算します。
+// 子要素群の大きさは、各子要素の要求位置と現在のサイズから求めた右端・下端の最大値です。
+func (l *AbsoluteLayout) contentOffset(container Container) (int, int) {
+	if l.ContentAlign == (ContentAlignment{}) {
+		return 0, 0
+	}
+	var extentX, extentY int
+	for _, child := range getVisibleChildren(container) {
+		var x, y, w, h int
+		if pr, ok := child.(component.AbsolutePositioner); ok {
+			x, y = pr.GetRequestedPosition()
+		}
+		if ss, ok := child.(component.SizeSetter); ok {
+			w, h = ss.GetSize()
+		}
+		extentX = max(extentX, x+w)
+		extentY = max(extentY, y+h)
+	}
+	padding := container.GetPadding()
+	width, height := container.GetSize()
+	availableWidth := width - padding.Left - padding.Right
+	availableHeight := height - padding.Top - padding.Bottom
+	return l.ContentAlign.offsets(availableWidth-extentX, availableHeight-extentY)
+}
@@ -2,6 +2,7 @@ package layout
 
 import (
 	"furoshiki/component"
+	"furoshiki/stats"
 	"furoshiki/utils"
 	"math"
 )
@@ -16,6 +17,11 @@ const (
 	TrackSizingFixed TrackSizing = iota
 	// TrackSizingWeighted は、利用可能な残りのスペースを重みに応じて分配します。
 	TrackSizingWeighted
+	// TrackSizingAuto は、トラックに置かれた子の大きさに合わせます。Valueは使用されません。
+	// 列では子の最小の幅のうち最大のもの、行では確定した列の幅での子の高さのうち最大のものになります。
+	// 複数のトラックにまたがる子は、またがるトラックの合計で足りない分を、その中の自動サイズのトラックに均等に加えて満たします。
+	// 固定サイズと自動サイズのトラックを先に決め、残りのスペースを重み付けされたトラックで分けます。
+	TrackSizingAuto
 )
 
 // TrackDefinition は、単一の列または行のサイズ定義を保持します。
@@ -37,11 +43,24 @@ type AdvancedGridLayout struct {
 	RowDefinitions    []TrackDefinition
 	HorizontalGap     int
 	VerticalGap       int
+	// ContentAlign は、すべてのトラックが固定サイズでスペースが余る場合に、
+	// グリッド全体をコンテナ内のどこに寄せるかを指定します。
+	ContentAlign ContentAlignment
 }
 
+// コンパイル時にインターフェースの実装を検証します。
+var _ ContentAligner = (*AdvancedGridLayout)(nil)
+
+// SetContentAlignment は、グリッド全体の揃え位置を設定します。
+func (l *AdvancedGridLayout) SetContentAlignment(a ContentAlignment) { l.ContentAlign = a }
+
+// GetContentAlignment は、グリッド全体の揃え位置を返します。
+func (l *AdvancedGridLayout) GetContentAlignment() ContentAlignment { return l.ContentAlign }
+
 // Layout は AdvancedGridLayout のレイアウトロジックを実装します。
 // NOTE: Layoutインターフェースの変更に伴い、errorを返すようにシグネチャが更新されました。
 func (l *AdvancedGridLayout) Layout(container Container) error {
+	traceLayout(container)
 	children := getVisibleChildren(container)
 	if len(children) == 0 {
 		return nil
@@ -67,28 +86,84 @@ func (l *AdvancedGridLayout) Layout(container Container) error {
 	netWidth := availableWidth - totalHorizontalGap
 	netHeight := availableHeight - totalVerticalGap
 
-	// 2. 各トラックのサイズを計算
-	colWidths := calculateTrackSizes(l.ColumnDefinitions, netWidth)
-	rowHeights := calculateTrackSizes(l.RowDefinitions, netHeight)
+	// 2. 子の配置を解決し、各トラックのサイズを計算
+	// 自動サイズの行の高さは列の幅に依存するため、列を先に計算します。
+	placements := resolvePlacements(children, numCols, numRows)
+	autoCols, autoRows := hasAutoTrack(l.ColumnDefinitions), hasAutoTrack(l.RowDefinitions)
+	var minWidths, minHeights []int
+	if autoCols || autoRows {
+		minWidths, minHeights = make([]int, len(placements)), make([]int, len(placements))
+		stats.AddMeasured(len(placements))
+		for i, p := range placements {
+			minWidths[i], minHeights[i] = measureMinSize(p.widget)
+		}
+	}
 
-	// 3. 各トラックの開始位置を計算
-	colPositions := calculateTrackPositions(colWidths, l.HorizontalGap, containerX+padding.Left)
-	rowPositions := calculateTrackPositions(rowHeights, l.VerticalGap, containerY+padding.Top)
+	var autoColWidths []int
+	if autoCols {
+		autoColWidths = autoTrackSizes(l.ColumnDefinitions, placements, minWidths, l.HorizontalGap, func(p gridPlacement) (int, int) { return p.startCol, p.endCol })
+	}
+	colWidths := calculateTrackSizes(l.ColumnDefinitions, netWidth, autoColWidths)
+
+	var autoRowHeights []int
+	if autoRows {
+		// 折り返すテキストのように高さが幅に依存する子は、確定した列の幅での高さを使います。
+		for i, p := range placements {
+			if hw, ok := p.widget.(component.HeightForWider); ok {
+				width := spanSize(colWidths, p.startCol, p.endCol-p.startCol, l.HorizontalGap)
+				minHeights[i] = max(minHeights[i], measureHeightForWidth(p.widget, hw, width))
+			}
+		}
+		autoRowHeights = autoTrackSizes(l.RowDefinitions, placements, minHeights, l.VerticalGap, func(p gridPlacement) (int, int) { return p.startRow, p.endRow })
+	}
+	rowHeights := calculateTrackSizes(l.RowDefinitions, netHeight, autoRowHeights)
+
+	// 3. 各トラックの開始位置を計算 (余ったスペースはContentAlignに従って配分)
+	offsetX, offsetY := l.ContentAlign.offsets(netWidth-sumTrackSizes(colWidths), netHeight-sumTrackSizes(rowHeights))
+	colPositions := calculateTrackPositions(colWidths, l.HorizontalGap, containerX+padding.Left+offsetX)
+	rowPositions := calculateTrackPositions(rowHeights, l.VerticalGap, containerY+padding.Top+offsetY)
 
 	// 4. 子要素を配置
-	for _, child := range children {
-		var data any
-		var ok bool
-		var placementData GridPlacementData
+	for _, p := range placements {
+		x := colPositions[p.startCol]
+		y := rowPositions[p.startRow]
+		width := colPositions[p.endCol-1] + colWidths[p.endCol-1] - x
+		height := rowPositions[p.endRow-1] + rowHeights[p.endRow-1] - y
 
+		// 【提案1】型アサーションの追加: 位置とサイズの設定はそれぞれ
+		// PositionSetterとSizeSetterインターフェースが持つため、型アサーションを行います。
+		if ps, okSetPos := p.widget.(component.PositionSetter); okSetPos {
+			ps.SetPosition(x, y)
+		}
+		if ss, okSetSize := p.widget.(component.SizeSetter); okSetSize {
+			ss.SetSize(width, height)
+		}
+		traceArrange(p.widget)
+		stats.AddArranged(1)
+	}
+	return nil
+}
+
+// gridPlacement は、グリッドの範囲内に収めた子の配置です。endCol、endRowは終端のトラックの次の番号です。
+type gridPlacement struct {
+	widget             component.Widget
+	startCol, startRow int
+	endCol, endRow     int
+}
+
+// resolvePlacements は、配置情報を持つ子について、グリッドの範囲内に収めた配置を返します。
+// 配置情報がないウィジェットはレイアウト対象外とします。
+func resolvePlacements(children []component.Widget, numCols, numRows int) []gridPlacement {
+	placements := make([]gridPlacement, 0, len(children))
+	for _, child := range children {
 		// 【提案1】型アサーションの追加: GetLayoutDataはLayoutPropertiesインターフェースが持つため、
 		// 型アサーションを行い、実装しているウィジェットからデータを取得します。
-		if lp, okGet := child.(component.LayoutProperties); okGet {
+		var data any
+		if lp, ok := child.(component.LayoutProperties); ok {
 			data = lp.GetLayoutData()
 		}
-
-		if placementData, ok = data.(GridPlacementData); !ok {
-			// 配置情報がないウィジェットはレイアウト対象外とします。
+		placementData, ok := data.(GridPlacementData)
+		if !ok {
 			continue
 		}
 
@@ -96,40 +171,85 @@ func (l *AdvancedGridLayout) Layout(container Container) error {
 		// utils.Clamp を使用して冗長性を解消します。
 		startCol := utils.Clamp(placementData.Col, 0, numCols-1)
 		startRow := utils.Clamp(placementData.Row, 0, numRows-1)
-		endCol := utils.Clamp(placementData.Col+placementData.ColSpan, startCol+1, numCols)
-		endRow := utils.Clamp(placementData.Row+placementData.RowSpan, startRow+1, numRows)
+		placements = append(placements, gridPlacement{
+			widget:   child,
+			startCol: startCol,
+			startRow: startRow,
+			endCol:   utils.Clamp(placementData.Col+placementData.ColSpan, startCol+1, numCols),
+			endRow:   utils.Clamp(placementData.Row+placementData.RowSpan, startRow+1, numRows),
+		})
+	}
+	return placements
+}
 
-		// 位置とサイズを計算
-		x := colPositions[startCol]
-		y := rowPositions[startRow]
-		width := colPositions[endCol-1] + colWidths[endCol-1] - x
-		height := rowPositions[endRow-1] + rowHeights[endRow-1] - y
+// hasAutoTrack は、定義に自動サイズのトラックが含まれるかどうかを返します。
+func hasAutoTrack(definitions []TrackDefinition) bool {
+	for _, def := range definitions {
+		if def.Sizing == TrackSizingAuto {
+			return true
+		}
+	}
+	return false
+}
 
-		// 【提案1】型アサーションの追加: 位置とサイズの設定はそれぞれ
-		// PositionSetterとSizeSetterインターフェースが持つため、型アサーションを行います。
-		if ps, okSetPos := child.(component.PositionSetter); okSetPos {
-			ps.SetPosition(x, y)
+// autoTrackSizes は、自動サイズのトラックの大きさを、各子(placements[i])の最小サイズmins[i]から計算します。自動サイズでないトラックは0です。
+// spanは、子の配置から始まりと終わりのトラックを返します。1本のトラックに収まる子を先に反映し、
+// またがる子は、またがるトラック(固定サイズはその大きさ、重み付けは0として数えます)と間隔の合計で足りない分を、
+// その中の自動サイズのトラックに均等に加えます。自動サイズのトラックにまたがらない子は無視されます。
+func autoTrackSizes(definitions []TrackDefinition, placements []gridPlacement, mins []int, gap int, span func(p gridPlacement) (start, end int)) []int {
+	sizes := make([]int, len(definitions))
+	for i, p := range placements {
+		if start, end := span(p); end-start == 1 && definitions[start].Sizing == TrackSizingAuto {
+			sizes[start] = max(sizes[start], mins[i])
 		}
-		if ss, okSetSize := child.(component.SizeSetter); okSetSize {
-			ss.SetSize(width, height)
+	}
+	for i, p := range placements {
+		start, end := span(p)
+		if end-start == 1 {
+			continue
+		}
+		covered := (end - start - 1) * gap
+		var autoTracks []int
+		for t := start; t < end; t++ {
+			switch definitions[t].Sizing {
+			case TrackSizingFixed:
+				covered += int(definitions[t].Value)
+			case TrackSizingAuto:
+				covered += sizes[t]
+				autoTracks = append(autoTracks, t)
+			}
+		}
+		deficit := mins[i] - covered
+		if deficit <= 0 || len(autoTracks) == 0 {
+			continue
+		}
+		for j, t := range autoTracks {
+			sizes[t] += evenShare(deficit, len(autoTracks), j)
 		}
 	}
-	return nil
+	return sizes
 }
 
 // calculateTrackSizes は、定義に基づいて各トラック（列または行）の最終的なサイズを計算します。
-func calculateTrackSizes(definitions []TrackDefinition, availableSpace int) []int {
+// autoSizesは、自動サイズのトラックの大きさです(autoTrackSizesの結果)。自動サイズのトラックがない場合はnilです。
+func calculateTrackSizes(definitions []TrackDefinition, availableSpace int, autoSizes []int) []int {
 	sizes := make([]int, len(definitions))
 	var totalWeightedValue float64
 	remainingSpace := float64(availableSpace)
 
-	// 固定サイズのトラックを先に計算し、残りのスペースから引きます。
+	// 固定サイズと自動サイズのトラックを先に計算し、残りのスペースから引きます。
 	for i, def := range definitions {
-		if def.Sizing == TrackSizingFixed {
+		switch def.Sizing {
+		case TrackSizingFixed:
 			size := int(def.Value)
 			sizes[i] = size
 			remainingSpace -= float64(size)
-		} else {
+		case TrackSizingAuto:
+			if autoSizes != nil {
+				sizes[i] = autoSizes[i]
+			}
+			remainingSpace -= float64(sizes[i])
+		default:
 			totalWeightedValue += def.Value
 		}
 	}
@@ -160,4 +280,13 @@ func calculateTrackPositions(sizes []int, gap int, startOffset int) []int {
 		currentPos += size + gap
 	}
 	return positions
-}
\ No newline at end of file
+}
+
+// sumTrackSizes は、トラックサイズの合計を返します。
+func sumTrackSizes(sizes []int) int {
+	total := 0
+	for _, size := range sizes {
+		total += size
+	}
+	return total
+}
@@ -0,0 +1,36 @@
+package layout
+
+// ContentAlignment は、GridLayoutやAbsoluteLayoutのような非Flexレイアウトにおいて、
+// 子要素群全体をコンテナの余ったスペースのどこに寄せるかを指定します。
+// ゼロ値は両軸ともAlignStart（左上寄せ）で、従来の配置と同じです。
+// AlignStretch、AlignSpaceBetween、AlignSpaceAroundはAlignStartとして扱われます。
+type ContentAlignment struct {
+	Horizontal Alignment
+	Vertical   Alignment
+}
+
+// ContentAligner は、コンテナレベルのコンテンツ揃えをサポートするレイアウトのインターフェースです。
+type ContentAligner interface {
+	SetContentAlignment(a ContentAlignment)
+	GetContentAlignment() ContentAlignment
+}
+
+// offsets は、余ったスペース(freeX, freeY)に対する子要素群全体のオフセットを返します。
+func (a ContentAlignment) offsets(freeX, freeY int) (int, int) {
+	return alignmentOffset(a.Horizontal, freeX), alignmentOffset(a.Vertical, freeY)
+}
+
+// alignmentOffset は、余ったスペースに対する揃え位置のオフセットを計算します。
+// 余ったスペースがない場合は0を返し、内容は先頭からはみ出します。
+func alignmentOffset(align Alignment, free int) int {
+	if free <= 0 {
+		return 0
+	}
+	switch align {
+	case AlignCenter:
+		return free / 2
+	case AlignEnd:
+		return free
+	}
+	return 0
+}
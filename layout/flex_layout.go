@@ -2,6 +2,7 @@ package layout
 
 import (
 	"furoshiki/component"
+	"furoshiki/stats"
 	"furoshiki/style"
 	"furoshiki/utils"
 )
@@ -12,11 +13,34 @@ type FlexLayout struct {
 	Justify    Alignment
 	AlignItems Alignment
 	// AlignContent は、複数行/列になった際の、交差軸方向のラインの揃え位置を設定します。
-	// このプロパティは、Wrapがtrueの場合にのみ効果があります。
+	// AlignStretchでは余ったスペースを各ラインに均等に分けてラインを広げ、AlignSpaceBetweenとAlignSpaceAroundでは
+	// 余ったスペースをラインの間(と両端)に分けます。このプロパティは、Wrapがtrueの場合にのみ効果があります。
 	AlignContent Alignment
 	// Wrap は、アイテムが一行に収らない場合に折り返すかどうかを指定します。
 	Wrap bool
-	Gap  int
+	// Gap は、子要素間と折り返したライン間の両方に使われる間隔です。
+	Gap int
+	// RowGap は、行と行の間（垂直方向）の間隔です。0の場合はGapを使用します。
+	// 横並び(DirectionRow)では折り返したライン間、縦並び(DirectionColumn)では子要素間の間隔になります。
+	RowGap int
+	// ColumnGap は、列と列の間（水平方向）の間隔です。0の場合はGapを使用します。
+	// 横並び(DirectionRow)では子要素間、縦並び(DirectionColumn)では折り返したライン間の間隔になります。
+	ColumnGap int
+	// CollapseMargins は、主軸方向に隣り合う子のマージンを合計せず、大きい方の値にまとめるかどうかを指定します。
+	// 例えばVStackでは、上の子の下マージンと下の子の上マージンのうち、大きい方だけが子の間の間隔になります。
+	// マージンを持つ部品を積み重ねたときに、間隔が二重になるのを防ぎます。Gapはまとめられずに加えられ、
+	// 折り返したラインをまたぐマージンと、自動のマージンが吸収するスペースはまとめられません。
+	CollapseMargins bool
+}
+
+// axisGaps は、主軸方向の子要素間の間隔と、交差軸方向のライン間の間隔を返します。
+func (l *FlexLayout) axisGaps() (mainGap, lineGap int) {
+	rowGap := utils.IfThen(l.RowGap > 0, l.RowGap, l.Gap)
+	columnGap := utils.IfThen(l.ColumnGap > 0, l.ColumnGap, l.Gap)
+	if l.Direction == DirectionRow {
+		return columnGap, rowGap
+	}
+	return rowGap, columnGap
 }
 
 // flexItemInfo は、レイアウト計算中に各子要素の情報を保持するための中間構造体です。
@@ -26,6 +50,36 @@ type flexItemInfo struct {
 	mainMargin, crossMargin int
 	mainMarginStart         int
 	flex                    int
+	// maxMainSize は、Flexで伸長される際の主軸方向の上限です。0は上限なしを意味します。
+	maxMainSize int
+	// width, height は、ウィジェットに設定されている大きさです。minWidth, minHeight は、計測した最小サイズです。
+	// 最小サイズの計測はパスごとに1回だけ行い、以降の計算ではこの値を使い回します。
+	width, height       int
+	minWidth, minHeight int
+	// widthForHeight は、縦並びで高さを先に決め、幅をGetWidthForHeightで求めるアイテムの場合に設定されます。
+	widthForHeight component.WidthForHeighter
+	// autoMainStart, autoMainEnd, autoCrossStart, autoCrossEnd は、各軸の始端と終端のマージンが自動かどうかです。
+	autoMainStart, autoMainEnd   bool
+	autoCrossStart, autoCrossEnd bool
+}
+
+// crossAlign は、このアイテムに適用する交差軸の揃え位置を返します。
+// 交差軸に自動のマージンがある場合は、alignItemsの代わりに、マージンが余ったスペースを吸収する位置に揃えます。
+func (item *flexItemInfo) crossAlign(alignItems Alignment) Alignment {
+	switch {
+	case item.autoCrossStart && item.autoCrossEnd:
+		return AlignCenter
+	case item.autoCrossStart:
+		return AlignEnd
+	case item.autoCrossEnd:
+		return AlignStart
+	}
+	return alignItems
+}
+
+// autoMainCount は、主軸方向の自動のマージンの数を返します。
+func (item *flexItemInfo) autoMainCount() int {
+	return utils.IfThen(item.autoMainStart, 1, 0) + utils.IfThen(item.autoMainEnd, 1, 0)
 }
 
 // flexLine は、折り返しレイアウト時に一行（または一列）を表現する内部構造体です。
@@ -37,6 +91,7 @@ type flexLine struct {
 // Layout は FlexLayout のレイアウトロジックを実装します。
 // NOTE: Layoutインターフェースの変更に伴い、errorを返すようにシグネチャが更新されました。
 func (l *FlexLayout) Layout(container Container) error {
+	traceLayout(container)
 	children := getVisibleChildren(container)
 	if len(children) == 0 {
 		return nil
@@ -71,6 +126,9 @@ func (l *FlexLayout) Layout(container Container) error {
 
 // layoutSingleLine は、折り返しなしのレイアウト計算を実行します。
 func (l *FlexLayout) layoutSingleLine(items []*flexItemInfo, container Container, mainSize, crossSize int, isRow bool) {
+	if l.CollapseMargins {
+		collapseMainMargins(items)
+	}
 	var totalFlex float64
 	var totalBaseMainSize int
 	for _, item := range items {
@@ -80,19 +138,31 @@ func (l *FlexLayout) layoutSingleLine(items []*flexItemInfo, container Container
 		totalBaseMainSize += item.mainSize + item.mainMargin
 	}
 
-	distributeRemainingSpace(items, mainSize, totalBaseMainSize, totalFlex, l.Gap)
+	mainGap, _ := l.axisGaps()
+	distributeRemainingSpace(items, mainSize, totalBaseMainSize, totalFlex, mainGap)
 	calculateCrossAxisSizes(items, crossSize, isRow, l.AlignItems)
 	// シングルラインの場合、最終的なサイズを適用してから配置します。
 	applySizes(items, isRow)
-	positionItems(items, container, mainSize, crossSize, isRow, l.Justify, l.AlignItems, l.Gap)
+	positionItems(items, container, mainSize, crossSize, isRow, l.Justify, l.AlignItems, mainGap)
 }
 
 // layoutMultiLine は、折り返しありのレイアウト計算を実行します。
 func (l *FlexLayout) layoutMultiLine(items []*flexItemInfo, container Container, mainSize, crossSize int, isRow bool) {
+	mainGap, lineGap := l.axisGaps()
+
 	// 1. アイテムを複数のラインに分割
+	// マージンをまとめる場合、ラインへの分割はまとめる前の大きさで行い、分割後にライン内でまとめます。
 	lines := l.splitIntoLines(items, mainSize)
+	if l.CollapseMargins {
+		for _, line := range lines {
+			collapseMainMargins(line.items)
+		}
+	}
 
 	// 2. 各ラインのサイズを計算
+	// AlignItemsがAlignStretchの場合、アイテムはラインの大きさまで引き伸ばされるため、
+	// ラインの大きさはアイテムの本来の交差軸サイズから決め、引き伸ばしはラインの配置の後で行います。
+	measureAlign := utils.IfThen(l.AlignItems == AlignStretch, AlignStart, l.AlignItems)
 	var totalCrossAxisSize int
 	for _, line := range lines {
 		// ライン内のflexアイテムに余剰スペースを分配
@@ -104,10 +174,10 @@ func (l *FlexLayout) layoutMultiLine(items []*flexItemInfo, container Container,
 			}
 			lineTotalBaseMainSize += item.mainSize + item.mainMargin
 		}
-		distributeRemainingSpace(line.items, mainSize, lineTotalBaseMainSize, lineTotalFlex, l.Gap)
+		distributeRemainingSpace(line.items, mainSize, lineTotalBaseMainSize, lineTotalFlex, mainGap)
 
 		// ライン内のアイテムの交差軸サイズと、ライン自体の交差軸サイズを計算
-		calculateCrossAxisSizes(line.items, crossSize, isRow, l.AlignItems)
+		calculateCrossAxisSizes(line.items, crossSize, isRow, measureAlign)
 		line.crossAxisSize = calculateLineCrossSize(line.items)
 		totalCrossAxisSize += line.crossAxisSize
 	}
@@ -119,24 +189,45 @@ func (l *FlexLayout) layoutMultiLine(items []*flexItemInfo, container Container,
 
 	freeCrossSpace := crossSize - totalCrossAxisSize
 	if len(lines) > 1 {
-		freeCrossSpace -= (len(lines) - 1) * l.Gap
+		freeCrossSpace -= (len(lines) - 1) * lineGap
 	}
 
+	// lineSpacing は、各ラインの後ろにlineGapに加えて空ける間隔です。
+	lineSpacing := make([]int, len(lines))
 	if freeCrossSpace > 0 {
 		switch l.AlignContent {
 		case AlignCenter:
 			currentCross += freeCrossSpace / 2
 		case AlignEnd:
 			currentCross += freeCrossSpace
-			// NOTE: AlignStretch や AlignSpaceBetween などは、より複雑な計算が必要なため現バージョンでは未サポートです。
+		case AlignStretch:
+			for i, line := range lines {
+				line.crossAxisSize += evenShare(freeCrossSpace, len(lines), i)
+			}
+		case AlignSpaceBetween:
+			// ラインが1つの場合は、AlignStartと同じく先頭に揃えます。
+			for i := range len(lines) - 1 {
+				lineSpacing[i] = evenShare(freeCrossSpace, len(lines)-1, i)
+			}
+		case AlignSpaceAround:
+			around := freeCrossSpace / len(lines)
+			currentCross += around / 2
+			for i := range lineSpacing {
+				lineSpacing[i] = around
+			}
 		}
 	}
 
 	// 4. 各ライン内のアイテムを最終配置
-	for _, line := range lines {
+	for i, line := range lines {
+		for _, item := range line.items {
+			if item.crossAlign(l.AlignItems) == AlignStretch {
+				item.crossSize = max(0, line.crossAxisSize-item.crossMargin)
+			}
+		}
 		// positionItemsをラインごとに呼び出し、ラインの開始位置 (currentCross) を渡してアイテムを配置します。
-		positionItems(line.items, container, mainSize, line.crossAxisSize, isRow, l.Justify, l.AlignItems, l.Gap, currentCross)
-		currentCross += line.crossAxisSize + l.Gap
+		positionItems(line.items, container, mainSize, line.crossAxisSize, isRow, l.Justify, l.AlignItems, mainGap, currentCross)
+		currentCross += line.crossAxisSize + lineGap + lineSpacing[i]
 	}
 
 	// 5. 計算された最終的なサイズを全ウィジェットに適用
@@ -154,11 +245,12 @@ func (l *FlexLayout) splitIntoLines(items []*flexItemInfo, mainSize int) []*flex
 	currentLineItems := make([]*flexItemInfo, 0)
 	currentMainSize := 0
 
+	mainGap, _ := l.axisGaps()
 	for _, item := range items {
 		itemMainSize := item.mainSize + item.mainMargin
 		gap := 0
 		if len(currentLineItems) > 0 {
-			gap = l.Gap
+			gap = mainGap
 		}
 
 		// アイテムを追加すると主軸サイズを超える場合、現在のラインを確定して新しいラインを開始します。
@@ -181,6 +273,23 @@ func (l *FlexLayout) splitIntoLines(items []*flexItemInfo, mainSize int) []*flex
 	return lines
 }
 
+// collapseMainMargins は、隣り合うアイテムの間の主軸方向のマージンを、大きい方の値にまとめます。
+// 重なる分を後ろのアイテムの始端のマージンから差し引くため、以降の計算はマージンの合計をそのまま使えます。
+// 負のマージンはまとめません。
+func collapseMainMargins(items []*flexItemInfo) {
+	for i := 1; i < len(items); i++ {
+		prevEnd := items[i-1].mainMargin - items[i-1].mainMarginStart
+		overlap := max(0, min(prevEnd, items[i].mainMarginStart))
+		items[i].mainMarginStart -= overlap
+		items[i].mainMargin -= overlap
+	}
+}
+
+// evenShare は、totalをn個に均等に分けたときのi番目の大きさを返します。端数は先頭から1ずつ配ります。
+func evenShare(total, n, i int) int {
+	return total/n + utils.IfThen(i < total%n, 1, 0)
+}
+
 // calculateLineCrossSize は、ライン内のアイテムに基づいてライン自体の交差軸サイズを決定します。
 // ラインのサイズは、その中の最も大きいアイテムのサイズに合わせられます。
 func calculateLineCrossSize(lineItems []*flexItemInfo) int {
@@ -201,14 +310,11 @@ func collectItemInfo(children []component.Widget, isRow bool) []*flexItemInfo {
 	for i, child := range children {
 		// 【提案1】型アサーションの追加: スタイルやFlex値は特定のインターフェースが持つため、
 		// 型アサーションを通じて安全にアクセスします。
-		var s style.Style
+		// UPDATE: ディープコピーを行うGetStyle()の代わりに、キャッシュ済みの値型スタイルを使用します。
+		//        レイアウトのたびに子の数だけスタイルがコピーされるのを防ぎます。
+		var margin style.Insets
 		if sg, ok := child.(component.StyleGetterSetter); ok {
-			s = sg.GetStyle()
-		}
-
-		margin := style.Insets{}
-		if s.Margin != nil {
-			margin = *s.Margin
+			margin = sg.ComputedStyle().Margin
 		}
 
 		var mainMargin, crossMargin, mainMarginStart int
@@ -227,12 +333,33 @@ func collectItemInfo(children []component.Widget, isRow bool) []*flexItemInfo {
 			flex = lp.GetFlex()
 		}
 
+		maxMainSize := 0
+		if ms, ok := child.(component.MaxSizer); ok {
+			maxW, maxH := ms.GetMaxSize()
+			maxMainSize = utils.IfThen(isRow, maxW, maxH)
+		}
+
+		var auto component.MarginSide
+		if am, ok := child.(component.AutoMarginer); ok {
+			auto = am.AutoMargins()
+		}
+		mainStartSide, mainEndSide := component.MarginSideLeft, component.MarginSideRight
+		crossStartSide, crossEndSide := component.MarginSideTop, component.MarginSideBottom
+		if !isRow {
+			mainStartSide, mainEndSide, crossStartSide, crossEndSide = crossStartSide, crossEndSide, mainStartSide, mainEndSide
+		}
+
 		items[i] = &flexItemInfo{
 			widget:          child,
 			flex:            flex,
 			mainMargin:      mainMargin,
 			crossMargin:     crossMargin,
 			mainMarginStart: mainMarginStart,
+			maxMainSize:     maxMainSize,
+			autoMainStart:   auto.Has(mainStartSide),
+			autoMainEnd:     auto.Has(mainEndSide),
+			autoCrossStart:  auto.Has(crossStartSide),
+			autoCrossEnd:    auto.Has(crossEndSide),
 		}
 	}
 	return items
@@ -241,16 +368,17 @@ func collectItemInfo(children []component.Widget, isRow bool) []*flexItemInfo {
 // calculateBaseSizes は、各アイテムの基本サイズを決定します。
 // VStacks (`isRow == false`) のために、crossSize と alignItems を受け取るように修正されました。
 func calculateBaseSizes(items []*flexItemInfo, isRow bool, crossSize int, alignItems Alignment) {
-	for _, item := range items {
+	stats.AddMeasured(len(items))
+	// 各アイテムの計測は互いに独立しているため、並列計測が有効な場合はワーカーに分散されます。
+	measureItems(items, func(item *flexItemInfo) {
 		// 【提案1】型アサーションの追加: サイズ関連のメソッドはSizeSetter/MinSizeSetterが持つため、
 		// 型アサーションを通じて安全にアクセスします。
-		var w, h, minW, minH int
 		if ss, ok := item.widget.(component.SizeSetter); ok {
-			w, h = ss.GetSize()
-		}
-		if mss, ok := item.widget.(component.MinSizeSetter); ok {
-			minW, minH = mss.GetMinSize()
+			item.width, item.height = ss.GetSize()
 		}
+		// NOTE: 最小サイズはここで1回だけ計測し、交差軸の計算(calculateCrossAxisSizes)でも使い回します。
+		item.minWidth, item.minHeight = measureMinSize(item.widget)
+		w, h, minW, minH := item.width, item.height, item.minWidth, item.minHeight
 
 		if isRow { // HStack のロジックは変更なし
 			if item.flex > 0 {
@@ -261,7 +389,8 @@ func calculateBaseSizes(items []*flexItemInfo, isRow bool, crossSize int, alignI
 		} else { // VStack のための新しいロジック
 			// mainSize は高さであり、幅(crossSize)に依存する可能性があるため、先に幅を決定します。
 			itemWidth := crossSize - item.crossMargin // 利用可能な最大幅から開始
-			if alignItems != AlignStretch {
+			align := item.crossAlign(alignItems)
+			if align != AlignStretch {
 				// stretchでない場合、アイテムは自身の本来の幅を使います。
 				intrinsicWidth := max(utils.IfThen(w <= 0, minW, w), minW)
 				if intrinsicWidth < itemWidth {
@@ -272,19 +401,35 @@ func calculateBaseSizes(items []*flexItemInfo, isRow bool, crossSize int, alignI
 				itemWidth = 0
 			}
 
-			// 確定した幅を使って、正しい基本の高さを計算します。
-			if hw, ok := item.widget.(component.HeightForWider); ok {
-				item.mainSize = hw.GetHeightForWidth(itemWidth)
+			// 高さが制約される軸のアイテムは、高さを先に決め、幅は交差軸の計算でGetWidthForHeightから求めます。
+			if wh, ok := item.widget.(component.WidthForHeighter); ok && align != AlignStretch && isHeightConstrained(item) {
+				item.widthForHeight = wh
+				item.mainSize = utils.IfThen(item.flex > 0, minH, max(h, minH))
+			} else if hw, ok := item.widget.(component.HeightForWider); ok {
+				// 確定した幅を使って、正しい基本の高さを計算します。
+				item.mainSize = measureHeightForWidth(item.widget, hw, itemWidth)
 			} else {
 				// 折り返しをサポートしないウィジェットのフォールバック
 				item.mainSize = max(utils.IfThen(h <= 0, minH, h), minH)
 			}
 		}
+	})
+}
+
+// isHeightConstrained は、縦並びのアイテムの高さが内容より先に決まり、幅を高さから求めるべきかどうかを返します。
+// Flexを持つアイテムと、ビルダーのSizeで高さが指定されたアイテムが該当します。Sizeで幅も指定されている場合は、その幅を優先します。
+func isHeightConstrained(item *flexItemInfo) bool {
+	es, ok := item.widget.(interface{ HasExplicitSize() (width, height bool) })
+	if !ok {
+		return item.flex > 0
 	}
+	width, height := es.HasExplicitSize()
+	return !width && (height || item.flex > 0)
 }
 
 // distributeRemainingSpace は、残りの空間をflexアイテムに分配します。
 // ポインタのスライスを受け取るように変更しました。
+// 最大サイズを持つアイテムが上限に達した場合は、その分の空間を残りのflexアイテムに再分配します。
 func distributeRemainingSpace(items []*flexItemInfo, mainSize, totalBaseMainSize int, totalFlex float64, gap int) {
 	totalGap := 0
 	if len(items) > 1 {
@@ -293,42 +438,69 @@ func distributeRemainingSpace(items []*flexItemInfo, mainSize, totalBaseMainSize
 
 	remainingSpace := mainSize - totalBaseMainSize - totalGap
 
-	if totalFlex > 0 && remainingSpace > 0 {
+	if totalFlex <= 0 || remainingSpace <= 0 {
+		return
+	}
+
+	// 上限に達したアイテムを固定し、残りのアイテムで分配し直すことを、上限を超えるアイテムがなくなるまで繰り返します。
+	// 上限を持つアイテムがない場合、ループは1回で終わります。
+	for {
 		sizePerFlex := float64(remainingSpace) / totalFlex
+		clamped := false
+		for _, item := range items {
+			if item.flex <= 0 || item.maxMainSize <= 0 {
+				continue
+			}
+			if item.mainSize+int(sizePerFlex*float64(item.flex)) > item.maxMainSize {
+				// 上限まで伸ばした上で、以降の分配の対象から外します。
+				// flexItemInfoはレイアウト計算ごとに生成されるため、flexを書き換えてもウィジェットには影響しません。
+				grown := max(0, item.maxMainSize-item.mainSize)
+				item.mainSize += grown
+				remainingSpace -= grown
+				totalFlex -= float64(item.flex)
+				item.flex = 0
+				clamped = true
+			}
+		}
+		if clamped {
+			if totalFlex <= 0 || remainingSpace <= 0 {
+				return
+			}
+			continue
+		}
 		for _, item := range items {
 			if item.flex > 0 {
 				item.mainSize += int(sizePerFlex * float64(item.flex))
 			}
 		}
+		return
 	}
 }
 
 // calculateCrossAxisSizes は、交差軸のサイズを計算します。
 // ポインタのスライスを受け取るように変更しました。
 func calculateCrossAxisSizes(items []*flexItemInfo, crossSize int, isRow bool, alignItems Alignment) {
-	for _, item := range items {
+	measureItems(items, func(item *flexItemInfo) {
 		// デフォルトでは、子は親の利用可能な交差軸スペース全体を占有します (AlignStretch)。
 		item.crossSize = crossSize - item.crossMargin
 
 		// AlignStretchでない場合、子は自身のコンテンツに合わせたサイズになることができます。
-		if alignItems != AlignStretch {
+		// 交差軸に自動のマージンがあるアイテムは、引き伸ばされません。
+		if item.crossAlign(alignItems) != AlignStretch {
 			var intrinsicCrossSize int
-			// 【提案1】型アサーションの追加
-			var w, h, minW, minH int
-			if ss, ok := item.widget.(component.SizeSetter); ok {
-				w, h = ss.GetSize()
-			}
-			if mss, ok := item.widget.(component.MinSizeSetter); ok {
-				minW, minH = mss.GetMinSize()
-			}
+			// 大きさと最小サイズは、calculateBaseSizesで記録した値を使います。
+			w, h, minW, minH := item.width, item.height, item.minWidth, item.minHeight
 
 			if isRow { // HStack の交差軸(高さ)を計算
 				if hw, ok := item.widget.(component.HeightForWider); ok {
 					// アイテムの幅(mainSize)は既に確定しているので、それに基づき正しい高さを計算
-					intrinsicCrossSize = hw.GetHeightForWidth(item.mainSize)
+					intrinsicCrossSize = measureHeightForWidth(item.widget, hw, item.mainSize)
 				} else {
 					intrinsicCrossSize = max(utils.IfThen(h <= 0, minH, h), minH)
 				}
+			} else if item.widthForHeight != nil { // 高さが先に決まったVStackのアイテムの交差軸(幅)を計算
+				// アイテムの高さ(mainSize)は既に確定しているので、その高さに収まる幅を計算
+				intrinsicCrossSize = measureWidthForHeight(item.widget, item.widthForHeight, item.mainSize)
 			} else { // VStack の交差軸(幅)を計算
 				// 幅はテキストの折り返しに依存しないので、単純に本来の幅を計算
 				intrinsicCrossSize = max(utils.IfThen(w <= 0, minW, w), minW)
@@ -343,7 +515,7 @@ func calculateCrossAxisSizes(items []*flexItemInfo, crossSize int, isRow bool, a
 		if item.crossSize < 0 {
 			item.crossSize = 0
 		}
-	}
+	})
 }
 
 // applySizes は、計算されたサイズを各ウィジェットに設定します。
@@ -372,16 +544,22 @@ func positionItems(items []*flexItemInfo, container Container, mainSize, crossSi
 		totalGap = (len(items) - 1) * gap
 	}
 
-	// 主軸方向のアイテムの合計サイズを計算
+	// 主軸方向のアイテムの合計サイズと、自動のマージンの数を計算
+	autoMargins := 0
 	for _, item := range items {
 		currentTotalMainSize += item.mainSize + item.mainMargin
+		autoMargins += item.autoMainCount()
 	}
 	currentTotalMainSize += totalGap
 
 	// Justifyプロパティに基づいて主軸方向の開始オフセットを計算
+	// 自動のマージンがある場合、余ったスペースはすべて自動のマージンに分けられるため、Justifyは効果を持ちません。
 	freeSpace := mainSize - currentTotalMainSize
 	mainOffset := 0
-	if freeSpace > 0 {
+	if freeSpace <= 0 {
+		autoMargins = 0
+	}
+	if freeSpace > 0 && autoMargins == 0 {
 		switch justify {
 		case AlignCenter:
 			mainOffset = freeSpace / 2
@@ -402,15 +580,26 @@ func positionItems(items []*flexItemInfo, container Container, mainSize, crossSi
 	}
 
 	currentMain := mainStart + mainOffset
+	stats.AddArranged(len(items))
+
+	// autoIndex は、次に余ったスペースを受け取る自動のマージンの番号です。
+	autoIndex := 0
+	autoShare := func(auto bool) int {
+		if !auto || autoMargins == 0 {
+			return 0
+		}
+		autoIndex++
+		return evenShare(freeSpace, autoMargins, autoIndex-1)
+	}
 
 	for _, item := range items {
-		currentMain += item.mainMarginStart
+		currentMain += item.mainMarginStart + autoShare(item.autoMainStart)
 
-		// AlignItemsプロパティに基づいて交差軸方向のオフセットを計算
+		// AlignItemsプロパティ(交差軸に自動のマージンがある場合はその位置)に基づいて交差軸方向のオフセットを計算
 		crossOffset := 0
 		availableCrossSpace := crossSize - item.crossSize - item.crossMargin
 		if availableCrossSpace > 0 {
-			switch alignItems {
+			switch item.crossAlign(alignItems) {
 			case AlignCenter:
 				crossOffset = availableCrossSpace / 2
 			case AlignEnd:
@@ -430,7 +619,8 @@ func positionItems(items []*flexItemInfo, container Container, mainSize, crossSi
 				ps.SetPosition(containerX+finalCrossPos, containerY+currentMain)
 			}
 		}
+		traceArrange(item.widget)
 
-		currentMain += item.mainSize + (item.mainMargin - item.mainMarginStart) + gap
+		currentMain += item.mainSize + (item.mainMargin - item.mainMarginStart) + autoShare(item.autoMainEnd) + gap
 	}
 }
\ No newline at end of file
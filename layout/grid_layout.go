@@ -2,7 +2,8 @@ package layout
 
 import (
 	"furoshiki/component"
-	"math"
+	"furoshiki/stats"
+	"furoshiki/utils"
 )
 
 // GridLayout は、子要素を格子状（グリッド）に配置するレイアウトです。
@@ -11,11 +12,54 @@ type GridLayout struct {
 	Rows          int
 	HorizontalGap int
 	VerticalGap   int
+	// CellWidth, CellHeight は、セルの固定サイズです。0の場合、利用可能なスペースを均等に分割します。
+	// 固定サイズを指定するとグリッド全体の外側に余白ができ、ContentAlignで寄せる位置を指定できます。
+	CellWidth  int
+	CellHeight int
+	// ContentAlign は、グリッド全体をコンテナ内のどこに寄せるかを指定します。
+	ContentAlign ContentAlignment
+	// RespectMinSize は、列と行を子の最小サイズより小さくしないかどうかを指定します。
+	// 有効にすると、各列の幅は列内の子の最小幅以上に、各行の高さは行内の子の最小の高さ(折り返すテキストでは、列の幅での高さ)以上になり、
+	// 足りない分は他の列や行を縮めて補います。それでも収まらない場合、グリッドはコンテナの右と下へはみ出し、
+	// はみ出した大きさをOverflowで確認できます。はみ出した部分は、コンテナのOverflowの設定に従ってクリップされます。
+	RespectMinSize bool
+
+	// overflowWidth, overflowHeight は、直前のレイアウトでグリッドがコンテンツ領域からはみ出した大きさです。
+	overflowWidth, overflowHeight int
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ ContentAligner = (*GridLayout)(nil)
+
+// SetContentAlignment は、グリッド全体の揃え位置を設定します。
+func (l *GridLayout) SetContentAlignment(a ContentAlignment) { l.ContentAlign = a }
+
+// GetContentAlignment は、グリッド全体の揃え位置を返します。
+func (l *GridLayout) GetContentAlignment() ContentAlignment { return l.ContentAlign }
+
+// Overflow は、直前のレイアウトで、グリッドがコンテナのコンテンツ領域からはみ出した幅と高さを返します。
+// RespectMinSizeが有効で、子の最小サイズがコンテナに収まらなかった場合に0より大きくなります。
+// セルの内容が読めないほど小さくなっていないかを、テストやデバッグ表示で確認するために使用します。
+func (l *GridLayout) Overflow() (width, height int) { return l.overflowWidth, l.overflowHeight }
+
+// GridSpan は、GridLayoutの子が占める列と行の数です。子のLayoutDataに設定します。
+// 1未満の値は1として扱われ、ColSpanは列数までに切り詰められます。
+// 例えば、ダッシュボードの1つのタイルだけを2列分の幅にする場合は GridSpan{ColSpan: 2, RowSpan: 1} を設定します。
+type GridSpan struct {
+	ColSpan, RowSpan int
+}
+
+// gridCell は、自動配置で決まった子の位置とスパンです。
+type gridCell struct {
+	row, col         int
+	rowSpan, colSpan int
 }
 
 // Layout は GridLayout のレイアウトロジックを実装します。
+// 子は行優先で順にセルへ配置され、GridSpanを持つ子は、すでに配置された子と重ならない最初の位置に置かれます。
 // NOTE: Layoutインターフェースの変更に伴い、errorを返すようにシグネチャが更新されました。
 func (l *GridLayout) Layout(container Container) error {
+	traceLayout(container)
 	children := getVisibleChildren(container)
 	childCount := len(children)
 	if childCount == 0 {
@@ -27,9 +71,10 @@ func (l *GridLayout) Layout(container Container) error {
 		columns = 1
 	}
 
+	cells, usedRows := placeCells(children, columns)
 	rows := l.Rows
 	if rows <= 0 {
-		rows = int(math.Ceil(float64(childCount) / float64(columns)))
+		rows = usedRows
 	}
 	if rows == 0 {
 		return nil
@@ -44,25 +89,199 @@ func (l *GridLayout) Layout(container Container) error {
 
 	totalHorizontalGap := (columns - 1) * l.HorizontalGap
 	totalVerticalGap := (rows - 1) * l.VerticalGap
+	netWidth := availableWidth - totalHorizontalGap
+	netHeight := availableHeight - totalVerticalGap
 
-	cellWidth := (availableWidth - totalHorizontalGap) / columns
-	cellHeight := (availableHeight - totalVerticalGap) / rows
+	cellWidth := utils.IfThen(l.CellWidth > 0, l.CellWidth, netWidth/columns)
+	cellHeight := utils.IfThen(l.CellHeight > 0, l.CellHeight, netHeight/rows)
+	colWidths := uniformTracks(columns, cellWidth)
+	rowHeights := uniformTracks(rows, cellHeight)
+	if l.RespectMinSize {
+		colWidths, rowHeights = l.minContentTracks(children, cells, columns, rows, netWidth, netHeight)
+	}
 
-	for i, child := range children {
-		row := i / columns
-		col := i % columns
+	// Rowsで指定した行数に収まらない子は、最後の行の下に、同じ高さの行を続けて配置します。
+	// はみ出した大きさと配置の基準には、追加した行も含めます。
+	for len(rowHeights) < usedRows {
+		rowHeights = append(rowHeights, rowHeights[rows-1])
+	}
 
-		cellX := containerX + padding.Left + col*(cellWidth+l.HorizontalGap)
-		cellY := containerY + padding.Top + row*(cellHeight+l.VerticalGap)
+	gridWidth := sumTrackSizes(colWidths) + totalHorizontalGap
+	gridHeight := sumTrackSizes(rowHeights) + (len(rowHeights)-1)*l.VerticalGap
+	l.overflowWidth = max(0, gridWidth-availableWidth)
+	l.overflowHeight = max(0, gridHeight-availableHeight)
+	offsetX, offsetY := l.ContentAlign.offsets(availableWidth-gridWidth, availableHeight-gridHeight)
+	colPositions := calculateTrackPositions(colWidths, l.HorizontalGap, containerX+padding.Left+offsetX)
+	rowPositions := calculateTrackPositions(rowHeights, l.VerticalGap, containerY+padding.Top+offsetY)
+
+	stats.AddArranged(len(children))
+	for i, child := range children {
+		cell := cells[i]
 
 		// 【提案1】型アサーションの追加: 位置とサイズの設定はそれぞれ
 		// PositionSetterとSizeSetterインターフェースが持つため、型アサーションを行います。
 		if ps, ok := child.(component.PositionSetter); ok {
-			ps.SetPosition(cellX, cellY)
+			ps.SetPosition(colPositions[cell.col], rowPositions[cell.row])
 		}
 		if ss, ok := child.(component.SizeSetter); ok {
-			ss.SetSize(cellWidth, cellHeight)
+			ss.SetSize(spanSize(colWidths, cell.col, cell.colSpan, l.HorizontalGap), spanSize(rowHeights, cell.row, cell.rowSpan, l.VerticalGap))
 		}
+		traceArrange(child)
 	}
 	return nil
+}
+
+// placeCells は、子を行優先で順にcolumns列のセルへ配置し、各子の位置と、配置に使われた行数を返します。
+// 各子は、直前の子の後ろから探して、すでに配置された子と重ならない最初の位置に置かれます。
+// スパンを持つ子を置けずに飛ばしたセルは、後の子で埋め戻されません。
+func placeCells(children []component.Widget, columns int) ([]gridCell, int) {
+	cells := make([]gridCell, len(children))
+	var occupied [][]bool
+	isFree := func(row, col, rowSpan, colSpan int) bool {
+		for r := row; r < row+rowSpan && r < len(occupied); r++ {
+			for c := col; c < col+colSpan; c++ {
+				if occupied[r][c] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	cursor, usedRows := 0, 0
+	for i, child := range children {
+		var span GridSpan
+		if lp, ok := child.(component.LayoutProperties); ok {
+			span, _ = lp.GetLayoutData().(GridSpan)
+		}
+		colSpan := utils.Clamp(span.ColSpan, 1, columns)
+		rowSpan := max(1, span.RowSpan)
+
+		pos := cursor
+		for pos%columns+colSpan > columns || !isFree(pos/columns, pos%columns, rowSpan, colSpan) {
+			pos++
+		}
+		cell := gridCell{row: pos / columns, col: pos % columns, rowSpan: rowSpan, colSpan: colSpan}
+		for len(occupied) < cell.row+rowSpan {
+			occupied = append(occupied, make([]bool, columns))
+		}
+		for r := cell.row; r < cell.row+rowSpan; r++ {
+			for c := cell.col; c < cell.col+colSpan; c++ {
+				occupied[r][c] = true
+			}
+		}
+
+		cells[i] = cell
+		cursor = pos + colSpan
+		usedRows = max(usedRows, cell.row+rowSpan)
+	}
+	return cells, usedRows
+}
+
+// spanSize は、start番目からspan本のトラックと、その間の間隔を合わせた大きさを返します。
+// トラックの範囲を超える分は含みません。
+func spanSize(sizes []int, start, span, gap int) int {
+	end := min(start+span, len(sizes))
+	return sumTrackSizes(sizes[start:end]) + max(0, end-start-1)*gap
+}
+
+// minContentTracks は、RespectMinSizeが有効な場合の列の幅と行の高さを計算します。
+// 列の幅を先に決め、行の高さは確定した列の幅での子の高さから求めます。
+// 複数のトラックにまたがる子の最小サイズは、またがるトラックの合計で足りない分を、それらのトラックに均等に加えて満たします。
+func (l *GridLayout) minContentTracks(children []component.Widget, cells []gridCell, columns, rows, netWidth, netHeight int) (colWidths, rowHeights []int) {
+	minWidths := make([]int, columns)
+	childMinWidths := make([]int, len(children))
+	childMinHeights := make([]int, len(children))
+	stats.AddMeasured(len(children))
+	for i, child := range children {
+		childMinWidths[i], childMinHeights[i] = measureMinSize(child)
+	}
+	requireTrackMins(minWidths, cells, childMinWidths, l.HorizontalGap, func(c gridCell) (int, int) { return c.col, c.colSpan })
+	colWidths = fitTracks(minWidths, netWidth, l.CellWidth)
+
+	for i, child := range children {
+		if hw, ok := child.(component.HeightForWider); ok {
+			width := spanSize(colWidths, cells[i].col, cells[i].colSpan, l.HorizontalGap)
+			childMinHeights[i] = max(childMinHeights[i], measureHeightForWidth(child, hw, width))
+		}
+	}
+	minHeights := make([]int, rows)
+	requireTrackMins(minHeights, cells, childMinHeights, l.VerticalGap, func(c gridCell) (int, int) { return c.row, c.rowSpan })
+	rowHeights = fitTracks(minHeights, netHeight, l.CellHeight)
+	return colWidths, rowHeights
+}
+
+// requireTrackMins は、各子の最小サイズmins[i]を満たすように、トラックの最小サイズtrackMinsを引き上げます。
+// trackは、子の配置から始まりのトラックとスパンを返します。1本のトラックに収まる子を先に反映し、
+// またがる子は、その後でトラックの合計と間隔で足りない分を均等に加えます。トラックの範囲外にある分は無視されます。
+func requireTrackMins(trackMins []int, cells []gridCell, mins []int, gap int, track func(c gridCell) (start, span int)) {
+	for i, cell := range cells {
+		if start, span := track(cell); span == 1 && start < len(trackMins) {
+			trackMins[start] = max(trackMins[start], mins[i])
+		}
+	}
+	for i, cell := range cells {
+		start, span := track(cell)
+		if span == 1 || start >= len(trackMins) {
+			continue
+		}
+		span = min(span, len(trackMins)-start)
+		deficit := mins[i] - spanSize(trackMins, start, span, gap)
+		if deficit <= 0 {
+			continue
+		}
+		for j := range span {
+			trackMins[start+j] += evenShare(deficit, span, j)
+		}
+	}
+}
+
+// uniformTracks は、n本のトラックをすべてsizeにしたスライスを返します。
+func uniformTracks(n, size int) []int {
+	sizes := make([]int, n)
+	for i := range sizes {
+		sizes[i] = size
+	}
+	return sizes
+}
+
+// fitTracks は、各トラックを最小サイズmins以上に保ちながら、availableを分けたトラックのサイズを返します。
+// fixedが0より大きい場合は、各トラックをfixed(最小サイズの方が大きければ最小サイズ)にします。
+// 最小サイズより大きく分けられるトラックから先に縮め、すべてのトラックが最小サイズになっても収まらない場合は、合計がavailableを超えます。
+func fitTracks(mins []int, available, fixed int) []int {
+	sizes := make([]int, len(mins))
+	if fixed > 0 {
+		for i, m := range mins {
+			sizes[i] = max(fixed, m)
+		}
+		return sizes
+	}
+
+	// 均等に分けた大きさより最小サイズが大きいトラックを最小サイズで固定し、残りのトラックで分け直すことを、
+	// 固定するトラックがなくなるまで繰り返します。
+	pinned := make([]bool, len(mins))
+	remaining, free := available, len(mins)
+	for changed := true; changed && free > 0; {
+		changed = false
+		share := max(0, remaining) / free
+		for i, m := range mins {
+			if !pinned[i] && m > share {
+				pinned[i] = true
+				sizes[i] = m
+				remaining -= m
+				free--
+				changed = true
+			}
+		}
+	}
+
+	remaining = max(0, remaining)
+	n := 0
+	for i := range sizes {
+		if !pinned[i] {
+			sizes[i] = evenShare(remaining, free, n)
+			n++
+		}
+	}
+	return sizes
 }
\ No newline at end of file
@@ -5,6 +5,7 @@ import (
 )
 
 // Layout は、コンテナ内の子要素をどのように配置するかを決定するロジックのインターフェースです。
+// 子の計測(GetMinSize、GetHeightForWidth)の呼び出し方は、component/measure_cache.go に記載された約束事に従ってください。
 // NOTE: Layoutメソッドがerrorを返すようにシグネチャが変更されました。
 //       これにより、レイアウト計算中の問題をpanicさせることなく、安全に呼び出し元へ伝えることができます。
 type Layout interface {
@@ -46,6 +47,12 @@ const (
 	AlignCenter
 	AlignEnd
 	AlignStretch
+	// AlignSpaceBetween は、先頭と末尾を両端に揃え、余ったスペースを間に均等に分けます。
+	// 現在はFlexLayoutのAlignContentでのみ有効で、それ以外ではAlignStartとして扱われます。
+	AlignSpaceBetween
+	// AlignSpaceAround は、余ったスペースを各要素の前後に均等に分けます。要素間の間隔は両端の間隔の2倍になります。
+	// 現在はFlexLayoutのAlignContentでのみ有効で、それ以外ではAlignStartとして扱われます。
+	AlignSpaceAround
 )
 
 // Direction は要素を並べる方向を定義します。
@@ -0,0 +1,72 @@
+package layout
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelMeasureThreshold は、並列計測を行う兄弟要素数の下限です。
+// 子の数がこれ未満のコンテナでは、ゴルーチンの起動コストの方が大きくなるため逐次的に計測します。
+var ParallelMeasureThreshold = 256
+
+var parallelMeasureEnabled atomic.Bool
+
+// SetParallelMeasure は、FlexLayoutの計測フェーズを複数のゴルーチンで並列に実行するかを設定します。
+// インベントリやノードエディタのように、数千のウィジェットを持つUI向けのオプトイン機能で、デフォルトは無効です。
+//
+// 並列計測では、兄弟ウィジェットのGetMinSizeとGetHeightForWidthが同時に呼び出されます。
+// フォントフェイスは複数のゴルーチンから同時に使用できないため、標準のウィジェットはテキストの計測を
+// component.MeasureStringなどのロックを取る関数を通して行います。このためテキストの計測そのものは直列化され、
+// 主に単語の分割や折り返し処理が並列化されます。
+// カスタムウィジェットでは、フェイスのメソッドやfont.MeasureStringを直接呼び出さずにこれらの関数を使用してください。
+// また、これらのメソッドが共有状態を変更する場合は、有効にしないでください。
+func SetParallelMeasure(enabled bool) {
+	parallelMeasureEnabled.Store(enabled)
+}
+
+// ParallelMeasureEnabled は、並列計測が有効かどうかを返します。
+func ParallelMeasureEnabled() bool {
+	return parallelMeasureEnabled.Load()
+}
+
+// measureItems は、各アイテムに対してmeasureを呼び出します。
+// 並列計測が有効で、アイテム数がしきい値以上の場合は、アイテムをワーカーの数に分割して
+// 並列に処理し、すべての完了を待ってから戻ります。
+// measureは、渡されたアイテムとそのウィジェットの状態だけを変更しなければなりません。
+func measureItems(items []*flexItemInfo, measure func(item *flexItemInfo)) {
+	workers := runtime.GOMAXPROCS(0)
+	if !parallelMeasureEnabled.Load() || len(items) < ParallelMeasureThreshold || workers < 2 {
+		for _, item := range items {
+			measure(item)
+		}
+		return
+	}
+
+	chunkSize := (len(items) + workers - 1) / workers
+	var wg sync.WaitGroup
+	var panicOnce sync.Once
+	var recovered any
+
+	for start := 0; start < len(items); start += chunkSize {
+		chunk := items[start:min(start+chunkSize, len(items))]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// ワーカー内のパニックでプロセス全体が落ちないように捕捉し、呼び出し元のゴルーチンで再送出します。
+			defer func() {
+				if r := recover(); r != nil {
+					panicOnce.Do(func() { recovered = r })
+				}
+			}()
+			for _, item := range chunk {
+				measure(item)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if recovered != nil {
+		panic(recovered)
+	}
+}
@@ -1,6 +1,9 @@
 package layout
 
-import "furoshiki/component"
+import (
+	"furoshiki/component"
+	"furoshiki/stats"
+)
 
 // ScrollViewLayout は、ScrollViewウィジェットのための専用レイアウトマネージャです。
 type ScrollViewLayout struct{}
@@ -9,6 +12,7 @@ type ScrollViewLayout struct{}
 // 2パスレイアウト（計測→配置）のアプローチを取ります。
 // NOTE: Layoutインターフェースの変更に伴い、errorを返すようにシグネチャが更新されました。
 func (l *ScrollViewLayout) Layout(container Container) error {
+	traceLayout(container)
 	scroller, ok := container.(ScrollViewer)
 	if !ok {
 		// 【提案1】インターフェースがスリム化したため、containerがScrollViewerを
@@ -55,7 +59,7 @@ func (l *ScrollViewLayout) Layout(container Container) error {
 	if hw, ok := content.(component.HeightForWider); ok {
 		// ケース1: HeightForWiderを実装するウィジェット (例: 折り返し可能なLabel)
 		// 幅から直接、必要な高さを計算できるため、最も効率的です。
-		measuredContentHeight = hw.GetHeightForWidth(potentialContentWidth)
+		measuredContentHeight = measureHeightForWidth(content, hw, potentialContentWidth)
 	} else if c, ok := content.(Container); ok {
 		// ケース2: コンテナウィジェット (例: VStack, HStack)
 		// 描画せずにコンテンツの本来の高さを知るため、十分な高さを与えて
@@ -99,12 +103,10 @@ func (l *ScrollViewLayout) Layout(container Container) error {
 	} else {
 		// ケース3: 上記以外のウィジェット (HeightForWiderを実装しない単一ウィジェット)
 		// コンテンツ自身の最小の高さを必要な高さとみなします。
-		// 【提案1】型アサーションの追加
-		if mss, ok := content.(component.MinSizeSetter); ok {
-			_, measuredContentHeight = mss.GetMinSize()
-		}
+		_, measuredContentHeight = measureMinSize(content)
 	}
 	scroller.SetContentHeight(measuredContentHeight)
+	stats.AddMeasured(1)
 
 	// --- 2. 配置(Arrange)パス ---
 	// 計測した高さに基づき、スクロールバーの要否を決定し、最終的な配置を計算します。
@@ -127,7 +129,7 @@ func (l *ScrollViewLayout) Layout(container Container) error {
 	if finalContentWidth != potentialContentWidth {
 		// 再計測が必要なのは、幅に依存して高さが変わるウィジェットのみ
 		if hw, ok := content.(component.HeightForWider); ok {
-			measuredContentHeight = hw.GetHeightForWidth(finalContentWidth)
+			measuredContentHeight = measureHeightForWidth(content, hw, finalContentWidth)
 		} else if c, ok := content.(Container); ok {
 			// NOTE: 幅が変わったため、コンテナの高さも再計測します。
 			const layoutMeasureHeight = 1_000_000
@@ -161,12 +163,15 @@ func (l *ScrollViewLayout) Layout(container Container) error {
 	if ps, ok := content.(component.PositionSetter); ok {
 		ps.SetPosition(viewX+padding.Left, viewY+padding.Top-int(currentScrollY))
 	}
+	traceArrange(content)
+	stats.AddArranged(1)
 
 	if isVScrollNeeded && vScrollBar != nil {
 		// NOTE: インターフェース定義にPositionSetterとSizeSetterを
 		// 含めたので直接呼び出し可能です。
 		vScrollBar.SetPosition(viewX+viewWidth-padding.Right-scrollBarWidth, viewY+padding.Top)
 		vScrollBar.SetSize(scrollBarWidth, contentAreaHeight)
+		traceArrange(vScrollBar)
 
 		contentRatio := float64(contentAreaHeight) / float64(measuredContentHeight)
 		scrollRatio := 0.0
@@ -0,0 +1,230 @@
+package layout
+
+import (
+	"fmt"
+	"furoshiki/component"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// このファイルは、レイアウト計算の各段階を記録するトレース機能を提供します。
+// 有効にすると、レイアウトの実行、子の計測(GetMinSize、GetHeightForWidth)、子の配置のたびに、
+// 入力された制約と結果がトレーサーに渡されます。「なぜこのLabelの高さが0なのか」といった問題を、
+// レイアウトのコードに出力を書き足すことなく調べるために使用します。
+//
+//	layout.SetTracer(panel, layout.LogTracer(os.Stderr))
+//	defer layout.SetTracer(nil, nil)
+//
+// 出力の例:
+//
+//	layout VStack#panel content=280x400
+//	  measure Label#title min -> 120x0
+//	  measure Label#title heightForWidth(280) -> 0
+//	  arrange Label#title -> (10,10 280x0)
+//
+// トレースが無効な間は、各段階で1回の原子的な読み込みが追加されるだけです。
+
+// TracePhase は、トレースされたレイアウト計算の段階です。
+type TracePhase int
+
+const (
+	// TraceLayout は、コンテナのレイアウトの実行の開始です。Width、Heightはコンテンツ領域の大きさです。
+	TraceLayout TracePhase = iota
+	// TraceMeasure は、子の計測です。Width、Heightは計測結果です。
+	TraceMeasure
+	// TraceArrange は、子の配置です。X、Y、Width、Heightは配置後の境界です。
+	TraceArrange
+)
+
+// String は、段階の名前を返します。
+func (p TracePhase) String() string {
+	switch p {
+	case TraceLayout:
+		return "layout"
+	case TraceMeasure:
+		return "measure"
+	case TraceArrange:
+		return "arrange"
+	default:
+		return fmt.Sprintf("TracePhase(%d)", int(p))
+	}
+}
+
+// TraceEvent は、トレースされた1回のレイアウト計算の段階です。
+type TraceEvent struct {
+	Phase  TracePhase
+	Widget component.Widget
+	// Depth は、SetTracerに渡したルートを0とした、Widgetの深さです。
+	Depth int
+	// Query は、計測の種類です。"min"(GetMinSize)、"heightForWidth"(GetHeightForWidth)、"widthForHeight"(GetWidthForHeight)のいずれかです。
+	// widthForHeightでは、Heightが与えられた高さ、Widthが計測結果の幅です。
+	Query string
+	// AvailableWidth は、heightForWidthの計測に与えられた幅です。それ以外では-1です。
+	AvailableWidth int
+	X, Y           int
+	Width, Height  int
+}
+
+// String は、イベントを1行の文字列で返します。字下げは含みません。
+func (e TraceEvent) String() string {
+	name := traceName(e.Widget)
+	switch e.Phase {
+	case TraceLayout:
+		return fmt.Sprintf("layout %s content=%dx%d", name, e.Width, e.Height)
+	case TraceMeasure:
+		if e.Query == "heightForWidth" {
+			return fmt.Sprintf("measure %s heightForWidth(%d) -> %d", name, e.AvailableWidth, e.Height)
+		}
+		if e.Query == "widthForHeight" {
+			return fmt.Sprintf("measure %s widthForHeight(%d) -> %d", name, e.Height, e.Width)
+		}
+		return fmt.Sprintf("measure %s %s -> %dx%d", name, e.Query, e.Width, e.Height)
+	default:
+		return fmt.Sprintf("%s %s -> (%d,%d %dx%d)", e.Phase, name, e.X, e.Y, e.Width, e.Height)
+	}
+}
+
+// Tracer は、トレースされたイベントを受け取る関数です。
+// 並列計測(SetParallelMeasure)が有効な場合も、同時に呼び出されることはありません。
+type Tracer func(e TraceEvent)
+
+// traceState は、有効なトレースの設定です。
+type traceState struct {
+	root   component.Widget
+	tracer Tracer
+	mu     sync.Mutex
+}
+
+// activeTrace は、有効なトレースの設定です。トレースが無効な間はnilです。
+var activeTrace atomic.Pointer[traceState]
+
+// SetTracer は、rootとその子孫のレイアウト計算をtracerに渡すトレースを開始します。
+// rootがnilの場合は、すべてのウィジェットを対象にします。tracerがnilの場合はトレースを終了します。
+// トレースは同時に1つだけ有効にできます。
+func SetTracer(root component.Widget, tracer Tracer) {
+	if tracer == nil {
+		activeTrace.Store(nil)
+		return
+	}
+	activeTrace.Store(&traceState{root: root, tracer: tracer})
+}
+
+// IsTracing は、トレースが有効かどうかを返します。
+func IsTracing() bool {
+	return activeTrace.Load() != nil
+}
+
+// LogTracer は、各イベントを深さに応じて字下げした1行としてwに書き出すトレーサーを返します。
+// 書き込みのエラーは無視されます。
+func LogTracer(w io.Writer) Tracer {
+	return func(e TraceEvent) {
+		fmt.Fprintf(w, "%s%s\n", strings.Repeat("  ", e.Depth), e)
+	}
+}
+
+// emit は、ウィジェットがトレースの対象であれば、イベントをトレーサーに渡します。
+func (t *traceState) emit(e TraceEvent) {
+	depth, ok := traceDepth(t.root, e.Widget)
+	if !ok {
+		return
+	}
+	e.Depth = depth
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tracer(e)
+}
+
+// traceDepth は、wがrootまたはその子孫であればrootからの深さとtrueを返します。
+// rootがnilの場合は、ツリーのルートからの深さを返します。
+func traceDepth(root, w component.Widget) (int, bool) {
+	depth := 0
+	for current := w; current != nil; current = current.GetParent() {
+		if current == root {
+			return depth, true
+		}
+		if current.GetParent() == nil {
+			break
+		}
+		depth++
+	}
+	return depth, root == nil
+}
+
+// traceName は、イベントに表示するウィジェットの名前を返します(例: "Label#title")。
+func traceName(w component.Widget) string {
+	if w == nil {
+		return "<nil>"
+	}
+	name := component.WidgetName(w)
+	if ident, ok := w.(component.Identifiable); ok && ident.GetID() != "" {
+		name += "#" + ident.GetID()
+	}
+	return name
+}
+
+// traceLayout は、トレース中であれば、コンテナのレイアウトの開始を記録します。
+func traceLayout(container Container) {
+	t := activeTrace.Load()
+	if t == nil {
+		return
+	}
+	width, height := container.GetSize()
+	padding := container.GetPadding()
+	t.emit(TraceEvent{
+		Phase:          TraceLayout,
+		Widget:         container,
+		AvailableWidth: -1,
+		Width:          width - padding.Left - padding.Right,
+		Height:         height - padding.Top - padding.Bottom,
+	})
+}
+
+// measureMinSize は、ウィジェットの最小サイズを返します。MinSizeSetterを実装していない場合は0です。
+// トレース中であれば、計測を記録します。
+func measureMinSize(w component.Widget) (minW, minH int) {
+	mss, ok := w.(component.MinSizeSetter)
+	if !ok {
+		return 0, 0
+	}
+	minW, minH = mss.GetMinSize()
+	if t := activeTrace.Load(); t != nil {
+		t.emit(TraceEvent{Phase: TraceMeasure, Widget: w, Query: "min", AvailableWidth: -1, Width: minW, Height: minH})
+	}
+	return minW, minH
+}
+
+// measureHeightForWidth は、幅widthでのウィジェットの高さを返します。トレース中であれば、計測を記録します。
+func measureHeightForWidth(w component.Widget, hw component.HeightForWider, width int) int {
+	height := hw.GetHeightForWidth(width)
+	if t := activeTrace.Load(); t != nil {
+		t.emit(TraceEvent{Phase: TraceMeasure, Widget: w, Query: "heightForWidth", AvailableWidth: width, Width: width, Height: height})
+	}
+	return height
+}
+
+// measureWidthForHeight は、高さheightでのウィジェットの幅を返します。トレース中であれば、計測を記録します。
+func measureWidthForHeight(w component.Widget, wh component.WidthForHeighter, height int) int {
+	width := wh.GetWidthForHeight(height)
+	if t := activeTrace.Load(); t != nil {
+		t.emit(TraceEvent{Phase: TraceMeasure, Widget: w, Query: "widthForHeight", AvailableWidth: -1, Width: width, Height: height})
+	}
+	return width
+}
+
+// traceArrange は、トレース中であれば、配置を終えたウィジェットの境界を記録します。
+func traceArrange(w component.Widget) {
+	t := activeTrace.Load()
+	if t == nil {
+		return
+	}
+	e := TraceEvent{Phase: TraceArrange, Widget: w, AvailableWidth: -1}
+	if ps, ok := w.(component.PositionSetter); ok {
+		e.X, e.Y = ps.GetPosition()
+	}
+	if ss, ok := w.(component.SizeSetter); ok {
+		e.Width, e.Height = ss.GetSize()
+	}
+	t.emit(e)
+}
@@ -0,0 +1,110 @@
+package furoshiki
+
+import (
+	"furoshiki/component"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// lowPowerGraceFrames は、入力や変化がなくなってから更新と描画を止めるまでに、追加で処理するフレーム数です。
+// ホバー状態の変化など、入力の直後に起きる変化を描画し終えるために使用します。
+const lowPowerGraceFrames = 2
+
+// lowPower は、省電力モードの状態です。
+var lowPower struct {
+	enabled bool
+	// active は、このフレームで更新と描画が必要かどうかを表します。
+	active bool
+	// graceFrames は、変化がなくなった後に処理を続ける残りのフレーム数です。
+	graceFrames int
+	// cursorX, cursorY は、前回のフレームのカーソルの位置です。
+	cursorX, cursorY int
+}
+
+// SetLowPowerMode は、省電力モードを有効または無効にします。
+// 有効な間は、ウィジェットがダーティにならず入力もないフレームで、ShouldUpdateとShouldDrawがfalseを返します。
+// メニューだけの画面などで、何も変化しない間のCPUとGPUの消費を抑えるために使用します。
+//
+//	func (g *Game) Update() error {
+//		furoshiki.NextFrame()
+//		if !furoshiki.ShouldUpdate() {
+//			return nil
+//		}
+//		...
+//	}
+//
+//	func (g *Game) Draw(screen *ebiten.Image) {
+//		if !furoshiki.ShouldDraw() {
+//			return // 前回のフレームの内容がそのまま表示されます
+//		}
+//		...
+//	}
+//
+// 描画を省略したフレームで前回の内容を残すため、有効な間は ebiten.SetScreenClearedEveryFrame(false) が設定されます。
+// NOTE: Updateが呼び出されない間は、毎フレームの関数で位置や変換を決めるウィジェット(ui.WorldAnchor、
+//       Container.TrackTransformなど)も止まります。ゲームの画面が動き続ける場面では有効にしないでください。
+func SetLowPowerMode(on bool) {
+	lowPower.enabled = on
+	lowPower.active = true
+	lowPower.graceFrames = lowPowerGraceFrames
+	ebiten.SetScreenClearedEveryFrame(!on)
+	component.RequestFrame()
+}
+
+// LowPowerMode は、省電力モードが有効かどうかを返します。
+func LowPowerMode() bool {
+	return lowPower.enabled
+}
+
+// ShouldUpdate は、このフレームでUIの更新(イベントのディスパッチとUpdate)を行う必要があるかを返します。
+// ebiten.GameのUpdateの先頭で毎フレーム1回呼び出してください。省電力モードが無効な場合は常にtrueです。
+func ShouldUpdate() bool {
+	if !lowPower.enabled {
+		return true
+	}
+	// 入力の判定は、カーソルの位置の記録を更新するため、常に行います。
+	input := inputOccurred()
+	switch {
+	case component.TakeFrameRequest() || input:
+		lowPower.graceFrames = lowPowerGraceFrames
+		lowPower.active = true
+	case lowPower.graceFrames > 0:
+		lowPower.graceFrames--
+		lowPower.active = true
+	default:
+		lowPower.active = false
+	}
+	return lowPower.active
+}
+
+// ShouldDraw は、このフレームでUIを描画する必要があるかを返します。
+// 直前のShouldUpdateの判定に従います。省電力モードが無効な場合は常にtrueです。
+func ShouldDraw() bool {
+	return !lowPower.enabled || lowPower.active
+}
+
+// inputOccurred は、前回の呼び出しからマウス、キーボード、タッチの入力があったかどうかを返します。
+func inputOccurred() bool {
+	x, y := ebiten.CursorPosition()
+	moved := x != lowPower.cursorX || y != lowPower.cursorY
+	lowPower.cursorX, lowPower.cursorY = x, y
+	if moved {
+		return true
+	}
+	if wx, wy := ebiten.Wheel(); wx != 0 || wy != 0 {
+		return true
+	}
+	for b := ebiten.MouseButton0; b <= ebiten.MouseButtonMax; b++ {
+		if ebiten.IsMouseButtonPressed(b) || inpututil.IsMouseButtonJustReleased(b) {
+			return true
+		}
+	}
+	if len(inpututil.AppendPressedKeys(nil)) > 0 || len(inpututil.AppendJustReleasedKeys(nil)) > 0 {
+		return true
+	}
+	if len(ebiten.AppendInputChars(nil)) > 0 {
+		return true
+	}
+	return len(ebiten.AppendTouchIDs(nil)) > 0 || len(inpututil.AppendJustReleasedTouchIDs(nil)) > 0
+}
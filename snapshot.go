@@ -0,0 +1,42 @@
+package furoshiki
+
+import (
+	"furoshiki/component"
+	"furoshiki/stats"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Snapshot は、ウィジェットとその子孫を、ウィジェットの大きさの新しい画像に描画して返します。
+// 複雑なパネルのサムネイル、共有用のスクリーンショット、テストでの描画結果の確認などに使用します。
+//
+// ウィジェットの左上が画像の原点になるよう描画オフセットを設定し、描画キャッシュ、シェーダー、
+// 描画フックも画面への描画と同じように適用します。一度もレイアウトされていないウィジェットは、
+// 先にUpdateを呼び出してレイアウトを確定させます。
+// 大きさが0のウィジェットの場合はnilを返します。返された画像が不要になったら、Deallocateで解放してください。
+func Snapshot(w component.Widget) *ebiten.Image {
+	if w == nil {
+		return nil
+	}
+	if is, ok := w.(component.InteractiveState); ok && !is.HasBeenLaidOut() {
+		w.Update()
+	}
+	var x, y, width, height int
+	if ps, ok := w.(component.PositionSetter); ok {
+		x, y = ps.GetPosition()
+	}
+	if ss, ok := w.(component.SizeSetter); ok {
+		width, height = ss.GetSize()
+	}
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	img := ebiten.NewImage(width, height)
+	stats.AddOffscreenAllocation()
+	// 新しい画像への描画を始める前に、保留中の描画命令を確定させます。
+	component.FlushDraws()
+	component.DrawWidget(w, component.DrawInfo{Screen: img, OffsetX: -x, OffsetY: -y})
+	component.FlushDraws()
+	return img
+}
@@ -0,0 +1,190 @@
+// Package stats は、フレーム単位のパフォーマンスカウンタを提供します。
+// レイアウトや描画、イベント処理のコードはこのパッケージの関数を呼び出してカウンタを加算し、
+// アプリケーションは furoshiki.Stats() を通じて直前のフレームの集計結果を取得します。
+//
+// 計測はデフォルトで無効です。無効な間、各Add関数はアトミックなフラグを確認するだけで
+// すぐに戻るため、計測コードを常に埋め込んでおいても実質的なコストはありません。
+package stats
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FrameStats は、1フレーム分のカウンタの集計結果です。
+type FrameStats struct {
+	// Frame は、NextFrameが呼び出された回数に基づくフレーム番号です。
+	Frame uint64
+	// LayoutPasses は、コンテナのレイアウト計算が実行された回数です。
+	LayoutPasses int64
+	// WidgetsMeasured は、レイアウト計算中に固有サイズを計測されたウィジェットの数です。
+	WidgetsMeasured int64
+	// WidgetsArranged は、レイアウト計算によって位置とサイズを設定されたウィジェットの数です。
+	WidgetsArranged int64
+	// DrawCalls は、描画ヘルパーが発行したEbitengineの描画命令の数です。
+	DrawCalls int64
+	// OffscreenAllocations は、クリッピングなどのために確保されたオフスクリーン画像の数です。
+	OffscreenAllocations int64
+	// EventDispatchTime は、イベントディスパッチに費やされた時間の合計です。
+	EventDispatchTime time.Duration
+	// LayoutTime は、コンテナのレイアウト計算に費やされた時間の合計です。
+	LayoutTime time.Duration
+	// RedrawRequests は、再描画だけを要求するMarkDirtyの呼び出し回数です。
+	RedrawRequests int64
+	// RelayoutRequests は、再レイアウトを要求するMarkDirtyの呼び出し回数です。親への伝播による呼び出しも含みます。
+	RelayoutRequests int64
+	// OffscreenBytes は、フレームの終わりの時点で、ライブラリが保持しているオフスクリーン画像の推定使用量(バイト)です。
+	// 他のカウンタと異なりフレームごとにリセットされず、計測が無効な間も集計されます。
+	OffscreenBytes int64
+}
+
+var (
+	enabled atomic.Bool
+
+	layoutPasses         atomic.Int64
+	widgetsMeasured      atomic.Int64
+	widgetsArranged      atomic.Int64
+	drawCalls            atomic.Int64
+	offscreenAllocations atomic.Int64
+	eventDispatchNanos   atomic.Int64
+	layoutNanos          atomic.Int64
+	redrawRequests       atomic.Int64
+	relayoutRequests     atomic.Int64
+	// offscreenBytes は、計測の有効・無効にかかわらず集計される、オフスクリーン画像の現在の使用量です。
+	offscreenBytes atomic.Int64
+
+	frameMutex sync.Mutex
+	frame      uint64
+	lastFrame  FrameStats
+)
+
+// SetEnabled は、計測の有効・無効を切り替えます。
+func SetEnabled(on bool) {
+	enabled.Store(on)
+}
+
+// Enabled は、計測が有効かどうかを返します。
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// AddLayoutPass は、レイアウト計算の実行回数を1加算します。
+func AddLayoutPass() {
+	if enabled.Load() {
+		layoutPasses.Add(1)
+	}
+}
+
+// AddMeasured は、計測されたウィジェットの数を加算します。
+func AddMeasured(n int) {
+	if enabled.Load() {
+		widgetsMeasured.Add(int64(n))
+	}
+}
+
+// AddArranged は、配置されたウィジェットの数を加算します。
+func AddArranged(n int) {
+	if enabled.Load() {
+		widgetsArranged.Add(int64(n))
+	}
+}
+
+// AddDrawCalls は、描画命令の数を加算します。
+func AddDrawCalls(n int) {
+	if enabled.Load() {
+		drawCalls.Add(int64(n))
+	}
+}
+
+// AddOffscreenAllocation は、オフスクリーン画像の確保回数を1加算します。
+func AddOffscreenAllocation() {
+	if enabled.Load() {
+		offscreenAllocations.Add(1)
+	}
+}
+
+// AddEventDispatchTime は、イベントディスパッチに費やされた時間を加算します。
+func AddEventDispatchTime(d time.Duration) {
+	if enabled.Load() {
+		eventDispatchNanos.Add(int64(d))
+	}
+}
+
+// AddLayoutTime は、レイアウト計算に費やされた時間を加算します。
+func AddLayoutTime(d time.Duration) {
+	if enabled.Load() {
+		layoutNanos.Add(int64(d))
+	}
+}
+
+// AddDirtyRequest は、MarkDirtyの呼び出し回数を1加算します。relayoutは、再レイアウトの要求かどうかです。
+func AddDirtyRequest(relayout bool) {
+	if !enabled.Load() {
+		return
+	}
+	if relayout {
+		relayoutRequests.Add(1)
+	} else {
+		redrawRequests.Add(1)
+	}
+}
+
+// AddOffscreenBytes は、オフスクリーン画像の使用量を加算します。画像を解放した場合は負の値を渡します。
+// 使用量は予算(component.SetOffscreenBudget)の判定にも使われるため、計測が無効な間も集計されます。
+func AddOffscreenBytes(delta int64) {
+	offscreenBytes.Add(delta)
+}
+
+// OffscreenBytes は、ライブラリが現在保持しているオフスクリーン画像の推定使用量(バイト)を返します。
+func OffscreenBytes() int64 {
+	return offscreenBytes.Load()
+}
+
+// NextFrame は、現在のフレームのカウンタを確定させて LastFrame から参照できるようにし、
+// 次のフレームのためにカウンタをリセットします。
+// アプリケーションのUpdateの先頭で、毎フレーム1回呼び出してください。
+func NextFrame() {
+	frameMutex.Lock()
+	defer frameMutex.Unlock()
+	frame++
+	lastFrame = FrameStats{
+		Frame:                frame,
+		LayoutPasses:         layoutPasses.Swap(0),
+		WidgetsMeasured:      widgetsMeasured.Swap(0),
+		WidgetsArranged:      widgetsArranged.Swap(0),
+		DrawCalls:            drawCalls.Swap(0),
+		OffscreenAllocations: offscreenAllocations.Swap(0),
+		EventDispatchTime:    time.Duration(eventDispatchNanos.Swap(0)),
+		LayoutTime:           time.Duration(layoutNanos.Swap(0)),
+		RedrawRequests:       redrawRequests.Swap(0),
+		RelayoutRequests:     relayoutRequests.Swap(0),
+		OffscreenBytes:       offscreenBytes.Load(),
+	}
+}
+
+// LastFrame は、直前に確定したフレームの集計結果を返します。
+func LastFrame() FrameStats {
+	frameMutex.Lock()
+	defer frameMutex.Unlock()
+	return lastFrame
+}
+
+// Current は、現在集計中のフレームのカウンタの値を返します。
+func Current() FrameStats {
+	frameMutex.Lock()
+	defer frameMutex.Unlock()
+	return FrameStats{
+		Frame:                frame + 1,
+		LayoutPasses:         layoutPasses.Load(),
+		WidgetsMeasured:      widgetsMeasured.Load(),
+		WidgetsArranged:      widgetsArranged.Load(),
+		DrawCalls:            drawCalls.Load(),
+		OffscreenAllocations: offscreenAllocations.Load(),
+		EventDispatchTime:    time.Duration(eventDispatchNanos.Load()),
+		LayoutTime:           time.Duration(layoutNanos.Load()),
+		RedrawRequests:       redrawRequests.Load(),
+		RelayoutRequests:     relayoutRequests.Load(),
+		OffscreenBytes:       offscreenBytes.Load(),
+	}
+}
@@ -0,0 +1,95 @@
+package style
+
+import (
+	"image/color"
+
+	"golang.org/x/image/font"
+)
+
+// Computed は、Styleのポインタフィールドをすべて解決した値型のスタイルです。
+// 未設定のプロパティにはデフォルト値が入り、Opacityは各色にあらかじめ適用されています。
+//
+// Styleは「未設定」を表現するためにポインタを多用するため、描画やレイアウトのたびに
+// 参照を辿ったり、不透明度を適用した色を生成したりするとヒープ割り当てが発生します。
+// Computedは一度だけ解決してキャッシュしておき、ホットパスではこれを値のまま受け渡すことで、
+// フレームごとの割り当てをなくします。
+type Computed struct {
+	// Background は背景色です。nilの場合、背景は描画されません。
+	Background color.Color
+	// BorderColor は境界線の色です。nilの場合、境界線は描画されません。
+	BorderColor  color.Color
+	BorderWidth  float32
+	BorderRadius float32
+	Margin       Insets
+	Padding      Insets
+	// Font はテキストのフォントです。nilの場合、テキストは描画されません。
+	Font          font.Face
+	TextColor     color.Color
+	Opacity       float64
+	TextAlign     TextAlignType
+	VerticalAlign VerticalAlignType
+	TextTruncate  TextTruncateType
+	LineBreak     LineBreakType
+}
+
+// Resolve は、Styleを解決してComputedを生成します。
+// 未設定のプロパティには、描画ヘルパーが従来使用していたものと同じデフォルト値が設定されます。
+func Resolve(s Style) Computed {
+	c := Computed{
+		TextColor:     color.Black,
+		Opacity:       1,
+		TextAlign:     TextAlignStart,
+		VerticalAlign: VerticalAlignMiddle,
+	}
+	if s.Opacity != nil {
+		c.Opacity = *s.Opacity
+	}
+	if s.Background != nil && *s.Background != nil && *s.Background != color.Transparent {
+		c.Background = withOpacity(*s.Background, s.Opacity)
+	}
+	if s.BorderWidth != nil {
+		c.BorderWidth = *s.BorderWidth
+	}
+	if s.BorderColor != nil && *s.BorderColor != nil && *s.BorderColor != color.Transparent && c.BorderWidth > 0 {
+		c.BorderColor = withOpacity(*s.BorderColor, s.Opacity)
+	}
+	if s.BorderRadius != nil {
+		c.BorderRadius = *s.BorderRadius
+	}
+	if s.Margin != nil {
+		c.Margin = *s.Margin
+	}
+	if s.Padding != nil {
+		c.Padding = *s.Padding
+	}
+	if s.Font != nil {
+		c.Font = *s.Font
+	}
+	if s.TextColor != nil && *s.TextColor != nil {
+		c.TextColor = *s.TextColor
+	}
+	c.TextColor = withOpacity(c.TextColor, s.Opacity)
+	if s.TextAlign != nil {
+		c.TextAlign = *s.TextAlign
+	}
+	if s.VerticalAlign != nil {
+		c.VerticalAlign = *s.VerticalAlign
+	}
+	if s.TextTruncate != nil {
+		c.TextTruncate = *s.TextTruncate
+	}
+	if s.LineBreak != nil {
+		c.LineBreak = *s.LineBreak
+	}
+	return c
+}
+
+// withOpacity は、色に不透明度を適用した新しい色を返します。opacityがnilの場合は元の色を返します。
+func withOpacity(c color.Color, opacity *float64) color.Color {
+	if c == nil || opacity == nil {
+		return c
+	}
+	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	nrgba.A = uint8(float64(nrgba.A) * (*opacity))
+	return nrgba
+}
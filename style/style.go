@@ -14,6 +14,10 @@ const (
 	TextAlignLeft TextAlignType = iota
 	TextAlignCenter
 	TextAlignRight
+	// TextAlignStart は、段落の先頭側に揃えます。左から右に書くテキストでは左揃え、右から左に書くテキストでは右揃えになります。
+	TextAlignStart
+	// TextAlignEnd は、段落の末尾側に揃えます。TextAlignStartの反対側になります。
+	TextAlignEnd
 )
 
 // VerticalAlignType はテキストの垂直方向の揃え位置を定義します。
@@ -25,6 +29,35 @@ const (
 	VerticalAlignBottom
 )
 
+// TextTruncateType は、折り返さないテキストがコンテンツ領域に収まらない場合の扱いを定義します。
+type TextTruncateType int
+
+const (
+	// TextTruncateNone は、テキストを切り詰めずにそのまま描画します（はみ出します）。
+	TextTruncateNone TextTruncateType = iota
+	// TextTruncateClip は、コンテンツ領域の境界でテキストを切り取ります。
+	TextTruncateClip
+	// TextTruncateEllipsis は、末尾を "..." に置き換えて収まるように切り詰めます。
+	TextTruncateEllipsis
+	// TextTruncateMiddleEllipsis は、中央を "..." に置き換えて先頭と末尾を残します。ファイルパスなどに使用します。
+	TextTruncateMiddleEllipsis
+	// TextTruncateFade は、コンテンツ領域の境界で切り取り、末尾を徐々に透明にします。
+	TextTruncateFade
+)
+
+// LineBreakType は、テキストを折り返す位置の決め方を定義します。
+type LineBreakType int
+
+const (
+	// LineBreakAuto は、空白の位置に加えて、日本語や中国語などの単語を空白で区切らない文字(漢字、ひらがな、カタカナなど)の
+	// 前後でも折り返します。空白で区切る言語のテキストはLineBreakWordと同じ結果になります。
+	LineBreakAuto LineBreakType = iota
+	// LineBreakWord は、空白の位置でのみ折り返します。
+	LineBreakWord
+	// LineBreakCharacter は、空白で区切られた単語の途中を含め、任意の文字の間で折り返します。
+	LineBreakCharacter
+)
+
 // Styleはコンポーネントの視覚的プロパティを定義します。
 // 多くのフィールドがポインタ型になっており、「未設定」の状態を区別できます。
 type Style struct {
@@ -39,6 +72,8 @@ type Style struct {
 	Opacity       *float64
 	TextAlign     *TextAlignType
 	VerticalAlign *VerticalAlignType
+	TextTruncate  *TextTruncateType
+	LineBreak     *LineBreakType
 }
 
 // Insetsはマージンやパディングの四方の値を表します。
@@ -83,6 +118,12 @@ func Merge(base, overlay Style) Style {
 	if overlay.VerticalAlign != nil {
 		result.VerticalAlign = overlay.VerticalAlign
 	}
+	if overlay.TextTruncate != nil {
+		result.TextTruncate = overlay.TextTruncate
+	}
+	if overlay.LineBreak != nil {
+		result.LineBreak = overlay.LineBreak
+	}
 	return result
 }
 
@@ -134,6 +175,12 @@ func (s Style) DeepCopy() Style {
 	if s.VerticalAlign != nil {
 		newStyle.VerticalAlign = PVerticalAlignType(*s.VerticalAlign)
 	}
+	if s.TextTruncate != nil {
+		newStyle.TextTruncate = PTextTruncateType(*s.TextTruncate)
+	}
+	if s.LineBreak != nil {
+		newStyle.LineBreak = PLineBreakType(*s.LineBreak)
+	}
 	// s.Font (*font.Face) はインターフェースなのでディープコピーしない
 	return newStyle
 }
@@ -151,7 +198,9 @@ func (s Style) Equals(other Style) bool {
 		compareFloat32Ptr(s.BorderRadius, other.BorderRadius) &&
 		compareFloat64Ptr(s.Opacity, other.Opacity) &&
 		compareTextAlignTypePtr(s.TextAlign, other.TextAlign) &&
-		compareVerticalAlignTypePtr(s.VerticalAlign, other.VerticalAlign)
+		compareVerticalAlignTypePtr(s.VerticalAlign, other.VerticalAlign) &&
+		compareTextTruncateTypePtr(s.TextTruncate, other.TextTruncate) &&
+		compareLineBreakTypePtr(s.LineBreak, other.LineBreak)
 }
 
 // --- Pointer comparison helpers ---
@@ -226,6 +275,26 @@ func compareVerticalAlignTypePtr(a, b *VerticalAlignType) bool {
 	return *a == *b
 }
 
+func compareTextTruncateTypePtr(a, b *TextTruncateType) bool {
+	if a == nil && b == nil {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return *a == *b
+}
+
+func compareLineBreakTypePtr(a, b *LineBreakType) bool {
+	if a == nil && b == nil {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return *a == *b
+}
+
 // --- Pointer Helpers ---
 // これらを使用することで、一時変数を宣言することなく、直接スタイル構造体に値を設定できます。
 // 例: style.Style{ Background: style.PColor(color.White) }
@@ -237,6 +306,8 @@ func PInsets(i Insets) *Insets                                  { return &i }
 func PFont(f font.Face) *font.Face                              { return &f }
 func PTextAlignType(t TextAlignType) *TextAlignType             { return &t }
 func PVerticalAlignType(v VerticalAlignType) *VerticalAlignType { return &v }
+func PTextTruncateType(t TextTruncateType) *TextTruncateType    { return &t }
+func PLineBreakType(l LineBreakType) *LineBreakType             { return &l }
 
 // --- Style Options (Functional) ---
 // 【提案3対応】オプション関数パターンを導入します。
@@ -278,4 +349,10 @@ func WithTextAlign(t TextAlignType) StyleOption {
 }
 func WithVerticalAlign(v VerticalAlignType) StyleOption {
 	return func(s *Style) { s.VerticalAlign = PVerticalAlignType(v) }
+}
+func WithTextTruncate(t TextTruncateType) StyleOption {
+	return func(s *Style) { s.TextTruncate = PTextTruncateType(t) }
+}
+func WithLineBreak(l LineBreakType) StyleOption {
+	return func(s *Style) { s.LineBreak = PLineBreakType(l) }
 }
\ No newline at end of file
@@ -0,0 +1,167 @@
+package theme
+
+import (
+	"fmt"
+	"furoshiki/style"
+	"image/color"
+	"math"
+)
+
+// このファイルは、テーマの文字色と背景色の組み合わせが、WCAG 2.xのコントラスト比の基準を満たしているかを検査する機能を提供します。
+//
+//	for _, issue := range theme.CheckContrast(theme.GetCurrent(), theme.ContrastAA) {
+//		log.Println(issue) // 例: Button.Hovered: contrast 3.21:1 is below 4.50:1 (text #000000 on #c6c6c6)
+//	}
+
+// WCAGで定められたコントラスト比の基準です。
+const (
+	// ContrastAA は、通常の大きさのテキストに求められるレベルAAの基準(4.5:1)です。
+	ContrastAA = 4.5
+	// ContrastAALarge は、大きなテキスト(18pt以上、または14pt以上の太字)に求められるレベルAAの基準(3:1)です。
+	ContrastAALarge = 3.0
+	// ContrastAAA は、通常の大きさのテキストに求められるレベルAAAの基準(7:1)です。
+	ContrastAAA = 7.0
+)
+
+// ContrastIssue は、コントラスト比が基準を下回る文字色と背景色の組み合わせです。
+type ContrastIssue struct {
+	// Name は、組み合わせが使われているスタイルの名前です(例: "Button.Pressed")。
+	Name string
+	// Foreground と Background は、不透明度を適用して合成した後の、実際に表示される文字色と背景色です。
+	Foreground, Background color.Color
+	// Ratio は実際のコントラスト比、Required は求められるコントラスト比です。
+	Ratio, Required float64
+}
+
+// String は、問題の内容を1行の文字列で返します。
+func (i ContrastIssue) String() string {
+	return fmt.Sprintf("%s: contrast %.2f:1 is below %.2f:1 (text %s on %s)",
+		i.Name, i.Ratio, i.Required, hexColor(i.Foreground), hexColor(i.Background))
+}
+
+// ContrastRatio は、2つの色のWCAGのコントラスト比(1から21)を返します。色の順序は問いません。
+// 半透明の色は、不透明の色として扱われます。合成後の色で比較するには、呼び出し側で背景と合成してください。
+func ContrastRatio(a, b color.Color) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// relativeLuminance は、WCAGの定義に従って色の相対輝度(0から1)を返します。
+func relativeLuminance(c color.Color) float64 {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	channel := func(v uint8) float64 {
+		s := float64(v) / 255
+		if s <= 0.04045 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(n.R) + 0.7152*channel(n.G) + 0.0722*channel(n.B)
+}
+
+// CheckContrast は、テーマに含まれるウィジェットのスタイルのうち、文字色と背景色のコントラスト比がminRatioを下回るものを返します。
+// minRatioが0以下の場合はContrastAAを使用します。tがnilの場合は現在のテーマを検査します。
+// 背景が透明なスタイルはテーマのBackgroundColorの上に、状態ごとのスタイル(Hoveredなど)は基本のスタイルに重ねて評価します。
+// 無効状態(Button.Disabled)は、WCAGでコントラストの基準の対象外とされているため検査しません。
+func CheckContrast(t *Theme, minRatio float64) []ContrastIssue {
+	if t == nil {
+		t = GetCurrent()
+	}
+	if minRatio <= 0 {
+		minRatio = ContrastAA
+	}
+	page := opaque(t.BackgroundColor, color.White)
+	text := t.TextColor
+	if text == nil {
+		text = color.Black
+	}
+
+	var issues []ContrastIssue
+	check := func(name string, s style.Style, base color.Color) {
+		fg, bg := effectiveColors(s, text, base)
+		if ratio := ContrastRatio(fg, bg); ratio < minRatio {
+			issues = append(issues, ContrastIssue{Name: name, Foreground: fg, Background: bg, Ratio: ratio, Required: minRatio})
+		}
+	}
+
+	check("Theme", style.Style{}, page)
+	check("Button.Normal", t.Button.Normal, page)
+	check("Button.Hovered", t.Button.Hovered, page)
+	check("Button.Pressed", t.Button.Pressed, page)
+	check("Button.Focused", t.Button.Focused, page)
+	check("Label.Default", t.Label.Default, page)
+
+	input := t.TextInput.Default
+	check("TextInput.Default", input, page)
+	check("TextInput.Focused", style.Merge(input, t.TextInput.Focused), page)
+	check("TextInput.Invalid", style.Merge(input, t.TextInput.Invalid), page)
+	check("TextInput.ErrorMessage", t.TextInput.ErrorMessage, page)
+	// 選択範囲とチップは入力欄の背景の上に重ねて描画されます。
+	_, inputBg := effectiveColors(input, text, page)
+	if sel := t.TextInput.SelectionColor; sel != nil {
+		check("TextInput.SelectionColor", style.Merge(input, style.Style{Background: style.PColor(sel)}), inputBg)
+	}
+	check("TextInput.Chip", t.TextInput.Chip, inputBg)
+
+	// リストの行は、行のスタイルを基本に、ホバーや選択の強調を重ねて描画されます。
+	item := t.List.Item
+	check("List.Item", item, page)
+	check("List.Hovered", style.Merge(item, t.List.Hovered), page)
+	check("List.Selected", style.Merge(item, t.List.Selected), page)
+	check("List.Header", t.List.Header, page)
+	check("List.Action", t.List.Action, page)
+	check("Badge", t.Badge, page)
+	return issues
+}
+
+// effectiveColors は、スタイルの文字色と背景色を、不透明度を適用して合成した、実際に表示される色を返します。
+// 文字色が未設定の場合はtext、背景が未設定または透明の場合はbaseを使用します。
+func effectiveColors(s style.Style, text, base color.Color) (fg, bg color.Color) {
+	c := style.Resolve(s)
+	if s.TextColor == nil || *s.TextColor == nil {
+		c.TextColor = withOpacity(text, c.Opacity)
+	}
+	bg = base
+	if c.Background != nil {
+		bg = blend(c.Background, base)
+	}
+	return blend(c.TextColor, bg), bg
+}
+
+// withOpacity は、色のアルファ値にopacityを掛けた色を返します。
+func withOpacity(c color.Color, opacity float64) color.Color {
+	if opacity >= 1 {
+		return c
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	n.A = uint8(float64(n.A) * max(0, opacity))
+	return n
+}
+
+// blend は、fgをbg(不透明)の上にアルファ合成した不透明の色を返します。
+func blend(fg, bg color.Color) color.Color {
+	f := color.NRGBAModel.Convert(fg).(color.NRGBA)
+	b := color.NRGBAModel.Convert(bg).(color.NRGBA)
+	a := float64(f.A) / 255
+	mix := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x)*a + float64(y)*(1-a)))
+	}
+	return color.NRGBA{R: mix(f.R, b.R), G: mix(f.G, b.G), B: mix(f.B, b.B), A: 255}
+}
+
+// opaque は、cが未設定の場合はfallbackを、そうでなければ白の上に合成した不透明の色を返します。
+func opaque(c, fallback color.Color) color.Color {
+	if c == nil {
+		return fallback
+	}
+	return blend(c, color.White)
+}
+
+// hexColor は、色を #rrggbb の形式の文字列で返します。
+func hexColor(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x", n.R, n.G, n.B)
+}
@@ -0,0 +1,52 @@
+package theme
+
+import (
+	"slices"
+
+	"golang.org/x/image/font"
+)
+
+// SizedFace は、特定のサイズで生成されたフォントフェイスです。
+type SizedFace struct {
+	Size float64
+	Face font.Face
+}
+
+// FaceSet は、同じ書体を複数のサイズで生成したフォントフェイスの集合です。サイズの昇順に並んでいます。
+// Label.AutoFitなど、表示領域に合わせてフォントサイズを選ぶ機能で使用されます。
+type FaceSet []SizedFace
+
+// NewFaceSet は、指定されたフェイスからサイズの昇順に並んだFaceSetを生成します。
+// Faceがnilの要素は無視されます。
+func NewFaceSet(faces ...SizedFace) FaceSet {
+	set := make(FaceSet, 0, len(faces))
+	for _, f := range faces {
+		if f.Face != nil {
+			set = append(set, f)
+		}
+	}
+	slices.SortFunc(set, func(a, b SizedFace) int {
+		switch {
+		case a.Size < b.Size:
+			return -1
+		case a.Size > b.Size:
+			return 1
+		default:
+			return 0
+		}
+	})
+	return set
+}
+
+// Between は、サイズがminSize以上maxSize以下のフェイスを昇順で返します。
+func (fs FaceSet) Between(minSize, maxSize float64) FaceSet {
+	start := 0
+	for start < len(fs) && fs[start].Size < minSize {
+		start++
+	}
+	end := start
+	for end < len(fs) && fs[end].Size <= maxSize {
+		end++
+	}
+	return fs[start:end]
+}
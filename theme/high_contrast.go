@@ -0,0 +1,117 @@
+package theme
+
+import (
+	"furoshiki/style"
+	"image/color"
+)
+
+// NewHighContrastTheme は、弱視の利用者などのための、黒の背景に白と黄色の文字を使うハイコントラストのテーマを生成します。
+// すべての文字色と背景色の組み合わせは、WCAGのレベルAAA(ContrastAAA、7:1)を満たします。
+// ウィジェットは生成時にテーマのスタイルを取り込むため、UIを構築する前にSetCurrentで設定してください。
+//
+//	t := theme.NewHighContrastTheme()
+//	t.SetDefaultFont(face)
+//	theme.SetCurrent(t)
+func NewHighContrastTheme() *Theme {
+	black := color.Black
+	white := color.White
+	yellow := color.RGBA{255, 255, 0, 255}
+	cyan := color.RGBA{0, 255, 255, 255}
+
+	btnNormal := style.Style{
+		Background:    style.PColor(black),
+		TextColor:     style.PColor(white),
+		BorderColor:   style.PColor(white),
+		BorderWidth:   style.PFloat32(2),
+		Padding:       style.PInsets(style.Insets{Top: 5, Right: 10, Bottom: 5, Left: 10}),
+		TextAlign:     style.PTextAlignType(style.TextAlignCenter),
+		VerticalAlign: style.PVerticalAlignType(style.VerticalAlignMiddle),
+	}
+	// 半透明による表現はコントラストを下げるため、状態の違いは色の反転と枠線の色で表します。
+	btnHovered := style.Merge(btnNormal, style.Style{TextColor: style.PColor(yellow), BorderColor: style.PColor(yellow)})
+	btnPressed := style.Merge(btnNormal, style.Style{Background: style.PColor(yellow), TextColor: style.PColor(black), BorderColor: style.PColor(yellow)})
+	btnDisabled := style.Merge(btnNormal, style.Style{TextColor: style.PColor(color.RGBA{160, 160, 160, 255}), BorderColor: style.PColor(color.RGBA{160, 160, 160, 255})})
+	btnFocused := style.Merge(btnNormal, style.Style{BorderColor: style.PColor(cyan)})
+
+	lblDefault := style.Style{
+		Background: style.PColor(color.Transparent),
+		TextColor:  style.PColor(white),
+		Padding:    style.PInsets(style.Insets{Top: 2, Right: 5, Bottom: 2, Left: 5}),
+	}
+
+	inputDefault := style.Style{
+		Background:  style.PColor(black),
+		TextColor:   style.PColor(white),
+		BorderColor: style.PColor(white),
+		BorderWidth: style.PFloat32(2),
+		Padding:     style.PInsets(style.Insets{Top: 4, Right: 6, Bottom: 4, Left: 6}),
+	}
+	inputFocused := style.Style{
+		BorderColor: style.PColor(cyan),
+		BorderWidth: style.PFloat32(3),
+	}
+	errorPink := color.RGBA{255, 150, 150, 255}
+	inputInvalid := style.Style{
+		BorderColor: style.PColor(errorPink),
+		BorderWidth: style.PFloat32(3),
+	}
+	inputErrorMessage := style.Style{TextColor: style.PColor(errorPink)}
+	inputChip := style.Style{
+		Background:   style.PColor(yellow),
+		TextColor:    style.PColor(black),
+		BorderRadius: style.PFloat32(8),
+		Padding:      style.PInsets(style.Insets{Top: 0, Right: 6, Bottom: 0, Left: 6}),
+	}
+	badge := style.Style{
+		Background:  style.PColor(yellow),
+		TextColor:   style.PColor(black),
+		BorderColor: style.PColor(black),
+		BorderWidth: style.PFloat32(1),
+		Padding:     style.PInsets(style.Insets{Top: 1, Right: 5, Bottom: 1, Left: 5}),
+		TextAlign:   style.PTextAlignType(style.TextAlignCenter),
+	}
+
+	listItem := style.Style{
+		TextColor: style.PColor(white),
+		Padding:   style.PInsets(style.Insets{Top: 2, Right: 4, Bottom: 2, Left: 4}),
+	}
+	listHovered := style.Style{Background: style.PColor(color.RGBA{40, 40, 40, 255})}
+	listSelected := style.Style{Background: style.PColor(yellow), TextColor: style.PColor(black)}
+	listFocused := style.Style{
+		BorderColor: style.PColor(cyan),
+		BorderWidth: style.PFloat32(2),
+	}
+	listHeader := style.Style{
+		Background: style.PColor(black),
+		TextColor:  style.PColor(yellow),
+		Padding:    style.PInsets(style.Insets{Top: 4, Right: 4, Bottom: 4, Left: 4}),
+	}
+	listAction := style.Style{
+		Background: style.PColor(white),
+		TextColor:  style.PColor(black),
+		Padding:    style.PInsets(style.Insets{Top: 0, Right: 10, Bottom: 0, Left: 10}),
+	}
+
+	return &Theme{
+		BackgroundColor: black,
+		TextColor:       white,
+		PrimaryColor:    yellow,
+		SecondaryColor:  cyan,
+		Button: ButtonTheme{
+			Normal: btnNormal, Hovered: btnHovered, Pressed: btnPressed, Disabled: btnDisabled, Focused: btnFocused,
+		},
+		Label: LabelTheme{Default: lblDefault},
+		TextInput: TextInputTheme{
+			Default: inputDefault, Focused: inputFocused, Invalid: inputInvalid, ErrorMessage: inputErrorMessage,
+			// 選択範囲の上でも文字が読めるように、暗い青を不透明で重ねます。
+			SelectionColor: color.RGBA{0, 0, 160, 255},
+			Chip:           inputChip,
+		},
+		List: ListTheme{
+			Item: listItem, Hovered: listHovered, Selected: listSelected, Focused: listFocused,
+			DragHandleColor: white, Header: listHeader, Action: listAction,
+		},
+		FocusRing: FocusRingTheme{Color: cyan, Width: 3, Offset: 2},
+		Badge:     badge,
+	}
+}
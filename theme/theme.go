@@ -3,6 +3,7 @@ package theme
 import (
 	"furoshiki/style"
 	"image/color"
+	"strings"
 	"sync"
 
 	"golang.org/x/image/font"
@@ -10,7 +11,7 @@ import (
 
 // ButtonTheme はButtonウィジェットに関連するスタイルを定義します。
 type ButtonTheme struct {
-	Normal, Hovered, Pressed, Disabled style.Style
+	Normal, Hovered, Pressed, Disabled, Focused style.Style
 }
 
 // LabelTheme はLabelウィジェットに関連するスタイルを定義します。
@@ -18,6 +19,41 @@ type LabelTheme struct {
 	Default style.Style
 }
 
+// TextInputTheme はTextInputウィジェットに関連するスタイルを定義します。
+// Focused はフォーカスを持っている間に、Invalid は検証に失敗している間にDefaultにマージされます。
+// ErrorMessage は、入力欄の下に表示される検証エラーのメッセージのスタイルです。
+// SelectionColor は、選択範囲の背景色です。
+// Chip は、ChipInputで入力が確定した値を表すチップに適用されます。
+type TextInputTheme struct {
+	Default, Focused, Invalid style.Style
+	ErrorMessage              style.Style
+	SelectionColor            color.Color
+	Chip                      style.Style
+}
+
+// ListTheme はListウィジェットに関連するスタイルを定義します。
+// Item は各行に適用され、主に行の余白を指定します。
+// Hovered はホバー中の行の、Selected は選択中の行の強調に使用されます。
+// Focused は、リストがフォーカスを持っている間、現在の行(選択の有無にかかわらず)を示すために重ねて描画されます。
+// DragHandleColor は、並べ替えのドラッグハンドルの色です。
+// Header は、SectionedListの見出しの行に適用されます。背景は、固定表示中に下の行を隠すために不透明にしてください。
+// Action は、行の操作ボタン(削除、編集など)に適用されます。背景はListActionのColorで上書きできます。
+type ListTheme struct {
+	Item, Hovered, Selected, Focused style.Style
+	DragHandleColor                  color.Color
+	Header                           style.Style
+	Action                           style.Style
+}
+
+// FocusRingTheme は、フォーカスを持っているウィジェットの周囲に描画するフォーカスリングを定義します。
+// Width が0の場合、フォーカスリングは描画されません。
+// Offset は、ウィジェットの境界からリングまでの距離です。負の値にするとウィジェットの内側に描画されます。
+type FocusRingTheme struct {
+	Color  color.Color
+	Width  float32
+	Offset float32
+}
+
 // Theme はUI全体の視覚的スタイルを定義します。
 type Theme struct {
 	DefaultFont     font.Face
@@ -27,6 +63,30 @@ type Theme struct {
 	SecondaryColor  color.Color
 	Button          ButtonTheme
 	Label           LabelTheme
+	TextInput       TextInputTheme
+	List            ListTheme
+	FocusRing       FocusRingTheme
+	// Badge は、ウィジェットの角に表示するバッジのスタイルです。角の丸みはバッジの高さに合わせて常に半円になります。
+	Badge style.Style
+	// Faces は、表示領域に合わせてフォントサイズを選ぶ機能(Label.AutoFitなど)が使用するフェイスの集合です。
+	Faces FaceSet
+	// LocaleFaces は、DefaultFontでは表示できない言語のために、ロケール("ja", "zh-TW"など)ごとに使用するフェイスです。
+	// TextKeyでテキストを設定したウィジェットは、ロケールが変わると、DefaultFontの代わりにこのフェイスで描画されます。
+	LocaleFaces map[string]font.Face
+}
+
+// FaceForLocale は、localeで使用するフェイスを返します。
+// LocaleFacesに、ロケールそのもの、その言語部分("ja-JP"なら"ja")の順に探し、見つからない場合はDefaultFontを返します。
+func (t *Theme) FaceForLocale(locale string) font.Face {
+	if f, ok := t.LocaleFaces[locale]; ok && f != nil {
+		return f
+	}
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		if f, ok := t.LocaleFaces[locale[:i]]; ok && f != nil {
+			return f
+		}
+	}
+	return t.DefaultFont
 }
 
 // SetDefaultFont はテーマ内のすべてのウィジェットスタイルにデフォルトフォントを設定するヘルパーです。
@@ -39,7 +99,12 @@ func (t *Theme) SetDefaultFont(f font.Face) {
 	t.Button.Hovered.Font = style.PFont(f)
 	t.Button.Pressed.Font = style.PFont(f)
 	t.Button.Disabled.Font = style.PFont(f)
+	t.Button.Focused.Font = style.PFont(f)
 	t.Label.Default.Font = style.PFont(f)
+	t.TextInput.Default.Font = style.PFont(f)
+	t.TextInput.ErrorMessage.Font = style.PFont(f)
+	t.List.Action.Font = style.PFont(f)
+	t.Badge.Font = style.PFont(f)
 }
 
 var (
@@ -90,6 +155,7 @@ func newDefaultTheme() *Theme {
 	btnHovered := style.Merge(btnNormal, style.Style{Opacity: style.PFloat64(0.9)})
 	btnPressed := style.Merge(btnHovered, style.Style{Background: style.PColor(darkGray), TextColor: style.PColor(white), Opacity: style.PFloat64(1.0)})
 	btnDisabled := style.Merge(btnNormal, style.Style{Opacity: style.PFloat64(0.5)})
+	btnFocused := style.Merge(btnNormal, style.Style{BorderColor: style.PColor(color.RGBA{70, 130, 180, 255})})
 
 	lblDefault := style.Style{
 		Background: style.PColor(color.Transparent),
@@ -97,14 +163,75 @@ func newDefaultTheme() *Theme {
 		Padding:    style.PInsets(style.Insets{Top: 2, Right: 5, Bottom: 2, Left: 5}),
 	}
 
+	inputDefault := style.Style{
+		Background:  style.PColor(white),
+		TextColor:   style.PColor(black),
+		BorderColor: style.PColor(darkGray),
+		BorderWidth: style.PFloat32(1),
+		Padding:     style.PInsets(style.Insets{Top: 4, Right: 6, Bottom: 4, Left: 6}),
+	}
+	inputFocused := style.Style{
+		BorderColor: style.PColor(color.RGBA{70, 130, 180, 255}),
+		BorderWidth: style.PFloat32(2),
+	}
+	errorRed := color.RGBA{200, 40, 40, 255}
+	inputInvalid := style.Style{
+		BorderColor: style.PColor(errorRed),
+		BorderWidth: style.PFloat32(2),
+	}
+	inputErrorMessage := style.Style{TextColor: style.PColor(errorRed)}
+	inputChip := style.Style{
+		Background:   style.PColor(color.RGBA{221, 232, 243, 255}),
+		TextColor:    style.PColor(black),
+		BorderRadius: style.PFloat32(8),
+		Padding:      style.PInsets(style.Insets{Top: 0, Right: 6, Bottom: 0, Left: 6}),
+	}
+
+	badge := style.Style{
+		Background: style.PColor(color.RGBA{200, 40, 40, 255}),
+		TextColor:  style.PColor(white),
+		Padding:    style.PInsets(style.Insets{Top: 1, Right: 5, Bottom: 1, Left: 5}),
+		TextAlign:  style.PTextAlignType(style.TextAlignCenter),
+	}
+
+	listItem := style.Style{
+		Padding: style.PInsets(style.Insets{Top: 2, Right: 4, Bottom: 2, Left: 4}),
+	}
+	listHovered := style.Style{Background: style.PColor(color.RGBA{232, 238, 244, 255})}
+	listSelected := style.Style{Background: style.PColor(color.RGBA{190, 212, 232, 255})}
+	listFocused := style.Style{
+		BorderColor: style.PColor(color.RGBA{70, 130, 180, 255}),
+		BorderWidth: style.PFloat32(1),
+	}
+	listHeader := style.Style{
+		Background: style.PColor(color.RGBA{228, 228, 228, 255}),
+		Padding:    style.PInsets(style.Insets{Top: 4, Right: 4, Bottom: 4, Left: 4}),
+	}
+	listAction := style.Style{
+		Background: style.PColor(color.RGBA{70, 130, 180, 255}),
+		TextColor:  style.PColor(white),
+		Padding:    style.PInsets(style.Insets{Top: 0, Right: 10, Bottom: 0, Left: 10}),
+	}
+
 	return &Theme{
 		BackgroundColor: color.RGBA{245, 245, 245, 255},
 		TextColor:       black,
 		PrimaryColor:    color.RGBA{70, 130, 180, 255}, // SteelBlue
 		SecondaryColor:  lightGray,
 		Button: ButtonTheme{
-			Normal: btnNormal, Hovered: btnHovered, Pressed: btnPressed, Disabled: btnDisabled,
+			Normal: btnNormal, Hovered: btnHovered, Pressed: btnPressed, Disabled: btnDisabled, Focused: btnFocused,
 		},
 		Label: LabelTheme{Default: lblDefault},
+		TextInput: TextInputTheme{
+			Default: inputDefault, Focused: inputFocused, Invalid: inputInvalid, ErrorMessage: inputErrorMessage,
+			SelectionColor: color.RGBA{70, 130, 180, 90},
+			Chip:           inputChip,
+		},
+		List: ListTheme{
+			Item: listItem, Hovered: listHovered, Selected: listSelected, Focused: listFocused,
+			DragHandleColor: darkGray, Header: listHeader, Action: listAction,
+		},
+		FocusRing: FocusRingTheme{Color: color.RGBA{70, 130, 180, 255}, Width: 2, Offset: 2},
+		Badge:     badge,
 	}
 }
@@ -0,0 +1,132 @@
+package furoshiki
+
+import (
+	"bufio"
+	"fmt"
+	"furoshiki/component"
+	"furoshiki/style"
+	"image/color"
+	"io"
+	"strings"
+)
+
+// DumpTree は、rootとその子孫を、深さに応じて字下げしたテキストのツリーとしてwに書き出します。
+// 各行には、種類とID、境界(絶対座標の位置と大きさ)、最小サイズ、Flex、ダーティ状態、設定されているスタイルの要約が含まれます。
+// devtoolsのInspectorを文字で補うもので、不具合の報告やログにレイアウトの状態を残すために使用します。
+//
+//	Container (0,0 800x600) min=0x0 flex=0 dirty=clean style{bg=#f0f0f0ff padding=10,10,10,10}
+//	  Button#save (10,10 80x30) min=62x24 flex=0 dirty=redraw style{bg=#dcdcdcff border=1 #696969ff padding=5,10,5,10}
+//
+// 非表示のウィジェットには"hidden"、無効なウィジェットには"disabled"、レイアウト境界には"boundary"が付きます。
+// 書き込みに失敗した場合は、そのエラーを返します。
+func DumpTree(root component.Widget, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	component.Walk(root, func(node component.Widget, depth int) component.WalkResult {
+		bw.WriteString(strings.Repeat("  ", depth))
+		bw.WriteString(dumpLine(node))
+		bw.WriteByte('\n')
+		return component.WalkContinue
+	})
+	return bw.Flush()
+}
+
+// dumpLine は、1つのウィジェットの情報を1行の文字列にします。
+func dumpLine(w component.Widget) string {
+	var b strings.Builder
+	b.WriteString(component.WidgetName(w))
+	if ident, ok := w.(component.Identifiable); ok && ident.GetID() != "" {
+		b.WriteString("#" + ident.GetID())
+	}
+
+	var x, y, width, height int
+	if ps, ok := w.(component.PositionSetter); ok {
+		x, y = ps.GetPosition()
+	}
+	if ss, ok := w.(component.SizeSetter); ok {
+		width, height = ss.GetSize()
+	}
+	fmt.Fprintf(&b, " (%d,%d %dx%d)", x, y, width, height)
+	if mss, ok := w.(component.MinSizeSetter); ok {
+		minW, minH := mss.GetMinSize()
+		fmt.Fprintf(&b, " min=%dx%d", minW, minH)
+	}
+	if lp, ok := w.(component.LayoutProperties); ok {
+		fmt.Fprintf(&b, " flex=%d", lp.GetFlex())
+		if lp.IsLayoutBoundary() {
+			b.WriteString(" boundary")
+		}
+	}
+	b.WriteString(" dirty=" + dirtyLevel(w))
+	if is, ok := w.(component.InteractiveState); ok {
+		if !is.IsVisible() {
+			b.WriteString(" hidden")
+		}
+		if is.IsDisabled() {
+			b.WriteString(" disabled")
+		}
+	}
+	if sg, ok := w.(component.StyleGetterSetter); ok {
+		if summary := styleSummary(sg.ReadOnlyStyle()); summary != "" {
+			b.WriteString(" style{" + summary + "}")
+		}
+	}
+	return b.String()
+}
+
+// dirtyLevel は、ウィジェットのダーティ状態を"relayout"、"redraw"、"clean"のいずれかで返します。
+func dirtyLevel(w component.Widget) string {
+	switch {
+	case w.NeedsRelayout():
+		return "relayout"
+	case w.IsDirty():
+		return "redraw"
+	default:
+		return "clean"
+	}
+}
+
+// styleSummary は、スタイルのうち設定されているプロパティだけを空白区切りで並べた文字列を返します。
+// Insetsは"上,右,下,左"の順で表します。
+func styleSummary(s style.Style) string {
+	var parts []string
+	if s.Background != nil {
+		parts = append(parts, "bg="+hexColor(*s.Background))
+	}
+	if s.TextColor != nil {
+		parts = append(parts, "text="+hexColor(*s.TextColor))
+	}
+	if s.BorderWidth != nil && *s.BorderWidth > 0 {
+		border := fmt.Sprintf("border=%g", *s.BorderWidth)
+		if s.BorderColor != nil {
+			border += " " + hexColor(*s.BorderColor)
+		}
+		parts = append(parts, border)
+	}
+	if s.BorderRadius != nil && *s.BorderRadius > 0 {
+		parts = append(parts, fmt.Sprintf("radius=%g", *s.BorderRadius))
+	}
+	if s.Padding != nil && *s.Padding != (style.Insets{}) {
+		parts = append(parts, "padding="+insetsText(*s.Padding))
+	}
+	if s.Margin != nil && *s.Margin != (style.Insets{}) {
+		parts = append(parts, "margin="+insetsText(*s.Margin))
+	}
+	if s.Opacity != nil && *s.Opacity != 1 {
+		parts = append(parts, fmt.Sprintf("opacity=%g", *s.Opacity))
+	}
+	return strings.Join(parts, " ")
+}
+
+// hexColor は、色を"#rrggbbaa"の形式の文字列にします。
+func hexColor(c color.Color) string {
+	if c == nil {
+		return "-"
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x%02x", n.R, n.G, n.B, n.A)
+}
+
+// insetsText は、Insetsを"上,右,下,左"の順の文字列にします。
+func insetsText(i style.Insets) string {
+	return fmt.Sprintf("%d,%d,%d,%d", i.Top, i.Right, i.Bottom, i.Left)
+}
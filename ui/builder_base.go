@@ -1,8 +1,11 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
 	"furoshiki/component"
 	"furoshiki/container"
+	"furoshiki/layout"
 	"furoshiki/widget"
 )
 
@@ -42,6 +45,16 @@ func (b *BaseContainerBuilder[T]) Label(buildFunc func(*widget.LabelBuilder)) T
 	return b.Self
 }
 
+// RichLabel は、コンテナに色やフォントの異なる区間からなるRichLabelウィジェットを追加します。
+func (b *BaseContainerBuilder[T]) RichLabel(buildFunc func(*widget.RichLabelBuilder)) T {
+	builder := widget.NewRichLabelBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	addWidget(b, builder)
+	return b.Self
+}
+
 // Button は、コンテナにButtonウィジェットを追加します。
 func (b *BaseContainerBuilder[T]) Button(buildFunc func(*widget.ButtonBuilder)) T {
 	builder := widget.NewButtonBuilder()
@@ -52,6 +65,58 @@ func (b *BaseContainerBuilder[T]) Button(buildFunc func(*widget.ButtonBuilder))
 	return b.Self
 }
 
+// ToggleButton は、コンテナにToggleButtonウィジェットを追加します。
+// 排他的な選択にするには、各ボタンで同じButtonGroupを指定します。
+// 例: b.ToggleButton(func(t *widget.ToggleButtonBuilder) { t.Text("Bold").Group(group) })
+func (b *BaseContainerBuilder[T]) ToggleButton(buildFunc func(*widget.ToggleButtonBuilder)) T {
+	builder := widget.NewToggleButtonBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	addWidget(b, builder)
+	return b.Self
+}
+
+// TextInput は、コンテナに1行のテキスト入力欄を追加します。
+func (b *BaseContainerBuilder[T]) TextInput(buildFunc func(*widget.TextInputBuilder)) T {
+	builder := widget.NewTextInputBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	addWidget(b, builder)
+	return b.Self
+}
+
+// NumberInput は、コンテナに数値の入力欄を追加します。
+func (b *BaseContainerBuilder[T]) NumberInput(buildFunc func(*widget.NumberInputBuilder)) T {
+	builder := widget.NewNumberInputBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	addWidget(b, builder)
+	return b.Self
+}
+
+// SearchBox は、コンテナに検索欄を追加します。
+func (b *BaseContainerBuilder[T]) SearchBox(buildFunc func(*widget.SearchBoxBuilder)) T {
+	builder := widget.NewSearchBoxBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	addWidget(b, builder)
+	return b.Self
+}
+
+// ChipInput は、コンテナに入力した値をチップとして並べるChipInputウィジェットを追加します。
+func (b *BaseContainerBuilder[T]) ChipInput(buildFunc func(*widget.ChipInputBuilder)) T {
+	builder := widget.NewChipInputBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	addWidget(b, builder)
+	return b.Self
+}
+
 // Spacer は、コンテナにSpacerウィジェットを追加します。
 // 主にFlexLayout内で使用され、利用可能なスペースを埋めるために伸縮します。
 func (b *BaseContainerBuilder[T]) Spacer() T {
@@ -60,6 +125,39 @@ func (b *BaseContainerBuilder[T]) Spacer() T {
 	return b.Self
 }
 
+// FixedSpacer は、コンテナに固定サイズの余白を追加します。
+// FlexLayoutのコンテナでは主軸方向(HStackなら幅、VStackなら高さ)だけにサイズを設定し、
+// それ以外のレイアウトでは両方向に設定します。
+func (b *BaseContainerBuilder[T]) FixedSpacer(px int) T {
+	w, h := b.spacerAxes(px)
+	addWidget(b, widget.NewSpacerBuilder().Size(w, h))
+	return b.Self
+}
+
+// BoundedSpacer は、コンテナにminPxからmaxPxの範囲で伸縮する余白を追加します。
+// maxPxに0を指定すると上限なしになります。サイズは主軸方向にのみ適用されます。
+func (b *BaseContainerBuilder[T]) BoundedSpacer(minPx, maxPx int) T {
+	if maxPx > 0 && maxPx < minPx {
+		b.AddError(fmt.Errorf("spacer max size %d is smaller than min size %d", maxPx, minPx))
+		return b.Self
+	}
+	minW, minH := b.spacerAxes(minPx)
+	maxW, maxH := b.spacerAxes(maxPx)
+	addWidget(b, widget.NewSpacerBuilder().Flex(1).MinSize(minW, minH).MaxSize(maxW, maxH))
+	return b.Self
+}
+
+// spacerAxes は、余白のサイズをコンテナの主軸方向に合わせた(幅, 高さ)に変換します。
+func (b *BaseContainerBuilder[T]) spacerAxes(px int) (int, int) {
+	if flexLayout, ok := b.Widget.GetLayout().(*layout.FlexLayout); ok {
+		if flexLayout.Direction == layout.DirectionRow {
+			return px, 0
+		}
+		return 0, px
+	}
+	return px, px
+}
+
 // ScrollView は、コンテナにScrollViewウィジェットを追加します。
 func (b *BaseContainerBuilder[T]) ScrollView(buildFunc func(*widget.ScrollViewBuilder)) T {
 	builder := widget.NewScrollViewBuilder()
@@ -70,6 +168,86 @@ func (b *BaseContainerBuilder[T]) ScrollView(buildFunc func(*widget.ScrollViewBu
 	return b.Self
 }
 
+// List は、コンテナに項目の選択を管理するListウィジェットを追加します。
+func (b *BaseContainerBuilder[T]) List(buildFunc func(*widget.ListBuilder)) T {
+	builder := widget.NewListBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	addWidget(b, builder)
+	return b.Self
+}
+
+// FileList は、コンテナにディレクトリの内容を一覧表示するFileListウィジェットを追加します。
+func (b *BaseContainerBuilder[T]) FileList(buildFunc func(*widget.FileListBuilder)) T {
+	builder := widget.NewFileListBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	addWidget(b, builder)
+	return b.Self
+}
+
+// GridView は、コンテナに同じ大きさのセルを格子状に並べて表示するGridViewウィジェットを追加します。
+func (b *BaseContainerBuilder[T]) GridView(buildFunc func(*widget.GridViewBuilder)) T {
+	builder := widget.NewGridViewBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	addWidget(b, builder)
+	return b.Self
+}
+
+// SectionedList は、コンテナに項目を見出し付きの区分に分けて表示するSectionedListウィジェットを追加します。
+func (b *BaseContainerBuilder[T]) SectionedList(buildFunc func(*widget.SectionedListBuilder)) T {
+	builder := widget.NewSectionedListBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	addWidget(b, builder)
+	return b.Self
+}
+
+// StatsView は、コンテナにフレーム統計を表示するStatsViewウィジェットを追加します。
+func (b *BaseContainerBuilder[T]) StatsView(buildFunc func(*widget.StatsViewBuilder)) T {
+	builder := widget.NewStatsViewBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	addWidget(b, builder)
+	return b.Self
+}
+
+// DebugHUD は、コンテナにFPSやフレーム統計を表示するDebugHUDウィジェットを追加します。
+func (b *BaseContainerBuilder[T]) DebugHUD(buildFunc func(*widget.DebugHUDBuilder)) T {
+	builder := widget.NewDebugHUDBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	addWidget(b, builder)
+	return b.Self
+}
+
+// Canvas は、コンテナに描画を利用者の関数に任せるCanvasウィジェットを追加します。
+func (b *BaseContainerBuilder[T]) Canvas(buildFunc func(*widget.CanvasBuilder)) T {
+	builder := widget.NewCanvasBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	addWidget(b, builder)
+	return b.Self
+}
+
+// Avatar は、コンテナに画像を円や角丸矩形に切り抜いて表示するAvatarウィジェットを追加します。
+func (b *BaseContainerBuilder[T]) Avatar(buildFunc func(*widget.AvatarBuilder)) T {
+	builder := widget.NewAvatarBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	addWidget(b, builder)
+	return b.Self
+}
+
 // --- ネストされたコンテナ追加メソッド ---
 
 // HStack は、コンテナに水平方向のFlexコンテナをネストして追加します。
@@ -102,6 +280,67 @@ func (b *BaseContainerBuilder[T]) AdvancedGrid(buildFunc func(*AdvancedGridBuild
 	return b.Self
 }
 
+// Form は、コンテナにラベル付きの入力欄を並べたフォームをネストして追加します。
+func (b *BaseContainerBuilder[T]) Form(buildFunc func(*FormBuilder)) T {
+	addNestedContainer(b, Form(buildFunc))
+	return b.Self
+}
+
+// --- 条件付き・データ駆動の子要素追加 ---
+
+// Repeat は、fnをn回呼び出して子要素を追加します。iは0から始まる呼び出し回数です。
+// 例: b.Repeat(3, func(i int, b *ui.FlexBuilder) { b.Button(...) })
+func (b *BaseContainerBuilder[T]) Repeat(n int, fn func(i int, b T)) T {
+	if fn == nil {
+		b.AddError(errors.New("repeat function cannot be nil"))
+		return b.Self
+	}
+	for i := 0; i < n; i++ {
+		fn(i, b.Self)
+	}
+	return b.Self
+}
+
+// If は、condがtrueの場合にのみfnを呼び出して子要素を追加します。
+// 省略可能なセクションを、ビルダーのクロージャを抜けずに宣言的に記述できます。
+func (b *BaseContainerBuilder[T]) If(cond bool, fn func(b T)) T {
+	if cond && fn != nil {
+		fn(b.Self)
+	}
+	return b.Self
+}
+
+// IfElse は、condがtrueの場合はfnTrueを、falseの場合はfnFalseを呼び出して子要素を追加します。
+// どちらの関数もnilを指定でき、その場合は何も追加しません。
+func (b *BaseContainerBuilder[T]) IfElse(cond bool, fnTrue, fnFalse func(b T)) T {
+	fn := fnFalse
+	if cond {
+		fn = fnTrue
+	}
+	if fn != nil {
+		fn(b.Self)
+	}
+	return b.Self
+}
+
+// ForEach は、itemsの各要素についてfnを呼び出して子要素を追加します。
+// Goのメソッドは型パラメータを持てないため、ビルダーのメソッドではなく関数として提供します。
+// 例:
+//
+//	ui.ForEach(b, names, func(i int, name string, b *ui.FlexBuilder) {
+//		b.Label(func(l *widget.LabelBuilder) { l.Text(name) })
+//	})
+func ForEach[T any, B interface{ component.ErrorAdder }](b B, items []T, fn func(i int, item T, b B)) B {
+	if fn == nil {
+		b.AddError(errors.New("foreach function cannot be nil"))
+		return b
+	}
+	for i, item := range items {
+		fn(i, item, b)
+	}
+	return b
+}
+
 // --- 共通コンテナ設定メソッド ---
 
 // SetLayoutBoundary はコンテナをレイアウト境界として設定します。
@@ -117,12 +356,98 @@ func (b *BaseContainerBuilder[T]) ClipChildren(clips bool) T {
 	return b.Self
 }
 
+// ContentAlign は、子要素群全体をコンテナの余ったスペースのどこに寄せるかを設定します。
+// GridやZStackのようにContentAlignerを実装するレイアウトでのみ有効です。
+// 例: グリッドを中央に配置する場合は ContentAlign(layout.AlignCenter, layout.AlignCenter)
+func (b *BaseContainerBuilder[T]) ContentAlign(horizontal, vertical layout.Alignment) T {
+	aligner, ok := b.Widget.GetLayout().(layout.ContentAligner)
+	if !ok {
+		b.AddError(fmt.Errorf("layout %T does not support content alignment", b.Widget.GetLayout()))
+		return b.Self
+	}
+	aligner.SetContentAlignment(layout.ContentAlignment{Horizontal: horizontal, Vertical: vertical})
+	b.Widget.MarkDirty(true)
+	return b.Self
+}
+
+// Overflow は、子要素がコンテナからはみ出した場合の扱いを設定します。
+//   - container.OverflowHidden: はみ出した部分をクリップします（ClipChildren(true)と同じ）。
+//   - container.OverflowScroll: Build時に子要素を内部のコンテナへ移し、それをScrollViewで包みます。
+//   - container.OverflowEllipsis: クリップした上で、省略されていることを示す印を描画します。
+func (b *BaseContainerBuilder[T]) Overflow(o container.Overflow) T {
+	b.Widget.SetOverflow(o)
+	return b.Self
+}
+
+// Transform は、子孫に座標変換(平行移動と拡大縮小)を適用します。子孫はコンテナの境界でクリップされます。
+func (b *BaseContainerBuilder[T]) Transform(t container.Transform) T {
+	b.Widget.SetTransform(t)
+	return b.Self
+}
+
+// TrackTransform は、毎フレーム座標変換を返す関数を設定します。ゲームのカメラに追従するレイヤーに使用します。
+func (b *BaseContainerBuilder[T]) TrackTransform(fn func() container.Transform) T {
+	b.Widget.TrackTransform(fn)
+	return b.Self
+}
+
+// Build はコンテナの構築を完了します。
+// OverflowScrollが設定されている場合は、子要素をScrollViewで包んでから構築を完了します。
+func (b *BaseContainerBuilder[T]) Build() (*container.Container, error) {
+	// 警告の検査は、スクロール用に子を内部コンテナへ移す前の、利用者が構成したとおりのコンテナに対して行います。
+	lintContainer(b, b.Widget)
+	if b.Widget != nil && b.Widget.GetOverflow() == container.OverflowScroll {
+		b.AddError(wrapInScrollView(b.Widget))
+	}
+	return b.Builder.Build()
+}
+
+// MustBuild は、Buildを呼び出し、エラーがあればすべてのエラーのレポートと共にpanicします。
+func (b *BaseContainerBuilder[T]) MustBuild() *container.Container {
+	return component.MustBuild(b.Build())
+}
+
+// wrapInScrollView は、コンテナの子要素とレイアウトを新しい内部コンテナへ移し、
+// その内部コンテナをコンテンツとするScrollViewをコンテナの唯一の子として追加します。
+// 外側のコンテナはサイズ・Flex・背景・境界線などの設定と、AssignToやIDによる参照をそのまま保持します。
+func wrapInScrollView(c *container.Container) error {
+	content, err := container.NewContainer()
+	if err != nil {
+		return err
+	}
+	content.SetLayout(c.GetLayout())
+	// AddChildは元の親から子を切り離すため、コピーしたスライスを反復します。
+	children := append([]component.Widget(nil), c.GetChildren()...)
+	for _, child := range children {
+		content.AddChild(child)
+	}
+
+	sv, err := widget.NewScrollViewBuilder().Flex(1).Content(content).Build()
+	if err != nil {
+		return err
+	}
+	c.SetLayout(&layout.FlexLayout{Direction: layout.DirectionColumn, AlignItems: layout.AlignStretch})
+	c.AddChild(sv)
+	return nil
+}
+
 // --- ビルドヘルパー (非公開) ---
 
 // builderConstraint は、BaseContainerBuilderが内部で使用する制約です。
 type builderConstraint interface {
 	component.ErrorAdder
+	warningAdder
 	component.WidgetContainer
+	childCount() int
+}
+
+// childCount は、コンテナに現在追加されている子要素の数を返します。
+// 子のビルドエラーに付与するインデックスの算出に使用します。
+func (b *BaseContainerBuilder[T]) childCount() int {
+	if b.Widget == nil {
+		return 0
+	}
+	return len(b.Widget.GetChildren())
 }
 
 // addWidget は、ウィジェットビルダーからウィジェットをビルドし、親コンテナビルダーに追加します。
@@ -130,12 +455,7 @@ func addWidget[B builderConstraint, W component.Widget, WB interface {
 	component.BuilderFinalizer[W]
 	component.ErrorAdder
 }](parentBuilder B, widgetBuilder WB) {
-	widget, err := widgetBuilder.Build()
-	if err != nil {
-		parentBuilder.AddError(err)
-	}
-	// エラーがあってもウィジェット自体は追加を試みる
-	parentBuilder.AddChild(widget)
+	addBuilt(parentBuilder, widgetBuilder)
 }
 
 // addNestedContainer は、ネストされたコンテナビルダーをビルドし、親コンテナビルダーに追加します。
@@ -143,9 +463,19 @@ func addNestedContainer[B builderConstraint, C component.Widget, CB interface {
 	component.BuilderFinalizer[C]
 	component.ErrorAdder
 }](parentBuilder B, nestedBuilder CB) {
-	containerWidget, err := nestedBuilder.Build()
+	addBuilt(parentBuilder, nestedBuilder)
+}
+
+// addBuilt は、ビルダーからウィジェットをビルドして親コンテナビルダーに追加する共通処理です。
+// ビルドエラーには、親コンテナ内でのインデックスを付与してから親ビルダーに追加します。
+// NOTE: ビルドに失敗したビルダーは型付きnilを返すため、そのウィジェットは追加しません。
+func addBuilt[B builderConstraint, W component.Widget](parentBuilder B, builder component.BuilderFinalizer[W]) {
+	index := parentBuilder.childCount()
+	w, err := builder.Build()
 	if err != nil {
-		parentBuilder.AddError(err)
+		parentBuilder.AddError(component.WithChildIndex(err, index))
+		return
 	}
-	parentBuilder.AddChild(containerWidget)
-}
\ No newline at end of file
+	inheritWarnings(parentBuilder, index, builder)
+	parentBuilder.AddChild(w)
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"errors"
+	"furoshiki/component"
+)
+
+// このファイルは、パラメータを受け取ってウィジェットのサブツリーを生成する
+// 再利用可能なテンプレート（ビュー関数）のための仕組みを提供します。
+// カード、リストの行、フォームの項目などを関数として定義し、
+// b.Component(MyCard(data)) のように宣言的なツリーの中で使用できます。
+
+// ErrNilComponent は、nilのComponentが渡された場合のエラーです。
+var ErrNilComponent = errors.New("component cannot be nil")
+
+// Component は、ウィジェットのサブツリーを生成するテンプレートです。
+// Buildは呼び出されるたびに新しいウィジェットを生成することが期待されます。
+type Component interface {
+	Build() (component.Widget, error)
+}
+
+// ComponentFunc は、関数をComponentとして扱うためのアダプタです。
+//
+//	func MyCard(title string) ui.Component {
+//		return ui.ComponentFunc(func() (component.Widget, error) {
+//			return ui.VStack(func(b *ui.FlexBuilder) { ... }).Build()
+//		})
+//	}
+type ComponentFunc func() (component.Widget, error)
+
+// Build は関数を呼び出してウィジェットを生成します。
+func (f ComponentFunc) Build() (component.Widget, error) {
+	return f()
+}
+
+// From は、VStackやwidget.NewLabelBuilderのような任意のビルダーをComponentに変換します。
+// 例: return ui.From(ui.VStack(func(b *ui.FlexBuilder) { ... }))
+func From[W component.Widget](builder component.BuilderFinalizer[W]) Component {
+	return ComponentFunc(func() (component.Widget, error) {
+		return builder.Build()
+	})
+}
+
+// Component は、テンプレートからウィジェットを生成してコンテナに追加します。
+func (b *BaseContainerBuilder[T]) Component(c Component) T {
+	if c == nil {
+		b.AddError(ErrNilComponent)
+		return b.Self
+	}
+	index := b.childCount()
+	w, err := c.Build()
+	if err != nil {
+		// 生成に失敗したウィジェットは型付きnilの可能性があるため、追加しません。
+		b.AddError(component.WithChildIndex(err, index))
+		return b.Self
+	}
+	b.AddChild(w)
+	return b.Self
+}
+
+// Memo は、キーごとにテンプレートの生成結果をキャッシュします。
+// 同じキーで再度要求された場合は、テンプレートを再実行せずに前回生成したウィジェットを返します。
+// タブの切り替えのように、同じサブツリーを何度も表示し直す場面での再構築を省けます。
+//
+// NOTE: RemoveChild・ReplaceChild・ClearChildrenはウィジェットのCleanupを呼び出すため、
+// これらで取り外したウィジェットは再利用できません。その場合は先にForgetを呼び出してください。
+type Memo[K comparable] struct {
+	template func(key K) Component
+	cache    map[K]component.Widget
+}
+
+// NewMemo は、キーからテンプレートを生成する関数を受け取り、新しいMemoを生成します。
+func NewMemo[K comparable](template func(key K) Component) *Memo[K] {
+	return &Memo[K]{
+		template: template,
+		cache:    make(map[K]component.Widget),
+	}
+}
+
+// Get は、キーに対応するテンプレートをComponentとして返します。
+// 返されたComponentは、初回のBuildでのみテンプレートを実行し、以降はキャッシュされたウィジェットを返します。
+func (m *Memo[K]) Get(key K) Component {
+	return ComponentFunc(func() (component.Widget, error) {
+		if w, ok := m.cache[key]; ok {
+			return w, nil
+		}
+		if m.template == nil {
+			return nil, ErrNilComponent
+		}
+		c := m.template(key)
+		if c == nil {
+			return nil, ErrNilComponent
+		}
+		w, err := c.Build()
+		if err != nil {
+			return w, err
+		}
+		m.cache[key] = w
+		return w, nil
+	})
+}
+
+// Forget は、キーに対応するキャッシュを破棄し、次回のBuildでテンプレートを再実行させます。
+func (m *Memo[K]) Forget(key K) {
+	delete(m.cache, key)
+}
+
+// Reset は、すべてのキャッシュを破棄します。
+func (m *Memo[K]) Reset() {
+	clear(m.cache)
+}
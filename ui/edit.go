@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"furoshiki/component"
+	"furoshiki/container"
+)
+
+// このファイルは、構築済みのコンテナに対して、構築時と同じ流暢な構文で
+// 子要素の追加や設定の変更を行うための関数を提供します。
+//
+//	err := ui.Edit(panel, func(b *ui.FlexBuilder) {
+//		b.Label(func(l *widget.LabelBuilder) { l.Text("New row") })
+//	})
+
+// Edit は、既存のコンテナに対してFlexBuilderのスコープを開き、fnを実行します。
+// fn内で追加した子要素は既存の子要素の後ろに追加され、再レイアウトは最後に1回だけ行われます。
+// コンテナのレイアウトは変更されません。fn内で発生したエラーは、パス付きのエラーとして返されます。
+// OverflowScrollで構築されたコンテナでは、子要素とレイアウトはScrollViewの内部のコンテナに移されているため、
+// スコープはその内部のコンテナに対して開かれます(container.Container.ContentContainer)。
+func Edit(c *container.Container, fn func(b *FlexBuilder)) error {
+	return edit(c, fn, func(base *BaseContainerBuilder[*FlexBuilder]) *FlexBuilder {
+		return &FlexBuilder{BaseContainerBuilder: base}
+	})
+}
+
+// EditGrid は、既存のグリッドコンテナに対してGridBuilderのスコープを開き、fnを実行します。
+func EditGrid(c *container.Container, fn func(b *GridBuilder)) error {
+	return edit(c, fn, func(base *BaseContainerBuilder[*GridBuilder]) *GridBuilder {
+		return &GridBuilder{BaseContainerBuilder: base}
+	})
+}
+
+// EditZStack は、既存のZStackコンテナに対してZStackBuilderのスコープを開き、fnを実行します。
+func EditZStack(c *container.Container, fn func(b *ZStackBuilder)) error {
+	return edit(c, fn, func(base *BaseContainerBuilder[*ZStackBuilder]) *ZStackBuilder {
+		return &ZStackBuilder{BaseContainerBuilder: base}
+	})
+}
+
+// EditAdvancedGrid は、既存の高度なグリッドコンテナに対してAdvancedGridBuilderのスコープを開き、fnを実行します。
+func EditAdvancedGrid(c *container.Container, fn func(b *AdvancedGridBuilder)) error {
+	return edit(c, fn, func(base *BaseContainerBuilder[*AdvancedGridBuilder]) *AdvancedGridBuilder {
+		return &AdvancedGridBuilder{BaseContainerBuilder: base}
+	})
+}
+
+// edit は、Edit系関数の共通処理です。newBuilderで具象ビルダーを生成し、
+// コンテナの更新をまとめた状態でfnを実行します。
+func edit[T any](c *container.Container, fn func(b T), newBuilder func(base *BaseContainerBuilder[T]) T) error {
+	if c == nil {
+		return component.ErrWidgetNotInitialized
+	}
+	// 子要素の追加先が、スクロールされる内部のコンテナになるようにします。
+	c = c.ContentContainer()
+	base := &BaseContainerBuilder[T]{}
+	b := newBuilder(base)
+	base.Init(b, c)
+	if fn != nil {
+		c.BatchUpdate(func() { fn(b) })
+	}
+	return base.Err()
+}
@@ -0,0 +1,308 @@
+package ui
+
+import (
+	"furoshiki/component"
+	"furoshiki/container"
+	"furoshiki/event"
+	"furoshiki/layout"
+	"furoshiki/style"
+	"furoshiki/theme"
+	"furoshiki/widget"
+	"image/color"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// このファイルは、FileListを使ったファイルを開く・保存するためのモーダルなダイアログを提供します。
+// ダイアログはStageのモーダルなレイヤーとして追加され、表示中は奥のレイヤーにポインタのイベントが届きません。
+//
+//	saves, _ := ui.NewFileDialog(stage, "save-dialog", ui.FileDialogOptions{
+//		Mode:       ui.FileDialogSave,
+//		FS:         os.DirFS(saveDir),
+//		Extensions: []string{".sav"},
+//	})
+//	saves.Show(func(p string, ok bool) {
+//		if ok {
+//			os.WriteFile(filepath.Join(saveDir, filepath.FromSlash(p)), data, 0o644)
+//		}
+//	})
+//
+// ディレクトリを開けなかった場合は、パスの欄にエラーを表示します。別のディレクトリを開くと、パスの表示に戻ります。
+//
+// ダイアログはファイルを読み書きしません。選ばれたパス(ファイルシステムのルートからの"/"区切りのパス)を返すだけなので、
+// 読み書きや、保存時の上書きの確認はゲームが行います。
+
+const (
+	// defaultFileDialogWidth, defaultFileDialogHeight は、ダイアログのパネルの既定の大きさです。
+	defaultFileDialogWidth  = 420
+	defaultFileDialogHeight = 320
+)
+
+// FileDialogMode は、ファイルダイアログの用途です。
+type FileDialogMode int
+
+const (
+	// FileDialogOpen は、既存のファイルを選んで開くダイアログです。
+	FileDialogOpen FileDialogMode = iota
+	// FileDialogSave は、保存先のディレクトリとファイル名を入力するダイアログです。
+	FileDialogSave
+)
+
+// FileDialogOptions は、ファイルダイアログの設定です。
+type FileDialogOptions struct {
+	Mode FileDialogMode
+	// FS は、閲覧するファイルシステムです。必須です。
+	FS fs.FS
+	// Dir は、最初に開くディレクトリです。空の場合はルート(".")です。
+	Dir string
+	// Extensions は、表示するファイルの拡張子です。空の場合はすべてのファイルを表示します。
+	// 保存ダイアログでは、入力されたファイル名の拡張子がいずれにも一致しない場合、先頭の拡張子を付け加えます。
+	Extensions []string
+	// FileName は、保存ダイアログのファイル名の初期値です。
+	FileName string
+	// Title, ConfirmText, CancelText は、見出しとボタンの文字列です。空の場合は英語の既定の文字列を使用します。
+	Title, ConfirmText, CancelText string
+	// Width, Height は、ダイアログのパネルの大きさです。0の場合は既定の大きさです。
+	Width, Height int
+}
+
+// FileDialog は、Stageのモーダルなレイヤーとして表示される、ファイルを開く・保存するためのダイアログです。
+// 一度生成したダイアログは、Showで何度でも表示できます。
+type FileDialog struct {
+	layer *Layer
+	mode  FileDialogMode
+	exts  []string
+
+	list      *widget.FileList
+	pathLabel *widget.Label
+	nameInput *widget.TextInput
+	confirm   *widget.Button
+
+	onDone func(filePath string, ok bool)
+}
+
+// NewFileDialog は、ファイルダイアログを生成し、nameという名前の非表示のモーダルなレイヤーとしてstageに追加します。
+func NewFileDialog(stage *Stage, name string, opts FileDialogOptions) (*FileDialog, error) {
+	if opts.FS == nil {
+		return nil, widget.ErrNoFileSystem
+	}
+	d := &FileDialog{mode: opts.Mode, exts: opts.Extensions}
+	root, err := d.build(opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Dir != "" {
+		if err := d.list.SetDir(opts.Dir); err != nil {
+			root.Cleanup()
+			return nil, err
+		}
+	}
+	layer, err := stage.AddLayer(name, root)
+	if err != nil {
+		root.Cleanup()
+		return nil, err
+	}
+	layer.SetModal(true)
+	layer.SetVisible(false)
+	d.layer = layer
+	return d, nil
+}
+
+// build は、ダイアログのウィジェットを構築し、画面全体を覆うルートを返します。
+func (d *FileDialog) build(opts FileDialogOptions) (*container.Container, error) {
+	title, confirmText, cancelText := opts.Title, opts.ConfirmText, opts.CancelText
+	if title == "" {
+		title = "Open File"
+		if d.mode == FileDialogSave {
+			title = "Save File"
+		}
+	}
+	if confirmText == "" {
+		confirmText = "Open"
+		if d.mode == FileDialogSave {
+			confirmText = "Save"
+		}
+	}
+	if cancelText == "" {
+		cancelText = "Cancel"
+	}
+	width, height := opts.Width, opts.Height
+	if width <= 0 {
+		width = defaultFileDialogWidth
+	}
+	if height <= 0 {
+		height = defaultFileDialogHeight
+	}
+	viewW, viewH := ViewportSize()
+	t := theme.GetCurrent()
+
+	return ZStack(func(b *ZStackBuilder) {
+		b.Size(viewW, viewH).
+			BackgroundColor(color.RGBA{A: 140}).
+			ContentAlign(layout.AlignCenter, layout.AlignCenter)
+		b.VStack(func(b *FlexBuilder) {
+			b.Size(width, height).Padding(10).Gap(8).
+				BackgroundColor(t.BackgroundColor).Border(1, t.TextColor).
+				AccessibleRole(component.RoleDialog).AccessibleLabel(title)
+			b.Label(func(l *widget.LabelBuilder) {
+				l.Text(title).Size(0, 20)
+			})
+			b.Label(func(l *widget.LabelBuilder) {
+				l.Text(dirLabel(".")).Size(0, 20).Truncate(style.TextTruncateMiddleEllipsis).AssignTo(&d.pathLabel)
+			})
+			b.FileList(func(l *widget.FileListBuilder) {
+				l.FS(opts.FS).Flex(1).
+					OnDirChanged(func(dir string) { d.pathLabel.SetText(dirLabel(dir)) }).
+					OnSelectionChanged(func(int) { d.selectionChanged() }).
+					OnFileActivated(func(string) { d.Confirm() }).
+					OnError(func(err error) { d.pathLabel.SetText(err.Error()) }).
+					AssignTo(&d.list)
+				if len(opts.Extensions) > 0 {
+					l.Extensions(opts.Extensions...)
+				}
+			})
+			if d.mode == FileDialogSave {
+				b.TextInput(func(t *widget.TextInputBuilder) {
+					t.Value(opts.FileName).Placeholder("File name").Size(0, 28).
+						OnChange(func(string) { d.updateConfirm() }).
+						OnSubmit(func(string) { d.Confirm() }).
+						AssignTo(&d.nameInput)
+				})
+			}
+			b.HStack(func(b *FlexBuilder) {
+				b.Size(0, 28).Gap(8)
+				b.Spacer()
+				b.Button(func(btn *widget.ButtonBuilder) {
+					btn.Text(cancelText).Size(80, 28).AddOnClick(func(e *event.Event) event.Propagation {
+						d.Close()
+						return event.StopPropagation
+					})
+				})
+				b.Button(func(btn *widget.ButtonBuilder) {
+					btn.Text(confirmText).Size(80, 28).AssignTo(&d.confirm).AddOnClick(func(e *event.Event) event.Propagation {
+						d.Confirm()
+						return event.StopPropagation
+					})
+				})
+			})
+		})
+	}).Build()
+}
+
+// dirLabel は、ディレクトリのパスを、ルートを"/"とした表示用の文字列にします。
+func dirLabel(dir string) string {
+	if dir == "." {
+		return "/"
+	}
+	return "/" + dir
+}
+
+// Show は、表示中のディレクトリを読み込み直してダイアログを表示します。
+// onDoneは、ファイルが選ばれたとき(ok=true)またはキャンセルされたとき(ok=false)に1回だけ呼び出されます。
+func (d *FileDialog) Show(onDone func(filePath string, ok bool)) error {
+	if err := d.list.Refresh(); err != nil {
+		return err
+	}
+	d.onDone = onDone
+	d.updateConfirm()
+	d.layer.SetVisible(true)
+	component.SetFocus(d.list.List)
+	return nil
+}
+
+// IsShown は、ダイアログが表示されているかどうかを返します。
+func (d *FileDialog) IsShown() bool {
+	return d.layer.IsVisible()
+}
+
+// Close は、ダイアログをキャンセルして閉じます。表示されていない場合は何もしません。
+func (d *FileDialog) Close() {
+	d.finish("", false)
+}
+
+// Confirm は、選択中のファイル(保存ダイアログでは入力されたファイル名)でダイアログを閉じます。
+// 開くダイアログでディレクトリが選択されている場合は、そのディレクトリを開きます。確定できない場合は何もしません。
+func (d *FileDialog) Confirm() {
+	if d.mode == FileDialogSave {
+		if name := d.fileName(); name != "" {
+			d.finish(path.Join(d.list.Dir(), name), true)
+		}
+		return
+	}
+	entry := d.list.SelectedEntry()
+	switch {
+	case entry == nil:
+	case entry.IsDir():
+		d.list.Activate(d.list.Selected())
+	default:
+		d.finish(d.list.SelectedPath(), true)
+	}
+}
+
+// FileList は、ダイアログが使用しているFileListを返します。
+func (d *FileDialog) FileList() *widget.FileList {
+	return d.list
+}
+
+// Layer は、ダイアログを表示しているStageのレイヤーを返します。
+func (d *FileDialog) Layer() *Layer {
+	return d.layer
+}
+
+// finish は、ダイアログを閉じて、結果を完了時の関数に渡します。
+func (d *FileDialog) finish(filePath string, ok bool) {
+	if !d.layer.IsVisible() {
+		return
+	}
+	d.layer.SetVisible(false)
+	component.Blur(d.list.List)
+	if d.nameInput != nil {
+		component.Blur(d.nameInput)
+	}
+	onDone := d.onDone
+	d.onDone = nil
+	if onDone != nil {
+		onDone(filePath, ok)
+	}
+}
+
+// selectionChanged は、保存ダイアログでファイルが選択されたときに、その名前をファイル名の欄に入れます。
+func (d *FileDialog) selectionChanged() {
+	if d.nameInput != nil {
+		if entry := d.list.SelectedEntry(); entry != nil && !entry.IsDir() {
+			d.nameInput.SetValue(entry.Name())
+		}
+	}
+	d.updateConfirm()
+}
+
+// fileName は、保存ダイアログに入力されたファイル名を、必要に応じて拡張子を付け加えて返します。
+// ファイル名として使えない場合は空文字列を返します。
+func (d *FileDialog) fileName() string {
+	name := strings.TrimSpace(d.nameInput.Value())
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return ""
+	}
+	if len(d.exts) == 0 {
+		return name
+	}
+	ext := strings.ToLower(path.Ext(name))
+	for _, e := range d.exts {
+		if strings.ToLower("."+strings.TrimPrefix(e, ".")) == ext {
+			return name
+		}
+	}
+	return name + "." + strings.TrimPrefix(d.exts[0], ".")
+}
+
+// updateConfirm は、確定できる状態かどうかに合わせて、確定ボタンの有効・無効を切り替えます。
+func (d *FileDialog) updateConfirm() {
+	var enabled bool
+	if d.mode == FileDialogSave {
+		enabled = d.fileName() != ""
+	} else {
+		enabled = d.list.SelectedEntry() != nil
+	}
+	d.confirm.SetDisabled(!enabled)
+}
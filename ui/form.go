@@ -0,0 +1,390 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"furoshiki/component"
+	"furoshiki/container"
+	"furoshiki/event"
+	"furoshiki/layout"
+	"furoshiki/widget"
+)
+
+// このファイルは、ラベル付きの入力欄を並べたフォームを構築し、
+// 入力欄の検証と送信をまとめて扱うための仕組みを提供します。
+//
+//	ui.Form(func(f *ui.FormBuilder) {
+//		f.Section("Account")
+//		f.TextField("name", "Name", func(t *widget.TextInputBuilder) { t.Validate(widget.Required("")) })
+//		f.NumberField("age", "Age", func(n *widget.NumberInputBuilder) { n.Range(0, 150) })
+//		f.Submit("Save", nil).OnSubmit(func(v ui.FormValues) { save(v.String("name"), v.Float("age")) })
+//	})
+
+const (
+	// defaultFormLabelWidth は、フォームのラベル列の既定の幅です。
+	defaultFormLabelWidth = 120
+	// defaultFormGap は、フォームの行と列の既定の間隔です。
+	defaultFormGap = 8
+)
+
+// ErrDuplicateFormField は、フォームに同じ名前の項目が複数登録された場合のエラーです。
+var ErrDuplicateFormField = errors.New("duplicate form field")
+
+// FormValues は、フォームの項目名をキーとする入力値です。
+// 値の型は項目の種類によって決まります(TextField: string, NumberField: float64, ToggleField: bool)。
+type FormValues map[string]any
+
+// String は、指定された項目の文字列の値を返します。項目がない、または型が異なる場合は空文字列です。
+func (v FormValues) String(name string) string {
+	s, _ := v[name].(string)
+	return s
+}
+
+// Float は、指定された項目の数値を返します。項目がない、または型が異なる場合は0です。
+func (v FormValues) Float(name string) float64 {
+	f, _ := v[name].(float64)
+	return f
+}
+
+// Bool は、指定された項目の真偽値を返します。項目がない、または型が異なる場合はfalseです。
+func (v FormValues) Bool(name string) bool {
+	b, _ := v[name].(bool)
+	return b
+}
+
+// formField は、フォームに登録された1つの項目です。
+type formField struct {
+	name   string
+	widget component.Widget
+	// input は、検証の対象となる入力欄です。検証を持たない項目ではnilです。
+	input *widget.TextInput
+	value func() any
+}
+
+// FormState は、フォームに登録された項目をまとめて管理します。
+// 項目全体の検証、送信ボタンの有効・無効の切り替え、送信時の値の収集を行います。
+type FormState struct {
+	fields   []*formField
+	submit   *widget.Button
+	onSubmit []func(values FormValues)
+}
+
+// Field は、指定された名前の項目のウィジェットを返します。見つからない場合はnilです。
+func (f *FormState) Field(name string) component.Widget {
+	if field := f.field(name); field != nil {
+		return field.widget
+	}
+	return nil
+}
+
+func (f *FormState) field(name string) *formField {
+	for _, field := range f.fields {
+		if field.name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// Values は、すべての項目の現在の値を返します。
+func (f *FormState) Values() FormValues {
+	values := make(FormValues, len(f.fields))
+	for _, field := range f.fields {
+		values[field.name] = field.value()
+	}
+	return values
+}
+
+// IsValid は、すべての項目の現在の値が検証を満たすかどうかを返します。
+// 結果は入力欄の見た目に反映されません。
+func (f *FormState) IsValid() bool {
+	for _, field := range f.fields {
+		if field.input != nil && !field.input.CheckValidity() {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate は、まだ操作されていない項目も含めてすべての項目を検証し、結果を各入力欄に表示します。
+// すべての項目が検証に成功した場合はtrueを返します。
+func (f *FormState) Validate() bool {
+	valid := true
+	for _, field := range f.fields {
+		if field.input != nil && field.input.Validate() != nil {
+			valid = false
+		}
+	}
+	f.refresh()
+	return valid
+}
+
+// Submit は、すべての項目を検証し、成功した場合は送信時の関数を項目の値と共に呼び出します。
+// 送信した場合はtrueを返します。
+func (f *FormState) Submit() bool {
+	if !f.Validate() {
+		return false
+	}
+	values := f.Values()
+	for _, fn := range f.onSubmit {
+		fn(values)
+	}
+	return true
+}
+
+// AddOnSubmit は、フォームが送信されたときに呼び出される関数を追加します。
+func (f *FormState) AddOnSubmit(fn func(values FormValues)) {
+	if fn != nil {
+		f.onSubmit = append(f.onSubmit, fn)
+	}
+}
+
+// refresh は、現在の値の検証結果に合わせて送信ボタンの有効・無効を切り替えます。
+func (f *FormState) refresh() {
+	if f.submit != nil {
+		f.submit.SetDisabled(!f.IsValid())
+	}
+}
+
+// --- FormBuilder ---
+
+// FormBuilder は、ラベル付きの項目を1行ずつ並べたフォームを構築するためのビルダーです。
+// 内部ではAdvancedGridLayoutを使用し、1列目にラベル、2列目に入力欄を配置します。
+// 各行の高さは、その行の要素の最小の高さに合わせて決まります。
+type FormBuilder struct {
+	component.Builder[*FormBuilder, *container.Container]
+	grid  *layout.AdvancedGridLayout
+	state *FormState
+
+	labelWidth int
+	// rowHeights は、追加された各行の高さです。Build時に行の定義に変換されます。
+	rowHeights []int
+}
+
+// Form は、ラベル付きの入力欄を並べ、検証と送信をまとめて扱うフォームを構築します。
+func Form(buildFunc func(*FormBuilder)) *FormBuilder {
+	gridLayout := &layout.AdvancedGridLayout{HorizontalGap: defaultFormGap, VerticalGap: defaultFormGap}
+	c, err := container.NewContainer()
+
+	b := &FormBuilder{
+		grid:       gridLayout,
+		state:      &FormState{},
+		labelWidth: defaultFormLabelWidth,
+	}
+	b.Init(b, c)
+	b.AddError(err)
+
+	if err == nil {
+		c.SetLayout(gridLayout)
+		if buildFunc != nil {
+			buildFunc(b)
+		}
+	}
+	return b
+}
+
+// State は、フォームの項目を管理するFormStateを返します。
+func (b *FormBuilder) State() *FormState {
+	return b.state
+}
+
+// AssignState は、フォームのFormStateを変数に代入します。
+// 例: var form *ui.FormState; f.AssignState(&form)
+func (b *FormBuilder) AssignState(target **FormState) *FormBuilder {
+	if target == nil {
+		b.AddError(errors.New("AssignState target cannot be nil"))
+		return b
+	}
+	*target = b.state
+	return b
+}
+
+// LabelWidth は、ラベル列の幅を設定します。
+func (b *FormBuilder) LabelWidth(px int) *FormBuilder {
+	if px < 0 {
+		b.AddError(fmt.Errorf("%w, label width %d", component.ErrInvalidSize, px))
+		return b
+	}
+	b.labelWidth = px
+	return b
+}
+
+// Gap は、行と列の間隔を設定します。
+func (b *FormBuilder) Gap(gap int) *FormBuilder {
+	b.grid.HorizontalGap = gap
+	b.grid.VerticalGap = gap
+	b.Widget.MarkDirty(true)
+	return b
+}
+
+// Section は、項目のまとまりの見出しを、ラベル列と入力欄の列にまたがる行として追加します。
+func (b *FormBuilder) Section(title string) *FormBuilder {
+	row := b.newRow()
+	place(b, row, 0, 2, widget.NewLabelBuilder().Text(title))
+	return b
+}
+
+// TextField は、文字列を入力する項目を追加します。値はstringとして収集されます。
+// 入力欄でEnterキーが押されると、フォームを送信します。
+func (b *FormBuilder) TextField(name, label string, buildFunc func(*widget.TextInputBuilder)) *FormBuilder {
+	builder := widget.NewTextInputBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	input, ok := addField(b, name, label, builder)
+	if ok {
+		b.trackInput(input, func() any { return input.Value() })
+	}
+	return b
+}
+
+// NumberField は、数値を入力する項目を追加します。値はfloat64として収集されます。
+// 入力欄でEnterキーが押されると、フォームを送信します。
+func (b *FormBuilder) NumberField(name, label string, buildFunc func(*widget.NumberInputBuilder)) *FormBuilder {
+	builder := widget.NewNumberInputBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	input, ok := addField(b, name, label, builder)
+	if ok {
+		b.trackInput(input.TextInput, func() any { return input.Value() })
+	}
+	return b
+}
+
+// ToggleField は、オン・オフを切り替える項目を追加します。値はboolとして収集されます。
+func (b *FormBuilder) ToggleField(name, label string, buildFunc func(*widget.ToggleButtonBuilder)) *FormBuilder {
+	builder := widget.NewToggleButtonBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	toggle, ok := addField(b, name, label, builder)
+	if ok {
+		b.state.fields[len(b.state.fields)-1].value = func() any { return toggle.IsSelected() }
+	}
+	return b
+}
+
+// Submit は、フォームの送信ボタンを入力欄の列に追加します。
+// ボタンは、すべての項目が検証を満たしている間だけ有効になります。
+func (b *FormBuilder) Submit(text string, buildFunc func(*widget.ButtonBuilder)) *FormBuilder {
+	if b.state.submit != nil {
+		b.AddError(errors.New("form already has a submit button"))
+		return b
+	}
+	builder := widget.NewButtonBuilder().Text(text)
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	state := b.state
+	builder.AddOnClick(func(e *event.Event) event.Propagation {
+		state.Submit()
+		return event.StopPropagation
+	})
+	row := b.newRow()
+	if btn, ok := place(b, row, 1, 1, builder); ok {
+		state.submit = btn
+	}
+	return b
+}
+
+// OnSubmit は、フォームが送信されたときに呼び出される関数を追加します。
+func (b *FormBuilder) OnSubmit(fn func(values FormValues)) *FormBuilder {
+	b.state.AddOnSubmit(fn)
+	return b
+}
+
+// Build は、行の定義を確定してフォームのコンテナを構築します。
+// フォームの最小サイズは、ラベル列の幅と各行の高さから算出されます。
+func (b *FormBuilder) Build() (*container.Container, error) {
+	if b.Widget != nil {
+		rows := make([]layout.TrackDefinition, len(b.rowHeights))
+		height := 0
+		for i, h := range b.rowHeights {
+			rows[i] = Fixed(float64(h))
+			height += h
+		}
+		height += max(0, len(rows)-1) * b.grid.VerticalGap
+		b.grid.ColumnDefinitions = []layout.TrackDefinition{Fixed(float64(b.labelWidth)), Weight(1)}
+		b.grid.RowDefinitions = rows
+		padding := b.Widget.GetPadding()
+		minW, minH := b.Widget.GetMinSize()
+		b.Widget.SetMinSize(
+			max(minW, b.labelWidth+b.grid.HorizontalGap+padding.Left+padding.Right),
+			max(minH, height+padding.Top+padding.Bottom),
+		)
+		b.state.refresh()
+	}
+	return b.Builder.Build()
+}
+
+// MustBuild は、Buildを呼び出し、エラーがあればすべてのエラーのレポートと共にpanicします。
+func (b *FormBuilder) MustBuild() *container.Container {
+	return component.MustBuild(b.Build())
+}
+
+// newRow は、新しい行を追加し、その行番号を返します。
+func (b *FormBuilder) newRow() int {
+	b.rowHeights = append(b.rowHeights, 0)
+	return len(b.rowHeights) - 1
+}
+
+// addField は、ラベルと入力欄からなる行を追加し、項目として登録します。
+// 名前が空または重複している場合や、入力欄の構築に失敗した場合はfalseを返します。
+func addField[W component.Widget](b *FormBuilder, name, label string, builder component.BuilderFinalizer[W]) (W, bool) {
+	var zero W
+	if name == "" {
+		b.AddError(errors.New("form field name cannot be empty"))
+		return zero, false
+	}
+	if b.state.field(name) != nil {
+		b.AddError(fmt.Errorf("%w: %q", ErrDuplicateFormField, name))
+		return zero, false
+	}
+	row := b.newRow()
+	place(b, row, 0, 1, widget.NewLabelBuilder().Text(label))
+	w, ok := place(b, row, 1, 1, builder)
+	if !ok {
+		return zero, false
+	}
+	b.state.fields = append(b.state.fields, &formField{name: name, widget: w})
+	return w, true
+}
+
+// trackInput は、直前に登録した項目を検証の対象にし、値の変化に合わせて送信ボタンの状態を更新します。
+func (b *FormBuilder) trackInput(input *widget.TextInput, value func() any) {
+	field := b.state.fields[len(b.state.fields)-1]
+	field.input = input
+	field.value = value
+	state := b.state
+	input.AddOnChange(func(string) { state.refresh() })
+	input.AddOnFocusChange(func(bool) { state.refresh() })
+	input.AddOnSubmit(func(string) { state.Submit() })
+}
+
+// place は、ビルダーからウィジェットを構築し、指定された行と列に配置します。
+// 行の高さは、配置したウィジェットの最小の高さと設定された高さのうち大きい方に合わせて広げます。
+func place[W component.Widget](b *FormBuilder, row, col, colSpan int, builder component.BuilderFinalizer[W]) (W, bool) {
+	index := len(b.Widget.GetChildren())
+	w, err := builder.Build()
+	if err != nil {
+		// 構築に失敗したウィジェットは型付きnilの可能性があるため、追加しません。
+		b.AddError(component.WithChildIndex(err, index))
+		var zero W
+		return zero, false
+	}
+	inheritWarnings(b, index, builder)
+	if lp, ok := any(w).(component.LayoutProperties); ok {
+		lp.SetLayoutData(layout.GridPlacementData{Row: row, Col: col, RowSpan: 1, ColSpan: colSpan})
+	}
+	b.Widget.AddChild(w)
+	if mss, ok := any(w).(component.MinSizeSetter); ok {
+		_, minH := mss.GetMinSize()
+		b.rowHeights[row] = max(b.rowHeights[row], minH)
+	}
+	if ss, ok := any(w).(component.SizeSetter); ok {
+		_, h := ss.GetSize()
+		b.rowHeights[row] = max(b.rowHeights[row], h)
+	}
+	return w, true
+}
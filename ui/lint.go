@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"fmt"
+	"furoshiki/component"
+	"furoshiki/container"
+	"furoshiki/layout"
+)
+
+// このファイルは、コンテナのビルド時に、エラーではないものの意図どおりに動作しない可能性が高い設定を
+// 警告(component.BuildWarning)として報告する検査を提供します。警告はBuildの結果に影響しません。
+// 子のビルダーの警告は親のビルダーに引き継がれるため、ルートのビルダーのWarningsですべてを確認できます。
+//
+//	b := ui.VStack(func(b *ui.FlexBuilder) { ... })
+//	root, err := b.Build()
+//	for _, w := range b.Warnings() {
+//		log.Printf("build warning: %s", w)
+//	}
+
+const (
+	// WarnFlexInAbsoluteLayout は、ZStack(AbsoluteLayout)の子にFlexが設定されていることを表します。AbsoluteLayoutはFlexを使用しません。
+	WarnFlexInAbsoluteLayout component.WarningCode = "flex-in-absolute"
+	// WarnWrapWithoutHeight は、高さもFlexも持たないVStackでWrapが有効になっていることを表します。折り返す位置が決まらないため、折り返されません。
+	WarnWrapWithoutHeight component.WarningCode = "wrap-without-height"
+	// WarnStretchFixedCrossSize は、AlignStretchのコンテナの子に、交差軸の大きさがSizeで指定されていることを表します。指定した大きさは引き伸ばしで上書きされます。
+	WarnStretchFixedCrossSize component.WarningCode = "stretch-fixed-cross-size"
+	// WarnGridPlacementOutsideTracks は、AdvancedGridの子の配置が、定義された列や行の範囲外にあることを表します。配置は範囲内に切り詰められます。
+	WarnGridPlacementOutsideTracks component.WarningCode = "grid-placement-outside-tracks"
+)
+
+// warningAdder は、警告を追加できるビルダーのインターフェースです。これは基底の `component.Builder` によって実装されます。
+type warningAdder interface {
+	AddWarning(code component.WarningCode, message string)
+	AddChildWarning(index int, child component.Widget, code component.WarningCode, message string)
+	AddChildWarnings(index int, warnings []*component.BuildWarning)
+}
+
+// inheritWarnings は、子のビルダーが報告した警告を、indexの位置の子の警告として親のビルダーに引き継ぎます。
+func inheritWarnings(parent warningAdder, index int, child any) {
+	if wr, ok := child.(component.WarningReporter); ok {
+		parent.AddChildWarnings(index, wr.Warnings())
+	}
+}
+
+// lintContainer は、コンテナのレイアウトと子の組み合わせを検査し、見つかった問題をbに警告として追加します。
+func lintContainer(b warningAdder, c *container.Container) {
+	if c == nil {
+		return
+	}
+	children := c.GetChildren()
+	switch l := c.GetLayout().(type) {
+	case *layout.AbsoluteLayout:
+		for i, child := range children {
+			if lp, ok := child.(component.LayoutProperties); ok && lp.GetFlex() > 0 {
+				b.AddChildWarning(i, child, WarnFlexInAbsoluteLayout, "Flex has no effect in a ZStack (AbsoluteLayout); use Size or AbsolutePosition")
+			}
+		}
+	case *layout.FlexLayout:
+		if l.Wrap && l.Direction == layout.DirectionColumn {
+			_, height := c.GetSize()
+			_, minHeight := c.GetMinSize()
+			if height <= 0 && minHeight <= 0 && c.GetFlex() == 0 {
+				b.AddWarning(WarnWrapWithoutHeight, "Wrap on a VStack needs a height to wrap at; set Size or Flex")
+			}
+		}
+		if l.AlignItems == layout.AlignStretch {
+			lintStretchChildren(b, children, l.Direction == layout.DirectionRow)
+		}
+	case *layout.AdvancedGridLayout:
+		lintGridPlacements(b, children, l)
+	}
+}
+
+// lintStretchChildren は、引き伸ばしで上書きされる交差軸の大きさがSizeで指定された子を警告します。
+func lintStretchChildren(b warningAdder, children []component.Widget, isRow bool) {
+	for i, child := range children {
+		es, ok := child.(interface{ HasExplicitSize() (width, height bool) })
+		if !ok {
+			continue
+		}
+		width, height := es.HasExplicitSize()
+		switch {
+		case isRow && height:
+			b.AddChildWarning(i, child, WarnStretchFixedCrossSize, "the height set with Size is overridden by AlignStretch; use MinSize or another AlignItems")
+		case !isRow && width:
+			b.AddChildWarning(i, child, WarnStretchFixedCrossSize, "the width set with Size is overridden by AlignStretch; use MinSize or another AlignItems")
+		}
+	}
+}
+
+// lintGridPlacements は、定義された列と行の範囲外に配置された子と、配置情報を持たない子を警告します。
+func lintGridPlacements(b warningAdder, children []component.Widget, l *layout.AdvancedGridLayout) {
+	if len(children) == 0 {
+		return
+	}
+	cols, rows := len(l.ColumnDefinitions), len(l.RowDefinitions)
+	if cols == 0 || rows == 0 {
+		b.AddWarning(WarnGridPlacementOutsideTracks, "AdvancedGrid has no column or row definitions, so no child is laid out; set Columns and Rows")
+		return
+	}
+	for i, child := range children {
+		lp, ok := child.(component.LayoutProperties)
+		if !ok {
+			continue
+		}
+		p, ok := lp.GetLayoutData().(layout.GridPlacementData)
+		if !ok {
+			b.AddChildWarning(i, child, WarnGridPlacementOutsideTracks, "child has no grid placement and is not laid out; add it with At or a ...At method")
+			continue
+		}
+		if p.Row < 0 || p.Col < 0 || p.RowSpan < 1 || p.ColSpan < 1 || p.Row+p.RowSpan > rows || p.Col+p.ColSpan > cols {
+			b.AddChildWarning(i, child, WarnGridPlacementOutsideTracks, fmt.Sprintf(
+				"placement (row %d, col %d, span %dx%d) is outside the %d rows x %d columns and is clamped",
+				p.Row, p.Col, p.RowSpan, p.ColSpan, rows, cols))
+		}
+	}
+}
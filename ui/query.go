@@ -0,0 +1,50 @@
+package ui
+
+import "furoshiki/component"
+
+// このファイルは、構築済みのウィジェットツリーをID・型・タグで検索するための関数を提供します。
+// ビルダーの ID や Tag で付与した情報を使うことで、多数の AssignTo 用変数を
+// 事前に宣言しておく必要がなくなります。
+// 走査そのものは component.Walk に委譲しています。
+
+// Find は、root以下のツリーから指定されたIDを持つ最初のウィジェットを返します。
+// 見つからない場合や、idが空文字列の場合はnilを返します。
+func Find(root component.Widget, id string) component.Widget {
+	// IDを持たないウィジェットのIDは空文字列のため、空のidはどのウィジェットにも一致させません。
+	if id == "" {
+		return nil
+	}
+	return component.FindFirst(root, func(w component.Widget) bool {
+		ident, ok := w.(component.Identifiable)
+		return ok && ident.GetID() == id
+	})
+}
+
+// FindAs は、指定されたIDを持つウィジェットを型Tとして返します。
+// ウィジェットが見つからない、または型が一致しない場合は第2戻り値がfalseになります。
+// 例: volume, ok := ui.FindAs[*widget.Label](root, "settings.volume")
+func FindAs[T component.Widget](root component.Widget, id string) (T, bool) {
+	typed, ok := Find(root, id).(T)
+	return typed, ok
+}
+
+// FindAllByType は、root以下のツリーから型Tのウィジェットをすべて、ツリーの深さ優先順で返します。
+// 例: buttons := ui.FindAllByType[*widget.Button](root)
+func FindAllByType[T component.Widget](root component.Widget) []T {
+	var result []T
+	component.Walk(root, func(w component.Widget, _ int) component.WalkResult {
+		if typed, ok := w.(T); ok {
+			result = append(result, typed)
+		}
+		return component.WalkContinue
+	})
+	return result
+}
+
+// FindByTag は、root以下のツリーから指定されたタグを持つウィジェットをすべて返します。
+func FindByTag(root component.Widget, tag string) []component.Widget {
+	return component.FindAll(root, func(w component.Widget) bool {
+		ident, ok := w.(component.Identifiable)
+		return ok && ident.HasTag(tag)
+	})
+}
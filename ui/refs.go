@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"furoshiki/component"
+	"furoshiki/container"
+	"furoshiki/widget"
+)
+
+// Refs は、名前をキーにウィジェットへの参照を保持するレジストリです。
+// ビルダーの Ref で登録し、型付きのゲッターで取り出します。
+// AssignToのように参照ごとに変数を宣言したり、リフレクションを使ったりする必要がありません。
+//
+//	refs := ui.NewRefs()
+//	b.Label(func(l *widget.LabelBuilder) { l.Text("Details").Ref(refs, "detail.title") })
+//	...
+//	refs.Label("detail.title").SetText("Item 1")
+type Refs struct {
+	widgets map[string]component.Widget
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ component.RefRegistry = (*Refs)(nil)
+
+// NewRefs は、空のRefsを生成します。
+func NewRefs() *Refs {
+	return &Refs{widgets: make(map[string]component.Widget)}
+}
+
+// Register は、ウィジェットを指定された名前で登録します。
+// 名前が空の場合や、同じ名前が既に登録されている場合はエラーを返します。
+func (r *Refs) Register(name string, w component.Widget) error {
+	if name == "" {
+		return errors.New("ref name cannot be empty")
+	}
+	if w == nil {
+		return component.ErrNilChild
+	}
+	if _, exists := r.widgets[name]; exists {
+		return fmt.Errorf("ref %q is already registered", name)
+	}
+	r.widgets[name] = w
+	return nil
+}
+
+// Unregister は、指定された名前の登録を解除します。
+func (r *Refs) Unregister(name string) {
+	delete(r.widgets, name)
+}
+
+// Get は、指定された名前で登録されたウィジェットを返します。見つからない場合はnilを返します。
+func (r *Refs) Get(name string) component.Widget {
+	return r.widgets[name]
+}
+
+// Label は、指定された名前で登録された *widget.Label を返します。
+// 見つからない、または型が異なる場合はnilを返します。
+func (r *Refs) Label(name string) *widget.Label {
+	l, _ := RefAs[*widget.Label](r, name)
+	return l
+}
+
+// Button は、指定された名前で登録された *widget.Button を返します。
+// 見つからない、または型が異なる場合はnilを返します。
+func (r *Refs) Button(name string) *widget.Button {
+	btn, _ := RefAs[*widget.Button](r, name)
+	return btn
+}
+
+// Container は、指定された名前で登録された *container.Container を返します。
+// 見つからない、または型が異なる場合はnilを返します。
+func (r *Refs) Container(name string) *container.Container {
+	c, _ := RefAs[*container.Container](r, name)
+	return c
+}
+
+// RefAs は、指定された名前で登録されたウィジェットを型Tとして返します。
+// 見つからない、または型が一致しない場合は第2戻り値がfalseになります。
+// 例: sv, ok := ui.RefAs[*widget.ScrollView](refs, "list")
+func RefAs[T component.Widget](r *Refs, name string) (T, bool) {
+	typed, ok := r.widgets[name].(T)
+	return typed, ok
+}
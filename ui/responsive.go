@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"errors"
+	"furoshiki/component"
+)
+
+// このファイルは、ウィンドウ(ビューポート)の大きさに応じてUIを切り替える仕組みを提供します。
+//
+//	ui.VStack(func(b *ui.FlexBuilder) {
+//		b.When(ui.WidthAtLeast(600), func(b *ui.FlexBuilder) {
+//			b.HStack(func(b *ui.FlexBuilder) { ... }) // 広い画面では横に並べます
+//		})
+//		b.When(ui.WidthBelow(600), func(b *ui.FlexBuilder) {
+//			b.VStack(func(b *ui.FlexBuilder) { ... }) // 狭い画面では縦に並べます
+//		})
+//	})
+//
+// ビューポートの大きさは、Stage.Resize(ebiten.GameのLayoutから呼び出します)またはSetViewportSizeで設定します。
+// Whenで追加した子要素は、条件を満たさない間は非表示になり、レイアウトの領域も占有しません。
+
+// Breakpoint は、ビューポートの大きさが条件を満たすかどうかを判定する関数です。
+type Breakpoint func(width, height int) bool
+
+// WidthBelow は、ビューポートの幅がpx未満の場合に満たされる条件を返します。
+func WidthBelow(px int) Breakpoint {
+	return func(width, height int) bool { return width < px }
+}
+
+// WidthAtLeast は、ビューポートの幅がpx以上の場合に満たされる条件を返します。
+func WidthAtLeast(px int) Breakpoint {
+	return func(width, height int) bool { return width >= px }
+}
+
+// HeightBelow は、ビューポートの高さがpx未満の場合に満たされる条件を返します。
+func HeightBelow(px int) Breakpoint {
+	return func(width, height int) bool { return height < px }
+}
+
+// HeightAtLeast は、ビューポートの高さがpx以上の場合に満たされる条件を返します。
+func HeightAtLeast(px int) Breakpoint {
+	return func(width, height int) bool { return height >= px }
+}
+
+// Portrait は、ビューポートが縦長(高さが幅より大きい)の場合に満たされる条件を返します。
+func Portrait() Breakpoint {
+	return func(width, height int) bool { return height > width }
+}
+
+// viewport は、Breakpointの判定に使用するビューポートの大きさです。
+// version は大きさが変わるたびに増え、Whenの規則が再評価の要否を判断するために使用します。
+var viewport struct {
+	width, height int
+	version       int
+}
+
+// SetViewportSize は、Breakpointの判定に使用するビューポートの大きさを設定します。
+// Stageを使用している場合は、Stage.Resizeが呼び出すため、直接呼び出す必要はありません。
+func SetViewportSize(width, height int) {
+	if viewport.width == width && viewport.height == height {
+		return
+	}
+	viewport.width, viewport.height = width, height
+	viewport.version++
+	component.RequestFrame()
+}
+
+// ViewportSize は、Breakpointの判定に使用するビューポートの大きさを返します。
+func ViewportSize() (width, height int) {
+	return viewport.width, viewport.height
+}
+
+// When は、fnで追加した子要素を、ビューポートの大きさがcondを満たす間だけ表示します。
+// Ifと異なり、条件はビルド時だけでなく、ビューポートの大きさが変わるたびに再評価されます。
+// fnの中で追加した子要素の表示状態だけが切り替わるため、コンテナ自身のスタイルやレイアウトの設定は切り替わりません。
+func (b *BaseContainerBuilder[T]) When(cond Breakpoint, fn func(b T)) T {
+	return b.WhenElse(cond, fn, nil)
+}
+
+// WhenElse は、condを満たす間はfnTrueで追加した子要素を、満たさない間はfnFalseで追加した子要素を表示します。
+// どちらの関数もnilを指定できます。
+func (b *BaseContainerBuilder[T]) WhenElse(cond Breakpoint, fnTrue, fnFalse func(b T)) T {
+	if cond == nil {
+		b.AddError(errors.New("breakpoint cannot be nil"))
+		return b.Self
+	}
+	onTrue := b.collectChildren(fnTrue)
+	onFalse := b.collectChildren(fnFalse)
+	if len(onTrue) == 0 && len(onFalse) == 0 {
+		return b.Self
+	}
+
+	version := -1
+	apply := func() {
+		if version == viewport.version {
+			return
+		}
+		version = viewport.version
+		match := cond(viewport.width, viewport.height)
+		setVisible(onTrue, match)
+		setVisible(onFalse, !match)
+	}
+	apply()
+	b.Widget.AddOnUpdate(apply)
+	return b.Self
+}
+
+// collectChildren は、fnを呼び出し、その間に追加された子要素を返します。
+func (b *BaseContainerBuilder[T]) collectChildren(fn func(b T)) []component.Widget {
+	if fn == nil {
+		return nil
+	}
+	before := b.childCount()
+	fn(b.Self)
+	children := b.Widget.GetChildren()
+	if len(children) <= before {
+		return nil
+	}
+	return append([]component.Widget(nil), children[before:]...)
+}
+
+// setVisible は、ウィジェットの表示状態をまとめて設定します。
+func setVisible(widgets []component.Widget, visible bool) {
+	for _, w := range widgets {
+		if is, ok := w.(component.InteractiveState); ok {
+			is.SetVisible(visible)
+		}
+	}
+}
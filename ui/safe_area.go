@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"furoshiki/component"
+	"furoshiki/container"
+	"furoshiki/layout"
+	"furoshiki/stats"
+	"image"
+	"math"
+	"slices"
+)
+
+// このファイルは、画面の安全領域と縦横比を考慮してUIを配置するルートのコンテナを提供します。
+//
+//	root, _ := ui.NewSafeAreaRoot(gameUI)
+//	root.SetAspectRatio(16, 9)                           // 16:9で表示し、余った部分は帯(レターボックス)にします
+//	root.SetSafeArea(layout.Insets{Top: 44, Bottom: 34}) // ノッチなどを避けます
+//	root.SetOverscan(0.05)                               // テレビのオーバースキャンに備えて外周5%を避けます
+//	root.AddAnchored(minimap, ui.AnchorTopRight, 8, 8)   // HUDは安全領域の隅に固定します
+//	stage.AddLayer("game", root)
+//
+// 内容のウィジェットは、安全領域の中で指定された縦横比を保つ最大の矩形に配置されます。
+// 帯の部分には、SafeAreaRoot自身の背景色が描画されます。
+// AddAnchoredで追加したHUDは、縦横比に関係なく安全領域の端を基準に配置されるため、横長の画面では画面の隅に表示されます。
+
+// Anchor は、安全領域の中でHUDを固定する位置です。
+type Anchor int
+
+const (
+	AnchorTopLeft Anchor = iota
+	AnchorTop
+	AnchorTopRight
+	AnchorLeft
+	AnchorCenter
+	AnchorRight
+	AnchorBottomLeft
+	AnchorBottom
+	AnchorBottomRight
+)
+
+// fractions は、アンカーの位置を、安全領域の幅と高さに対する割合(0, 0.5, 1)で返します。
+func (a Anchor) fractions() (float64, float64) {
+	return float64(a%3) / 2, float64(a/3) / 2
+}
+
+// anchoredWidget は、安全領域の端に固定されたHUDのウィジェットです。
+type anchoredWidget struct {
+	widget           component.Widget
+	anchor           Anchor
+	marginX, marginY int
+}
+
+// SafeAreaRoot は、安全領域の内側に、固定の縦横比で内容を配置するルートのコンテナです。
+type SafeAreaRoot struct {
+	*container.Container
+	content component.Widget
+	// insets は、画面の端から避ける量(ノッチなど)です。
+	insets layout.Insets
+	// overscan は、画面の大きさに対して外周から避ける割合です。
+	overscan float64
+	// aspectW, aspectH は、内容を表示する縦横比です。どちらかが0の場合は、安全領域全体に内容を広げます。
+	aspectW, aspectH int
+	anchored         []anchoredWidget
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ component.Container = (*SafeAreaRoot)(nil)
+
+// NewSafeAreaRoot は、contentを内容とする新しいSafeAreaRootを生成します。
+func NewSafeAreaRoot(content component.Widget) (*SafeAreaRoot, error) {
+	if content == nil {
+		return nil, component.ErrNilChild
+	}
+	c, err := container.NewContainer()
+	if err != nil {
+		return nil, err
+	}
+	r := &SafeAreaRoot{Container: c, content: content}
+	c.SetLayout(&safeAreaLayout{root: r})
+	c.AddChild(content)
+	return r, nil
+}
+
+// Content は、内容のウィジェットを返します。
+func (r *SafeAreaRoot) Content() component.Widget {
+	return r.content
+}
+
+// SetSafeArea は、画面の端から避ける量を設定します。ノッチや角の丸い画面などに使用します。
+func (r *SafeAreaRoot) SetSafeArea(insets layout.Insets) {
+	if r.insets != insets {
+		r.insets = insets
+		r.MarkDirty(true)
+	}
+}
+
+// SafeArea は、画面の端から避ける量を返します。
+func (r *SafeAreaRoot) SafeArea() layout.Insets {
+	return r.insets
+}
+
+// SetOverscan は、画面の幅と高さに対して、外周から避ける割合(0から0.5未満)を設定します。テレビのオーバースキャンなどに使用します。
+// SetSafeAreaで設定した量に加えて適用されます。
+func (r *SafeAreaRoot) SetOverscan(ratio float64) {
+	ratio = max(0, min(ratio, 0.49))
+	if r.overscan != ratio {
+		r.overscan = ratio
+		r.MarkDirty(true)
+	}
+}
+
+// SetAspectRatio は、内容を表示する縦横比を設定します。どちらかに0を指定すると、安全領域全体に内容を広げます。
+func (r *SafeAreaRoot) SetAspectRatio(width, height int) {
+	if width < 0 || height < 0 {
+		return
+	}
+	if r.aspectW != width || r.aspectH != height {
+		r.aspectW, r.aspectH = width, height
+		r.MarkDirty(true)
+	}
+}
+
+// AddAnchored は、wを安全領域のanchorの位置に固定して追加します。marginX, marginYは、安全領域の端からの距離です。
+// HUDは内容の上に重ねて描画され、内容より優先してイベントを受け取ります。
+// ウィジェットの大きさが設定されていない場合は、最小サイズで配置されます。
+func (r *SafeAreaRoot) AddAnchored(w component.Widget, anchor Anchor, marginX, marginY int) {
+	if w == nil {
+		return
+	}
+	r.anchored = slices.DeleteFunc(r.anchored, func(a anchoredWidget) bool { return a.widget == w })
+	r.anchored = append(r.anchored, anchoredWidget{widget: w, anchor: anchor, marginX: marginX, marginY: marginY})
+	r.AddChild(w)
+}
+
+// SafeBounds は、現在の位置と大きさから、安全領域とオーバースキャンを除いた矩形を返します。
+func (r *SafeAreaRoot) SafeBounds() image.Rectangle {
+	x, y := r.GetPosition()
+	w, h := r.GetSize()
+	ox := int(math.Round(float64(w) * r.overscan))
+	oy := int(math.Round(float64(h) * r.overscan))
+	rect := image.Rect(x+r.insets.Left+ox, y+r.insets.Top+oy, x+w-r.insets.Right-ox, y+h-r.insets.Bottom-oy)
+	return rect.Canon().Intersect(image.Rect(x, y, x+w, y+h))
+}
+
+// ContentBounds は、安全領域の中で縦横比を保って内容を配置する矩形を返します。
+func (r *SafeAreaRoot) ContentBounds() image.Rectangle {
+	safe := r.SafeBounds()
+	if r.aspectW == 0 || r.aspectH == 0 || safe.Empty() {
+		return safe
+	}
+	w, h := safe.Dx(), safe.Dy()
+	// 幅に合わせると高さがはみ出す場合は、高さに合わせます(左右に帯ができます)。
+	if w*r.aspectH > h*r.aspectW {
+		w = h * r.aspectW / r.aspectH
+	} else {
+		h = w * r.aspectH / r.aspectW
+	}
+	x := safe.Min.X + (safe.Dx()-w)/2
+	y := safe.Min.Y + (safe.Dy()-h)/2
+	return image.Rect(x, y, x+w, y+h)
+}
+
+// safeAreaLayout は、SafeAreaRootの内容とHUDを配置するレイアウトです。
+type safeAreaLayout struct {
+	root *SafeAreaRoot
+}
+
+// Layout は、内容を縦横比を保つ矩形に、HUDを安全領域の端に配置します。
+func (l *safeAreaLayout) Layout(c layout.Container) error {
+	r := l.root
+	children := c.GetChildren()
+	// 取り除かれたHUDの記録を破棄します。
+	r.anchored = slices.DeleteFunc(r.anchored, func(a anchoredWidget) bool {
+		return !slices.Contains(children, a.widget)
+	})
+
+	content := r.ContentBounds()
+	placeWidget(r.content, content.Min.X, content.Min.Y, content.Dx(), content.Dy())
+
+	safe := r.SafeBounds()
+	for _, a := range r.anchored {
+		if is, ok := a.widget.(component.InteractiveState); ok && !is.IsVisible() {
+			continue
+		}
+		w, h := anchoredSize(a.widget)
+		fx, fy := a.anchor.fractions()
+		// 端に固定する場合はマージンの分だけ内側へ、中央の場合はマージンの分だけずらします。
+		x := safe.Min.X + int(math.Round(fx*float64(safe.Dx()-w))) + int(math.Round((1-2*fx)*float64(a.marginX)))
+		y := safe.Min.Y + int(math.Round(fy*float64(safe.Dy()-h))) + int(math.Round((1-2*fy)*float64(a.marginY)))
+		placeWidget(a.widget, x, y, w, h)
+	}
+	stats.AddArranged(1 + len(r.anchored))
+	return nil
+}
+
+// placeWidget は、ウィジェットの位置と大きさを設定します。
+func placeWidget(w component.Widget, x, y, width, height int) {
+	if ss, ok := w.(component.SizeSetter); ok {
+		ss.SetSize(width, height)
+	}
+	if ps, ok := w.(component.PositionSetter); ok {
+		ps.SetPosition(x, y)
+	}
+}
+
+// anchoredSize は、HUDのウィジェットを配置する大きさを返します。大きさが設定されていない軸は、最小サイズを使用します。
+func anchoredSize(w component.Widget) (int, int) {
+	var width, height, minW, minH int
+	if ss, ok := w.(component.SizeSetter); ok {
+		width, height = ss.GetSize()
+	}
+	if mss, ok := w.(component.MinSizeSetter); ok {
+		minW, minH = mss.GetMinSize()
+	}
+	return max(width, minW), max(height, minH)
+}
@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"furoshiki/component"
+	"furoshiki/event"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// このファイルは、複数のUIのルートを重ねて管理するStageを提供します。
+// ゲームのHUD、メニュー、ダイアログ、デバッグ表示などを、それぞれ独立したルートを持つレイヤーとして重ねます。
+//
+//	stage := ui.NewStage()
+//	stage.AddLayer("hud", hudRoot)
+//	stage.AddLayer("menu", menuRoot)
+//	dialog, _ := stage.AddLayer("dialog", dialogRoot)
+//	dialog.SetModal(true)
+//	dialog.SetVisible(false)
+//
+//	// ebiten.Game
+//	func (g *Game) Update() error { g.stage.Update(); return nil }
+//	func (g *Game) Draw(screen *ebiten.Image) { g.stage.Draw(screen) }
+//
+// レイヤーは追加した順に奥から手前へ重なります。描画は奥のレイヤーから、ヒットテストは手前のレイヤーから行われます。
+// モーダルなレイヤーが表示されている間は、そのレイヤーより奥のレイヤーにはポインタのイベントが届きません。
+
+// ErrDuplicateLayer は、同じ名前のレイヤーがすでに存在する場合のエラーです。
+var ErrDuplicateLayer = errors.New("layer with the same name already exists")
+
+// Layer は、Stageに重ねる1つのUIのルートです。
+type Layer struct {
+	name    string
+	root    component.Widget
+	visible bool
+	modal   bool
+}
+
+// Name は、レイヤーの名前を返します。
+func (l *Layer) Name() string {
+	return l.name
+}
+
+// Root は、レイヤーのルートのウィジェットを返します。
+func (l *Layer) Root() component.Widget {
+	return l.root
+}
+
+// SetVisible は、レイヤーを表示するかどうかを設定します。非表示のレイヤーは、更新、描画、ヒットテストのすべてが省略されます。
+// レイヤーを表示したとき、ルート以下に役割がRoleDialogのウィジェットがあれば、ダイアログの表示が支援技術に通知されます。
+func (l *Layer) SetVisible(visible bool) {
+	if l.visible != visible {
+		l.visible = visible
+		component.RequestFrame()
+		if visible {
+			component.NotifyDialogOpened(l.root)
+		}
+	}
+}
+
+// IsVisible は、レイヤーが表示されているかどうかを返します。
+func (l *Layer) IsVisible() bool {
+	return l.visible
+}
+
+// SetModal は、レイヤーをモーダルにするかどうかを設定します。
+// モーダルなレイヤーが表示されている間は、ポインタがレイヤーのウィジェットに当たらない場合でも、奥のレイヤーにイベントが届きません。
+func (l *Layer) SetModal(modal bool) {
+	l.modal = modal
+}
+
+// IsModal は、レイヤーがモーダルかどうかを返します。
+func (l *Layer) IsModal() bool {
+	return l.modal
+}
+
+// Stage は、順序付けられたレイヤーを重ねて更新・描画し、ポインタのイベントを手前のレイヤーから配送します。
+type Stage struct {
+	// layers は、奥から手前の順に並んだレイヤーです。
+	layers       []*Layer
+	dispatcher   *event.Dispatcher
+	inputEnabled bool
+}
+
+// NewStage は、レイヤーを持たない新しいStageを生成します。イベントの配送には、共有のDispatcherを使用します。
+func NewStage() *Stage {
+	return &Stage{dispatcher: event.GetDispatcher(), inputEnabled: true}
+}
+
+// AddLayer は、rootをルートとするレイヤーを最も手前に追加します。追加したレイヤーは表示された状態になります。
+func (s *Stage) AddLayer(name string, root component.Widget) (*Layer, error) {
+	return s.InsertLayer(len(s.layers), name, root)
+}
+
+// InsertLayer は、rootをルートとするレイヤーを、奥から数えてindexの位置に追加します。
+func (s *Stage) InsertLayer(index int, name string, root component.Widget) (*Layer, error) {
+	if root == nil {
+		return nil, component.ErrNilChild
+	}
+	if s.Layer(name) != nil {
+		return nil, fmt.Errorf("%w: %q", ErrDuplicateLayer, name)
+	}
+	index = max(0, min(index, len(s.layers)))
+	l := &Layer{name: name, root: root, visible: true}
+	s.layers = slices.Insert(s.layers, index, l)
+	component.RequestFrame()
+	return l, nil
+}
+
+// RemoveLayer は、指定された名前のレイヤーを取り除きます。ルートのCleanupは呼び出しません。
+// レイヤーが見つからない場合はfalseを返します。
+func (s *Stage) RemoveLayer(name string) bool {
+	i := s.indexOf(name)
+	if i < 0 {
+		return false
+	}
+	s.layers = slices.Delete(s.layers, i, i+1)
+	component.RequestFrame()
+	return true
+}
+
+// Layer は、指定された名前のレイヤーを返します。ない場合はnilです。
+func (s *Stage) Layer(name string) *Layer {
+	if i := s.indexOf(name); i >= 0 {
+		return s.layers[i]
+	}
+	return nil
+}
+
+// Layers は、奥から手前の順に並んだレイヤーを返します。
+func (s *Stage) Layers() []*Layer {
+	return slices.Clone(s.layers)
+}
+
+// SetDispatcher は、イベントの配送に使用するDispatcherを設定します。
+func (s *Stage) SetDispatcher(d *event.Dispatcher) {
+	if d != nil {
+		s.dispatcher = d
+	}
+}
+
+// SetInputEnabled は、Updateでポインタのイベントを配送するかどうかを設定します。
+// デバッグ用のインスペクタがマウスの入力を使っている間など、UIに入力を届けたくない場合にfalseにします。
+func (s *Stage) SetInputEnabled(enabled bool) {
+	s.inputEnabled = enabled
+}
+
+// Resize は、すべてのレイヤーのルートの大きさを変更し、ビューポートの大きさ(SetViewportSize)を設定します。
+// ルートは大きさの変更によって再レイアウトされます。ウィンドウの大きさの変更に追従するため、
+// ebiten.GameのLayoutから、outsideWidth, outsideHeightを渡して呼び出してください。
+func (s *Stage) Resize(width, height int) {
+	SetViewportSize(width, height)
+	for _, l := range s.layers {
+		if ss, ok := l.root.(component.SizeSetter); ok {
+			ss.SetSize(width, height)
+		}
+	}
+}
+
+// HitTest は、ポップアップと、手前から順に表示中のレイヤーをヒットテストし、最初に当たったウィジェットを返します。
+// モーダルなレイヤーに当たらなかった場合は、それより奥のレイヤーはテストせずにnilを返します。
+func (s *Stage) HitTest(x, y int) component.Widget {
+	if hit := component.HitTestPopups(x, y); hit != nil {
+		return hit
+	}
+	for i := len(s.layers) - 1; i >= 0; i-- {
+		l := s.layers[i]
+		if !l.visible {
+			continue
+		}
+		if hit := l.root.HitTest(x, y); hit != nil {
+			return hit
+		}
+		if l.modal {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Update は、カーソルの位置でヒットテストしてイベントを配送した後、表示中のレイヤーのルートを奥から順に更新します。
+// ebiten.GameのUpdateから毎フレーム呼び出してください。
+func (s *Stage) Update() {
+	if s.inputEnabled {
+		cx, cy := ebiten.CursorPosition()
+		var target event.EventTarget
+		if hit := s.HitTest(cx, cy); hit != nil {
+			target, _ = hit.(event.EventTarget)
+		}
+		s.dispatcher.Dispatch(target, cx, cy)
+	}
+	for _, l := range slices.Clone(s.layers) {
+		if l.visible {
+			l.root.Update()
+		}
+	}
+}
+
+// Draw は、表示中のレイヤーを奥から順に描画し、最後にポップアップを描画します。
+func (s *Stage) Draw(screen *ebiten.Image) {
+	info := component.DrawInfo{Screen: screen}
+	for _, l := range s.layers {
+		if l.visible {
+			component.DrawWidget(l.root, info)
+			component.FlushDraws()
+		}
+	}
+	component.DrawPopups(screen)
+}
+
+// indexOf は、指定された名前のレイヤーのインデックスを返します。ない場合は-1です。
+func (s *Stage) indexOf(name string) int {
+	return slices.IndexFunc(s.layers, func(l *Layer) bool { return l.name == name })
+}
@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"furoshiki/component"
+	"furoshiki/event"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// このファイルは、UIのルートを画面ではなくオフスクリーンのテクスチャに描画する仕組みを提供します。
+// ゲーム内のモニターや看板にUIを貼り付ける場合や、分割画面のビューポートごとにUIを表示する場合に使用します。
+//
+//	monitor, _ := ui.NewSurface(root)
+//
+//	// Update
+//	if tx, ty, ok := screenToMonitor(cx, cy); ok {
+//		monitor.SetPointer(tx, ty)
+//	} else {
+//		monitor.ClearPointer()
+//	}
+//	monitor.Update()
+//
+//	// Draw
+//	monitor.Draw()
+//	screen.DrawImage(monitor.Texture(), monitorOpts)
+//
+// Surfaceは独自のevent.Dispatcherを持つため、画面のUIとはホバーや押下の状態が混ざりません。
+// ポインタの位置は、テクスチャの左上を原点とする座標で指定します。
+//
+// NOTE: ポップアップ(入力候補のリストなど)は画面全体で共有されるため、Surfaceのテクスチャには描画されません。
+//       また、ebiten.CursorPositionを直接参照するウィジェットのドラッグ操作は、テクスチャ上の座標に追従しません。
+
+// Surface は、UIのルートをオフスクリーンのテクスチャに描画し、手動で与えたポインタの位置でイベントを処理します。
+type Surface struct {
+	root       component.Widget
+	dispatcher *event.Dispatcher
+	texture    *ebiten.Image
+
+	// pointer は、次のUpdateで使用するポインタの状態です。座標はテクスチャ上の座標です。
+	pointer event.Pointer
+	// hasPointer は、ポインタがテクスチャの上にあるかどうかを表します。
+	hasPointer bool
+	// injected は、pointerのボタンとホイールの状態が、マウスではなくInjectPointerで与えられたものかどうかを表します。
+	injected bool
+}
+
+// NewSurface は、rootをテクスチャに描画する新しいSurfaceを生成します。
+// テクスチャの大きさは、rootの大きさに合わせられます。
+func NewSurface(root component.Widget) (*Surface, error) {
+	if root == nil {
+		return nil, component.ErrNilChild
+	}
+	return &Surface{root: root, dispatcher: event.NewDispatcher()}, nil
+}
+
+// Root は、テクスチャに描画するUIのルートを返します。
+func (s *Surface) Root() component.Widget {
+	return s.root
+}
+
+// Texture は、最後のDrawで描画したテクスチャを返します。一度もDrawを呼び出していない場合はnilです。
+func (s *Surface) Texture() *ebiten.Image {
+	return s.texture
+}
+
+// SetPointer は、ポインタがテクスチャ上の(x, y)にあることを設定します。
+// ボタンとホイールの状態は、次のUpdateの時点のマウスの状態を使用します。
+func (s *Surface) SetPointer(x, y int) {
+	s.pointer = event.Pointer{X: x, Y: y}
+	s.hasPointer = true
+	s.injected = false
+}
+
+// SetPointerInRect は、テクスチャを画面上のdstの矩形に引き伸ばして描画している場合に、
+// 画面上の(x, y)をテクスチャ上の座標に変換してポインタを設定します。
+// (x, y)がdstの外にある場合は、ポインタを解除してfalseを返します。
+func (s *Surface) SetPointerInRect(dst image.Rectangle, x, y int) bool {
+	tw, th := s.textureSize()
+	if !image.Pt(x, y).In(dst) || tw <= 0 || th <= 0 {
+		s.ClearPointer()
+		return false
+	}
+	tx := (x - dst.Min.X) * tw / dst.Dx()
+	ty := (y - dst.Min.Y) * th / dst.Dy()
+	s.SetPointer(tx, ty)
+	return true
+}
+
+// InjectPointer は、ボタンとホイールの状態を含むポインタの入力を、マウスの代わりに次のUpdateで使用します。
+// ゲームパッドで動かすカーソルなど、マウス以外の入力でUIを操作する場合に使用します。座標はテクスチャ上の座標です。
+func (s *Surface) InjectPointer(p event.Pointer) {
+	s.pointer = p
+	s.hasPointer = true
+	s.injected = true
+}
+
+// ClearPointer は、ポインタがテクスチャの上にないことを設定します。ホバー中のウィジェットにはMouseLeaveが送られます。
+func (s *Surface) ClearPointer() {
+	s.hasPointer = false
+	s.injected = false
+}
+
+// Update は、設定されたポインタの位置でヒットテストとイベントの発行を行ってから、ルートを更新します。
+// ebiten.GameのUpdateから毎フレーム呼び出してください。
+func (s *Surface) Update() {
+	rootX, rootY := 0, 0
+	if ps, ok := s.root.(component.PositionSetter); ok {
+		rootX, rootY = ps.GetPosition()
+	}
+
+	p := s.pointer
+	if !s.injected {
+		wheelX, wheelY := ebiten.Wheel()
+		p.JustReleased = inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft)
+		if s.hasPointer {
+			p.JustPressed = inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+			p.WheelX, p.WheelY = wheelX, wheelY
+		}
+	}
+	// テクスチャ上の座標を、ルートの座標系に変換します。
+	p.X += rootX
+	p.Y += rootY
+
+	var target event.EventTarget
+	if s.hasPointer {
+		if hit := s.root.HitTest(p.X, p.Y); hit != nil {
+			target, _ = hit.(event.EventTarget)
+		}
+	} else {
+		// テクスチャの外では押下を始めませんが、テクスチャの外で離された押下は終了させます。
+		p.JustPressed = false
+		p.WheelX, p.WheelY = 0, 0
+	}
+	s.dispatcher.DispatchPointer(target, p)
+	if s.injected {
+		// 注入された押下と解放は、1フレームだけ有効です。
+		s.pointer.JustPressed, s.pointer.JustReleased = false, false
+		s.pointer.WheelX, s.pointer.WheelY = 0, 0
+	}
+
+	s.root.Update()
+}
+
+// Draw は、テクスチャをクリアし、ルートを描画します。ebiten.GameのDrawから毎フレーム呼び出してください。
+// テクスチャの大きさがルートの大きさと異なる場合は、テクスチャを作り直します。
+func (s *Surface) Draw() {
+	width, height := 0, 0
+	if ss, ok := s.root.(component.SizeSetter); ok {
+		width, height = ss.GetSize()
+	}
+	if width <= 0 || height <= 0 {
+		return
+	}
+	s.texture = component.EnsureOffscreen(s.texture, width, height)
+
+	rootX, rootY := 0, 0
+	if ps, ok := s.root.(component.PositionSetter); ok {
+		rootX, rootY = ps.GetPosition()
+	}
+	// テクスチャへの描画を始める前に、これまでの描画命令を確定させます。
+	component.FlushDraws()
+	s.texture.Clear()
+	component.DrawWidget(s.root, component.DrawInfo{Screen: s.texture, OffsetX: -rootX, OffsetY: -rootY})
+	component.FlushDraws()
+}
+
+// Cleanup は、テクスチャとルートのリソースを解放します。
+func (s *Surface) Cleanup() {
+	s.dispatcher.Reset()
+	if s.texture != nil {
+		component.ReleaseOffscreen(s.texture)
+		s.texture = nil
+	}
+	s.root.Cleanup()
+}
+
+// textureSize は、テクスチャの大きさを返します。まだ描画していない場合は、ルートの大きさを返します。
+func (s *Surface) textureSize() (int, int) {
+	if s.texture != nil {
+		b := s.texture.Bounds()
+		return b.Dx(), b.Dy()
+	}
+	if ss, ok := s.root.(component.SizeSetter); ok {
+		return ss.GetSize()
+	}
+	return 0, 0
+}
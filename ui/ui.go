@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
 	"furoshiki/component"
 	"furoshiki/container"
 	"furoshiki/layout"
@@ -65,7 +67,8 @@ func (b *FlexBuilder) Wrap(wrap bool) *FlexBuilder {
 }
 
 // AlignContent は、複数行/列になった際の、交差軸方向のラインの揃え位置を設定します。
-// このプロパティは、Wrapがtrueの場合にのみ効果があります。
+// AlignStretchを指定するとラインが余ったスペースを分け合って広がり、AlignSpaceBetweenやAlignSpaceAroundを指定すると
+// ラインの間に余ったスペースが分配されます。このプロパティは、Wrapがtrueの場合にのみ効果があります。
 func (b *FlexBuilder) AlignContent(alignment layout.Alignment) *FlexBuilder {
 	if flexLayout, ok := b.Widget.GetLayout().(*layout.FlexLayout); ok {
 		if flexLayout.AlignContent != alignment {
@@ -77,10 +80,53 @@ func (b *FlexBuilder) AlignContent(alignment layout.Alignment) *FlexBuilder {
 }
 
 // Gap は、FlexLayout内の子要素間の間隔を設定します。
-func (b *FlexBuilder) Gap(gap int) *FlexBuilder {
+// 値を1つ指定すると、子要素間と折り返したライン間の両方に同じ間隔を使用します。
+// 2つ指定すると、1つ目を水平方向(ColumnGap)、2つ目を垂直方向(RowGap)の間隔として設定します。
+// 例: Wrap(true).Gap(4, 12) は、横の間隔を詰めつつライン間を広く取ります。
+func (b *FlexBuilder) Gap(gap int, vertical ...int) *FlexBuilder {
+	if len(vertical) > 1 {
+		b.AddError(fmt.Errorf("gap accepts at most 2 values, got %d", len(vertical)+1))
+		return b
+	}
 	if flexLayout, ok := b.Widget.GetLayout().(*layout.FlexLayout); ok {
-		if flexLayout.Gap != gap {
-			flexLayout.Gap = gap
+		if len(vertical) == 0 {
+			flexLayout.Gap, flexLayout.ColumnGap, flexLayout.RowGap = gap, 0, 0
+		} else {
+			flexLayout.Gap, flexLayout.ColumnGap, flexLayout.RowGap = 0, gap, vertical[0]
+		}
+		b.Widget.MarkDirty(true)
+	}
+	return b
+}
+
+// RowGap は、行と行の間（垂直方向）の間隔を設定します。
+func (b *FlexBuilder) RowGap(gap int) *FlexBuilder {
+	if flexLayout, ok := b.Widget.GetLayout().(*layout.FlexLayout); ok {
+		if flexLayout.RowGap != gap {
+			flexLayout.RowGap = gap
+			b.Widget.MarkDirty(true)
+		}
+	}
+	return b
+}
+
+// ColumnGap は、列と列の間（水平方向）の間隔を設定します。
+func (b *FlexBuilder) ColumnGap(gap int) *FlexBuilder {
+	if flexLayout, ok := b.Widget.GetLayout().(*layout.FlexLayout); ok {
+		if flexLayout.ColumnGap != gap {
+			flexLayout.ColumnGap = gap
+			b.Widget.MarkDirty(true)
+		}
+	}
+	return b
+}
+
+// CollapseMargins は、隣り合う子のマージンを合計せず、大きい方の値にまとめるかどうかを設定します。
+// VStackでは、上下に並んだ子の間の間隔が、上の子の下マージンと下の子の上マージンの大きい方になります。
+func (b *FlexBuilder) CollapseMargins(collapse bool) *FlexBuilder {
+	if flexLayout, ok := b.Widget.GetLayout().(*layout.FlexLayout); ok {
+		if flexLayout.CollapseMargins != collapse {
+			flexLayout.CollapseMargins = collapse
 			b.Widget.MarkDirty(true)
 		}
 	}
@@ -110,7 +156,7 @@ func (b *FlexBuilder) AlignItems(alignment layout.Alignment) *FlexBuilder {
 }
 
 // Build はコンテナの構築を完了します。
-func (b *FlexBuilder) Build() (*container.Container, error) { return b.Builder.Build() }
+func (b *FlexBuilder) Build() (*container.Container, error) { return b.BaseContainerBuilder.Build() }
 
 // --- GridBuilder (Grid用) ---
 
@@ -183,8 +229,56 @@ func (b *GridBuilder) VerticalGap(gap int) *GridBuilder {
 	return b
 }
 
+// CellSize は、グリッドのセルを固定サイズにします。0を指定した軸は、利用可能なスペースを均等に分割します。
+// ContentAlignと組み合わせると、グリッド全体をパネルの中央などに配置できます。
+func (b *GridBuilder) CellSize(width, height int) *GridBuilder {
+	if width < 0 || height < 0 {
+		b.AddError(fmt.Errorf("%w, got %dx%d", component.ErrInvalidSize, width, height))
+		return b
+	}
+	if gridLayout, ok := b.Widget.GetLayout().(*layout.GridLayout); ok {
+		gridLayout.CellWidth = width
+		gridLayout.CellHeight = height
+		b.Widget.MarkDirty(true)
+	}
+	return b
+}
+
+// Span は、直前に追加した子が占める列と行の数を設定します。以降の子は、その子と重ならないセルへ順に配置されます。
+// 例: b.Label(...).Span(2, 1) は、直前のLabelを2列分の幅にします。
+// 構築済みのウィジェットには、SetLayoutDataで layout.GridSpan を直接設定することもできます。
+func (b *GridBuilder) Span(colSpan, rowSpan int) *GridBuilder {
+	if colSpan < 1 || rowSpan < 1 {
+		b.AddError(fmt.Errorf("grid span must be at least 1, got %dx%d", colSpan, rowSpan))
+		return b
+	}
+	children := b.Widget.GetChildren()
+	if len(children) == 0 {
+		b.AddError(errors.New("grid span requires a child to be added first"))
+		return b
+	}
+	if lp, ok := children[len(children)-1].(component.LayoutProperties); ok {
+		lp.SetLayoutData(layout.GridSpan{ColSpan: colSpan, RowSpan: rowSpan})
+		b.Widget.MarkDirty(true)
+	}
+	return b
+}
+
+// RespectMinSize は、列と行を子の最小サイズより小さくしないかどうかを設定します。
+// 有効にすると、テキストなどの内容が収まらない列や行は広げられ、その分だけ他の列や行が縮められます。
+// すべてが収まらない場合、グリッドはコンテナからはみ出すため、ClipChildrenやOverflowと組み合わせて使用します。
+func (b *GridBuilder) RespectMinSize(respect bool) *GridBuilder {
+	if gridLayout, ok := b.Widget.GetLayout().(*layout.GridLayout); ok {
+		if gridLayout.RespectMinSize != respect {
+			gridLayout.RespectMinSize = respect
+			b.Widget.MarkDirty(true)
+		}
+	}
+	return b
+}
+
 // Build はコンテナの構築を完了します。
-func (b *GridBuilder) Build() (*container.Container, error) { return b.Builder.Build() }
+func (b *GridBuilder) Build() (*container.Container, error) { return b.BaseContainerBuilder.Build() }
 
 // --- ZStackBuilder (ZStack用) ---
 
@@ -213,7 +307,7 @@ func ZStack(buildFunc func(*ZStackBuilder)) *ZStackBuilder {
 }
 
 // Build はコンテナの構築を完了します。
-func (b *ZStackBuilder) Build() (*container.Container, error) { return b.Builder.Build() }
+func (b *ZStackBuilder) Build() (*container.Container, error) { return b.BaseContainerBuilder.Build() }
 
 // --- AdvancedGridBuilder ---
 
@@ -252,6 +346,12 @@ func Weight(weight float64) layout.TrackDefinition {
 	return layout.TrackDefinition{Sizing: layout.TrackSizingWeighted, Value: weight}
 }
 
+// Auto は、トラックに置かれた子の大きさに合わせる、自動サイズのトラック定義を返します。
+// 例: Columns(ui.Auto(), ui.Weight(1)) は、1列目をラベルの幅に合わせ、残りを2列目に割り当てます。
+func Auto() layout.TrackDefinition {
+	return layout.TrackDefinition{Sizing: layout.TrackSizingAuto}
+}
+
 // Columns は、グリッドの列定義を設定します。
 func (b *AdvancedGridBuilder) Columns(defs ...layout.TrackDefinition) *AdvancedGridBuilder {
 	if gridLayout, ok := b.Widget.GetLayout().(*layout.AdvancedGridLayout); ok {
@@ -297,33 +397,106 @@ func (b *AdvancedGridBuilder) Gap(gap int) *AdvancedGridBuilder {
 
 // add は、ウィジェットビルダーからウィジェットをビルドし、グリッドに追加する内部ヘルパーです。
 // ジェネリックなためAPIとして公開せず、ButtonAtのような具体的なメソッド経由で利用されます。
-func add[W component.Widget, WB interface {
-	component.BuilderFinalizer[W]
-	component.ErrorAdder
-}](b *AdvancedGridBuilder, row, col, rowSpan, colSpan int, builder WB) {
+func add[W component.Widget](b *AdvancedGridBuilder, row, col, rowSpan, colSpan int, builder component.BuilderFinalizer[W]) {
+	index := b.childCount()
 	widget, err := builder.Build()
 	if err != nil {
-		b.AddError(err)
-		// エラーがあっても不完全なウィジェットを追加することで、レイアウトの崩れを確認しやすくします
+		// NOTE: ビルドに失敗したビルダーは型付きnilを返すため、そのウィジェットは追加しません。
+		b.AddError(component.WithChildIndex(err, index))
+		return
+	}
+	inheritWarnings(b, index, builder)
+	if !b.placeAt(row, col, rowSpan, colSpan, widget) {
+		b.AddError(nilChildError(widget, index))
 	}
+}
+
+// nilChildError は、グリッドに追加できなかったnil（型付きnilを含む）の子を、
+// 型名とインデックスをパスに含むビルドエラーとして返します。
+// NOTE: 型付きnilのメソッドは呼び出せないため、ラベルには component.WidgetLabel ではなく型名だけを使います。
+func nilChildError(w component.Widget, index int) error {
+	name := "<nil>"
+	if t := reflect.TypeOf(w); t != nil {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		name = t.Name()
+	}
+	return component.WithChildIndex(component.BuildErrors{{Path: []string{name}, Err: component.ErrNilChild}}, index)
+}
 
+// placeAt は、ウィジェットに配置情報を設定してグリッドに追加します。
+// ウィジェットがnil（型付きnilを含む）の場合は何もせずfalseを返します。
+func (b *AdvancedGridBuilder) placeAt(row, col, rowSpan, colSpan int, w component.Widget) bool {
 	// NOTE: Goでは、型を持つnilインターフェースは `nil` との比較で `false` を返します。
 	// (例: `var w component.Widget = (*widget.Button)(nil)` は `w != nil` がtrueになる)
 	// これを避けるため、リフレクションで値が本当にnilかを検査します。
-	v := reflect.ValueOf(widget)
-	isNil := !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil())
+	v := reflect.ValueOf(w)
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return false
+	}
+	placement := layout.GridPlacementData{
+		Row: row, Col: col, RowSpan: rowSpan, ColSpan: colSpan,
+	}
+	if lp, ok := w.(component.LayoutProperties); ok {
+		lp.SetLayoutData(placement)
+	}
+	b.AddChild(w)
+	return true
+}
 
-	if !isNil {
-		placement := layout.GridPlacementData{
-			Row: row, Col: col, RowSpan: rowSpan, ColSpan: colSpan,
-		}
-		// 【提案1対応】ジェネリック型の変数に対して型アサーションを行うため、
-		// 一度any型にキャストしてからアサーションを実行します。
-		if lp, ok := any(widget).(component.LayoutProperties); ok {
-			lp.SetLayoutData(placement)
-		}
-		b.AddChild(widget)
+// At は、構築済みのウィジェットを指定された位置とスパンでグリッドに追加します。
+// 配置情報(GridPlacementData)は自動的に設定されるため、SetLayoutDataを直接呼び出す必要はありません。
+func (b *AdvancedGridBuilder) At(row, col, rowSpan, colSpan int, w component.Widget) *AdvancedGridBuilder {
+	index := b.childCount()
+	if !b.placeAt(row, col, rowSpan, colSpan, w) {
+		b.AddError(nilChildError(w, index))
+	}
+	return b
+}
+
+// ComponentAt は、テンプレートからウィジェットを生成し、指定された位置とスパンでグリッドに追加します。
+func (b *AdvancedGridBuilder) ComponentAt(row, col, rowSpan, colSpan int, c Component) *AdvancedGridBuilder {
+	if c == nil {
+		b.AddError(ErrNilComponent)
+		return b
 	}
+	add[component.Widget](b, row, col, rowSpan, colSpan, c)
+	return b
+}
+
+// VStackAt は、垂直方向のFlexコンテナを指定された位置とスパンでグリッドに追加します。
+func (b *AdvancedGridBuilder) VStackAt(row, col, rowSpan, colSpan int, buildFunc func(*FlexBuilder)) *AdvancedGridBuilder {
+	add(b, row, col, rowSpan, colSpan, VStack(buildFunc))
+	return b
+}
+
+// HStackAt は、水平方向のFlexコンテナを指定された位置とスパンでグリッドに追加します。
+func (b *AdvancedGridBuilder) HStackAt(row, col, rowSpan, colSpan int, buildFunc func(*FlexBuilder)) *AdvancedGridBuilder {
+	add(b, row, col, rowSpan, colSpan, HStack(buildFunc))
+	return b
+}
+
+// ZStackAt は、重ね合わせのコンテナを指定された位置とスパンでグリッドに追加します。
+func (b *AdvancedGridBuilder) ZStackAt(row, col, rowSpan, colSpan int, buildFunc func(*ZStackBuilder)) *AdvancedGridBuilder {
+	add(b, row, col, rowSpan, colSpan, ZStack(buildFunc))
+	return b
+}
+
+// GridAt は、グリッドコンテナを指定された位置とスパンでグリッドに追加します。
+func (b *AdvancedGridBuilder) GridAt(row, col, rowSpan, colSpan int, buildFunc func(*GridBuilder)) *AdvancedGridBuilder {
+	add(b, row, col, rowSpan, colSpan, Grid(buildFunc))
+	return b
+}
+
+// ScrollViewAt は、ScrollViewを指定された位置とスパンでグリッドに追加します。
+func (b *AdvancedGridBuilder) ScrollViewAt(row, col, rowSpan, colSpan int, buildFunc func(*widget.ScrollViewBuilder)) *AdvancedGridBuilder {
+	builder := widget.NewScrollViewBuilder()
+	if buildFunc != nil {
+		buildFunc(builder)
+	}
+	add(b, row, col, rowSpan, colSpan, builder)
+	return b
 }
 
 // ButtonAt は、指定された位置とスパンでButtonウィジェットをグリッドに追加します。
@@ -347,4 +520,4 @@ func (b *AdvancedGridBuilder) LabelAt(row, col, rowSpan, colSpan int, buildFunc
 }
 
 // Build はコンテナの構築を完了します。
-func (b *AdvancedGridBuilder) Build() (*container.Container, error) { return b.Builder.Build() }
+func (b *AdvancedGridBuilder) Build() (*container.Container, error) { return b.BaseContainerBuilder.Build() }
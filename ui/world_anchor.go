@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"furoshiki/component"
+	"furoshiki/container"
+	"math"
+)
+
+// このファイルは、ウィジェットをゲームのワールド座標に追従させる仕組みを提供します。
+// キャラクターの頭上の名札や、敵の上の体力バーなど、ゲーム内の物体に重ねて表示するUIに使用します。
+//
+//	anchor, _ := ui.NewWorldAnchor(nameTag, camera.WorldToScreen)
+//	anchor.SetTarget(func() (float64, float64) { return player.X, player.Y })
+//	anchor.SetOffset(0, -4) // 頭上から少し離します
+//	overlay.AddChild(anchor)
+//
+// WorldAnchorは、通常のレイアウトに従わず、毎フレームのUpdateで内容の位置を決めます。
+// 描画とイベント(ヒットテスト)は、通常のウィジェットと同じ仕組みで行われます。
+// 親のレイアウトの領域を占有しないよう、画面全体を覆うZStackなどのレイヤーに追加してください。
+
+// WorldProjection は、ワールド座標を画面座標に変換する関数です。
+// 変換した位置が画面に表示されない場合(カメラの背後など)は、okにfalseを返します。その間、内容は非表示になります。
+type WorldProjection func(worldX, worldY float64) (screenX, screenY float64, ok bool)
+
+// WorldAnchor は、内容のウィジェットを、ワールド座標を画面座標に変換した位置に配置するコンテナです。
+type WorldAnchor struct {
+	*container.Container
+	content component.Widget
+	project WorldProjection
+	// target は、追従するワールド座標を返します。nilの場合は、worldX, worldYを使用します。
+	target         func() (x, y float64)
+	worldX, worldY float64
+	// pivotX, pivotY は、内容の中で画面座標に合わせる点を、内容の大きさに対する割合で表したものです。
+	pivotX, pivotY float64
+	// offsetX, offsetY は、画面座標からさらにずらす量(ピクセル)です。
+	offsetX, offsetY int
+	// projected は、直前のフレームで位置が画面に表示されるものだったかどうかを表します。
+	projected bool
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ component.Container = (*WorldAnchor)(nil)
+
+// NewWorldAnchor は、contentをprojectで変換した位置に配置する新しいWorldAnchorを生成します。
+// 既定では、内容の下端中央がワールド座標の位置に合わせられます(頭上の名札などに適しています)。
+func NewWorldAnchor(content component.Widget, project WorldProjection) (*WorldAnchor, error) {
+	if content == nil {
+		return nil, component.ErrNilChild
+	}
+	c, err := container.NewContainer()
+	if err != nil {
+		return nil, err
+	}
+	a := &WorldAnchor{Container: c, content: content, project: project, pivotX: 0.5, pivotY: 1, projected: true}
+	// 内容の位置はUpdateで決めるため、レイアウトは使用しません。
+	c.SetLayout(nil)
+	// 内容の大きさの変化が、親のレイアウトのやり直しに波及しないようにします。
+	c.SetLayoutBoundary(true)
+	c.AddChild(content)
+	return a, nil
+}
+
+// Content は、内容のウィジェットを返します。
+func (a *WorldAnchor) Content() component.Widget {
+	return a.content
+}
+
+// SetProjection は、ワールド座標を画面座標に変換する関数を設定します。
+func (a *WorldAnchor) SetProjection(project WorldProjection) {
+	a.project = project
+}
+
+// SetWorldPosition は、追従するワールド座標を固定の位置に設定します。SetTargetで設定した関数は解除されます。
+func (a *WorldAnchor) SetWorldPosition(x, y float64) {
+	a.target = nil
+	a.worldX, a.worldY = x, y
+}
+
+// SetTarget は、追従するワールド座標を毎フレーム返す関数を設定します。移動するキャラクターなどに使用します。
+func (a *WorldAnchor) SetTarget(target func() (x, y float64)) {
+	a.target = target
+}
+
+// SetPivot は、内容の中でワールド座標の位置に合わせる点を、内容の幅と高さに対する割合(0から1)で設定します。
+// (0.5, 1)は下端中央、(0.5, 0.5)は中心、(0, 0)は左上です。
+func (a *WorldAnchor) SetPivot(x, y float64) {
+	a.pivotX, a.pivotY = x, y
+}
+
+// SetOffset は、ワールド座標を変換した画面座標から、さらに内容をずらす量をピクセル単位で設定します。
+func (a *WorldAnchor) SetOffset(dx, dy int) {
+	a.offsetX, a.offsetY = dx, dy
+}
+
+// Update は、内容をワールド座標に追従させてから、通常の更新を行います。
+func (a *WorldAnchor) Update() {
+	if !a.IsVisible() {
+		return
+	}
+	a.place()
+	a.Container.Update()
+}
+
+// place は、ワールド座標を画面座標に変換し、ピボットが合うように内容のサブツリーを移動します。
+func (a *WorldAnchor) place() {
+	if a.project == nil {
+		return
+	}
+	wx, wy := a.worldX, a.worldY
+	if a.target != nil {
+		wx, wy = a.target()
+	}
+	sx, sy, ok := a.project(wx, wy)
+	if ok != a.projected {
+		a.projected = ok
+		if is, isOK := a.content.(component.InteractiveState); isOK {
+			is.SetVisible(ok)
+		}
+	}
+	if !ok {
+		return
+	}
+
+	w, h := a.contentSize()
+	x := int(math.Round(sx-a.pivotX*float64(w))) + a.offsetX
+	y := int(math.Round(sy-a.pivotY*float64(h))) + a.offsetY
+	if ps, isOK := a.content.(component.PositionSetter); isOK {
+		oldX, oldY := ps.GetPosition()
+		component.TranslateTree(a.content, x-oldX, y-oldY)
+	}
+}
+
+// contentSize は、内容の大きさを返します。大きさが設定されていない場合は、最小の大きさを内容の大きさにします。
+func (a *WorldAnchor) contentSize() (int, int) {
+	ss, ok := a.content.(component.SizeSetter)
+	if !ok {
+		return 0, 0
+	}
+	w, h := ss.GetSize()
+	if w > 0 && h > 0 {
+		return w, h
+	}
+	if mss, ok := a.content.(component.MinSizeSetter); ok {
+		minW, minH := mss.GetMinSize()
+		if minW > w || minH > h {
+			w, h = max(w, minW), max(h, minH)
+			ss.SetSize(w, h)
+		}
+	}
+	return w, h
+}
+
+// HitTest は、内容だけをヒットテストします。WorldAnchor自身の領域はイベントを受け取りません。
+func (a *WorldAnchor) HitTest(x, y int) component.Widget {
+	if !a.IsVisible() {
+		return nil
+	}
+	return a.content.HitTest(x, y)
+}
+
+// WorldAnchor は、テンプレートから生成したウィジェットを、ワールド座標に追従するWorldAnchorに包んでコンテナに追加します。
+// targetは、追従するワールド座標を毎フレーム返す関数です。
+func (b *BaseContainerBuilder[T]) WorldAnchor(project WorldProjection, target func() (x, y float64), c Component) T {
+	if c == nil {
+		b.AddError(ErrNilComponent)
+		return b.Self
+	}
+	index := b.childCount()
+	w, err := c.Build()
+	if err != nil {
+		b.AddError(component.WithChildIndex(err, index))
+		return b.Self
+	}
+	anchor, err := NewWorldAnchor(w, project)
+	if err != nil {
+		b.AddError(component.WithChildIndex(err, index))
+		return b.Self
+	}
+	anchor.SetTarget(target)
+	b.AddChild(anchor)
+	return b.Self
+}
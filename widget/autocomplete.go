@@ -0,0 +1,353 @@
+package widget
+
+import (
+	"furoshiki/component"
+	"furoshiki/event"
+	"furoshiki/stats"
+	"furoshiki/style"
+	"image"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+const (
+	// defaultMaxSuggestions は、候補リストに表示する候補の既定の最大数です。
+	defaultMaxSuggestions = 8
+	// suggestionPadding は、候補リストの各行の上下左右の余白です。
+	suggestionPadding = 4
+)
+
+// SuggestionFunc は、入力中の文字列に対する候補を同期的に返す関数です。
+type SuggestionFunc func(query string) []string
+
+// AsyncSuggestionFunc は、入力中の文字列に対する候補を非同期に提供する関数です。
+// 候補が揃ったらdoneを呼び出します。doneは任意のゴルーチンから呼び出せます。
+// 結果が届く前に入力が変化した場合、古い問い合わせの結果は破棄されます。
+type AsyncSuggestionFunc func(query string, done func(suggestions []string))
+
+// Autocomplete は、TextInputに入力候補のドロップダウンを追加する部品です。
+// 入力のたびに候補を問い合わせ、入力欄の下にポップアップとして表示します。
+// フォーカス中は上下の矢印キーで候補を選び、Enterで確定、Escapeで閉じます。候補のクリックでも確定できます。
+//
+// 候補リストはcomponentパッケージのポップアップ層に表示されるため、
+// アプリケーションはcomponent.HitTestとcomponent.DrawPopupsを使用する必要があります。
+type Autocomplete struct {
+	input  *TextInput
+	popup  *suggestionPopup
+	source AsyncSuggestionFunc
+
+	debounce time.Duration
+	minChars int
+	maxItems int
+
+	// pendingTicks は、デバウンス中の問い合わせを実行するまでの残りのティック数です。0の場合は待機中の問い合わせはありません。
+	pendingTicks int
+	// generation は、問い合わせごとに増える番号です。古い問い合わせの結果を識別するために使用します。
+	generation int
+	// arrived は、非同期に届いた結果です。UIのゴルーチンで次のフレームに反映されます。
+	mu      sync.Mutex
+	arrived *suggestionResult
+	// accepting は、候補の確定による値の変更で、新たな問い合わせを行わないためのフラグです。
+	accepting bool
+
+	onSelect []func(value string)
+}
+
+type suggestionResult struct {
+	generation  int
+	suggestions []string
+}
+
+// AttachAutocomplete は、同期的に候補を返す関数を使用するAutocompleteを入力欄に追加します。
+// 候補は入力のたびに、その場で問い合わせられます。
+func AttachAutocomplete(input *TextInput, source SuggestionFunc) *Autocomplete {
+	return AttachAsyncAutocomplete(input, func(query string, done func([]string)) {
+		done(source(query))
+	}, 0)
+}
+
+// AttachAsyncAutocomplete は、非同期に候補を提供する関数を使用するAutocompleteを入力欄に追加します。
+// 問い合わせは、最後の入力からdebounceの間、入力が止まってから行われます。
+func AttachAsyncAutocomplete(input *TextInput, source AsyncSuggestionFunc, debounce time.Duration) *Autocomplete {
+	a := &Autocomplete{
+		input:    input,
+		source:   source,
+		debounce: max(0, debounce),
+		minChars: 1,
+		maxItems: defaultMaxSuggestions,
+	}
+	a.popup = newSuggestionPopup(a)
+
+	input.AddOnChange(func(string) { a.schedule() })
+	input.AddOnFocusChange(func(focused bool) {
+		if !focused {
+			a.Close()
+		}
+	})
+	input.addInputHook(a.update)
+	// 入力欄が実際に描画された画面上の位置に候補リストを配置します。
+	input.AddOnAfterDraw(func(info component.DrawInfo, bounds image.Rectangle) {
+		bounds.Max.Y -= input.errorSlotHeight()
+		a.popup.place(bounds)
+	})
+	return a
+}
+
+// SetMinChars は、問い合わせを行うのに必要な最小の文字数を設定します。既定は1です。
+func (a *Autocomplete) SetMinChars(n int) {
+	a.minChars = max(0, n)
+}
+
+// SetMaxItems は、候補リストに表示する候補の最大数を設定します。0以下を指定すると既定値(8)になります。
+func (a *Autocomplete) SetMaxItems(n int) {
+	if n <= 0 {
+		n = defaultMaxSuggestions
+	}
+	a.maxItems = n
+}
+
+// AddOnSelect は、候補が確定されたときに呼び出される関数を追加します。
+func (a *Autocomplete) AddOnSelect(fn func(value string)) {
+	if fn != nil {
+		a.onSelect = append(a.onSelect, fn)
+	}
+}
+
+// IsOpen は、候補リストが表示されているかどうかを返します。
+func (a *Autocomplete) IsOpen() bool {
+	return component.IsPopupShown(a.popup)
+}
+
+// Close は、候補リストを閉じ、待機中の問い合わせを取り消します。
+func (a *Autocomplete) Close() {
+	a.pendingTicks = 0
+	a.generation++
+	component.HidePopup(a.popup)
+}
+
+// schedule は、値の変化に応じて問い合わせを予約します。デバウンスが0の場合はその場で問い合わせます。
+func (a *Autocomplete) schedule() {
+	if a.accepting {
+		return
+	}
+	if len(a.input.runes) < a.minChars {
+		a.Close()
+		return
+	}
+	if a.debounce == 0 {
+		a.query()
+		return
+	}
+	a.pendingTicks = durationToTicks(a.debounce)
+}
+
+// query は、現在の値で候補を問い合わせます。
+func (a *Autocomplete) query() {
+	a.pendingTicks = 0
+	a.generation++
+	gen := a.generation
+	a.source(a.input.Value(), func(suggestions []string) {
+		a.mu.Lock()
+		a.arrived = &suggestionResult{generation: gen, suggestions: suggestions}
+		a.mu.Unlock()
+		// 結果は別のゴルーチンから届くことがあるため、次のフレームの更新を要求して反映させます。
+		component.RequestFrame()
+	})
+	// 同期的に結果が届いた場合は、次のフレームを待たずに反映します。
+	a.applyArrived()
+}
+
+// applyArrived は、届いている結果を候補リストに反映します。古い問い合わせの結果は破棄します。
+func (a *Autocomplete) applyArrived() {
+	a.mu.Lock()
+	result := a.arrived
+	a.arrived = nil
+	a.mu.Unlock()
+	if result == nil || result.generation != a.generation {
+		return
+	}
+	items := result.suggestions
+	if len(items) > a.maxItems {
+		items = items[:a.maxItems]
+	}
+	a.popup.setItems(items)
+	if len(items) == 0 {
+		component.HidePopup(a.popup)
+		return
+	}
+	component.ShowPopup(a.popup)
+}
+
+// update は、入力欄がフォーカスを持っている間、毎フレーム呼び出されます。
+// デバウンスの経過と非同期の結果の反映、および候補リストのキー操作を行います。
+func (a *Autocomplete) update() bool {
+	if a.pendingTicks > 0 {
+		a.pendingTicks--
+		if a.pendingTicks == 0 {
+			a.query()
+		} else {
+			// 省電力モードでも、待機中は更新を続けてデバウンスを進めます。
+			component.RequestFrame()
+		}
+	}
+	a.applyArrived()
+	if !a.IsOpen() {
+		return false
+	}
+	p := a.popup
+	switch {
+	case keyRepeated(ebiten.KeyArrowDown):
+		p.setSelected((p.selected + 1) % len(p.items))
+	case keyRepeated(ebiten.KeyArrowUp):
+		if p.selected <= 0 {
+			p.setSelected(len(p.items) - 1)
+		} else {
+			p.setSelected(p.selected - 1)
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		a.Close()
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter):
+		if p.selected >= 0 {
+			a.accept(p.selected)
+			return true
+		}
+		a.Close()
+	}
+	return false
+}
+
+// accept は、指定された候補で入力欄の値を置き換え、候補リストを閉じます。
+func (a *Autocomplete) accept(index int) {
+	if index < 0 || index >= len(a.popup.items) {
+		return
+	}
+	value := a.popup.items[index]
+	a.Close()
+	a.accepting = true
+	a.input.replaceValue(value)
+	a.accepting = false
+	for _, fn := range a.onSelect {
+		fn(value)
+	}
+}
+
+// --- suggestionPopup ---
+
+// suggestionPopup は、候補を縦に並べて表示するポップアップです。
+type suggestionPopup struct {
+	*component.LayoutableWidget
+	owner    *Autocomplete
+	items    []string
+	selected int
+}
+
+func newSuggestionPopup(owner *Autocomplete) *suggestionPopup {
+	p := &suggestionPopup{owner: owner, selected: -1}
+	p.LayoutableWidget = component.NewLayoutableWidget()
+	// NOTE: 新しいウィジェットに非nilのselfを渡すため、Initは失敗しません。
+	_ = p.Init(p)
+	p.AddEventHandler(event.MouseMove, func(e *event.Event) event.Propagation {
+		if i := p.indexAt(e.Y); i >= 0 {
+			p.setSelected(i)
+		}
+		return event.StopPropagation
+	})
+	p.AddEventHandler(event.MouseDown, func(e *event.Event) event.Propagation {
+		owner.accept(p.indexAt(e.Y))
+		return event.StopPropagation
+	})
+	return p
+}
+
+func (p *suggestionPopup) setItems(items []string) {
+	p.items = append(p.items[:0], items...)
+	p.selected = -1
+	p.resize()
+}
+
+func (p *suggestionPopup) setSelected(i int) {
+	p.selected = i
+}
+
+// itemHeight は、候補1行分の高さを返します。
+func (p *suggestionPopup) itemHeight() int {
+	c := p.owner.input.ComputedStyle()
+	if c.Font == nil {
+		return 0
+	}
+	m := component.FaceMetrics(c.Font)
+	return (m.Ascent + m.Descent).Ceil() + suggestionPadding*2
+}
+
+// place は、入力欄の枠の画面上の位置に合わせて、その直下に候補リストを配置します。
+func (p *suggestionPopup) place(box image.Rectangle) {
+	p.SetPosition(box.Min.X, box.Max.Y)
+	p.SetSize(box.Dx(), len(p.items)*p.itemHeight())
+}
+
+// resize は、候補の数に合わせて高さを変更します。
+func (p *suggestionPopup) resize() {
+	w, _ := p.GetSize()
+	p.SetSize(w, len(p.items)*p.itemHeight())
+}
+
+// indexAt は、画面上のy座標にある候補のインデックスを返します。ない場合は-1です。
+func (p *suggestionPopup) indexAt(y int) int {
+	_, top := p.GetPosition()
+	h := p.itemHeight()
+	if h <= 0 || y < top {
+		return -1
+	}
+	if i := (y - top) / h; i < len(p.items) {
+		return i
+	}
+	return -1
+}
+
+// Draw は、入力欄と同じ背景と枠で候補リストを描画し、選択中の候補を強調します。
+func (p *suggestionPopup) Draw(info component.DrawInfo) {
+	input := p.owner.input
+	c := input.ComputedStyle()
+	if c.Font == nil || len(p.items) == 0 {
+		return
+	}
+	x, y := p.GetPosition()
+	w, h := p.GetSize()
+	component.DrawComputedBackground(info.Screen, x, y, w, h, c)
+	itemH := p.itemHeight()
+	if p.selected >= 0 {
+		component.DrawFilledRect(info.Screen, float32(x), float32(y+p.selected*itemH), float32(w), float32(itemH), input.selectionColor)
+	}
+	component.FlushDraws()
+	ascent := component.FaceMetrics(c.Font).Ascent.Ceil()
+	for i, item := range p.items {
+		label := component.TruncateText(c.Font, item, w-suggestionPadding*2, style.TextTruncateEllipsis)
+		text.Draw(info.Screen, component.VisualLine(label, component.IsRTL(label)), c.Font, x+suggestionPadding, y+i*itemH+suggestionPadding+ascent, c.TextColor)
+	}
+	stats.AddDrawCalls(len(p.items))
+}
+
+// --- TextInputBuilder ---
+
+// Suggestions は、同期的に候補を返す関数で入力候補のドロップダウンを追加します。
+// 最小文字数などを設定する場合は、AttachAutocompleteを直接使用してください。
+// 例: t.Suggestions(func(q string) []string { return filterNames(q) })
+func (b *TextInputBuilder) Suggestions(source SuggestionFunc) *TextInputBuilder {
+	if source != nil {
+		AttachAutocomplete(b.Widget, source)
+	}
+	return b
+}
+
+// AsyncSuggestions は、非同期に候補を提供する関数で入力候補のドロップダウンを追加します。
+// 問い合わせは、入力がdebounceの間止まってから行われます。
+func (b *TextInputBuilder) AsyncSuggestions(source AsyncSuggestionFunc, debounce time.Duration) *TextInputBuilder {
+	if source != nil {
+		AttachAsyncAutocomplete(b.Widget, source, debounce)
+	}
+	return b
+}
@@ -0,0 +1,409 @@
+package widget
+
+import (
+	"fmt"
+	"furoshiki/component"
+	"furoshiki/style"
+	"furoshiki/theme"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// AvatarShape は、Avatarの画像を切り抜く形です。
+type AvatarShape int
+
+const (
+	// AvatarCircle は、ウィジェットの中央に内接する円です(既定)。
+	AvatarCircle AvatarShape = iota
+	// AvatarRoundedRect は、スタイルのBorderRadiusを半径とする角丸矩形です。
+	AvatarRoundedRect
+)
+
+// AvatarStatus は、Avatarの右下に点で表示する在席状態です。
+type AvatarStatus int
+
+const (
+	// AvatarStatusNone は、状態の点を表示しません(既定)。
+	AvatarStatusNone AvatarStatus = iota
+	// AvatarOnline は、オンライン(緑)です。
+	AvatarOnline
+	// AvatarAway は、離席中(黄)です。
+	AvatarAway
+	// AvatarBusy は、取り込み中(赤)です。
+	AvatarBusy
+	// AvatarOffline は、オフライン(灰)です。
+	AvatarOffline
+)
+
+// String は、支援技術に伝える状態の名前を返します。
+func (s AvatarStatus) String() string {
+	switch s {
+	case AvatarOnline:
+		return "online"
+	case AvatarAway:
+		return "away"
+	case AvatarBusy:
+		return "busy"
+	case AvatarOffline:
+		return "offline"
+	default:
+		return ""
+	}
+}
+
+// color は、状態の点の色を返します。
+func (s AvatarStatus) color() color.Color {
+	switch s {
+	case AvatarOnline:
+		return color.RGBA{46, 160, 67, 255}
+	case AvatarAway:
+		return color.RGBA{230, 160, 0, 255}
+	case AvatarBusy:
+		return color.RGBA{200, 40, 40, 255}
+	case AvatarOffline:
+		return color.RGBA{150, 150, 150, 255}
+	default:
+		return nil
+	}
+}
+
+const (
+	// defaultAvatarSize は、Avatarの既定の幅と高さです。
+	defaultAvatarSize = 40
+	// minAvatarStatusSize は、状態の点の最小の直径です。点の直径は、通常Avatarの短辺の1/4です。
+	minAvatarStatusSize = 8
+	// avatarStatusRing は、状態の点を画像から切り離して見せるための、点の周りの縁の幅です。
+	avatarStatusRing = 2
+)
+
+// avatarPalette は、画像がなくスタイルの背景も設定されていない場合に、名前から選ぶ背景色です。
+// いずれも白い文字とのコントラスト比が4.5:1以上になる色です。
+var avatarPalette = []color.Color{
+	color.RGBA{52, 101, 164, 255},
+	color.RGBA{46, 125, 50, 255},
+	color.RGBA{173, 20, 87, 255},
+	color.RGBA{106, 27, 154, 255},
+	color.RGBA{0, 121, 107, 255},
+	color.RGBA{191, 54, 12, 255},
+	color.RGBA{69, 90, 100, 255},
+	color.RGBA{40, 53, 147, 255},
+}
+
+// avatarFallbackColor は、名前が空の場合の背景色です。
+var avatarFallbackColor = color.RGBA{110, 110, 110, 255}
+
+// Avatar は、利用者やキャラクターの画像を円または角丸矩形に切り抜いて表示するウィジェットです。
+// 画像が設定されていない場合は、名前から作った頭文字(イニシャル)を、名前ごとに決まる色の背景の上に表示します。
+// 右下には、オンラインなどの在席状態を表す点を重ねて表示できます。
+//
+// 背景と文字のスタイルは、頭文字を表示するときに使用されます。背景が設定されていない場合は、名前から選んだ色を使用します。
+// 境界線は、画像を表示する場合も切り抜いた形に沿って描画されます。
+type Avatar struct {
+	*component.LayoutableWidget
+	image    *ebiten.Image
+	name     string
+	initials string
+	// customInitials は、SetInitialsで頭文字が明示的に設定されているかどうかです。
+	customInitials bool
+	shape          AvatarShape
+	status         AvatarStatus
+}
+
+// newAvatar は、Avatarウィジェットの新しいインスタンスを生成し、初期化します。
+func newAvatar() (*Avatar, error) {
+	a := &Avatar{}
+	a.LayoutableWidget = component.NewLayoutableWidget()
+	if err := a.Init(a); err != nil {
+		return nil, err
+	}
+	t := theme.GetCurrent()
+	a.SetStyle(style.Style{
+		Font:          style.PFont(t.DefaultFont),
+		TextColor:     style.PColor(color.White),
+		TextAlign:     style.PTextAlignType(style.TextAlignCenter),
+		VerticalAlign: style.PVerticalAlignType(style.VerticalAlignMiddle),
+		BorderRadius:  style.PFloat32(6),
+	})
+	a.SetSize(defaultAvatarSize, defaultAvatarSize)
+	return a, nil
+}
+
+// SetImage は、表示する画像を設定します。nilを渡すと、頭文字を表示します。
+// 画像は切り抜く形を覆うように縦横比を保って拡大縮小され、はみ出した部分は切り取られます。
+func (a *Avatar) SetImage(img *ebiten.Image) {
+	if a.image != img {
+		a.image = img
+		a.MarkDirty(false)
+	}
+}
+
+// Image は、表示する画像を返します。
+func (a *Avatar) Image() *ebiten.Image {
+	return a.image
+}
+
+// SetName は、名前を設定します。名前は支援技術に伝えるラベルと、画像がない場合の頭文字と背景色に使用されます。
+// 頭文字は、最初と最後の単語の先頭の文字です(例: "Ada Lovelace"なら"AL")。
+func (a *Avatar) SetName(name string) {
+	if a.name == name {
+		return
+	}
+	a.name = name
+	if !a.customInitials {
+		a.initials = avatarInitials(name)
+	}
+	a.MarkDirty(false)
+	a.NotifyLabelChanged()
+}
+
+// Name は、名前を返します。
+func (a *Avatar) Name() string {
+	return a.name
+}
+
+// SetInitials は、名前から作る代わりに表示する頭文字を設定します。空文字列を渡すと、名前から作る頭文字に戻ります。
+func (a *Avatar) SetInitials(initials string) {
+	a.customInitials = initials != ""
+	if !a.customInitials {
+		initials = avatarInitials(a.name)
+	}
+	if a.initials != initials {
+		a.initials = initials
+		a.MarkDirty(false)
+	}
+}
+
+// Initials は、画像がない場合に表示する頭文字を返します。
+func (a *Avatar) Initials() string {
+	return a.initials
+}
+
+// SetShape は、画像を切り抜く形を設定します。
+func (a *Avatar) SetShape(shape AvatarShape) {
+	if a.shape != shape {
+		a.shape = shape
+		a.MarkDirty(false)
+	}
+}
+
+// Shape は、画像を切り抜く形を返します。
+func (a *Avatar) Shape() AvatarShape {
+	return a.shape
+}
+
+// SetStatus は、右下に表示する在席状態を設定します。AvatarStatusNoneの場合、点は表示されません。
+func (a *Avatar) SetStatus(status AvatarStatus) {
+	if a.status != status {
+		a.status = status
+		a.MarkDirty(false)
+		a.NotifyLabelChanged()
+	}
+}
+
+// Status は、在席状態を返します。
+func (a *Avatar) Status() AvatarStatus {
+	return a.status
+}
+
+// DefaultAccessibleRole は、component.DefaultRoleProviderインターフェースの実装です。
+func (a *Avatar) DefaultAccessibleRole() component.Role {
+	return component.RoleImage
+}
+
+// DefaultAccessibleLabel は、component.DefaultLabelProviderインターフェースの実装です。
+// 名前と在席状態を返します(例: "Ada Lovelace, online")。
+func (a *Avatar) DefaultAccessibleLabel() string {
+	if s := a.status.String(); s != "" && a.name != "" {
+		return a.name + ", " + s
+	}
+	return a.name
+}
+
+// Draw は、画像(または頭文字)、境界線、在席状態の点の順に描画します。
+func (a *Avatar) Draw(info component.DrawInfo) {
+	if !a.IsVisible() || !a.HasBeenLaidOut() {
+		return
+	}
+	x, y := a.GetPosition()
+	w, h := a.GetSize()
+	x += info.OffsetX
+	y += info.OffsetY
+	rect := image.Rect(x, y, x+w, y+h)
+	if a.shape == AvatarCircle {
+		rect = avatarCircleRect(rect)
+	}
+	if rect.Empty() {
+		return
+	}
+	c := a.ComputedStyle()
+	screen := info.Screen
+
+	if a.image != nil {
+		if a.shape == AvatarCircle {
+			component.DrawImageCircle(screen, a.image, rect)
+		} else {
+			component.DrawImageRounded(screen, a.image, rect, c.BorderRadius)
+		}
+	} else {
+		a.drawInitials(screen, rect, c)
+	}
+	a.drawBorder(screen, rect, c)
+	a.drawStatus(screen, rect)
+}
+
+// drawInitials は、背景の上に頭文字を描画します。
+func (a *Avatar) drawInitials(screen *ebiten.Image, rect image.Rectangle, c style.Computed) {
+	bg := c.Background
+	if bg == nil {
+		bg = avatarColor(a.name)
+	}
+	if a.shape == AvatarCircle {
+		r := float32(rect.Dx()) / 2
+		component.DrawFilledCircle(screen, float32(rect.Min.X)+r, float32(rect.Min.Y)+r, r, bg)
+	} else {
+		component.DrawComputedBackground(screen, rect.Min.X, rect.Min.Y, rect.Dx(), rect.Dy(), style.Computed{Background: bg, BorderRadius: c.BorderRadius})
+	}
+	if a.initials == "" {
+		return
+	}
+	// 頭文字は形の中央に置くため、スタイルの余白は使用しません。
+	c.Padding = style.Insets{}
+	component.DrawComputedText(screen, a.initials, rect, c, false)
+}
+
+// drawBorder は、切り抜いた形に沿って境界線を描画します。
+func (a *Avatar) drawBorder(screen *ebiten.Image, rect image.Rectangle, c style.Computed) {
+	if c.BorderColor == nil || c.BorderWidth <= 0 {
+		return
+	}
+	if a.shape == AvatarCircle {
+		r := float32(rect.Dx()) / 2
+		component.FlushDraws()
+		vector.StrokeCircle(screen, float32(rect.Min.X)+r, float32(rect.Min.Y)+r, r-c.BorderWidth/2, c.BorderWidth, c.BorderColor, true)
+		return
+	}
+	component.DrawComputedBackground(screen, rect.Min.X, rect.Min.Y, rect.Dx(), rect.Dy(),
+		style.Computed{BorderColor: c.BorderColor, BorderWidth: c.BorderWidth, BorderRadius: c.BorderRadius})
+}
+
+// drawStatus は、在席状態の点を、テーマの背景色の縁で囲んで右下に描画します。
+// 円の場合は円周上の右下(45度の位置)に、角丸矩形の場合は右下の角に置きます。
+func (a *Avatar) drawStatus(screen *ebiten.Image, rect image.Rectangle) {
+	clr := a.status.color()
+	if clr == nil {
+		return
+	}
+	r := float32(max(minAvatarStatusSize, min(rect.Dx(), rect.Dy())/4)) / 2
+	var cx, cy float32
+	if a.shape == AvatarCircle {
+		half := float32(rect.Dx()) / 2
+		offset := half * math.Sqrt2 / 2
+		cx = float32(rect.Min.X) + half + offset
+		cy = float32(rect.Min.Y) + half + offset
+	} else {
+		cx = float32(rect.Max.X) - r
+		cy = float32(rect.Max.Y) - r
+	}
+	if ring := theme.GetCurrent().BackgroundColor; ring != nil {
+		component.DrawFilledCircle(screen, cx, cy, r+avatarStatusRing, ring)
+	}
+	component.DrawFilledCircle(screen, cx, cy, r, clr)
+}
+
+// avatarCircleRect は、rectの中央に置いた、rectに収まる最大の正方形を返します。
+func avatarCircleRect(rect image.Rectangle) image.Rectangle {
+	size := min(rect.Dx(), rect.Dy())
+	x := rect.Min.X + (rect.Dx()-size)/2
+	y := rect.Min.Y + (rect.Dy()-size)/2
+	return image.Rect(x, y, x+size, y+size)
+}
+
+// avatarInitials は、名前の最初と最後の単語の先頭の文字を大文字にして返します。単語が1つの場合は、その先頭の文字だけです。
+func avatarInitials(name string) string {
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		return ""
+	}
+	first, _ := utf8.DecodeRuneInString(words[0])
+	initials := string(unicode.ToUpper(first))
+	if len(words) > 1 {
+		last, _ := utf8.DecodeRuneInString(words[len(words)-1])
+		initials += string(unicode.ToUpper(last))
+	}
+	return initials
+}
+
+// avatarColor は、名前から決まる背景色を返します。同じ名前には常に同じ色が選ばれます。
+func avatarColor(name string) color.Color {
+	if name == "" {
+		return avatarFallbackColor
+	}
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return avatarPalette[h.Sum32()%uint32(len(avatarPalette))]
+}
+
+// --- AvatarBuilder ---
+type AvatarBuilder struct {
+	component.Builder[*AvatarBuilder, *Avatar]
+}
+
+// NewAvatarBuilder は新しいAvatarBuilderを生成します。
+func NewAvatarBuilder() *AvatarBuilder {
+	a, err := newAvatar()
+	b := &AvatarBuilder{}
+	b.Init(b, a)
+	b.AddError(err)
+	return b
+}
+
+// Image は、表示する画像を設定します。
+func (b *AvatarBuilder) Image(img *ebiten.Image) *AvatarBuilder {
+	b.Widget.SetImage(img)
+	return b
+}
+
+// Name は、名前を設定します。画像がない場合は、名前の頭文字が表示されます。
+func (b *AvatarBuilder) Name(name string) *AvatarBuilder {
+	b.Widget.SetName(name)
+	return b
+}
+
+// Initials は、名前から作る代わりに表示する頭文字を設定します。
+func (b *AvatarBuilder) Initials(initials string) *AvatarBuilder {
+	b.Widget.SetInitials(initials)
+	return b
+}
+
+// Shape は、画像を切り抜く形を設定します。
+func (b *AvatarBuilder) Shape(shape AvatarShape) *AvatarBuilder {
+	if shape != AvatarCircle && shape != AvatarRoundedRect {
+		b.AddError(fmt.Errorf("invalid avatar shape: %d", shape))
+		return b
+	}
+	b.Widget.SetShape(shape)
+	return b
+}
+
+// Status は、右下に表示する在席状態を設定します。
+func (b *AvatarBuilder) Status(status AvatarStatus) *AvatarBuilder {
+	if status < AvatarStatusNone || status > AvatarOffline {
+		b.AddError(fmt.Errorf("invalid avatar status: %d", status))
+		return b
+	}
+	b.Widget.SetStatus(status)
+	return b
+}
+
+// Build は最終的なAvatarウィジェットを返します。
+func (b *AvatarBuilder) Build() (*Avatar, error) {
+	return b.Builder.Build()
+}
@@ -1,7 +1,9 @@
 package widget
 
 import (
+	"errors"
 	"furoshiki/component"
+	"furoshiki/i18n"
 	"furoshiki/style"
 	"image/color"
 )
@@ -12,6 +14,7 @@ import (
 type textWidget interface {
 	component.Buildable
 	SetText(string)
+	SetTextKey(key string, params i18n.Params)
 	SetWrapText(bool) // 折り返し設定メソッドを追加
 }
 
@@ -31,6 +34,27 @@ func (b *Builder[T, W]) Text(text string) T {
 	return b.Self
 }
 
+// TextKey は、i18nのカタログのキーからテキストを設定します。テキストは現在のロケールで解決されます。
+// paramsには、メッセージの {name} に埋め込むパラメータを指定できます。
+// 例: l.TextKey("menu.start") / l.TextKey("score", i18n.Params{"points": 1200})
+func (b *Builder[T, W]) TextKey(key string, params ...i18n.Params) T {
+	if key == "" {
+		b.AddError(errors.New("text key cannot be empty"))
+		return b.Self
+	}
+	var merged i18n.Params
+	for _, p := range params {
+		if merged == nil {
+			merged = i18n.Params{}
+		}
+		for k, v := range p {
+			merged[k] = v
+		}
+	}
+	b.Widget.SetTextKey(key, merged)
+	return b.Self
+}
+
 // WrapText は、ウィジェットの幅を超えるテキストを自動的に折り返すかどうかを設定します。
 func (b *Builder[T, W]) WrapText(wrap bool) T {
 	b.Widget.SetWrapText(wrap)
@@ -50,4 +74,17 @@ func (b *Builder[T, W]) TextAlign(align style.TextAlignType) T {
 // VerticalAlign はテキストの垂直方向の揃え位置を設定します。
 func (b *Builder[T, W]) VerticalAlign(align style.VerticalAlignType) T {
 	return b.Style(style.Style{VerticalAlign: style.PVerticalAlignType(align)})
+}
+
+// Truncate は、折り返さないテキストが幅に収まらない場合の扱いを設定します。
+// 切り詰めを有効にすると、テキスト全体の幅が最小幅として扱われなくなり、レイアウトによって縮められるようになります。
+// 例: l.Text(path).Truncate(style.TextTruncateMiddleEllipsis)
+func (b *Builder[T, W]) Truncate(mode style.TextTruncateType) T {
+	return b.Style(style.Style{TextTruncate: style.PTextTruncateType(mode)})
+}
+
+// LineBreak は、WrapTextで折り返す位置の決め方を設定します。
+// 既定のstyle.LineBreakAutoでは、日本語や中国語のテキストも文字の間で折り返されます。
+func (b *Builder[T, W]) LineBreak(mode style.LineBreakType) T {
+	return b.Style(style.Style{LineBreak: style.PLineBreakType(mode)})
 }
\ No newline at end of file
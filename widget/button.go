@@ -4,6 +4,8 @@ import (
 	"furoshiki/component"
 	"furoshiki/style"
 	"furoshiki/theme"
+
+	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // Button は、クリック可能なUI要素です。
@@ -12,6 +14,13 @@ import (
 type Button struct {
 	*component.TextWidget
 	// component.InteractiveMixin // 廃止
+
+	// icon は、テキストの横または上下に表示する任意の画像です。詳細は button_icon.go を参照してください。
+	icon          *ebiten.Image
+	iconPlacement IconPlacement
+	iconSpacing   int
+	// repeat は、押し続けたときのオートリピートの設定と状態です。詳細は button_repeat.go を参照してください。
+	repeat autoRepeat
 }
 
 // newButtonは、ボタンウィジェットの新しいインスタンスを生成し、初期化します。
@@ -19,25 +28,41 @@ type Button struct {
 //       常にNewButtonBuilder()を使用してください。これにより、初期化漏れを防ぎます。
 func newButton(text string) (*Button, error) {
 	button := &Button{}
-	button.TextWidget = component.NewTextWidget(text)
-	if err := button.Init(button); err != nil {
+	if err := button.initButton(button, text); err != nil {
 		return nil, err
 	}
+	return button, nil
+}
+
+// initButton は、ボタンの共通の初期化処理を行います。
+// ToggleButtonのようにButtonを埋め込むウィジェットは、自身をselfとして渡します。
+func (b *Button) initButton(self component.Widget, text string) error {
+	b.TextWidget = component.NewTextWidget(text)
+	if err := b.Init(self); err != nil {
+		return err
+	}
 
 	// NOTE: テーマから各種状態のスタイルを取得し、StyleManagerに設定します。
 	t := theme.GetCurrent()
 	// 1. Normal状態のスタイルを、ウィジェットの「基本スタイル」として設定します。
-	button.SetStyle(t.Button.Normal)
+	b.SetStyle(t.Button.Normal)
 	// 2. 他の状態のスタイルを、状態固有のスタイルとして設定します。
 	//    これらは描画時に基本スタイルとマージされます。
 	// NOTE: LayoutableWidgetに新設されたラッパーメソッドを経由して設定します。
-	button.SetStyleForState(component.StateHovered, t.Button.Hovered)
-	button.SetStyleForState(component.StatePressed, t.Button.Pressed)
-	button.SetStyleForState(component.StateDisabled, t.Button.Disabled)
+	b.SetStyleForState(component.StateHovered, t.Button.Hovered)
+	b.SetStyleForState(component.StatePressed, t.Button.Pressed)
+	b.SetStyleForState(component.StateDisabled, t.Button.Disabled)
+	b.SetStyleForState(component.StateFocused, t.Button.Focused)
 
-	button.SetSize(100, 40)
+	b.SetSize(100, 40)
+	b.iconSpacing = defaultIconSpacing
 
-	return button, nil
+	return nil
+}
+
+// DefaultAccessibleRole は、component.DefaultRoleProviderインターフェースの実装です。
+func (b *Button) DefaultAccessibleRole() component.Role {
+	return component.RoleButton
 }
 
 // SetStyle はウィジェットの基本スタイル(Normal状態の基礎)を設定します。
@@ -51,14 +76,22 @@ func (b *Button) SetStyle(s style.Style) {
 // NOTE: StyleManagerを利用して、現在の状態に最適なスタイルを効率的に取得します。
 func (b *Button) Draw(info component.DrawInfo) {
 	// 現在の状態（Normal, Hoveredなど）を取得します。
-	currentState := b.LayoutableWidget.CurrentState()
+	b.drawInState(info, b.LayoutableWidget.CurrentState())
+}
+
+// drawInState は、指定された状態のスタイルでボタンを描画します。
+func (b *Button) drawInState(info component.DrawInfo, currentState component.WidgetState) {
 	// StyleManagerから、現在の状態に基づいて適用すべきスタイルを取得します。
 	// このメソッドは内部でキャッシュを利用するため、毎フレームの不要なスタイルコピーを回避できます。
 	// NOTE: カプセル化されたLayoutableWidgetのラッパーメソッドを経由します。
-	styleToUse := b.LayoutableWidget.GetStyleForState(currentState)
+	// UPDATE: 値型に解決済みのスタイルを取得し、描画時のヒープ割り当てをなくします。
+	styleToUse := b.LayoutableWidget.ComputedStyleForState(currentState)
 	// 取得したスタイルでウィジェットを描画します。
-	// UPDATE: DrawWithStyleにinfoを渡すように変更
-	b.TextWidget.DrawWithStyle(info, styleToUse)
+	if b.icon != nil {
+		b.drawWithIcon(info, styleToUse, currentState)
+		return
+	}
+	b.TextWidget.DrawWithComputedStyle(info, styleToUse)
 }
 
 // SetStyleForState は、指定された単一の状態のスタイルを、既存のスタイルにマージします。
@@ -76,6 +109,7 @@ func (b *Button) StyleAllStates(s style.Style) {
 	b.SetStyleForState(component.StateHovered, s)
 	b.SetStyleForState(component.StatePressed, s)
 	b.SetStyleForState(component.StateDisabled, s)
+	b.SetStyleForState(component.StateFocused, s)
 }
 
 // --- ButtonBuilder ---
@@ -83,9 +117,9 @@ func (b *Button) StyleAllStates(s style.Style) {
 // ButtonBuilder は、汎用の InteractiveTextBuilder を利用してButtonを構築します。
 // これにより、状態ごとのスタイル設定（HoverStyle, PressedStyleなど）のロジックを再利用します。
 type ButtonBuilder struct {
-	// InteractiveTextBuilderを埋め込むことで、状態管理機能を持つテキストベースのウィジェットの
-	// ビルダー機能を継承します。
-	InteractiveTextBuilder[*ButtonBuilder, *Button]
+	// IconButtonBuilder(InteractiveTextBuilderを埋め込む)を埋め込むことで、状態管理機能を持つ
+	// テキストベースのウィジェットのビルダー機能と、アイコンの設定を継承します。
+	IconButtonBuilder[*ButtonBuilder, *Button]
 }
 
 // NewButtonBuilder は新しいButtonBuilderを生成します。
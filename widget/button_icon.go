@@ -0,0 +1,207 @@
+package widget
+
+import (
+	"errors"
+	"furoshiki/component"
+	"furoshiki/stats"
+	"furoshiki/style"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// IconPlacement は、ボタンのテキストに対するアイコンの配置位置を定義します。
+type IconPlacement int
+
+const (
+	// IconLeft は、アイコンをテキストの左に配置します。
+	IconLeft IconPlacement = iota
+	// IconRight は、アイコンをテキストの右に配置します。
+	IconRight
+	// IconTop は、アイコンをテキストの上に配置します。ツールバーのボタンなどに使用します。
+	IconTop
+	// IconBottom は、アイコンをテキストの下に配置します。
+	IconBottom
+)
+
+// defaultIconSpacing は、アイコンとテキストの間隔の初期値です。
+const defaultIconSpacing = 4
+
+// ErrInvalidIconSpacing は、アイコンとテキストの間隔に負の値が指定された場合のエラーです。
+var ErrInvalidIconSpacing = errors.New("icon spacing must be non-negative")
+
+// Icon は、ボタンに設定されているアイコンを返します。
+func (b *Button) Icon() *ebiten.Image {
+	return b.icon
+}
+
+// SetIcon は、ボタンに表示するアイコンを設定します。nilを渡すとアイコンを取り除きます。
+func (b *Button) SetIcon(img *ebiten.Image) {
+	if b.icon != img {
+		b.icon = img
+		// アイコンは最小サイズに影響するため、再レイアウトを要求します。
+		b.MarkDirty(true)
+	}
+}
+
+// SetIconPlacement は、テキストに対するアイコンの配置位置を設定します。
+func (b *Button) SetIconPlacement(p IconPlacement) {
+	if b.iconPlacement != p {
+		b.iconPlacement = p
+		b.MarkDirty(true)
+	}
+}
+
+// IconPlacement は、テキストに対するアイコンの配置位置を返します。
+func (b *Button) IconPlacement() IconPlacement {
+	return b.iconPlacement
+}
+
+// SetIconSpacing は、アイコンとテキストの間隔をピクセル単位で設定します。
+func (b *Button) SetIconSpacing(spacing int) {
+	if b.iconSpacing != spacing {
+		b.iconSpacing = spacing
+		b.MarkDirty(true)
+	}
+}
+
+// GetMinSize は、テキストの最小サイズに加えて、アイコンとその間隔を含めた最小サイズを返します。
+func (b *Button) GetMinSize() (int, int) {
+	baseW, baseH := b.TextWidget.GetMinSize()
+	if b.icon == nil {
+		return baseW, baseH
+	}
+	c := b.ComputedStyle()
+	w, h := b.iconContentSize(c.Font)
+	w += c.Padding.Left + c.Padding.Right
+	h += c.Padding.Top + c.Padding.Bottom
+	return max(baseW, w), max(baseH, h)
+}
+
+// textSize は、1行のテキストの幅と高さを返します。テキストが空の場合は(0, 0)です。
+func (b *Button) textSize(f font.Face) (int, int) {
+	if b.Text() == "" || f == nil {
+		return 0, 0
+	}
+	m := component.FaceMetrics(f)
+	return component.MeasureString(f, b.Text()).Ceil(), (m.Ascent + m.Descent).Ceil()
+}
+
+// iconContentSize は、アイコンとテキストを並べたコンテンツ全体の大きさを返します。
+func (b *Button) iconContentSize(f font.Face) (int, int) {
+	iconW, iconH := b.icon.Bounds().Dx(), b.icon.Bounds().Dy()
+	textW, textH := b.textSize(f)
+	if textW == 0 {
+		return iconW, iconH
+	}
+	switch b.iconPlacement {
+	case IconTop, IconBottom:
+		return max(iconW, textW), iconH + b.iconSpacing + textH
+	default:
+		return iconW + b.iconSpacing + textW, max(iconH, textH)
+	}
+}
+
+// drawWithIcon は、背景を描画した後、アイコンとテキストを1つのまとまりとして
+// TextAlignとVerticalAlignに従って配置し、描画します。
+// アイコン付きのボタンではテキストは折り返されません。
+func (b *Button) drawWithIcon(info component.DrawInfo, c style.Computed, state component.WidgetState) {
+	if !b.IsVisible() || !b.HasBeenLaidOut() {
+		return
+	}
+	x, y := b.GetPosition()
+	width, height := b.GetSize()
+	finalX, finalY := x+info.OffsetX, y+info.OffsetY
+	component.DrawComputedBackground(info.Screen, finalX, finalY, width, height, c)
+
+	content := image.Rect(finalX+c.Padding.Left, finalY+c.Padding.Top, finalX+width-c.Padding.Right, finalY+height-c.Padding.Bottom)
+	groupW, groupH := b.iconContentSize(c.Font)
+	groupX, groupY := content.Min.X, content.Min.Y
+	rtl := component.IsRTL(b.Text())
+	switch component.ResolveTextAlign(c.TextAlign, rtl) {
+	case style.TextAlignCenter:
+		groupX += (content.Dx() - groupW) / 2
+	case style.TextAlignRight:
+		groupX = content.Max.X - groupW
+	}
+	switch c.VerticalAlign {
+	case style.VerticalAlignMiddle:
+		groupY += (content.Dy() - groupH) / 2
+	case style.VerticalAlignBottom:
+		groupY = content.Max.Y - groupH
+	}
+
+	// まとまりの中でのアイコンとテキストの位置を求めます。交差軸方向は中央に揃えます。
+	iconW, iconH := b.icon.Bounds().Dx(), b.icon.Bounds().Dy()
+	textW, textH := b.textSize(c.Font)
+	var iconPos, textPos image.Point
+	switch b.iconPlacement {
+	case IconRight:
+		textPos = image.Pt(groupX, groupY+(groupH-textH)/2)
+		iconPos = image.Pt(groupX+groupW-iconW, groupY+(groupH-iconH)/2)
+	case IconTop:
+		iconPos = image.Pt(groupX+(groupW-iconW)/2, groupY)
+		textPos = image.Pt(groupX+(groupW-textW)/2, groupY+groupH-textH)
+	case IconBottom:
+		textPos = image.Pt(groupX+(groupW-textW)/2, groupY)
+		iconPos = image.Pt(groupX+(groupW-iconW)/2, groupY+groupH-iconH)
+	default:
+		iconPos = image.Pt(groupX, groupY+(groupH-iconH)/2)
+		textPos = image.Pt(groupX+groupW-textW, groupY+(groupH-textH)/2)
+	}
+
+	// アイコンとテキストはバッチを経由せずに描画されるため、先に蓄積された背景を描画して順序を保ちます。
+	component.FlushDraws()
+	opts := &ebiten.DrawImageOptions{}
+	opts.GeoM.Translate(float64(iconPos.X), float64(iconPos.Y))
+	if state == component.StateDisabled {
+		// 無効状態のアイコンは半透明にして、テキストの色の変化と見た目を揃えます。
+		opts.ColorScale.ScaleAlpha(0.5)
+	}
+	info.Screen.DrawImage(b.icon, opts)
+	stats.AddDrawCalls(1)
+
+	if textW > 0 {
+		text.Draw(info.Screen, component.VisualLine(b.Text(), rtl), c.Font, textPos.X, textPos.Y+component.FaceMetrics(c.Font).Ascent.Ceil(), c.TextColor)
+		stats.AddDrawCalls(1)
+	}
+}
+
+// --- IconButtonBuilder ---
+
+// iconWidget は、アイコンを表示できるボタン系のウィジェットが満たすインターフェースです。
+type iconWidget interface {
+	interactiveTextWidget
+	SetIcon(img *ebiten.Image)
+	SetIconPlacement(p IconPlacement)
+	SetIconSpacing(spacing int)
+}
+
+// IconButtonBuilder は、InteractiveTextBuilderにアイコンの設定を加えた、ボタン系のビルダーの共通部分です。
+type IconButtonBuilder[T any, W iconWidget] struct {
+	InteractiveTextBuilder[T, W]
+}
+
+// Icon は、ボタンに表示するアイコンを設定します。
+func (b *IconButtonBuilder[T, W]) Icon(img *ebiten.Image) T {
+	b.Widget.SetIcon(img)
+	return b.Self
+}
+
+// IconPlacement は、テキストに対するアイコンの配置位置を設定します。
+func (b *IconButtonBuilder[T, W]) IconPlacement(p IconPlacement) T {
+	b.Widget.SetIconPlacement(p)
+	return b.Self
+}
+
+// IconSpacing は、アイコンとテキストの間隔を設定します。負の値はエラーになります。
+func (b *IconButtonBuilder[T, W]) IconSpacing(spacing int) T {
+	if spacing < 0 {
+		b.AddError(ErrInvalidIconSpacing)
+		return b.Self
+	}
+	b.Widget.SetIconSpacing(spacing)
+	return b.Self
+}
@@ -0,0 +1,106 @@
+package widget
+
+import (
+	"errors"
+	"furoshiki/clock"
+	"furoshiki/event"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ErrInvalidRepeat は、オートリピートの遅延または間隔に不正な値が指定された場合のエラーです。
+var ErrInvalidRepeat = errors.New("repeat delay must be non-negative and interval must be positive")
+
+// autoRepeat は、押し続けている間にクリックを繰り返し発生させるための状態です。
+// 時間はフレーム(ティック)単位で数えるため、Updateが毎フレーム呼び出されることを前提とします。
+type autoRepeat struct {
+	enabled  bool
+	delay    time.Duration
+	interval time.Duration
+	// heldTicks は、押下が始まってから経過したティック数です。
+	heldTicks int
+	// repeated は、現在の押下中に1回以上リピートが発生したかどうかです。
+	// trueの場合、ボタンを離したときの通常のクリックは発生させません。
+	repeated bool
+}
+
+// durationToTicks は、時間をティック数に変換します。最小値は1です。
+func durationToTicks(d time.Duration) int {
+	return max(1, int(d*time.Duration(ebiten.TPS())/time.Second))
+}
+
+// SetRepeat は、押し続けたときのオートリピートを設定します。
+// 押下からinitialDelayが経過すると最初のクリックが発生し、以降はintervalごとにクリックが発生します。
+// intervalに0以下を指定するとオートリピートを無効にします。
+// リピートが発生した押下では、ボタンを離したときの通常のクリックは発生しません。
+// 短く押しただけの場合は、通常のボタンと同様に離したときに1回だけクリックが発生します。
+func (b *Button) SetRepeat(initialDelay, interval time.Duration) {
+	b.repeat = autoRepeat{
+		enabled:  interval > 0,
+		delay:    max(0, initialDelay),
+		interval: interval,
+	}
+}
+
+// Update は、オートリピートが有効な場合に押下時間を数え、必要に応じてクリックを発生させます。
+func (b *Button) Update() {
+	b.TextWidget.Update()
+	b.updateRepeat()
+}
+
+// updateRepeat は、押下状態とフレームの経過に基づいてリピートのクリックを発生させます。
+// ボタンの外にカーソルが出ている間は、押下中でもリピートを一時停止します。
+func (b *Button) updateRepeat() {
+	r := &b.repeat
+	if !r.enabled {
+		return
+	}
+	if !b.IsPressed() || b.IsDisabled() {
+		r.heldTicks = 0
+		return
+	}
+	r.heldTicks++
+	if !b.IsHovered() {
+		return
+	}
+	delay := durationToTicks(r.delay)
+	if r.heldTicks < delay || (r.heldTicks-delay)%durationToTicks(r.interval) != 0 {
+		return
+	}
+	r.repeated = true
+	x, y := b.GetPosition()
+	e := event.Event{Type: event.EventClick, Target: b, X: x, Y: y, Timestamp: clock.Now().UnixNano(), MouseButton: ebiten.MouseButtonLeft}
+	// 自身のHandleEventはリピート後のクリックを取り除くため、基底の実装を直接呼び出します。
+	b.LayoutableWidget.HandleEvent(&e)
+}
+
+// HandleEvent は、リピートが発生した押下の後の通常のクリックを取り除いてから、イベントを処理します。
+func (b *Button) HandleEvent(e *event.Event) {
+	switch e.Type {
+	case event.MouseDown:
+		// ボタンの外で離された場合はクリックが届かないため、押下の開始時にもリセットします。
+		b.repeat.repeated = false
+	case event.EventClick:
+		if b.repeat.repeated {
+			// リピートが発生した押下の後に、ディスパッチャから届くクリックです。
+			b.repeat.repeated = false
+			return
+		}
+	}
+	b.LayoutableWidget.HandleEvent(e)
+}
+
+// --- ButtonBuilder ---
+
+// Repeat は、押し続けたときにクリックハンドラを繰り返し呼び出すよう設定します。
+// スクロールの矢印や+/-のステッパーなどに使用します。
+// 例: btn.Text("+").Repeat(400*time.Millisecond, 50*time.Millisecond).AddOnClick(...)
+func (b *ButtonBuilder) Repeat(initialDelay, interval time.Duration) *ButtonBuilder {
+	if initialDelay < 0 || interval <= 0 {
+		b.AddError(ErrInvalidRepeat)
+		return b
+	}
+	b.Widget.SetRepeat(initialDelay, interval)
+	return b
+}
@@ -0,0 +1,159 @@
+package widget
+
+import (
+	"errors"
+	"fmt"
+	"furoshiki/component"
+	"image"
+)
+
+// CanvasDrawFunc は、Canvasの内容を描画する関数です。
+// boundsは、親から渡された描画オフセットを適用済みの、描画先画像(info.Screen)上でのCanvasの境界です。
+// 境界の外に描画しないようにする場合は、info.Screen.SubImage(bounds)に描画してください。
+type CanvasDrawFunc func(info component.DrawInfo, bounds image.Rectangle)
+
+// Canvas は、描画を利用者の関数に任せるウィジェットです。
+// グラフ、波形表示、ミニゲームなどの独自の表示を、ウィジェットを一から実装せずにレイアウトの中に配置できます。
+// 背景と境界線はスタイルに従って描画され、その上に描画関数の内容が重ねられます。
+//
+// 表示する内容が変化した場合は、MarkDirty(false)を呼び出して再描画を要求してください(描画キャッシュを使用している場合に必要です)。
+type Canvas struct {
+	*component.LayoutableWidget
+	draw CanvasDrawFunc
+	// preferredWidth, preferredHeight は、レイアウトに要求する大きさです。最小サイズとして扱われます。
+	preferredWidth, preferredHeight int
+	// heightForWidth は、与えられた幅に対して必要な高さを返す関数です。nilの場合は幅に依存しません。
+	heightForWidth func(width int) int
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ component.HeightForWider = (*Canvas)(nil)
+
+// newCanvas は、Canvasウィジェットの新しいインスタンスを生成し、初期化します。
+func newCanvas() (*Canvas, error) {
+	c := &Canvas{}
+	c.LayoutableWidget = component.NewLayoutableWidget()
+	if err := c.Init(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SetDrawFunc は、内容を描画する関数を設定します。
+func (c *Canvas) SetDrawFunc(fn CanvasDrawFunc) {
+	c.draw = fn
+	c.MarkDirty(false)
+}
+
+// SetPreferredSize は、レイアウトに要求する大きさを設定します。
+// SetMinSizeで設定した最小サイズと、この大きさの大きい方が最小サイズになります。
+func (c *Canvas) SetPreferredSize(width, height int) {
+	if width < 0 || height < 0 {
+		return
+	}
+	if c.preferredWidth != width || c.preferredHeight != height {
+		c.preferredWidth, c.preferredHeight = width, height
+		c.MarkDirty(true)
+	}
+}
+
+// PreferredSize は、レイアウトに要求する大きさを返します。
+func (c *Canvas) PreferredSize() (width, height int) {
+	return c.preferredWidth, c.preferredHeight
+}
+
+// SetHeightForWidth は、与えられた幅に対して必要な高さを返す関数を設定します。
+// 縦横比を保つグラフなど、高さが幅に依存する内容に使用します。
+func (c *Canvas) SetHeightForWidth(fn func(width int) int) {
+	c.heightForWidth = fn
+	c.MarkDirty(true)
+}
+
+// GetMinSize は、ユーザーが設定した最小サイズと、要求する大きさの大きい方を返します。
+func (c *Canvas) GetMinSize() (int, int) {
+	userW, userH := c.LayoutableWidget.GetMinSize()
+	return max(userW, c.preferredWidth), max(userH, c.preferredHeight)
+}
+
+// GetHeightForWidth は、HeightForWiderインターフェースの実装です。
+// SetHeightForWidthで関数が設定されていない場合は、幅に依存しない本来の高さを返します。
+func (c *Canvas) GetHeightForWidth(width int) int {
+	_, minH := c.GetMinSize()
+	if c.heightForWidth != nil {
+		return max(c.heightForWidth(width), minH)
+	}
+	_, h := c.GetSize()
+	if h <= 0 {
+		return minH
+	}
+	return max(h, minH)
+}
+
+// DefaultAccessibleRole は、component.DefaultRoleProviderインターフェースの実装です。
+// 描画内容は支援技術から分からないため、AccessibleLabelで内容を説明してください。
+func (c *Canvas) DefaultAccessibleRole() component.Role {
+	return component.RoleImage
+}
+
+// Draw は、背景を描画した後、描画関数を呼び出します。
+func (c *Canvas) Draw(info component.DrawInfo) {
+	if !c.IsVisible() || !c.HasBeenLaidOut() {
+		return
+	}
+	c.LayoutableWidget.Draw(info)
+	if c.draw == nil {
+		return
+	}
+	x, y := c.GetPosition()
+	w, h := c.GetSize()
+	x += info.OffsetX
+	y += info.OffsetY
+	// 描画関数はebitenの描画APIを直接呼び出す可能性があるため、保留中の背景の描画を確定させます。
+	component.FlushDraws()
+	c.draw(info, image.Rect(x, y, x+w, y+h))
+}
+
+// --- CanvasBuilder ---
+type CanvasBuilder struct {
+	component.Builder[*CanvasBuilder, *Canvas]
+}
+
+// NewCanvasBuilder は新しいCanvasBuilderを生成します。
+func NewCanvasBuilder() *CanvasBuilder {
+	c, err := newCanvas()
+	b := &CanvasBuilder{}
+	b.Init(b, c)
+	b.AddError(err)
+	return b
+}
+
+// OnDraw は、内容を描画する関数を設定します。
+func (b *CanvasBuilder) OnDraw(fn CanvasDrawFunc) *CanvasBuilder {
+	if fn == nil {
+		b.AddError(errors.New("canvas draw func cannot be nil"))
+		return b
+	}
+	b.Widget.SetDrawFunc(fn)
+	return b
+}
+
+// PreferredSize は、レイアウトに要求する大きさを設定します。
+func (b *CanvasBuilder) PreferredSize(width, height int) *CanvasBuilder {
+	if width < 0 || height < 0 {
+		b.AddError(fmt.Errorf("%w, got %dx%d", component.ErrInvalidSize, width, height))
+		return b
+	}
+	b.Widget.SetPreferredSize(width, height)
+	return b
+}
+
+// HeightForWidth は、与えられた幅に対して必要な高さを返す関数を設定します。
+func (b *CanvasBuilder) HeightForWidth(fn func(width int) int) *CanvasBuilder {
+	b.Widget.SetHeightForWidth(fn)
+	return b
+}
+
+// Build は最終的なCanvasウィジェットを返します。
+func (b *CanvasBuilder) Build() (*Canvas, error) {
+	return b.Builder.Build()
+}
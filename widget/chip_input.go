@@ -0,0 +1,436 @@
+package widget
+
+import (
+	"errors"
+	"furoshiki/component"
+	"furoshiki/stats"
+	"furoshiki/style"
+	"furoshiki/theme"
+	"image"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+const (
+	// chipGap は、チップどうしの間隔と、最後のチップとテキストの間隔です。
+	chipGap = 4
+	// chipMinTextWidth は、チップが多い場合でも入力中のテキストのために残す最小の幅です。
+	chipMinTextWidth = 40
+)
+
+// ErrInvalidMaxChips は、チップの最大数に負の値が指定された場合のエラーです。
+var ErrInvalidMaxChips = errors.New("max chip count must be non-negative")
+
+// ChipInput は、入力した値を、入力欄の中に並ぶ取り除けるチップに変える入力欄です。
+// タグの編集や、検索の絞り込み条件の入力などに使用します。
+//
+// テキストは、Enterキー、区切り文字(既定は",")の入力、またはフォーカスを失ったときにチップとして確定します。
+// チップの"x"をクリックするか、テキストが空のときにBackspaceキーを押すと、チップを取り除けます。
+// チップが入力欄に収まらない場合は、先頭のチップが"+N"にまとめられます。
+//
+//	tags := widget.NewChipInputBuilder().
+//		Values("go", "ebiten").
+//		MaxChips(5).
+//		Suggestions(func(q string) []string { return matchTags(q) }).
+//		OnValuesChanged(func(v []string) { applyTags(v) })
+type ChipInput struct {
+	*TextInput
+	chips []string
+	// maxChips は、チップの最大数です。0の場合は制限しません。
+	maxChips   int
+	separators string
+	// userFilter は、SetInputFilterで設定された入力を受け付ける文字を判定する関数です。
+	userFilter func(r rune) bool
+	// chipStyle と disabledChipStyle は、通常時と無効状態のチップのスタイルです。
+	chipStyle         style.Computed
+	disabledChipStyle style.Computed
+	// autocomplete は、SetSuggestionsで追加した候補のドロップダウンです。ない場合はnilです。
+	autocomplete *Autocomplete
+	// slots は、最後の描画で配置したチップの位置です。クリックの判定に使用します。
+	slots []chipSlot
+
+	onValuesChanged []func(values []string)
+}
+
+// chipSlot は、描画されたチップ1つの位置です。indexが-1のものは、収まらないチップをまとめた"+N"です。
+type chipSlot struct {
+	index  int
+	label  string
+	rect   image.Rectangle
+	remove image.Rectangle
+}
+
+// newChipInput は、ChipInputの新しいインスタンスを生成し、初期化します。
+// NOTE: ウィジェットの生成には常にNewChipInputBuilder()を使用してください。
+func newChipInput() (*ChipInput, error) {
+	c := &ChipInput{TextInput: &TextInput{}, separators: ","}
+	if err := c.initTextInput(c); err != nil {
+		return nil, err
+	}
+	c.SetSize(240, 28)
+	c.SetChipStyle(theme.GetCurrent().TextInput.Chip)
+	c.TextInput.SetInputFilter(c.filterInput)
+	c.AddOnSubmit(func(string) { c.commitText() })
+	c.AddOnFocusChange(func(focused bool) {
+		if !focused {
+			c.commitText()
+		}
+	})
+	c.addInputHook(func() bool {
+		// テキストが空のときのBackspaceは、最後のチップを取り除きます。押し続けても1つずつしか取り除きません。
+		if len(c.runes) == 0 && len(c.chips) > 0 && inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
+			c.removeByUser(len(c.chips) - 1)
+		}
+		return false
+	})
+	c.addPointerHook(func(p image.Point) bool {
+		for _, slot := range c.slots {
+			if slot.index >= 0 && p.In(slot.remove) {
+				c.removeByUser(slot.index)
+				return true
+			}
+			if p.In(slot.rect) {
+				return true
+			}
+		}
+		return false
+	})
+	return c, nil
+}
+
+// Values は、チップの値を並んでいる順に返します。返されたスライスを変更してもチップには影響しません。
+func (c *ChipInput) Values() []string {
+	return slices.Clone(c.chips)
+}
+
+// SetValues は、チップをvaluesで置き換えます。空の値と重複する値は無視され、最大数を超える値は切り捨てられます。
+// 変更時のコールバックは呼び出されません。
+func (c *ChipInput) SetValues(values []string) {
+	c.chips = c.chips[:0]
+	for _, v := range values {
+		c.AddChip(v)
+	}
+	c.MarkDirty(false)
+}
+
+// AddChip は、値を末尾のチップとして追加し、追加したかどうかを返します。
+// 値の前後の空白は取り除かれます。空の値、既にある値、および最大数に達している場合は追加しません。
+// 変更時のコールバックは呼び出されません。
+func (c *ChipInput) AddChip(value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" || c.IsFull() || slices.Contains(c.chips, value) {
+		return false
+	}
+	c.chips = append(c.chips, value)
+	c.MarkDirty(false)
+	return true
+}
+
+// RemoveChip は、指定されたインデックスのチップを取り除きます。範囲外の場合は何もしません。
+// 変更時のコールバックは呼び出されません。
+func (c *ChipInput) RemoveChip(index int) {
+	if index < 0 || index >= len(c.chips) {
+		return
+	}
+	c.chips = slices.Delete(c.chips, index, index+1)
+	c.MarkDirty(false)
+}
+
+// IsFull は、チップが最大数に達しているかどうかを返します。
+func (c *ChipInput) IsFull() bool {
+	return c.maxChips > 0 && len(c.chips) >= c.maxChips
+}
+
+// SetMaxChips は、チップの最大数を設定します。0を指定すると制限しません。
+// 既に最大数を超えている場合は、末尾のチップが取り除かれます。
+func (c *ChipInput) SetMaxChips(n int) {
+	c.maxChips = max(0, n)
+	if c.maxChips > 0 && len(c.chips) > c.maxChips {
+		c.chips = c.chips[:c.maxChips]
+		c.MarkDirty(false)
+	}
+}
+
+// SetSeparators は、入力するとその時点のテキストをチップとして確定する文字を設定します。既定は","です。
+// 空文字列を指定すると、Enterキーとフォーカスの喪失でのみ確定します。
+func (c *ChipInput) SetSeparators(separators string) {
+	c.separators = separators
+}
+
+// SetInputFilter は、入力を受け付ける文字を判定する関数を設定します。区切り文字は、この関数より先に処理されます。
+func (c *ChipInput) SetInputFilter(filter func(r rune) bool) {
+	c.userFilter = filter
+}
+
+// SetChipStyle は、チップに使用するスタイルを設定します。
+func (c *ChipInput) SetChipStyle(s style.Style) {
+	c.chipStyle = style.Resolve(s)
+	c.disabledChipStyle = style.Resolve(style.Merge(s, style.Style{Opacity: style.PFloat64(0.5)}))
+	c.MarkDirty(false)
+}
+
+// SetSuggestions は、入力中のテキストに対する候補のドロップダウンを追加します。
+// チップになっている値は候補から除かれ、候補を確定するとその値がチップになります。
+func (c *ChipInput) SetSuggestions(source SuggestionFunc) *Autocomplete {
+	a := AttachAutocomplete(c.TextInput, func(query string) []string {
+		var items []string
+		for _, s := range source(query) {
+			if !slices.Contains(c.chips, s) {
+				items = append(items, s)
+			}
+		}
+		return items
+	})
+	a.AddOnSelect(func(string) { c.commitText() })
+	c.autocomplete = a
+	return a
+}
+
+// AddOnValuesChanged は、ユーザーの操作によってチップが追加または削除されたときに呼び出される関数を追加します。
+func (c *ChipInput) AddOnValuesChanged(fn func(values []string)) {
+	if fn != nil {
+		c.onValuesChanged = append(c.onValuesChanged, fn)
+	}
+}
+
+// AccessibleValue は、component.AccessibleValuerインターフェースの実装です。チップと入力中のテキストを","で区切って返します。
+func (c *ChipInput) AccessibleValue() string {
+	values := c.Values()
+	if text := c.TextInput.AccessibleValue(); text != "" {
+		values = append(values, text)
+	}
+	return strings.Join(values, ", ")
+}
+
+// Cleanup は、リソースを解放します。
+func (c *ChipInput) Cleanup() {
+	c.onValuesChanged = nil
+	c.autocomplete = nil
+	c.slots = nil
+	c.TextInput.Cleanup()
+}
+
+// filterInput は、区切り文字が入力されたときにテキストをチップとして確定し、区切り文字自体は入力しません。
+func (c *ChipInput) filterInput(r rune) bool {
+	if strings.ContainsRune(c.separators, r) {
+		c.commitText()
+		return false
+	}
+	return c.userFilter == nil || c.userFilter(r)
+}
+
+// commitText は、入力中のテキストをチップとして確定し、テキストを空にします。
+// 既にある値の場合はテキストだけを空にし、最大数に達している場合はテキストをそのまま残します。
+func (c *ChipInput) commitText() {
+	value := strings.TrimSpace(c.Value())
+	if value == "" || c.IsFull() {
+		return
+	}
+	if c.AddChip(value) {
+		c.notifyValues()
+	}
+	c.SetValue("")
+	c.scrollX = 0
+	if c.autocomplete != nil {
+		c.autocomplete.Close()
+	}
+}
+
+// removeByUser は、ユーザーの操作としてチップを取り除き、変更を通知します。
+func (c *ChipInput) removeByUser(index int) {
+	if index < 0 || index >= len(c.chips) {
+		return
+	}
+	c.RemoveChip(index)
+	c.notifyValues()
+}
+
+// notifyValues は、チップの変更をコールバックに通知します。
+func (c *ChipInput) notifyValues() {
+	values := c.Values()
+	for _, fn := range c.onValuesChanged {
+		fn(values)
+	}
+}
+
+// chipFont は、チップの文字に使用するフォントを返します。チップのスタイルで指定されていない場合は入力欄のフォントです。
+func (c *ChipInput) chipFont(input style.Computed) font.Face {
+	if c.chipStyle.Font != nil {
+		return c.chipStyle.Font
+	}
+	return input.Font
+}
+
+// chipWidth は、ラベルを表示するチップの幅を返します。removableがtrueの場合は削除ボタンの幅を含みます。
+func (c *ChipInput) chipWidth(f font.Face, label string, removable bool) int {
+	w := c.chipStyle.Padding.Left + component.MeasureString(f, label).Ceil() + c.chipStyle.Padding.Right
+	if removable {
+		w += inlineButtonPadding + component.MeasureString(f, clearLabel).Ceil()
+	}
+	return w
+}
+
+// layoutChips は、入力欄のコンテンツ領域の先頭からチップを配置し、テキストの前に確保する幅を求めます。
+// 入力中のテキストのためにchipMinTextWidthを残し、収まらない先頭のチップは"+N"にまとめます。
+func (c *ChipInput) layoutChips(input style.Computed) {
+	c.slots = c.slots[:0]
+	c.leadingWidth = 0
+	f := c.chipFont(input)
+	if f == nil || len(c.chips) == 0 {
+		return
+	}
+	area := c.contentRect(input)
+	if icon := c.leadingIconRect(); !icon.Empty() {
+		area.Min.X = icon.Max.X + inlineButtonPadding
+	}
+	available := area.Dx() - chipMinTextWidth
+
+	// 末尾のチップから、収まるだけ表示します。
+	widths := make([]int, len(c.chips))
+	total := 0
+	for i, chip := range c.chips {
+		widths[i] = c.chipWidth(f, chip, true)
+		total += widths[i] + chipGap
+	}
+	first := 0
+	more := ""
+	if total > available {
+		more = "+" + strconv.Itoa(len(c.chips))
+		used := c.chipWidth(f, more, false) + chipGap
+		first = len(c.chips)
+		for first > 0 && used+widths[first-1]+chipGap <= available {
+			first--
+			used += widths[first] + chipGap
+		}
+		more = "+" + strconv.Itoa(first)
+	}
+
+	x := area.Min.X
+	if more != "" {
+		w := c.chipWidth(f, more, false)
+		c.slots = append(c.slots, chipSlot{index: -1, label: more, rect: image.Rect(x, area.Min.Y, x+w, area.Max.Y)})
+		x += w + chipGap
+	}
+	removeW := component.MeasureString(f, clearLabel).Ceil() + c.chipStyle.Padding.Right
+	for i := first; i < len(c.chips); i++ {
+		rect := image.Rect(x, area.Min.Y, x+widths[i], area.Max.Y)
+		remove := image.Rect(rect.Max.X-removeW-inlineButtonPadding, rect.Min.Y, rect.Max.X, rect.Max.Y)
+		c.slots = append(c.slots, chipSlot{index: i, label: c.chips[i], rect: rect, remove: remove})
+		x += widths[i] + chipGap
+	}
+	c.leadingWidth = x - area.Min.X
+}
+
+// Draw は、チップの配置に合わせてテキストの領域を狭めて入力欄を描画し、その上にチップを描画します。
+func (c *ChipInput) Draw(info component.DrawInfo) {
+	if !c.IsVisible() || !c.HasBeenLaidOut() {
+		return
+	}
+	input := c.currentStyle()
+	c.layoutChips(input)
+	c.TextInput.Draw(info)
+	f := c.chipFont(input)
+	if f == nil || len(c.slots) == 0 {
+		return
+	}
+
+	offset := image.Pt(info.OffsetX, info.OffsetY)
+	chipStyle := c.chipStyle
+	if c.IsDisabled() {
+		chipStyle = c.disabledChipStyle
+	}
+	textColor := chipStyle.TextColor
+	if textColor == nil {
+		textColor = input.TextColor
+	}
+	for _, slot := range c.slots {
+		r := slot.rect.Add(offset)
+		component.DrawComputedBackground(info.Screen, r.Min.X, r.Min.Y, r.Dx(), r.Dy(), chipStyle)
+	}
+	component.FlushDraws()
+	m := component.FaceMetrics(f)
+	for _, slot := range c.slots {
+		r := slot.rect.Add(offset)
+		baseline := r.Min.Y + (r.Dy()-(m.Ascent+m.Descent).Ceil())/2 + m.Ascent.Ceil()
+		text.Draw(info.Screen, component.VisualLine(slot.label, component.IsRTL(slot.label)), f, r.Min.X+chipStyle.Padding.Left, baseline, textColor)
+		if slot.index >= 0 && !c.IsDisabled() {
+			text.Draw(info.Screen, clearLabel, f, r.Max.X-chipStyle.Padding.Right-component.MeasureString(f, clearLabel).Ceil(), baseline, mutedColor(textColor))
+		}
+	}
+	stats.AddDrawCalls(len(c.slots))
+}
+
+// --- ChipInputBuilder ---
+
+// ChipInputBuilder は、ChipInputを宣言的に構築するためのビルダーです。
+type ChipInputBuilder struct {
+	component.Builder[*ChipInputBuilder, *ChipInput]
+}
+
+// NewChipInputBuilder は新しいChipInputBuilderを生成します。
+func NewChipInputBuilder() *ChipInputBuilder {
+	c, err := newChipInput()
+	b := &ChipInputBuilder{}
+	b.Init(b, c)
+	b.AddError(err)
+	return b
+}
+
+// Values は、初期のチップを設定します。
+func (b *ChipInputBuilder) Values(values ...string) *ChipInputBuilder {
+	b.Widget.SetValues(values)
+	return b
+}
+
+// MaxChips は、チップの最大数を設定します。0を指定すると制限しません。
+func (b *ChipInputBuilder) MaxChips(n int) *ChipInputBuilder {
+	if n < 0 {
+		b.AddError(ErrInvalidMaxChips)
+		return b
+	}
+	b.Widget.SetMaxChips(n)
+	return b
+}
+
+// Separators は、入力するとテキストをチップとして確定する文字を設定します。
+func (b *ChipInputBuilder) Separators(separators string) *ChipInputBuilder {
+	b.Widget.SetSeparators(separators)
+	return b
+}
+
+// Placeholder は、チップもテキストもなく、フォーカスを持っていないときに表示されるヒントの文字列を設定します。
+func (b *ChipInputBuilder) Placeholder(placeholder string) *ChipInputBuilder {
+	b.Widget.SetPlaceholder(placeholder)
+	return b
+}
+
+// ChipStyle は、チップに使用するスタイルを設定します。
+func (b *ChipInputBuilder) ChipStyle(s style.Style) *ChipInputBuilder {
+	b.Widget.SetChipStyle(s)
+	return b
+}
+
+// Suggestions は、入力中のテキストに対する候補のドロップダウンを追加します。
+func (b *ChipInputBuilder) Suggestions(source SuggestionFunc) *ChipInputBuilder {
+	if source != nil {
+		b.Widget.SetSuggestions(source)
+	}
+	return b
+}
+
+// OnValuesChanged は、ユーザーの操作によってチップが追加または削除されたときに呼び出される関数を追加します。
+func (b *ChipInputBuilder) OnValuesChanged(fn func(values []string)) *ChipInputBuilder {
+	b.Widget.AddOnValuesChanged(fn)
+	return b
+}
+
+// Build は、最終的なChipInputを構築して返します。
+func (b *ChipInputBuilder) Build() (*ChipInput, error) {
+	return b.Builder.Build()
+}
@@ -0,0 +1,247 @@
+package widget
+
+import (
+	"fmt"
+	"furoshiki/component"
+	"furoshiki/stats"
+	"furoshiki/style"
+	"furoshiki/theme"
+	"image"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	// defaultDebugHUDToggleKey は、DebugHUDの表示を切り替える既定のキーです。
+	defaultDebugHUDToggleKey = ebiten.KeyF3
+	// debugHUDRefreshInterval は、表示する数値を更新する間隔です。毎フレーム書き換えると数値を読み取れないため、間引いて更新します。
+	debugHUDRefreshInterval = 250 * time.Millisecond
+)
+
+// DebugHUD は、フレームレートやウィジェットの数、直前のフレームのレイアウトと描画のカウンタを、
+// 半透明のパネルに複数行で表示するデバッグ用のウィジェットです。
+// 既定では非表示で、トグルキー(既定: F3)で表示を切り替えます。非表示の間も、キーの入力を受け取るためにUpdateは呼び出される必要があります。
+//
+// 表示する内容:
+//   - FPSとTPS(ebiten.ActualFPS、ebiten.ActualTPS)
+//   - 対象のツリーのウィジェットの数と、そのうち表示されているウィジェットの数
+//   - レイアウト計算の回数と所要時間、計測・配置されたウィジェットの数
+//   - 再描画と再レイアウトの要求(MarkDirty)の回数
+//   - 描画命令の数とオフスクリーン画像の確保回数、イベントディスパッチの所要時間
+//   - オフスクリーン画像の使用量と、設定されていればその予算(component.SetOffscreenBudget)
+//
+// カウンタは stats.LastFrame() から取得されるため、furoshiki.EnableStats(true) で計測を有効にする必要があります。
+// ウィジェットの数は、SetRootsで指定したルート(未指定の場合はDebugHUD自身が属するツリーのルート)以下を数えます。
+//
+// パネルはウィジェットの左上を基準に内容に合わせた大きさで描画され、ヒットテストには当たりません。
+// 画面の手前にレイヤー(ui.Stage)として重ねるか、ZStackなどに配置して使用します。
+type DebugHUD struct {
+	*component.LayoutableWidget
+	toggleKey   ebiten.Key
+	roots       []component.Widget
+	lines       []string
+	lastRefresh time.Time
+	// panelWidth, panelHeight は、現在の表示内容を囲むパネルの大きさです。
+	panelWidth, panelHeight int
+}
+
+// newDebugHUD は、DebugHUDの新しいインスタンスを生成し、初期化します。
+func newDebugHUD() (*DebugHUD, error) {
+	h := &DebugHUD{toggleKey: defaultDebugHUDToggleKey}
+	h.LayoutableWidget = component.NewLayoutableWidget()
+	if err := h.Init(h); err != nil {
+		return nil, err
+	}
+	t := theme.GetCurrent()
+	h.SetStyle(style.Style{
+		Font:         style.PFont(t.DefaultFont),
+		Background:   style.PColor(color.RGBA{A: 180}),
+		TextColor:    style.PColor(color.White),
+		BorderRadius: style.PFloat32(4),
+		Padding:      style.PInsets(style.Insets{Top: 6, Right: 8, Bottom: 6, Left: 8}),
+	})
+	// NOTE: 表示内容は定期的に変わるため、レイアウト境界として親コンテナへの再レイアウト要求の伝播を止めます。
+	h.SetLayoutBoundary(true)
+	h.SetVisible(false)
+	return h, nil
+}
+
+// SetToggleKey は、表示を切り替えるキーを設定します。
+func (h *DebugHUD) SetToggleKey(key ebiten.Key) {
+	h.toggleKey = key
+}
+
+// ToggleKey は、表示を切り替えるキーを返します。
+func (h *DebugHUD) ToggleKey() ebiten.Key {
+	return h.toggleKey
+}
+
+// SetRoots は、ウィジェットの数を数えるツリーのルートを設定します。
+// ui.Stageのレイヤーのように、ツリーが複数ある場合はすべてのルートを渡します。
+// 何も渡さない場合は、DebugHUD自身が属するツリーのルートを数えます。
+func (h *DebugHUD) SetRoots(roots ...component.Widget) {
+	h.roots = roots
+}
+
+// Update は、トグルキーの入力を処理し、表示中であれば一定の間隔で表示内容を更新します。
+func (h *DebugHUD) Update() {
+	h.LayoutableWidget.Update()
+	if inpututil.IsKeyJustPressed(h.toggleKey) {
+		h.SetVisible(!h.IsVisible())
+		if h.IsVisible() {
+			// 表示した直後から最新の内容を表示します。
+			h.lastRefresh = time.Time{}
+		}
+	}
+	if !h.IsVisible() || time.Since(h.lastRefresh) < debugHUDRefreshInterval {
+		return
+	}
+	h.lastRefresh = time.Now()
+	h.refresh()
+}
+
+// refresh は、表示する行を作り直し、パネルの大きさを更新します。
+func (h *DebugHUD) refresh() {
+	total, visible := h.countWidgets()
+	h.lines = append(h.lines[:0],
+		fmt.Sprintf("FPS %.1f  TPS %.1f", ebiten.ActualFPS(), ebiten.ActualTPS()),
+		fmt.Sprintf("widgets %d (visible %d)", total, visible),
+	)
+	if stats.Enabled() {
+		s := stats.LastFrame()
+		h.lines = append(h.lines,
+			fmt.Sprintf("layout %d passes %s", s.LayoutPasses, formatHUDDuration(s.LayoutTime)),
+			fmt.Sprintf("measured %d  arranged %d", s.WidgetsMeasured, s.WidgetsArranged),
+			fmt.Sprintf("dirty %d redraw  %d relayout", s.RedrawRequests, s.RelayoutRequests),
+			fmt.Sprintf("draws %d  offscreen %d allocs", s.DrawCalls, s.OffscreenAllocations),
+			fmt.Sprintf("events %s", formatHUDDuration(s.EventDispatchTime)),
+		)
+		if budget := component.OffscreenBudget(); budget > 0 {
+			h.lines = append(h.lines, fmt.Sprintf("offscreen %s / %s", formatHUDBytes(s.OffscreenBytes), formatHUDBytes(budget)))
+		} else {
+			h.lines = append(h.lines, fmt.Sprintf("offscreen %s", formatHUDBytes(s.OffscreenBytes)))
+		}
+	} else {
+		h.lines = append(h.lines, "stats disabled")
+	}
+
+	c := h.ComputedStyle()
+	width, height := 0, 0
+	if c.Font != nil {
+		metrics := component.FaceMetrics(c.Font)
+		for _, line := range h.lines {
+			width = max(width, component.MeasureString(c.Font, line).Ceil())
+		}
+		height = (metrics.Ascent + metrics.Descent).Ceil() * len(h.lines)
+	}
+	h.panelWidth = width + c.Padding.Left + c.Padding.Right
+	h.panelHeight = height + c.Padding.Top + c.Padding.Bottom
+	// NOTE: 数値の桁が変わるたびに再レイアウトが起きないよう、最小サイズは広げるだけで縮めません。
+	minW, minH := h.GetMinSize()
+	h.SetMinSize(max(minW, h.panelWidth), max(minH, h.panelHeight))
+	h.MarkDirty(false)
+}
+
+// countWidgets は、対象のツリーのウィジェットの数と、祖先を含めて表示されているウィジェットの数を返します。
+func (h *DebugHUD) countWidgets() (total, visible int) {
+	roots := h.roots
+	if len(roots) == 0 {
+		var root component.Widget = h
+		for root.GetParent() != nil {
+			root = root.GetParent()
+		}
+		roots = []component.Widget{root}
+	}
+	for _, root := range roots {
+		// hiddenDepth は、走査中の非表示のウィジェットの深さです。その子孫は表示されていないものとして数えます。
+		hiddenDepth := -1
+		component.Walk(root, func(w component.Widget, depth int) component.WalkResult {
+			total++
+			if hiddenDepth >= 0 && depth <= hiddenDepth {
+				hiddenDepth = -1
+			}
+			if hiddenDepth < 0 {
+				if is, ok := w.(component.InteractiveState); ok && !is.IsVisible() {
+					hiddenDepth = depth
+				} else {
+					visible++
+				}
+			}
+			return component.WalkContinue
+		})
+	}
+	return total, visible
+}
+
+// HitTest は常にnilを返します。DebugHUDは、その下にあるウィジェットへの入力を妨げません。
+func (h *DebugHUD) HitTest(x, y int) component.Widget {
+	return nil
+}
+
+// Draw は、ウィジェットの左上を基準に、背景のパネルと表示内容を描画します。
+func (h *DebugHUD) Draw(info component.DrawInfo) {
+	if !h.IsVisible() || !h.HasBeenLaidOut() || len(h.lines) == 0 {
+		return
+	}
+	x, y := h.GetPosition()
+	x += info.OffsetX
+	y += info.OffsetY
+	c := h.ComputedStyle()
+	component.DrawComputedBackground(info.Screen, x, y, h.panelWidth, h.panelHeight, c)
+	c.TextAlign = style.TextAlignLeft
+	c.VerticalAlign = style.VerticalAlignTop
+	component.DrawAlignedLines(info.Screen, h.lines, image.Rect(x, y, x+h.panelWidth, y+h.panelHeight), c)
+}
+
+// formatHUDDuration は、所要時間をミリ秒単位の文字列にします。
+func formatHUDDuration(d time.Duration) string {
+	return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+}
+
+// formatHUDBytes は、バイト数をKiBまたはMiB単位の文字列にします。
+func formatHUDBytes(n int64) string {
+	if n >= 1<<20 {
+		return fmt.Sprintf("%.1fMiB", float64(n)/(1<<20))
+	}
+	return fmt.Sprintf("%.1fKiB", float64(n)/(1<<10))
+}
+
+// --- DebugHUDBuilder ---
+type DebugHUDBuilder struct {
+	component.Builder[*DebugHUDBuilder, *DebugHUD]
+}
+
+// NewDebugHUDBuilder は新しいDebugHUDBuilderを生成します。
+func NewDebugHUDBuilder() *DebugHUDBuilder {
+	h, err := newDebugHUD()
+	b := &DebugHUDBuilder{}
+	b.Init(b, h)
+	b.AddError(err)
+	return b
+}
+
+// ToggleKey は、表示を切り替えるキーを設定します。
+func (b *DebugHUDBuilder) ToggleKey(key ebiten.Key) *DebugHUDBuilder {
+	b.Widget.SetToggleKey(key)
+	return b
+}
+
+// Roots は、ウィジェットの数を数えるツリーのルートを設定します。
+func (b *DebugHUDBuilder) Roots(roots ...component.Widget) *DebugHUDBuilder {
+	b.Widget.SetRoots(roots...)
+	return b
+}
+
+// Shown は、構築した時点でHUDを表示するかどうかを設定します。
+func (b *DebugHUDBuilder) Shown(shown bool) *DebugHUDBuilder {
+	b.Widget.SetVisible(shown)
+	return b
+}
+
+// Build は、最終的なDebugHUDを構築して返します。
+func (b *DebugHUDBuilder) Build() (*DebugHUD, error) {
+	return b.Builder.Build()
+}
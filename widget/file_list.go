@@ -0,0 +1,499 @@
+package widget
+
+import (
+	"errors"
+	"furoshiki/component"
+	"furoshiki/event"
+	"image"
+	"image/color"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// このファイルは、ファイルシステムのディレクトリの内容を一覧表示し、ディレクトリ間を移動できるFileListを提供します。
+// ファイルシステムはio/fs.FSとして注入するため、OSのファイルシステム(os.DirFS)のほか、
+// ゲームに埋め込んだアセット(embed.FS)や、セーブデータを格納した仮想的なファイルシステムも閲覧できます。
+//
+//	list := widget.NewFileListBuilder().
+//		FS(os.DirFS(saveDir)).
+//		Extensions(".sav").
+//		OnFileActivated(func(p string) { load(p) })
+//
+// パスは、io/fsの規則に従い、ファイルシステムのルートからの"/"区切りの相対パス(ルート自身は".")で表します。
+//
+//	ダブルクリック / Enter    ディレクトリを開く、またはファイルを確定します
+//	Backspace                 親ディレクトリへ移動します
+
+// parentDirName は、親ディレクトリへ移動するための行に表示する名前です。
+const parentDirName = ".."
+
+// ErrNoFileSystem は、ファイルシステムが設定されていないFileListでディレクトリを開こうとした場合のエラーです。
+var ErrNoFileSystem = errors.New("file list has no file system")
+
+var (
+	fileIconsOnce sync.Once
+	folderIcon    *ebiten.Image
+	fileIcon      *ebiten.Image
+)
+
+// defaultFileIcons は、FileListの既定のアイコン(フォルダとファイル)を返します。画像は最初の呼び出しで生成されます。
+func defaultFileIcons() (folder, file *ebiten.Image) {
+	fileIconsOnce.Do(func() {
+		const size = 12
+		folderImg := image.NewRGBA(image.Rect(0, 0, size, size))
+		folderColor := color.RGBA{220, 170, 60, 255}
+		for y := 1; y < size-1; y++ {
+			for x := range size {
+				// 左上のつまみと、その下の本体を描きます。
+				tab := y < 3 && x < 5
+				body := y >= 3
+				if tab || body {
+					folderImg.Set(x, y, folderColor)
+				}
+			}
+		}
+		folderIcon = ebiten.NewImageFromImage(folderImg)
+
+		fileImg := image.NewRGBA(image.Rect(0, 0, size, size))
+		edge := color.RGBA{110, 110, 110, 255}
+		paper := color.RGBA{245, 245, 245, 255}
+		for y := range size {
+			for x := 1; x < size-2; x++ {
+				// 右上の角を折った紙を、枠線と中の白で描きます。
+				fold := x-(size-7) > y
+				switch {
+				case fold:
+				case x == 1 || x == size-3 || y == 0 || y == size-1 || x-(size-7) == y:
+					fileImg.Set(x, y, edge)
+				default:
+					fileImg.Set(x, y, paper)
+				}
+			}
+		}
+		fileIcon = ebiten.NewImageFromImage(fileImg)
+	})
+	return folderIcon, fileIcon
+}
+
+// ExtensionFilter は、拡張子がextsのいずれかに一致するファイルだけを表示するフィルタを返します。
+// 拡張子は大文字と小文字を区別せずに比較し、先頭の"."は省略できます(".png"と"png"は同じです)。
+// 例: list.SetFilter(widget.ExtensionFilter(".png", ".jpg"))
+func ExtensionFilter(exts ...string) func(entry fs.DirEntry) bool {
+	normalized := make([]string, 0, len(exts))
+	for _, ext := range exts {
+		if ext == "" {
+			continue
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		normalized = append(normalized, strings.ToLower(ext))
+	}
+	return func(entry fs.DirEntry) bool {
+		return slices.Contains(normalized, strings.ToLower(path.Ext(entry.Name())))
+	}
+}
+
+// FileList は、ディレクトリの内容をアイコン付きの行で一覧表示し、ディレクトリ間を移動できるウィジェットです。
+// ディレクトリはファイルより前に並び、ルート以外のディレクトリでは先頭に親ディレクトリへ移動する".."の行が表示されます。
+// 行の選択やスタイルなど、一覧の表示と操作はListと共通です。
+type FileList struct {
+	*List
+	fsys fs.FS
+	dir  string
+	// entries は、現在のディレクトリから読み込んだすべての項目です。
+	entries []fs.DirEntry
+	// shown は、フィルタを通って表示されている項目です。親ディレクトリの行は含みません。
+	shown []fs.DirEntry
+	// hasParent は、先頭に親ディレクトリの行があるかどうかを表します。
+	hasParent bool
+
+	filter     func(entry fs.DirEntry) bool
+	showHidden bool
+	iconFunc   func(entry fs.DirEntry) *ebiten.Image
+
+	// lastPressItem, lastPressTime は、ダブルクリックの判定に使用する、最後に押された行の項目と時刻です。
+	lastPressItem component.Widget
+	lastPressTime int64
+
+	onDirChanged    []func(dir string)
+	onFileActivated []func(filePath string)
+	onError         []func(err error)
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ component.Container = (*FileList)(nil)
+
+// newFileList は、FileListの新しいインスタンスを生成し、初期化します。
+// NOTE: ウィジェットの生成には常にNewFileListBuilder()を使用してください。
+func newFileList() (*FileList, error) {
+	l := &FileList{List: &List{ScrollView: &ScrollView{}}, dir: "."}
+	if err := l.initList(l); err != nil {
+		return nil, err
+	}
+	l.AddOnActivate(l.activate)
+	return l, nil
+}
+
+// SetFS は、閲覧するファイルシステムを設定し、そのルートを開きます。nilを指定すると一覧を空にします。
+func (l *FileList) SetFS(fsys fs.FS) error {
+	l.fsys = fsys
+	if fsys == nil {
+		l.dir, l.entries = ".", nil
+		return l.rebuild()
+	}
+	return l.SetDir(".")
+}
+
+// FS は、閲覧しているファイルシステムを返します。設定されていない場合はnilです。
+func (l *FileList) FS() fs.FS {
+	return l.fsys
+}
+
+// SetDir は、指定されたディレクトリを開き、その内容を一覧表示します。
+// ディレクトリを読み込めない場合はエラーを返し、表示中のディレクトリはそのまま残ります。
+func (l *FileList) SetDir(dir string) error {
+	if l.fsys == nil {
+		return ErrNoFileSystem
+	}
+	clean := path.Clean(dir)
+	if !fs.ValidPath(clean) {
+		return &fs.PathError{Op: "readdir", Path: dir, Err: fs.ErrInvalid}
+	}
+	entries, err := fs.ReadDir(l.fsys, clean)
+	if err != nil {
+		return err
+	}
+	// fs.ReadDirは名前順に並べて返すため、ディレクトリを先頭に集めるだけで済みます。
+	slices.SortStableFunc(entries, func(a, b fs.DirEntry) int {
+		switch {
+		case a.IsDir() == b.IsDir():
+			return 0
+		case a.IsDir():
+			return -1
+		}
+		return 1
+	})
+	changed := clean != l.dir
+	l.dir, l.entries = clean, entries
+	if err := l.rebuild(); err != nil {
+		return err
+	}
+	l.SetScrollY(0)
+	if changed {
+		for _, fn := range l.onDirChanged {
+			fn(clean)
+		}
+	}
+	return nil
+}
+
+// Dir は、表示中のディレクトリのパスを返します。ルートは"."です。
+func (l *FileList) Dir() string {
+	return l.dir
+}
+
+// Up は、親ディレクトリへ移動し、移動前のディレクトリの行を選択します。ルートを表示中の場合は何もしません。
+func (l *FileList) Up() error {
+	if l.dir == "." {
+		return nil
+	}
+	from := path.Base(l.dir)
+	if err := l.SetDir(path.Dir(l.dir)); err != nil {
+		return err
+	}
+	if i := slices.IndexFunc(l.shown, func(e fs.DirEntry) bool { return e.IsDir() && e.Name() == from }); i >= 0 {
+		index := i + l.parentRows()
+		l.Select(index)
+		l.ScrollToItem(index)
+	}
+	return nil
+}
+
+// Refresh は、表示中のディレクトリを読み込み直します。ファイルを保存した後などに使用します。
+func (l *FileList) Refresh() error {
+	return l.SetDir(l.dir)
+}
+
+// SetFilter は、表示するファイルを選ぶ関数を設定します。nilを指定するとすべてのファイルを表示します。
+// ディレクトリは、移動できるようにフィルタにかかわらず表示されます。
+func (l *FileList) SetFilter(filter func(entry fs.DirEntry) bool) error {
+	l.filter = filter
+	return l.rebuild()
+}
+
+// SetShowHidden は、名前が"."で始まる隠しファイルと隠しディレクトリを表示するかどうかを設定します。既定では表示しません。
+func (l *FileList) SetShowHidden(show bool) error {
+	if l.showHidden == show {
+		return nil
+	}
+	l.showHidden = show
+	return l.rebuild()
+}
+
+// SetIconFunc は、各項目のアイコンを返す関数を設定します。
+// 関数がnilを返した場合、または関数にnilを指定した場合は、既定のフォルダとファイルのアイコンを使用します。
+func (l *FileList) SetIconFunc(fn func(entry fs.DirEntry) *ebiten.Image) error {
+	l.iconFunc = fn
+	return l.rebuild()
+}
+
+// SelectedEntry は、選択中の項目を返します。選択がない場合と、親ディレクトリの行が選択されている場合はnilです。
+func (l *FileList) SelectedEntry() fs.DirEntry {
+	return l.entryAt(l.Selected())
+}
+
+// SelectedPath は、選択中の項目のパスを返します。選択がない場合と、親ディレクトリの行が選択されている場合は空文字列です。
+func (l *FileList) SelectedPath() string {
+	if e := l.SelectedEntry(); e != nil {
+		return path.Join(l.dir, e.Name())
+	}
+	return ""
+}
+
+// AddOnDirChanged は、表示するディレクトリが変わったときに呼び出される関数を追加します。
+func (l *FileList) AddOnDirChanged(fn func(dir string)) {
+	if fn != nil {
+		l.onDirChanged = append(l.onDirChanged, fn)
+	}
+}
+
+// AddOnFileActivated は、ファイルの行がダブルクリックされたとき、またはフォーカス中にEnterキーで確定されたときに
+// 呼び出される関数を追加します。filePathはファイルシステムのルートからのパスです。
+func (l *FileList) AddOnFileActivated(fn func(filePath string)) {
+	if fn != nil {
+		l.onFileActivated = append(l.onFileActivated, fn)
+	}
+}
+
+// AddOnError は、行の確定やBackspaceキーによるディレクトリの移動に失敗したときに、そのエラーとともに呼び出される関数を追加します。
+// 注入されたファイルシステムでは、権限のないディレクトリや存在しないディレクトリへの移動が失敗することがあります。
+func (l *FileList) AddOnError(fn func(err error)) {
+	if fn != nil {
+		l.onError = append(l.onError, fn)
+	}
+}
+
+// reportError は、エラーをAddOnErrorの関数に通知します。
+func (l *FileList) reportError(err error) {
+	for _, fn := range l.onError {
+		fn(err)
+	}
+}
+
+// Update は、Listの更新に加えて、フォーカス中のBackspaceキーで親ディレクトリへ移動します。
+func (l *FileList) Update() {
+	l.List.Update()
+	if l.IsFocused() && inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
+		if err := l.Up(); err != nil {
+			l.reportError(err)
+		}
+	}
+}
+
+// Cleanup は、リソースを解放します。
+func (l *FileList) Cleanup() {
+	l.onDirChanged = nil
+	l.onFileActivated = nil
+	l.onError = nil
+	l.lastPressItem = nil
+	l.entries, l.shown = nil, nil
+	l.List.Cleanup()
+}
+
+// parentRows は、先頭にある親ディレクトリの行の数(0または1)を返します。
+func (l *FileList) parentRows() int {
+	if l.hasParent {
+		return 1
+	}
+	return 0
+}
+
+// entryAt は、指定されたインデックスの行が表す項目を返します。親ディレクトリの行と範囲外の場合はnilです。
+func (l *FileList) entryAt(index int) fs.DirEntry {
+	i := index - l.parentRows()
+	if index < 0 || i < 0 || i >= len(l.shown) {
+		return nil
+	}
+	return l.shown[i]
+}
+
+// isShown は、項目を一覧に表示するかどうかを返します。
+func (l *FileList) isShown(e fs.DirEntry) bool {
+	if !l.showHidden && strings.HasPrefix(e.Name(), ".") {
+		return false
+	}
+	return e.IsDir() || l.filter == nil || l.filter(e)
+}
+
+// rebuild は、読み込み済みの項目から、フィルタを通った項目の行を作り直します。選択は解除されます。
+func (l *FileList) rebuild() error {
+	l.content.BeginUpdate()
+	defer l.content.EndUpdate()
+	l.ClearItems()
+	l.lastPressItem = nil
+	l.shown = nil
+	for _, e := range l.entries {
+		if l.isShown(e) {
+			l.shown = append(l.shown, e)
+		}
+	}
+
+	folder, file := defaultFileIcons()
+	l.hasParent = l.fsys != nil && l.dir != "."
+	if l.hasParent {
+		if err := l.addEntryRow(folder, parentDirName); err != nil {
+			return err
+		}
+	}
+	for _, e := range l.shown {
+		var icon *ebiten.Image
+		if l.iconFunc != nil {
+			icon = l.iconFunc(e)
+		}
+		if icon == nil {
+			icon = file
+			if e.IsDir() {
+				icon = folder
+			}
+		}
+		if err := l.addEntryRow(icon, e.Name()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addEntryRow は、アイコンと名前を表示する行を末尾に追加し、ダブルクリックで確定するハンドラを登録します。
+func (l *FileList) addEntryRow(icon *ebiten.Image, name string) error {
+	item, err := newRichLabel()
+	if err != nil {
+		return err
+	}
+	item.SetSpans(Span{Icon: icon}, Span{Text: " " + name})
+	item.SetAccessibleLabel(name)
+	// 行の選択は行のハンドラが行うため、ここではダブルクリックの判定だけを行います。
+	item.AddEventHandler(event.MouseDown, func(e *event.Event) event.Propagation {
+		doubleClick := l.lastPressItem == item && e.Timestamp-l.lastPressTime <= doubleClickInterval.Nanoseconds()
+		if !doubleClick {
+			l.lastPressItem, l.lastPressTime = item, e.Timestamp
+			return event.Propagate
+		}
+		l.lastPressItem = nil
+		if i := slices.IndexFunc(l.rows, func(r *listRow) bool { return r.item == item }); i >= 0 {
+			l.Activate(i)
+		}
+		return event.Propagate
+	})
+	return l.AddItem(item)
+}
+
+// activate は、行が確定されたときに、ディレクトリであれば開き、ファイルであれば確定時の関数を呼び出します。
+func (l *FileList) activate(index int) {
+	if l.hasParent && index == 0 {
+		if err := l.Up(); err != nil {
+			l.reportError(err)
+		}
+		return
+	}
+	e := l.entryAt(index)
+	if e == nil {
+		return
+	}
+	p := path.Join(l.dir, e.Name())
+	if e.IsDir() {
+		if err := l.SetDir(p); err != nil {
+			l.reportError(err)
+		}
+		return
+	}
+	for _, fn := range l.onFileActivated {
+		fn(p)
+	}
+}
+
+// --- FileListBuilder ---
+
+// FileListBuilder は、FileListを宣言的に構築するためのビルダーです。
+type FileListBuilder struct {
+	component.Builder[*FileListBuilder, *FileList]
+}
+
+// NewFileListBuilder は新しいFileListBuilderを生成します。
+func NewFileListBuilder() *FileListBuilder {
+	l, err := newFileList()
+	b := &FileListBuilder{}
+	b.Init(b, l)
+	b.AddError(err)
+	return b
+}
+
+// FS は、閲覧するファイルシステムを設定し、そのルートを開きます。
+func (b *FileListBuilder) FS(fsys fs.FS) *FileListBuilder {
+	b.AddError(b.Widget.SetFS(fsys))
+	return b
+}
+
+// Dir は、最初に開くディレクトリを設定します。FSの後に呼び出してください。
+func (b *FileListBuilder) Dir(dir string) *FileListBuilder {
+	b.AddError(b.Widget.SetDir(dir))
+	return b
+}
+
+// Filter は、表示するファイルを選ぶ関数を設定します。ディレクトリは常に表示されます。
+func (b *FileListBuilder) Filter(filter func(entry fs.DirEntry) bool) *FileListBuilder {
+	b.AddError(b.Widget.SetFilter(filter))
+	return b
+}
+
+// Extensions は、拡張子がextsのいずれかに一致するファイルだけを表示します。
+func (b *FileListBuilder) Extensions(exts ...string) *FileListBuilder {
+	return b.Filter(ExtensionFilter(exts...))
+}
+
+// ShowHidden は、名前が"."で始まる隠しファイルと隠しディレクトリを表示するかどうかを設定します。
+func (b *FileListBuilder) ShowHidden(show bool) *FileListBuilder {
+	b.AddError(b.Widget.SetShowHidden(show))
+	return b
+}
+
+// IconFunc は、各項目のアイコンを返す関数を設定します。
+func (b *FileListBuilder) IconFunc(fn func(entry fs.DirEntry) *ebiten.Image) *FileListBuilder {
+	b.AddError(b.Widget.SetIconFunc(fn))
+	return b
+}
+
+// OnDirChanged は、表示するディレクトリが変わったときに呼び出される関数を追加します。
+func (b *FileListBuilder) OnDirChanged(fn func(dir string)) *FileListBuilder {
+	b.Widget.AddOnDirChanged(fn)
+	return b
+}
+
+// OnFileActivated は、ファイルの行がダブルクリックまたはEnterキーで確定されたときに呼び出される関数を追加します。
+func (b *FileListBuilder) OnFileActivated(fn func(filePath string)) *FileListBuilder {
+	b.Widget.AddOnFileActivated(fn)
+	return b
+}
+
+// OnError は、ディレクトリの移動に失敗したときに呼び出される関数を追加します。
+func (b *FileListBuilder) OnError(fn func(err error)) *FileListBuilder {
+	b.Widget.AddOnError(fn)
+	return b
+}
+
+// OnSelectionChanged は、選択中の項目が変化したときに呼び出される関数を追加します。
+func (b *FileListBuilder) OnSelectionChanged(fn func(index int)) *FileListBuilder {
+	b.Widget.AddOnSelectionChanged(fn)
+	return b
+}
+
+// Build は、最終的なFileListを構築して返します。
+func (b *FileListBuilder) Build() (*FileList, error) {
+	return b.Builder.Build()
+}
@@ -0,0 +1,657 @@
+package widget
+
+import (
+	"furoshiki/binding"
+	"furoshiki/component"
+	"furoshiki/container"
+	"furoshiki/event"
+	"furoshiki/layout"
+	"furoshiki/style"
+	"furoshiki/theme"
+	"image"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	// defaultGridCellSize は、GridViewのセルの既定の幅と高さです。
+	defaultGridCellSize = 64
+	// defaultGridGap は、GridViewのセル同士の既定の間隔です。
+	defaultGridGap = 4
+	// gridDoubleClickDuration は、同じセルを2回押したときにダブルクリックとみなす間隔です。
+	gridDoubleClickDuration = 400 * time.Millisecond
+)
+
+// GridView は、同じ大きさのセルを格子状に並べてスクロール表示するウィジェットです。
+// サムネイルの一覧、絵文字の選択、タイルのパレットなどに使用します。
+//
+// 列の数は、表示領域の幅に収まる数から自動的に決まります。
+// セルのウィジェットは表示範囲に入っているものだけが生成され、スクロールで表示範囲から外れたセルは
+// 別のインデックスのセルとして再利用されます。そのため、数万個のセルでも生成されるウィジェットは画面に収まる分だけです。
+// セルの内容は、SetCellFactoryで指定した関数で生成(create)し、インデックスに対応する内容に更新(bind)します。
+// データモデル(binding.ListModel)を設定すると、セルの数とセルの更新がモデルの変更に追従します(grid_view_model.go)。
+//
+// セルをクリックすると選択され、ダブルクリックするか、フォーカス中にEnterキーを押すと確定(OnCellActivated)されます。
+// フォーカス中は、矢印キー、Home、Endで選択を移動できます。強調のスタイルはListと共通(theme.ListTheme)です。
+type GridView struct {
+	*ScrollView
+	content *gridContent
+	count   int
+	cellW   int
+	cellH   int
+	gap     int
+
+	create func() component.Widget
+	bind   func(cell component.Widget, index int)
+	// model は、セルの数と変更通知の元になるデータです。SetModelで設定されていない場合はnilです。
+	model       binding.ListModel
+	unbindModel func()
+	// visible は、表示範囲にあるセルをインデックスで引くための表です。
+	visible map[int]*gridCell
+	// pool は、表示範囲から外れ、再利用を待っているセルです。
+	pool []*gridCell
+
+	selected int
+	hovered  int
+	focused  bool
+	// lastPressIndex, lastPressTime は、ダブルクリックの判定に使用する直前の押下です。
+	lastPressIndex int
+	lastPressTime  int64
+	// scrollTarget は、次のレイアウトの後に表示範囲へスクロールするセルのインデックスです。ない場合は-1です。
+	scrollTarget int
+
+	hoveredStyle  style.Computed
+	selectedStyle style.Computed
+	focusedStyle  style.Computed
+
+	onSelectionChanged []func(index int)
+	onCellActivated    []func(index int)
+}
+
+// gridContent は、GridViewのスクロールされる領域です。
+// セルの数と列の数から高さを計算できるため、HeightForWiderを実装してScrollViewLayoutによる子の仮配置を避けます。
+type gridContent struct {
+	*container.Container
+	grid *GridView
+}
+
+// GetHeightForWidth は、HeightForWiderインターフェースの実装です。すべてのセルを並べたときの高さを返します。
+func (c *gridContent) GetHeightForWidth(width int) int {
+	g := c.grid
+	columns := g.columnsFor(width)
+	rows := (g.count + columns - 1) / columns
+	if rows == 0 {
+		return 0
+	}
+	return rows*g.cellH + (rows-1)*g.gap
+}
+
+// gridContentLayout は、子の位置と大きさを変更しないレイアウトです。
+// セルはlayoutCellsが表示範囲に合わせて配置するため、コンテンツの再レイアウトでセルを並べ直す必要はありません。
+type gridContentLayout struct{}
+
+// Layout は、Layoutインターフェースの実装です。何も行いません。
+func (gridContentLayout) Layout(layout.Container) error { return nil }
+
+// gridCell は、セルのウィジェットを包むコンテナです。強調の背景は描画フックでセルの下に描画します。
+type gridCell struct {
+	*container.Container
+	widget component.Widget
+	index  int
+}
+
+// SetPosition は、セルを移動します。コンテナは子を配置し直さないため、セルのウィジェットも同じ量だけ移動します。
+func (c *gridCell) SetPosition(x, y int) {
+	oldX, oldY := c.GetPosition()
+	c.Container.SetPosition(x, y)
+	component.TranslateTree(c.widget, x-oldX, y-oldY)
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ component.Container = (*GridView)(nil)
+var _ component.Focusable = (*GridView)(nil)
+var _ component.HeightForWider = (*gridContent)(nil)
+
+// newGridView は、GridViewの新しいインスタンスを生成し、初期化します。
+// NOTE: ウィジェットの生成には常にNewGridViewBuilder()を使用してください。
+func newGridView() (*GridView, error) {
+	g := &GridView{
+		ScrollView:     &ScrollView{},
+		cellW:          defaultGridCellSize,
+		cellH:          defaultGridCellSize,
+		gap:            defaultGridGap,
+		visible:        make(map[int]*gridCell),
+		selected:       -1,
+		hovered:        -1,
+		lastPressIndex: -1,
+		scrollTarget:   -1,
+	}
+	if err := g.initScrollView(g); err != nil {
+		return nil, err
+	}
+	c, err := container.NewContainer()
+	if err != nil {
+		return nil, err
+	}
+	// セルの位置はlayoutCellsが決めるため、コンテンツのレイアウトはセルを動かしません。
+	c.SetLayout(gridContentLayout{})
+	g.content = &gridContent{Container: c, grid: g}
+	g.SetContent(g.content)
+
+	t := theme.GetCurrent()
+	g.hoveredStyle = style.Resolve(t.List.Hovered)
+	g.selectedStyle = style.Resolve(t.List.Selected)
+	g.focusedStyle = style.Resolve(t.List.Focused)
+
+	g.AddEventHandler(event.MouseDown, func(e *event.Event) event.Propagation {
+		component.SetFocus(g)
+		return event.Propagate
+	})
+	return g, nil
+}
+
+// SetCellFactory は、セルのウィジェットを生成する関数createと、セルの内容をインデックスに合わせて更新する関数bindを設定します。
+// bindは、セルが表示範囲に入るたびに呼び出されます。再利用されたセルには、以前のインデックスの内容が残っている点に注意してください。
+func (g *GridView) SetCellFactory(create func() component.Widget, bind func(cell component.Widget, index int)) {
+	g.create, g.bind = create, bind
+	g.releaseAll(true)
+	g.MarkDirty(true)
+}
+
+// DefaultAccessibleRole は、component.DefaultRoleProviderインターフェースの実装です。
+func (g *GridView) DefaultAccessibleRole() component.Role {
+	return component.RoleGrid
+}
+
+// SetCount は、セルの数を設定します。範囲外になった選択は解除されます。
+func (g *GridView) SetCount(count int) {
+	count = max(count, 0)
+	if g.count == count {
+		return
+	}
+	g.count = count
+	if g.selected >= count {
+		g.Select(-1)
+	}
+	// 範囲外になったセルは、bindが範囲外のインデックスで呼び出されないよう、すぐに再利用に回します。
+	for _, index := range g.visibleIndices() {
+		cell := g.visible[index]
+		if index >= count {
+			g.release(index, cell)
+		}
+	}
+	g.MarkDirty(true)
+}
+
+// Count は、セルの数を返します。
+func (g *GridView) Count() int {
+	return g.count
+}
+
+// SetCellSize は、セルの幅と高さを設定します。
+func (g *GridView) SetCellSize(width, height int) {
+	if width <= 0 || height <= 0 || (g.cellW == width && g.cellH == height) {
+		return
+	}
+	g.cellW, g.cellH = width, height
+	g.MarkDirty(true)
+}
+
+// SetGap は、セル同士の間隔を設定します。
+func (g *GridView) SetGap(gap int) {
+	if gap < 0 || g.gap == gap {
+		return
+	}
+	g.gap = gap
+	g.MarkDirty(true)
+}
+
+// Columns は、現在の幅での列の数を返します。
+func (g *GridView) Columns() int {
+	w, _ := g.content.GetSize()
+	return g.columnsFor(w)
+}
+
+// columnsFor は、幅widthに収まる列の数を返します。最小は1です。
+func (g *GridView) columnsFor(width int) int {
+	return max(1, (width+g.gap)/(g.cellW+g.gap))
+}
+
+// Refresh は、表示中のすべてのセルに対してbindを呼び出し、内容を更新します。
+// セルの数を変えずにデータだけが変わった場合に使用します。
+func (g *GridView) Refresh() {
+	for _, index := range g.visibleIndices() {
+		cell := g.visible[index]
+		g.bindCell(cell, index)
+	}
+}
+
+// Select は、指定されたインデックスのセルを選択します。-1を指定すると選択を解除します。範囲外のインデックスは無視されます。
+func (g *GridView) Select(index int) {
+	if index < -1 || index >= g.count || g.selected == index {
+		return
+	}
+	g.markCellDirty(g.selected)
+	g.selected = index
+	g.markCellDirty(index)
+	for _, fn := range g.onSelectionChanged {
+		fn(index)
+	}
+}
+
+// Selected は、選択中のセルのインデックスを返します。選択がない場合は-1です。
+func (g *GridView) Selected() int {
+	return g.selected
+}
+
+// ClearSelection は、選択を解除します。
+func (g *GridView) ClearSelection() {
+	g.Select(-1)
+}
+
+// AddOnSelectionChanged は、選択中のセルが変化したときに呼び出される関数を追加します。
+func (g *GridView) AddOnSelectionChanged(fn func(index int)) {
+	if fn != nil {
+		g.onSelectionChanged = append(g.onSelectionChanged, fn)
+	}
+}
+
+// AddOnCellActivated は、セルが確定(ダブルクリック、またはフォーカス中のEnterキー)されたときに呼び出される関数を追加します。
+func (g *GridView) AddOnCellActivated(fn func(index int)) {
+	if fn != nil {
+		g.onCellActivated = append(g.onCellActivated, fn)
+	}
+}
+
+// ActivateCell は、指定されたインデックスのセルを確定し、確定時の関数を呼び出します。範囲外の場合は何もしません。
+func (g *GridView) ActivateCell(index int) {
+	if index < 0 || index >= g.count {
+		return
+	}
+	for _, fn := range g.onCellActivated {
+		fn(index)
+	}
+}
+
+// ScrollToCell は、指定されたインデックスのセルが表示範囲に入るようにスクロールします。
+func (g *GridView) ScrollToCell(index int) {
+	if index < 0 || index >= g.count {
+		return
+	}
+	g.scrollTarget = index
+	g.MarkDirty(true)
+}
+
+// SetSelectedStyle は、選択中のセルの強調に使用するスタイルを設定します。
+func (g *GridView) SetSelectedStyle(s style.Style) {
+	g.selectedStyle = style.Resolve(s)
+	g.MarkDirty(false)
+}
+
+// SetHoveredStyle は、ホバー中のセルの強調に使用するスタイルを設定します。
+func (g *GridView) SetHoveredStyle(s style.Style) {
+	g.hoveredStyle = style.Resolve(s)
+	g.MarkDirty(false)
+}
+
+// SetFocused は、Focusableインターフェースの実装です。
+func (g *GridView) SetFocused(focused bool) {
+	if g.focused != focused {
+		g.focused = focused
+		g.markCellDirty(g.selected)
+	}
+}
+
+// IsFocused は、グリッドがキーボードフォーカスを持っているかどうかを返します。
+func (g *GridView) IsFocused() bool {
+	return g.focused
+}
+
+// Update は、ScrollViewの更新の後、表示範囲のセルを用意して配置し、キー操作を処理します。
+func (g *GridView) Update() {
+	g.ScrollView.Update()
+	g.applyScrollTarget()
+	g.layoutCells()
+	g.handleKeys()
+}
+
+// layoutCells は、表示範囲から外れたセルを再利用に回し、表示範囲のセルを用意して格子状に配置します。
+func (g *GridView) layoutCells() {
+	if g.create == nil || !g.HasBeenLaidOut() {
+		return
+	}
+	_, viewH := g.GetSize()
+	padding := g.GetPadding()
+	viewH -= padding.Top + padding.Bottom
+	contentX, contentY := g.content.GetPosition()
+	contentW, _ := g.content.GetSize()
+	columns := g.columnsFor(contentW)
+	pitchX, pitchY := g.cellW+g.gap, g.cellH+g.gap
+
+	scrollY := int(g.GetScrollY())
+	first := min(g.count, scrollY/pitchY*columns)
+	last := min(g.count, ((scrollY+viewH)/pitchY+1)*columns)
+
+	for _, index := range g.visibleIndices() {
+		cell := g.visible[index]
+		if index < first || index >= last {
+			g.release(index, cell)
+		}
+	}
+	for index := first; index < last; index++ {
+		cell, ok := g.visible[index]
+		if !ok {
+			cell = g.acquire()
+			if cell == nil {
+				return
+			}
+			g.visible[index] = cell
+			g.bindCell(cell, index)
+		}
+		cell.SetSize(g.cellW, g.cellH)
+		cell.SetPosition(contentX+index%columns*pitchX, contentY+index/columns*pitchY)
+		if cell.IsDirty() {
+			// 新しく配置したセルは、このフレームの描画の前にレイアウトを済ませます。
+			cell.Update()
+		}
+	}
+}
+
+// acquire は、再利用を待っているセルを取り出すか、新しいセルを生成します。
+func (g *GridView) acquire() *gridCell {
+	if n := len(g.pool); n > 0 {
+		cell := g.pool[n-1]
+		g.pool = g.pool[:n-1]
+		cell.SetVisible(true)
+		return cell
+	}
+	w := g.create()
+	if w == nil {
+		return nil
+	}
+	c, err := container.NewContainer()
+	if err != nil {
+		return nil
+	}
+	c.SetLayout(&layout.FlexLayout{Direction: layout.DirectionColumn, AlignItems: layout.AlignStretch})
+	// セルの大きさは一定のため、内容の更新による再レイアウトをセルの中にとどめます。
+	c.SetLayoutBoundary(true)
+	c.AddChild(w)
+	cell := &gridCell{Container: c, widget: w, index: -1}
+	g.registerCellHandlers(cell)
+	g.content.AddChild(cell)
+	return cell
+}
+
+// release は、表示範囲から外れたセルを非表示にして再利用に回します。
+func (g *GridView) release(index int, cell *gridCell) {
+	delete(g.visible, index)
+	if g.hovered == index {
+		g.hovered = -1
+	}
+	cell.index = -1
+	cell.SetVisible(false)
+	g.pool = append(g.pool, cell)
+}
+
+// visibleIndices は、表示中のセルのインデックスを昇順で返します。
+// mapの走査順は実行ごとに異なるため、セルを再利用する順序(どのセルがどのインデックスに割り当てられるか)を安定させるために使用します。
+func (g *GridView) visibleIndices() []int {
+	return slices.Sorted(maps.Keys(g.visible))
+}
+
+// releaseAll は、表示中のセルをすべて再利用に回します。discardがtrueの場合は、セルを破棄します。
+func (g *GridView) releaseAll(discard bool) {
+	for _, index := range g.visibleIndices() {
+		cell := g.visible[index]
+		g.release(index, cell)
+	}
+	if discard {
+		g.content.ClearChildren()
+		g.pool = nil
+	}
+}
+
+// bindCell は、セルにインデックスを割り当て、内容を更新します。
+func (g *GridView) bindCell(cell *gridCell, index int) {
+	cell.index = index
+	if g.bind != nil {
+		g.bind(cell.widget, index)
+	}
+	cell.MarkDirty(true)
+}
+
+// registerCellHandlers は、セルの選択、確定、ホバーのイベントハンドラと、強調の描画フックを登録します。
+func (g *GridView) registerCellHandlers(cell *gridCell) {
+	cell.AddEventHandler(event.MouseDown, func(e *event.Event) event.Propagation {
+		if cell.index < 0 {
+			return event.Propagate
+		}
+		doubleClick := cell.index == g.lastPressIndex && e.Timestamp-g.lastPressTime <= gridDoubleClickDuration.Nanoseconds()
+		g.Select(cell.index)
+		if doubleClick {
+			g.lastPressIndex = -1
+			g.ActivateCell(cell.index)
+		} else {
+			g.lastPressIndex, g.lastPressTime = cell.index, e.Timestamp
+		}
+		return event.Propagate
+	})
+	cell.AddEventHandler(event.MouseEnter, func(e *event.Event) event.Propagation {
+		g.hovered = cell.index
+		cell.MarkDirty(false)
+		return event.Propagate
+	})
+	cell.AddEventHandler(event.MouseLeave, func(e *event.Event) event.Propagation {
+		if g.hovered == cell.index {
+			g.hovered = -1
+		}
+		cell.MarkDirty(false)
+		return event.Propagate
+	})
+	cell.AddOnBeforeDraw(func(info component.DrawInfo, bounds image.Rectangle) {
+		if cell.index < 0 {
+			return
+		}
+		x, y, w, h := bounds.Min.X, bounds.Min.Y, bounds.Dx(), bounds.Dy()
+		switch {
+		case cell.index == g.selected:
+			component.DrawComputedBackground(info.Screen, x, y, w, h, g.selectedStyle)
+		case cell.index == g.hovered:
+			component.DrawComputedBackground(info.Screen, x, y, w, h, g.hoveredStyle)
+		}
+		if g.focused && cell.index == g.selected {
+			component.DrawComputedBackground(info.Screen, x, y, w, h, g.focusedStyle)
+		}
+	})
+}
+
+// markCellDirty は、指定されたインデックスのセルが表示中であれば再描画を要求します。
+func (g *GridView) markCellDirty(index int) {
+	if cell, ok := g.visible[index]; ok {
+		cell.MarkDirty(false)
+	}
+}
+
+// applyScrollTarget は、ScrollToCellで予約されたセルが表示範囲に入るようにスクロール位置を調整します。
+func (g *GridView) applyScrollTarget() {
+	if g.scrollTarget < 0 || !g.HasBeenLaidOut() {
+		return
+	}
+	index := g.scrollTarget
+	g.scrollTarget = -1
+	if index >= g.count {
+		return
+	}
+	_, viewH := g.GetSize()
+	padding := g.GetPadding()
+	viewH -= padding.Top + padding.Bottom
+	top := float64(index / g.Columns() * (g.cellH + g.gap))
+	bottom := top + float64(g.cellH)
+	scrollY := g.GetScrollY()
+	switch {
+	case top < scrollY:
+		scrollY = top
+	case bottom > scrollY+float64(viewH):
+		scrollY = bottom - float64(viewH)
+	default:
+		return
+	}
+	g.SetScrollY(scrollY)
+	// コンテンツの位置はレイアウトで決まるため、再レイアウトを要求します。
+	g.MarkDirty(true)
+}
+
+// handleKeys は、フォーカス中のキー操作を処理します。グリッドの外側が押された場合はフォーカスを手放します。
+func (g *GridView) handleKeys() {
+	if !g.focused {
+		return
+	}
+	if !g.IsVisible() || g.IsDisabled() {
+		component.Blur(g)
+		return
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if cx, cy := ebiten.CursorPosition(); g.HitTest(cx, cy) == nil && component.HitTestPopups(cx, cy) == nil {
+			component.Blur(g)
+			return
+		}
+	}
+	if g.count == 0 {
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter) {
+		g.ActivateCell(g.selected)
+		return
+	}
+
+	columns := g.Columns()
+	target := -1
+	switch {
+	case keyRepeated(ebiten.KeyArrowLeft):
+		target = g.selected - 1
+	case keyRepeated(ebiten.KeyArrowRight):
+		target = g.selected + 1
+	case keyRepeated(ebiten.KeyArrowUp):
+		target = g.selected - columns
+	case keyRepeated(ebiten.KeyArrowDown):
+		target = g.selected + columns
+	case inpututil.IsKeyJustPressed(ebiten.KeyHome):
+		target = 0
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnd):
+		target = g.count - 1
+	default:
+		return
+	}
+	if g.selected < 0 {
+		// 選択がない場合は、どのキーでも最初のセルから始めます。
+		target = 0
+	}
+	target = min(max(target, 0), g.count-1)
+	g.Select(target)
+	g.ScrollToCell(target)
+}
+
+// Cleanup は、リソースを解放します。
+func (g *GridView) Cleanup() {
+	component.Blur(g)
+	g.releaseModel()
+	g.onSelectionChanged = nil
+	g.onCellActivated = nil
+	g.visible = nil
+	g.pool = nil
+	g.content.Cleanup()
+	g.ScrollView.Cleanup()
+}
+
+// --- GridViewBuilder ---
+
+// GridViewBuilder は、GridViewを宣言的に構築するためのビルダーです。
+type GridViewBuilder struct {
+	component.Builder[*GridViewBuilder, *GridView]
+}
+
+// NewGridViewBuilder は新しいGridViewBuilderを生成します。
+func NewGridViewBuilder() *GridViewBuilder {
+	g, err := newGridView()
+	b := &GridViewBuilder{}
+	b.Init(b, g)
+	b.AddError(err)
+	return b
+}
+
+// Cells は、セルの数と、セルのウィジェットを生成・更新する関数を設定します。
+func (b *GridViewBuilder) Cells(count int, create func() component.Widget, bind func(cell component.Widget, index int)) *GridViewBuilder {
+	if create == nil {
+		b.AddError(component.ErrNilChild)
+		return b
+	}
+	b.Widget.SetCellFactory(create, bind)
+	b.Widget.SetCount(count)
+	return b
+}
+
+// Model は、セルの数をデータモデルの要素数と同期させ、モデルの変更に合わせてセルを更新します。
+func (b *GridViewBuilder) Model(model binding.ListModel) *GridViewBuilder {
+	b.Widget.SetModel(model)
+	return b
+}
+
+// CellSize は、セルの幅と高さを設定します。
+func (b *GridViewBuilder) CellSize(width, height int) *GridViewBuilder {
+	if width <= 0 || height <= 0 {
+		b.AddError(component.ErrInvalidSize)
+		return b
+	}
+	b.Widget.SetCellSize(width, height)
+	return b
+}
+
+// Gap は、セル同士の間隔を設定します。
+func (b *GridViewBuilder) Gap(gap int) *GridViewBuilder {
+	if gap < 0 {
+		b.AddError(component.ErrInvalidSize)
+		return b
+	}
+	b.Widget.SetGap(gap)
+	return b
+}
+
+// Selected は、初期の選択を設定します。
+func (b *GridViewBuilder) Selected(index int) *GridViewBuilder {
+	b.Widget.Select(index)
+	return b
+}
+
+// SelectedStyle は、選択中のセルの強調に使用するスタイルを設定します。
+func (b *GridViewBuilder) SelectedStyle(s style.Style) *GridViewBuilder {
+	b.Widget.SetSelectedStyle(s)
+	return b
+}
+
+// HoveredStyle は、ホバー中のセルの強調に使用するスタイルを設定します。
+func (b *GridViewBuilder) HoveredStyle(s style.Style) *GridViewBuilder {
+	b.Widget.SetHoveredStyle(s)
+	return b
+}
+
+// OnSelectionChanged は、選択中のセルが変化したときに呼び出される関数を追加します。
+func (b *GridViewBuilder) OnSelectionChanged(fn func(index int)) *GridViewBuilder {
+	b.Widget.AddOnSelectionChanged(fn)
+	return b
+}
+
+// OnCellActivated は、セルが確定(ダブルクリック、またはフォーカス中のEnterキー)されたときに呼び出される関数を追加します。
+func (b *GridViewBuilder) OnCellActivated(fn func(index int)) *GridViewBuilder {
+	b.Widget.AddOnCellActivated(fn)
+	return b
+}
+
+// Build は、最終的なGridViewを構築して返します。
+func (b *GridViewBuilder) Build() (*GridView, error) {
+	return b.Builder.Build()
+}
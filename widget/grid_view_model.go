@@ -0,0 +1,79 @@
+package widget
+
+import "furoshiki/binding"
+
+// このファイルは、GridViewのセルをデータモデル(binding.ListModel)と同期させる機能を提供します。
+//
+//	photos := binding.NewList(loadPhotos()...)
+//	grid.SetCellFactory(newThumbnail, func(cell component.Widget, index int) {
+//		cell.(*Thumbnail).SetPhoto(photos.At(index))
+//	})
+//	grid.SetModel(photos)
+//
+// セルの数はモデルの要素数に追従します。要素の置き換えでは該当するセルが表示中の場合だけbindが呼び出され、
+// 挿入・削除・移動ではインデックスがずれるため、表示中のセルがすべてbindし直されます。
+// 選択は、同じ要素を指し続けるようにインデックスが調整されます。
+
+// SetModel は、セルの数をデータモデルの要素数と同期させ、モデルの変更に合わせてセルを更新します。
+// セルの内容はSetCellFactoryで指定したbindで、モデルの要素から設定してください。
+// modelにnilを指定すると、モデルとの同期を解除します(セルの数はそのまま残ります)。
+func (g *GridView) SetModel(model binding.ListModel) {
+	g.releaseModel()
+	if model == nil {
+		return
+	}
+	g.model = model
+	g.Select(-1)
+	g.SetCount(model.Len())
+	g.Refresh()
+	g.unbindModel = model.Subscribe(g.applyModelChange)
+}
+
+// Model は、セルの元になるデータモデルを返します。設定されていない場合はnilです。
+func (g *GridView) Model() binding.ListModel {
+	return g.model
+}
+
+// releaseModel は、データモデルの変更通知の購読を解除します。
+func (g *GridView) releaseModel() {
+	if g.unbindModel != nil {
+		g.unbindModel()
+	}
+	g.model, g.unbindModel = nil, nil
+}
+
+// applyModelChange は、モデルの変更内容をセルの数、選択、表示中のセルに反映します。
+func (g *GridView) applyModelChange(change binding.Change) {
+	switch change.Kind {
+	case binding.ChangeUpdate:
+		if cell, ok := g.visible[change.Index]; ok {
+			g.bindCell(cell, change.Index)
+		}
+		return
+	case binding.ChangeInsert:
+		if g.selected >= change.Index {
+			g.selected += change.Count
+		}
+	case binding.ChangeRemove:
+		switch {
+		case g.selected >= change.Index+change.Count:
+			g.selected -= change.Count
+		case g.selected >= change.Index:
+			g.Select(-1)
+		}
+	case binding.ChangeMove:
+		switch {
+		case g.selected == change.OldIndex:
+			g.selected = change.Index
+		case change.OldIndex < g.selected && g.selected <= change.Index:
+			g.selected--
+		case change.Index <= g.selected && g.selected < change.OldIndex:
+			g.selected++
+		}
+	case binding.ChangeReset:
+		g.Select(-1)
+	}
+	g.SetCount(g.model.Len())
+	g.Refresh()
+	g.MarkDirty(true)
+}
@@ -0,0 +1,224 @@
+package widget
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+// ErrInvalidInputMask は、入力マスクのパターンが不正な場合のエラーです。
+var ErrInvalidInputMask = errors.New("invalid input mask")
+
+// よく使われる入力マスクのパターンです。
+const (
+	// MaskDate は、日付(例: 12/31/2024)の入力マスクです。
+	MaskDate = "##/##/####"
+	// MaskTime は、時刻(例: 23:59)の入力マスクです。
+	MaskTime = "##:##"
+	// MaskPhone は、電話番号(例: (555) 123-4567)の入力マスクです。
+	MaskPhone = "(###) ###-####"
+	// MaskHexColor は、16進数の色(例: #1E90FF)の入力マスクです。
+	MaskHexColor = `\#HHHHHH`
+)
+
+// maskSlot は、入力マスクの1文字分の定義です。acceptがnilの場合は固定の文字(literal)です。
+type maskSlot struct {
+	literal rune
+	accept  func(r rune) bool
+}
+
+// InputMask は、入力できる文字の種類と位置を制限し、区切りなどの固定の文字を自動的に挿入するパターンです。
+//
+// パターンでは、次の文字が1文字分の入力位置を表し、それ以外の文字は固定の文字としてそのまま表示されます。
+//
+//	#  数字 (0-9)
+//	A  文字
+//	H  16進数の数字 (0-9, a-f, A-F)
+//	*  任意の文字
+//	\  次の文字を固定の文字として扱います (例: `\#` は "#" を表示します)
+//
+// 固定の文字は、その後ろの入力位置に文字が入力された時点で挿入されます。
+type InputMask struct {
+	pattern string
+	slots   []maskSlot
+}
+
+// NewInputMask は、パターンから入力マスクを生成します。
+// 入力位置を1つも含まない場合や、パターンが "\" で終わる場合はエラーを返します。
+func NewInputMask(pattern string) (*InputMask, error) {
+	m := &InputMask{pattern: pattern}
+	escaped := false
+	inputs := 0
+	for _, r := range pattern {
+		if escaped {
+			m.slots = append(m.slots, maskSlot{literal: r})
+			escaped = false
+			continue
+		}
+		var accept func(rune) bool
+		switch r {
+		case '\\':
+			escaped = true
+			continue
+		case '#':
+			accept = func(r rune) bool { return r >= '0' && r <= '9' }
+		case 'A':
+			accept = unicode.IsLetter
+		case 'H':
+			accept = func(r rune) bool {
+				return (r >= '0' && r <= '9') || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')
+			}
+		case '*':
+			accept = unicode.IsPrint
+		}
+		if accept != nil {
+			inputs++
+		}
+		m.slots = append(m.slots, maskSlot{literal: r, accept: accept})
+	}
+	if escaped || inputs == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidInputMask, pattern)
+	}
+	return m, nil
+}
+
+// Pattern は、入力マスクのパターンを返します。
+func (m *InputMask) Pattern() string {
+	return m.pattern
+}
+
+// Raw は、文字列から固定の文字と受け付けられない文字を取り除き、入力位置に当てはまる文字だけを返します。
+// 書式済みの文字列と、固定の文字を含まない文字列のどちらも受け付けます。
+func (m *InputMask) Raw(s string) string {
+	raw := make([]rune, 0, len(m.slots))
+	i := 0
+	for _, r := range s {
+		if i >= len(m.slots) {
+			break
+		}
+		// 現在の位置の固定の文字と一致する文字は、書式の一部として読み飛ばします。
+		if m.slots[i].accept == nil && m.slots[i].literal == r {
+			i++
+			continue
+		}
+		j := i
+		for j < len(m.slots) && m.slots[j].accept == nil {
+			j++
+		}
+		if j < len(m.slots) && m.slots[j].accept(r) {
+			raw = append(raw, r)
+			i = j + 1
+		}
+	}
+	return string(raw)
+}
+
+// Format は、入力位置に当てはまる文字の並びに固定の文字を挿入した文字列を返します。
+// 固定の文字は最後の入力文字より後ろには挿入されません。入力位置に当てはまらない文字は取り除かれます。
+func (m *InputMask) Format(raw string) string {
+	out := make([]rune, 0, len(m.slots))
+	i := 0
+	for _, r := range raw {
+		j := i
+		for j < len(m.slots) && m.slots[j].accept == nil {
+			j++
+		}
+		if j >= len(m.slots) {
+			break
+		}
+		if !m.slots[j].accept(r) {
+			continue
+		}
+		for ; i < j; i++ {
+			out = append(out, m.slots[i].literal)
+		}
+		out = append(out, r)
+		i = j + 1
+	}
+	return string(out)
+}
+
+// IsComplete は、すべての入力位置に文字が入力されているかどうかを返します。
+func (m *InputMask) IsComplete(s string) bool {
+	n := 0
+	for _, slot := range m.slots {
+		if slot.accept != nil {
+			n++
+		}
+	}
+	return len([]rune(m.Raw(s))) == n
+}
+
+// positionAfterRaw は、書式済みの文字列において、先頭からn文字目の入力文字の直後の位置を返します。
+func (m *InputMask) positionAfterRaw(formatted []rune, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	count := 0
+	for i := range formatted {
+		if i < len(m.slots) && m.slots[i].accept != nil {
+			count++
+			if count == n {
+				return i + 1
+			}
+		}
+	}
+	return len(formatted)
+}
+
+// --- TextInput ---
+
+// SetInputMask は、入力マスクを設定します。nilを指定すると解除します。
+// 現在の値は、新しい入力マスクに合わせて書式が整えられます。
+func (t *TextInput) SetInputMask(m *InputMask) {
+	t.inputMask = m
+	if m != nil {
+		t.SetValue(t.Value())
+	}
+}
+
+// InputMask は、設定されている入力マスクを返します。設定されていない場合はnilです。
+func (t *TextInput) InputMask() *InputMask {
+	return t.inputMask
+}
+
+// RawValue は、入力マスクの固定の文字を取り除いた値を返します。入力マスクがない場合はValue()と同じです。
+func (t *TextInput) RawValue() string {
+	if t.inputMask == nil {
+		return t.Value()
+	}
+	return t.inputMask.Raw(t.Value())
+}
+
+// IsMaskComplete は、入力マスクのすべての入力位置に文字が入力されているかどうかを返します。
+// 入力マスクがない場合は常にtrueです。
+func (t *TextInput) IsMaskComplete() bool {
+	return t.inputMask == nil || t.inputMask.IsComplete(t.Value())
+}
+
+// applyMask は、編集後の値を入力マスクに合わせて書式を整え、キャレットの前にあった入力文字の数を保つように
+// キャレットを移動します。
+func (t *TextInput) applyMask() {
+	if t.inputMask == nil {
+		return
+	}
+	rawBefore := len([]rune(t.inputMask.Raw(string(t.runes[:t.cursor]))))
+	formatted := []rune(t.inputMask.Format(t.inputMask.Raw(string(t.runes))))
+	t.runes = formatted
+	t.cursor = t.inputMask.positionAfterRaw(formatted, rawBefore)
+	t.anchor = t.cursor
+}
+
+// --- TextInputBuilder ---
+
+// Mask は、パターンから入力マスクを生成して設定します。パターンの書式はInputMaskを参照してください。
+// 例: t.Mask(widget.MaskDate)
+func (b *TextInputBuilder) Mask(pattern string) *TextInputBuilder {
+	m, err := NewInputMask(pattern)
+	if err != nil {
+		b.AddError(err)
+		return b
+	}
+	b.Widget.SetInputMask(m)
+	return b
+}
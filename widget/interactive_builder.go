@@ -49,6 +49,11 @@ func (b *InteractiveTextBuilder[T, W]) DisabledStyle(s style.Style) T {
 	return b.SetStyleForState(component.StateDisabled, s)
 }
 
+// FocusedStyle sets the style for the Focused state.
+func (b *InteractiveTextBuilder[T, W]) FocusedStyle(s style.Style) T {
+	return b.SetStyleForState(component.StateFocused, s)
+}
+
 // Style overrides the base Style method to set the base style for the widget.
 // NOTE: このメソッドはウィジェットの基本スタイル（Normal状態の基礎）を設定します。
 // 以前はNormal状態のスタイルのみを設定していましたが、StyleManagerの導入に伴い、
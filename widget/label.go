@@ -8,6 +8,10 @@ import (
 // Labelはテキストを表示するためのシンプルなウィジェットです。
 type Label struct {
 	*component.TextWidget
+	// autoFit は、表示領域に合わせたフォントサイズの自動調整の設定です。詳細は label_autofit.go を参照してください。
+	autoFit labelAutoFit
+	// marquee は、幅に収まらないテキストを横にスクロールさせる設定です。詳細は label_marquee.go を参照してください。
+	marquee labelMarquee
 }
 
 // newLabelは、ラベルウィジェットの新しいインスタンスを生成し、初期化します。
@@ -19,6 +23,8 @@ func newLabel(text string) (*Label, error) {
 	if err := label.Init(label); err != nil {
 		return nil, err
 	}
+	// 自動調整の最小サイズも、テキストの最小サイズと同じくキャッシュを介して計測します。
+	label.SetContentMinSizeFunc(label.contentMinSize)
 
 	t := theme.GetCurrent()
 	label.SetStyle(t.Label.Default)
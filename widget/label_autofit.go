@@ -0,0 +1,172 @@
+package widget
+
+import (
+	"errors"
+	"furoshiki/component"
+	"furoshiki/style"
+	"furoshiki/theme"
+	"furoshiki/utils"
+
+	"golang.org/x/image/font"
+)
+
+// ErrInvalidAutoFit は、AutoFitのサイズ範囲が不正な場合のエラーです。
+var ErrInvalidAutoFit = errors.New("auto-fit size range must satisfy 0 < minSize <= maxSize")
+
+// labelAutoFit は、ラベルの表示領域に合わせてフォントサイズを選ぶための設定と、直近の選択結果です。
+type labelAutoFit struct {
+	enabled          bool
+	minSize, maxSize float64
+
+	// 直近に選択したフェイスと、その選択に使った入力です。サイズやテキストが変わるまで再利用します。
+	valid         bool
+	width, height int
+	text          string
+	wrap          bool
+	face          font.Face
+}
+
+// SetAutoFit は、テーマのFacesのうちminSizeからmaxSizeまでのサイズの中から、
+// テキストがラベルの領域に収まる最大のフェイスを選んで描画するよう設定します。
+// フェイスはラベルのサイズが変わるたびに選び直されます。
+// 範囲内に収まるフェイスがない場合は最も小さいフェイスを、範囲内にフェイスがない場合はスタイルのフォントを使用します。
+func (l *Label) SetAutoFit(minSize, maxSize float64) {
+	l.autoFit = labelAutoFit{enabled: true, minSize: minSize, maxSize: maxSize}
+	l.InvalidateMeasure()
+}
+
+// DisableAutoFit は、フォントサイズの自動調整を無効にし、スタイルのフォントで描画するよう戻します。
+func (l *Label) DisableAutoFit() {
+	if l.autoFit.enabled {
+		l.autoFit = labelAutoFit{}
+		l.InvalidateMeasure()
+	}
+}
+
+// candidateFaces は、自動調整で選択対象となるフェイスを昇順で返します。
+func (l *Label) candidateFaces() theme.FaceSet {
+	return theme.GetCurrent().Faces.Between(l.autoFit.minSize, l.autoFit.maxSize)
+}
+
+// contentMinSize は、コンテンツの最小サイズを計算します。計測結果は計測キャッシュに記録されます。
+// 自動調整が有効な場合は、範囲内で最も小さいフェイスで計測した最小サイズを返します。
+// これにより、レイアウトはラベルを最大のフォントサイズが必要とする幅より小さく縮められるようになります。
+func (l *Label) contentMinSize() (int, int) {
+	if l.autoFit.enabled {
+		if faces := l.candidateFaces(); len(faces) > 0 {
+			c := l.ComputedStyle()
+			fw, fh := measureFace(faces[0].Face, l.Text())
+			if l.IsWrapText() {
+				fw = 0
+			}
+			return fw + c.Padding.Left + c.Padding.Right, fh + c.Padding.Top + c.Padding.Bottom
+		}
+	}
+	return l.ContentMinSize()
+}
+
+// GetMinSize は、キャッシュされたコンテンツの最小サイズとユーザーが設定した最小サイズのうち、大きい方を返します。
+// マーキー表示が有効な場合、テキストはスクロールして表示されるため、テキストの幅は最小幅に含めません。
+func (l *Label) GetMinSize() (int, int) {
+	w, h := l.TextWidget.GetMinSize()
+	if l.marquee.enabled && !l.IsWrapText() {
+		userW, _ := l.UserMinSize()
+		c := l.ComputedStyle()
+		w = max(userW, c.Padding.Left+c.Padding.Right)
+	}
+	return w, h
+}
+
+// measureFace は、テキストを1行で描画した場合の幅と高さを返します。
+func measureFace(f font.Face, s string) (int, int) {
+	m := component.FaceMetrics(f)
+	return component.MeasureString(f, s).Ceil(), (m.Ascent + m.Descent).Ceil()
+}
+
+// fittedFace は、現在のサイズとテキストに対して自動調整で選ばれたフェイスを返します。
+// 自動調整が無効、または候補となるフェイスがない場合はnilを返します。
+func (l *Label) fittedFace() font.Face {
+	a := &l.autoFit
+	if !a.enabled {
+		return nil
+	}
+	width, height := l.GetSize()
+	if a.valid && a.width == width && a.height == height && a.text == l.Text() && a.wrap == l.IsWrapText() {
+		return a.face
+	}
+	faces := l.candidateFaces()
+	if len(faces) == 0 {
+		return nil
+	}
+
+	c := l.ComputedStyle()
+	contentW := width - c.Padding.Left - c.Padding.Right
+	contentH := height - c.Padding.Top - c.Padding.Bottom
+	chosen := faces[0].Face
+	// 大きいサイズから順に試し、最初に収まったものを採用します。
+	for i := len(faces) - 1; i >= 0; i-- {
+		f := faces[i].Face
+		var fits bool
+		if l.IsWrapText() {
+			_, h := component.CalculateWrappedTextWithBreak(f, l.Text(), contentW, c.LineBreak)
+			fits = h <= contentH && longestWordFits(f, l.Text(), contentW, c.LineBreak)
+		} else {
+			w, h := measureFace(f, l.Text())
+			fits = w <= contentW && h <= contentH
+		}
+		if fits {
+			chosen = f
+			break
+		}
+	}
+	*a = labelAutoFit{
+		enabled: true, minSize: a.minSize, maxSize: a.maxSize,
+		valid: true, width: width, height: height, text: l.Text(), wrap: l.IsWrapText(), face: chosen,
+	}
+	return chosen
+}
+
+// longestWordFits は、折り返しても1行に収まらない単語がないかを確認します。
+func longestWordFits(f font.Face, s string, maxWidth int, mode style.LineBreakType) bool {
+	for _, word := range utils.SplitIntoWords(s) {
+		for _, unit := range component.LineBreakUnits(word, mode) {
+			if component.MeasureString(f, unit).Ceil() > maxWidth {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// drawStyle は、自動調整で選ばれたフェイスを反映した描画用のスタイルを返します。
+func (l *Label) drawStyle() style.Computed {
+	c := l.ComputedStyle()
+	if f := l.fittedFace(); f != nil {
+		c.Font = f
+	}
+	return c
+}
+
+// Draw は、自動調整が有効な場合は選ばれたフェイスで描画します。
+// マーキー表示が有効でテキストが収まらない場合は、スクロール位置に合わせて描画します。
+func (l *Label) Draw(info component.DrawInfo) {
+	c := l.drawStyle()
+	if l.drawMarquee(info, c) {
+		return
+	}
+	l.DrawWithComputedStyle(info, c)
+}
+
+// --- LabelBuilder ---
+
+// AutoFit は、テキストがラベルの領域に収まる最大のフォントサイズを、テーマのFacesから自動的に選ぶよう設定します。
+// スコア表示や、レスポンシブなレイアウト内のボタンなどに使用します。
+// 例: l.Text("12,345").AutoFit(12, 48).Flex(1)
+func (b *LabelBuilder) AutoFit(minSize, maxSize float64) *LabelBuilder {
+	if minSize <= 0 || minSize > maxSize {
+		b.AddError(ErrInvalidAutoFit)
+		return b
+	}
+	b.Widget.SetAutoFit(minSize, maxSize)
+	return b
+}
@@ -0,0 +1,163 @@
+package widget
+
+import (
+	"errors"
+	"furoshiki/clock"
+	"furoshiki/component"
+	"furoshiki/stats"
+	"furoshiki/style"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+// MarqueeMode は、幅に収まらないテキストをスクロールさせる方法を定義します。
+type MarqueeMode int
+
+const (
+	// MarqueeLoop は、テキストを一方向に流し続け、末尾の後に間隔を空けて先頭を続けて表示します。ニュースティッカーなどに使用します。
+	MarqueeLoop MarqueeMode = iota
+	// MarqueeBounce は、テキストの先頭と末尾の間を往復させます。曲名やアイテム名などに使用します。
+	MarqueeBounce
+)
+
+// marqueeGap は、MarqueeLoopで末尾と次の先頭の間に空ける幅です。
+const marqueeGap = 32
+
+// ErrInvalidMarqueeSpeed は、マーキーの速度に0以下の値が指定された場合のエラーです。
+var ErrInvalidMarqueeSpeed = errors.New("marquee speed must be positive")
+
+// labelMarquee は、マーキー表示の設定と、現在のスクロール位置です。
+type labelMarquee struct {
+	enabled bool
+	mode    MarqueeMode
+	// speed は、1秒あたりにスクロールするピクセル数です。
+	speed float64
+	// offset は、テキストの先頭が左にずれているピクセル数です。
+	offset float64
+	// direction は、MarqueeBounceでのスクロール方向(1または-1)です。
+	direction float64
+}
+
+// SetMarquee は、幅に収まらないテキストを指定された速度(ピクセル/秒)で横にスクロールさせるよう設定します。
+// テキストが収まる場合はスクロールせず、通常どおりに描画されます。
+// マウスカーソルがラベル上にある間はスクロールを一時停止します。
+func (l *Label) SetMarquee(mode MarqueeMode, speed float64) {
+	l.marquee = labelMarquee{enabled: speed > 0, mode: mode, speed: speed, direction: 1}
+	l.MarkDirty(false)
+}
+
+// DisableMarquee は、マーキー表示を無効にします。
+func (l *Label) DisableMarquee() {
+	if l.marquee.enabled {
+		l.marquee = labelMarquee{}
+		l.MarkDirty(false)
+	}
+}
+
+// marqueeOverflow は、マーキー表示が有効でテキストが収まらない場合に、収まらない幅(ピクセル)を返します。
+func (l *Label) marqueeOverflow(c style.Computed) (textWidth, overflow int) {
+	if !l.marquee.enabled || c.Font == nil || l.Text() == "" || l.IsWrapText() {
+		return 0, 0
+	}
+	width, _ := l.GetSize()
+	textWidth = component.MeasureString(c.Font, l.Text()).Ceil()
+	return textWidth, textWidth - (width - c.Padding.Left - c.Padding.Right)
+}
+
+// Update は、マーキー表示が有効な場合にスクロール位置を1フレーム分進めます。
+func (l *Label) Update() {
+	l.TextWidget.Update()
+	m := &l.marquee
+	if !m.enabled || !l.IsVisible() {
+		return
+	}
+	c := l.drawStyle()
+	textWidth, overflow := l.marqueeOverflow(c)
+	if overflow <= 0 {
+		m.offset = 0
+		return
+	}
+	// 決定的モードではスクロールせず、テキストの先頭を表示し続けます。
+	if clock.IsDeterministic() {
+		m.offset, m.direction = 0, 1
+		return
+	}
+	if l.IsHovered() {
+		return
+	}
+	step := m.speed / float64(ebiten.TPS())
+	switch m.mode {
+	case MarqueeBounce:
+		m.offset += step * m.direction
+		if m.offset >= float64(overflow) {
+			m.offset, m.direction = float64(overflow), -1
+		} else if m.offset <= 0 {
+			m.offset, m.direction = 0, 1
+		}
+	default:
+		m.offset += step
+		if period := float64(textWidth + marqueeGap); m.offset >= period {
+			m.offset -= period
+		}
+	}
+	// スクロール位置はレイアウトに影響しないため、再描画のみを要求します。
+	l.MarkDirty(false)
+}
+
+// drawMarquee は、コンテンツ領域で切り取りながら、スクロール位置に合わせてテキストを描画します。
+// テキストが収まる場合はfalseを返し、呼び出し側が通常の描画を行います。
+func (l *Label) drawMarquee(info component.DrawInfo, c style.Computed) bool {
+	textWidth, overflow := l.marqueeOverflow(c)
+	if overflow <= 0 || !l.IsVisible() || !l.HasBeenLaidOut() {
+		return false
+	}
+	x, y := l.GetPosition()
+	width, height := l.GetSize()
+	finalX, finalY := x+info.OffsetX, y+info.OffsetY
+	component.DrawComputedBackground(info.Screen, finalX, finalY, width, height, c)
+
+	content := image.Rect(finalX+c.Padding.Left, finalY+c.Padding.Top, finalX+width-c.Padding.Right, finalY+height-c.Padding.Bottom)
+	if content.Empty() {
+		return true
+	}
+	m := component.FaceMetrics(c.Font)
+	lineHeight := (m.Ascent + m.Descent).Ceil()
+	baseline := content.Min.Y + m.Ascent.Ceil()
+	switch c.VerticalAlign {
+	case style.VerticalAlignMiddle:
+		baseline += (content.Dy() - lineHeight) / 2
+	case style.VerticalAlignBottom:
+		baseline = content.Max.Y - lineHeight + m.Ascent.Ceil()
+	}
+
+	// テキストはバッチを経由せずに描画されるため、先に蓄積された背景を描画して順序を保ちます。
+	component.FlushDraws()
+	clipped := info.Screen.SubImage(content).(*ebiten.Image)
+	textX := content.Min.X - int(l.marquee.offset)
+	display := component.VisualLine(l.Text(), component.IsRTL(l.Text()))
+	text.Draw(clipped, display, c.Font, textX, baseline, c.TextColor)
+	stats.AddDrawCalls(1)
+	if l.marquee.mode == MarqueeLoop {
+		// 末尾が見えている間は、間隔を空けて先頭を続けて描画します。
+		if next := textX + textWidth + marqueeGap; next < content.Max.X {
+			text.Draw(clipped, display, c.Font, next, baseline, c.TextColor)
+			stats.AddDrawCalls(1)
+		}
+	}
+	return true
+}
+
+// --- LabelBuilder ---
+
+// Marquee は、幅に収まらないテキストを指定された速度(ピクセル/秒)で横にスクロールさせます。
+// 例: l.Text(songTitle).Marquee(widget.MarqueeBounce, 30).Size(120, 20)
+func (b *LabelBuilder) Marquee(mode MarqueeMode, speed float64) *LabelBuilder {
+	if speed <= 0 {
+		b.AddError(ErrInvalidMarqueeSpeed)
+		return b
+	}
+	b.Widget.SetMarquee(mode, speed)
+	return b
+}
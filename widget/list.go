@@ -0,0 +1,676 @@
+package widget
+
+import (
+	"furoshiki/binding"
+	"furoshiki/component"
+	"furoshiki/container"
+	"furoshiki/event"
+	"furoshiki/layout"
+	"furoshiki/style"
+	"furoshiki/theme"
+	"image"
+	"image/color"
+	"math"
+	"slices"
+)
+
+// List は、項目を縦に並べてスクロール表示し、選択中の項目を管理するウィジェットです。
+// 各項目は行のコンテナに包まれ、行のクリックで選択されます。
+// ホバー中の行と選択中の行は、テーマ(theme.ListTheme)のスタイルで強調されます。
+// 項目には文字列(SetItems)のほか、任意のウィジェット(AddItem)を使用できます。
+// 複数選択については list_selection.go を、キーボード操作については list_navigation.go を、
+// ドラッグによる並べ替えについては list_reorder.go を、行の操作ボタンについては list_actions.go を、
+// ページ単位の読み込みについては list_loading.go を、
+// データモデル(binding.ListModel)による項目の差分更新については list_model.go を参照してください。
+type List struct {
+	*ScrollView
+	content *container.Container
+	rows    []*listRow
+	// mode は、単一選択か複数選択かを表します。
+	mode SelectionMode
+	// cursor は、最後にクリックまたは選択された行(現在の行)のインデックスです。ない場合は-1です。
+	// 複数選択では、現在の行が選択されていない場合もあります。
+	cursor int
+	// anchor は、Shift+クリックによる範囲選択の起点となる行のインデックスです。ない場合は-1です。
+	anchor  int
+	focused bool
+	// scrollTarget は、次のレイアウトの後に表示範囲へスクロールする項目のインデックスです。ない場合は-1です。
+	scrollTarget int
+
+	itemStyle     style.Style
+	hoveredStyle  style.Computed
+	selectedStyle style.Computed
+	focusedStyle  style.Computed
+
+	// reorderable は、ドラッグによる並べ替えが有効かどうかを表します。
+	reorderable bool
+	// dragHandles は、各行の右端にドラッグハンドルを表示するかどうかを表します。
+	dragHandles     bool
+	dragHandleColor color.Color
+	drag            listDrag
+
+	// loading は、読み込み中かどうかを表します。読み込み中は、末尾にloadingFooterが表示されます。
+	loading       bool
+	loadingFooter *container.Container
+	loadingColor  color.Color
+	spinnerTicks  int
+
+	// rowActions は、行の操作ボタンです。ホバー中の行に重ねて表示され、行を左へスワイプすると行の右側に現れます。
+	rowActions  []ListAction
+	actionStyle style.Computed
+	swipe       listSwipe
+
+	// pinnedRow は、他の行より前面に描画する行(SectionedListの固定表示中の見出しなど)です。ない場合はnilです。
+	pinnedRow *listRow
+
+	// model は、項目の元になるデータです。SetModelで設定されていない場合はnilです。
+	model        binding.ListModel
+	modelFactory func(index int) (component.Widget, error)
+	unbindModel  func()
+
+	onSelectionChanged []func(index int)
+	onActivate         []func(index int)
+	onReorder          []func(from, to int)
+	onRowAction        []func(index int, action string)
+}
+
+// listRow は、1つの項目を包む行です。強調の背景は描画フックで行の下に描画します。
+type listRow struct {
+	*container.Container
+	item     component.Widget
+	hovered  bool
+	selected bool
+	// header は、選択できない見出しの行かどうかを表します。見出しはSectionedListが使用します。
+	header bool
+	// collapsed は、見出しの行が表す区分が折りたたまれているかどうかを表します。
+	collapsed bool
+	// baseX, baseY は、レイアウトが決めた行の位置です。
+	baseX, baseY int
+	// offset は、並べ替え中に行を縦にずらして表示する量です。
+	offset float64
+	// swipeX は、操作ボタンを表示するために行を横にずらして表示する量です。左へずらす場合は負の値です。
+	swipeX float64
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ component.Container = (*List)(nil)
+var _ component.Focusable = (*List)(nil)
+
+// newList は、Listの新しいインスタンスを生成し、初期化します。
+// NOTE: ウィジェットの生成には常にNewListBuilder()を使用してください。
+func newList() (*List, error) {
+	l := &List{ScrollView: &ScrollView{}}
+	if err := l.initList(l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// initList は、Listを初期化します。selfには、Listを埋め込む具象ウィジェット(例: *SectionedList)を渡します。
+func (l *List) initList(self component.Container) error {
+	l.cursor, l.anchor, l.scrollTarget = -1, -1, -1
+	if err := l.initScrollView(self); err != nil {
+		return err
+	}
+	content, err := container.NewContainer()
+	if err != nil {
+		return err
+	}
+	content.SetLayout(&layout.FlexLayout{Direction: layout.DirectionColumn, AlignItems: layout.AlignStretch})
+	l.content = content
+	l.SetContent(content)
+
+	t := theme.GetCurrent()
+	l.itemStyle = t.List.Item
+	l.hoveredStyle = style.Resolve(t.List.Hovered)
+	l.selectedStyle = style.Resolve(t.List.Selected)
+	l.focusedStyle = style.Resolve(t.List.Focused)
+	l.dragHandleColor = t.List.DragHandleColor
+	l.loadingColor = t.PrimaryColor
+	l.actionStyle = style.Resolve(t.List.Action)
+	if l.actionStyle.Font == nil {
+		l.actionStyle.Font = t.DefaultFont
+	}
+
+	// スワイプで現れる操作ボタンは、ずれた行の右側の空いた領域に見えるよう、行より先に描画します。
+	content.AddOnBeforeDraw(func(info component.DrawInfo, bounds image.Rectangle) {
+		l.drawSwipeActions(info.Screen)
+	})
+	// 操作ボタンの押下は、行と、行の右側の空いた領域のどちらからもここに届きます。
+	content.AddEventHandler(event.MouseDown, func(e *event.Event) event.Propagation {
+		l.handleActionPress(e)
+		return event.Propagate
+	})
+
+	// ドラッグ中の行は、後ろの行に隠れないよう、コンテンツの描画の後にもう一度描画します。
+	content.AddOnAfterDraw(func(info component.DrawInfo, bounds image.Rectangle) {
+		if l.pinnedRow != nil {
+			component.DrawWidget(l.pinnedRow, info)
+		}
+		if l.drag.dragging {
+			component.DrawWidget(l.drag.row, info)
+		}
+	})
+
+	// 行の上での押下も伝播してくるため、リストのどこをクリックしてもフォーカスを取得します。
+	l.AddEventHandler(event.MouseDown, func(e *event.Event) event.Propagation {
+		component.SetFocus(l)
+		return event.Propagate
+	})
+	return nil
+}
+
+// DefaultAccessibleRole は、component.DefaultRoleProviderインターフェースの実装です。
+func (l *List) DefaultAccessibleRole() component.Role {
+	return component.RoleList
+}
+
+// SetItems は、すべての項目を、文字列を表示するラベルの行で置き換えます。選択は解除されます。
+func (l *List) SetItems(items []string) error {
+	l.ClearItems()
+	return l.AppendItems(items)
+}
+
+// AppendItems は、文字列を表示するラベルの行を末尾に追加します。ページ単位で項目を読み込む場合などに使用します。
+func (l *List) AppendItems(items []string) error {
+	for _, text := range items {
+		label, err := newLabel(text)
+		if err != nil {
+			return err
+		}
+		if err := l.AddItem(label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddItem は、ウィジェットを項目として末尾に追加します。
+// 行の高さは、追加した時点でのウィジェットの最小の高さ(または設定された高さ)に行の余白を加えたものです。
+func (l *List) AddItem(item component.Widget) error {
+	if item == nil {
+		return component.ErrNilChild
+	}
+	row, err := l.newRow(item, l.itemStyle)
+	if err != nil {
+		return err
+	}
+	l.insertRow(len(l.rows), row)
+	return nil
+}
+
+// insertRow は、行をindex番目に挿入し、現在の行と範囲選択の起点のインデックスを調整します。
+func (l *List) insertRow(index int, row *listRow) {
+	// 読み込み中の行が常に末尾に残るよう、行の並びと同じ位置に挿入します。
+	l.content.InsertChildAt(index, row)
+	l.rows = slices.Insert(l.rows, index, row)
+	if l.cursor >= index {
+		l.cursor++
+	}
+	if l.anchor >= index {
+		l.anchor++
+	}
+}
+
+// isSelectable は、指定されたインデックスの行を選択できるかどうかを返します。見出しの行と非表示の行は選択できません。
+func (l *List) isSelectable(index int) bool {
+	row := l.rows[index]
+	return !row.header && row.IsVisible()
+}
+
+// RemoveItem は、指定されたインデックスの項目を取り除きます。
+// 選択中の項目を取り除いた場合は、選択の変化が通知されます。
+func (l *List) RemoveItem(index int) {
+	if index < 0 || index >= len(l.rows) {
+		return
+	}
+	row := l.rows[index]
+	if row == l.drag.row {
+		l.endDrag()
+	}
+	if row == l.pinnedRow {
+		l.pinnedRow = nil
+	}
+	l.rows = slices.Delete(l.rows, index, index+1)
+	l.content.RemoveChild(row)
+	l.cursor = shiftAfterRemove(l.cursor, index)
+	l.anchor = shiftAfterRemove(l.anchor, index)
+	if row.selected {
+		l.notifySelection()
+	}
+}
+
+// shiftAfterRemove は、index番目の行が取り除かれた後の、行のインデックスiの新しい値を返します。
+// 取り除かれた行自身を指していた場合は-1を返します。
+func shiftAfterRemove(i, index int) int {
+	switch {
+	case i == index:
+		return -1
+	case i > index:
+		return i - 1
+	}
+	return i
+}
+
+// ClearItems は、すべての項目を取り除きます。選択がある場合は選択の変化が通知されます。
+func (l *List) ClearItems() {
+	hadSelection := l.Selected() >= 0
+	l.endDrag()
+	l.rows = nil
+	l.pinnedRow = nil
+	l.content.ClearChildren()
+	l.cursor, l.anchor, l.scrollTarget = -1, -1, -1
+	if l.loading {
+		l.addLoadingFooter()
+	}
+	if hadSelection {
+		l.notifySelection()
+	}
+}
+
+// Len は、項目の数を返します。
+func (l *List) Len() int {
+	return len(l.rows)
+}
+
+// Item は、指定されたインデックスの項目のウィジェットを返します。範囲外の場合はnilです。
+func (l *List) Item(index int) component.Widget {
+	if index < 0 || index >= len(l.rows) {
+		return nil
+	}
+	return l.rows[index].item
+}
+
+// Selected は、選択中の項目のインデックスを返します。選択がない場合は-1です。
+// 複数選択では、現在の行が選択されていればそのインデックスを、そうでなければ最初の選択項目を返します。
+func (l *List) Selected() int {
+	if l.cursor >= 0 && l.rows[l.cursor].selected {
+		return l.cursor
+	}
+	for i, row := range l.rows {
+		if row.selected {
+			return i
+		}
+	}
+	return -1
+}
+
+// SelectedItem は、選択中の項目のウィジェットを返します。選択がない場合はnilです。
+func (l *List) SelectedItem() component.Widget {
+	return l.Item(l.Selected())
+}
+
+// Select は、指定されたインデックスの項目だけを選択し、その行を現在の行にします。-1を指定すると選択を解除します。
+// 範囲外のインデックスは無視されます。選択が変化した場合は、選択の変化時の関数が呼び出されます。
+func (l *List) Select(index int) {
+	if index < -1 || index >= len(l.rows) || (index >= 0 && !l.isSelectable(index)) {
+		return
+	}
+	changed := false
+	for i, row := range l.rows {
+		if row.setSelected(i == index) {
+			changed = true
+		}
+	}
+	l.setCursor(index)
+	l.anchor = index
+	if changed {
+		l.notifySelection()
+	}
+}
+
+// ClearSelection は、選択を解除します。
+func (l *List) ClearSelection() {
+	l.Select(-1)
+}
+
+// AddOnSelectionChanged は、選択中の項目が変化したときに呼び出される関数を追加します。
+// indexはSelected()の値で、選択が解除された場合は-1です。複数選択ではSelectedIndicesで選択全体を取得できます。
+func (l *List) AddOnSelectionChanged(fn func(index int)) {
+	if fn != nil {
+		l.onSelectionChanged = append(l.onSelectionChanged, fn)
+	}
+}
+
+// ScrollToItem は、指定されたインデックスの項目が表示範囲に入るようにスクロールします。
+// 項目が既に表示されている場合はスクロールしません。
+// スクロール位置は次のレイアウトの後に決まるため、構築直後に呼び出すこともできます。
+func (l *List) ScrollToItem(index int) {
+	if index < 0 || index >= len(l.rows) {
+		return
+	}
+	l.scrollTarget = index
+	l.MarkDirty(true)
+}
+
+// SetSelectedStyle は、選択中の行の強調に使用するスタイルを設定します。
+func (l *List) SetSelectedStyle(s style.Style) {
+	l.selectedStyle = style.Resolve(s)
+	l.MarkDirty(false)
+}
+
+// SetHoveredStyle は、ホバー中の行の強調に使用するスタイルを設定します。
+func (l *List) SetHoveredStyle(s style.Style) {
+	l.hoveredStyle = style.Resolve(s)
+	l.MarkDirty(false)
+}
+
+func (l *List) notifySelection() {
+	index := l.Selected()
+	for _, fn := range l.onSelectionChanged {
+		fn(index)
+	}
+}
+
+// newRow は、項目を包む行を生成し、選択とホバーのイベントハンドラを登録します。sは行のスタイルです。
+func (l *List) newRow(item component.Widget, s style.Style) (*listRow, error) {
+	c, err := container.NewContainer()
+	if err != nil {
+		return nil, err
+	}
+	row := &listRow{Container: c, item: item}
+	c.SetLayout(&layout.FlexLayout{Direction: layout.DirectionColumn, AlignItems: layout.AlignStretch})
+	c.SetStyle(s)
+	c.AddChild(item)
+	row.fitItem()
+
+	// 項目の子孫で発生したイベントも行へ伝播するため、行のハンドラで選択とホバーを扱います。
+	c.AddEventHandler(event.MouseDown, func(e *event.Event) event.Propagation {
+		if i := slices.Index(l.rows, row); i >= 0 {
+			// 操作ボタンの押下では行を選択しません。ボタンの処理はコンテンツのハンドラで行います。
+			if _, action := l.rowActionAt(e.X, e.Y); action >= 0 {
+				return event.Propagate
+			}
+			l.handleRowPointerDown(i)
+			l.beginReorderPress(row, e)
+			l.beginSwipePress(row, e)
+		}
+		return event.Propagate
+	})
+	c.AddEventHandler(event.MouseEnter, func(e *event.Event) event.Propagation {
+		row.setHovered(true)
+		return event.Propagate
+	})
+	c.AddEventHandler(event.MouseLeave, func(e *event.Event) event.Propagation {
+		row.setHovered(false)
+		return event.Propagate
+	})
+	c.AddOnBeforeDraw(func(info component.DrawInfo, bounds image.Rectangle) {
+		x, y, w, h := bounds.Min.X, bounds.Min.Y, bounds.Dx(), bounds.Dy()
+		switch {
+		case row.selected, l.drag.dragging && l.drag.row == row:
+			component.DrawComputedBackground(info.Screen, x, y, w, h, l.selectedStyle)
+		case row.hovered:
+			component.DrawComputedBackground(info.Screen, x, y, w, h, l.hoveredStyle)
+		}
+		// フォーカス中は、現在の行を選択の有無にかかわらず示します。
+		if l.focused && l.cursor >= 0 && l.rows[l.cursor] == row {
+			component.DrawComputedBackground(info.Screen, x, y, w, h, l.focusedStyle)
+		}
+	})
+	c.AddOnAfterDraw(func(info component.DrawInfo, bounds image.Rectangle) {
+		if l.reorderable && l.dragHandles {
+			l.drawDragHandle(info.Screen, bounds)
+		}
+		if l.showsHoverActions(row) {
+			l.drawRowActions(info.Screen, l.hoverActionRects(row))
+		}
+	})
+	return row, nil
+}
+
+// fitItem は、行の最小の高さを、項目の最小の高さ(または設定された高さ)に行の余白を加えたものにします。
+func (r *listRow) fitItem() {
+	var itemH int
+	if mss, ok := r.item.(component.MinSizeSetter); ok {
+		_, itemH = mss.GetMinSize()
+	}
+	if ss, ok := r.item.(component.SizeSetter); ok {
+		_, h := ss.GetSize()
+		itemH = max(itemH, h)
+	}
+	padding := r.GetPadding()
+	r.SetMinSize(0, itemH+padding.Top+padding.Bottom)
+}
+
+// SetPosition は、レイアウトが決めた行の位置を記録し、並べ替え中のずれを加えた位置に行を配置します。
+func (r *listRow) SetPosition(x, y int) {
+	r.baseX, r.baseY = x, y
+	r.place()
+}
+
+// place は、行をレイアウト上の位置から(swipeX, offset)だけずらした位置に移動します。
+// コンテナは位置が変わっても子を配置し直さないため、項目とその子孫も同じ量だけ移動します。
+func (r *listRow) place() {
+	oldX, oldY := r.GetPosition()
+	x, y := r.baseX+int(math.Round(r.swipeX)), r.baseY+int(math.Round(r.offset))
+	r.Container.SetPosition(x, y)
+	component.TranslateTree(r.item, x-oldX, y-oldY)
+}
+
+// setOffset は、行を表示上ずらす量を設定します。
+func (r *listRow) setOffset(offset float64) {
+	if r.offset != offset {
+		r.offset = offset
+		r.place()
+	}
+}
+
+// setSelected は、行の選択状態を設定し、変化したかどうかを返します。
+func (r *listRow) setSelected(selected bool) bool {
+	if r.selected == selected {
+		return false
+	}
+	r.selected = selected
+	r.MarkDirty(false)
+	return true
+}
+
+func (r *listRow) setHovered(hovered bool) {
+	if r.hovered != hovered {
+		r.hovered = hovered
+		r.MarkDirty(false)
+	}
+}
+
+// Update は、ScrollViewの更新に加えて、キー操作と予約されたスクロールを反映します。
+func (l *List) Update() {
+	// ドロップによる並べ替えを同じフレームのレイアウトに反映するため、先に処理します。
+	l.updateReorder()
+	l.updateSwipe()
+	l.updateLoading()
+	l.ScrollView.Update()
+	l.handleKeys()
+	l.applyScrollTarget()
+}
+
+// applyScrollTarget は、ScrollToItemで予約された項目が表示範囲に入るようにスクロール位置を調整します。
+// 行がまだレイアウトされていない場合は、次のフレームに持ち越します。
+func (l *List) applyScrollTarget() {
+	if l.scrollTarget < 0 {
+		return
+	}
+	if l.scrollTarget >= len(l.rows) {
+		l.scrollTarget = -1
+		return
+	}
+	row := l.rows[l.scrollTarget]
+	_, viewH := l.GetSize()
+	padding := l.GetPadding()
+	viewH -= padding.Top + padding.Bottom
+	_, rowH := row.GetSize()
+	if viewH <= 0 || rowH <= 0 {
+		return
+	}
+	l.scrollTarget = -1
+
+	_, contentY := l.content.GetPosition()
+	_, rowY := row.GetPosition()
+	top := float64(rowY - contentY)
+	bottom := top + float64(rowH)
+	scrollY := l.GetScrollY()
+	switch {
+	case top < scrollY:
+		scrollY = top
+	case bottom > scrollY+float64(viewH):
+		scrollY = bottom - float64(viewH)
+	default:
+		return
+	}
+	l.SetScrollY(scrollY)
+	// コンテンツの位置はレイアウトで決まるため、再レイアウトを要求します。
+	l.MarkDirty(true)
+}
+
+// Cleanup は、リソースを解放します。
+func (l *List) Cleanup() {
+	component.Blur(l)
+	l.releaseModel()
+	l.onSelectionChanged = nil
+	l.onActivate = nil
+	l.onReorder = nil
+	l.onRowAction = nil
+	l.drag = listDrag{}
+	l.swipe = listSwipe{}
+	l.rows = nil
+	l.loadingFooter = nil
+	l.pinnedRow = nil
+	l.nearEnd = nil
+	l.content.Cleanup()
+	l.ScrollView.Cleanup()
+}
+
+// --- ListBuilder ---
+
+// ListBuilder は、Listを宣言的に構築するためのビルダーです。
+type ListBuilder struct {
+	component.Builder[*ListBuilder, *List]
+}
+
+// NewListBuilder は新しいListBuilderを生成します。
+func NewListBuilder() *ListBuilder {
+	l, err := newList()
+	b := &ListBuilder{}
+	b.Init(b, l)
+	b.AddError(err)
+	return b
+}
+
+// Items は、文字列を表示するラベルの行で項目を置き換えます。
+func (b *ListBuilder) Items(items ...string) *ListBuilder {
+	b.AddError(b.Widget.SetItems(items))
+	return b
+}
+
+// Item は、ウィジェットを項目として末尾に追加します。
+func (b *ListBuilder) Item(item component.Widget) *ListBuilder {
+	b.AddError(b.Widget.AddItem(item))
+	return b
+}
+
+// Selected は、初期の選択を設定します。
+func (b *ListBuilder) Selected(index int) *ListBuilder {
+	b.Widget.Select(index)
+	return b
+}
+
+// SelectedStyle は、選択中の行の強調に使用するスタイルを設定します。
+func (b *ListBuilder) SelectedStyle(s style.Style) *ListBuilder {
+	b.Widget.SetSelectedStyle(s)
+	return b
+}
+
+// HoveredStyle は、ホバー中の行の強調に使用するスタイルを設定します。
+func (b *ListBuilder) HoveredStyle(s style.Style) *ListBuilder {
+	b.Widget.SetHoveredStyle(s)
+	return b
+}
+
+// SelectionMode は、単一選択か複数選択かを設定します。
+func (b *ListBuilder) SelectionMode(mode SelectionMode) *ListBuilder {
+	b.Widget.SetSelectionMode(mode)
+	return b
+}
+
+// FocusedStyle は、フォーカス中に現在の行を示すスタイルを設定します。
+func (b *ListBuilder) FocusedStyle(s style.Style) *ListBuilder {
+	b.Widget.SetFocusedStyle(s)
+	return b
+}
+
+// OnSelectionChanged は、選択中の項目が変化したときに呼び出される関数を追加します。
+func (b *ListBuilder) OnSelectionChanged(fn func(index int)) *ListBuilder {
+	b.Widget.AddOnSelectionChanged(fn)
+	return b
+}
+
+// OnActivate は、項目が確定(フォーカス中のEnterキー)されたときに呼び出される関数を追加します。
+func (b *ListBuilder) OnActivate(fn func(index int)) *ListBuilder {
+	b.Widget.AddOnActivate(fn)
+	return b
+}
+
+// Reorderable は、ドラッグによる並べ替えを有効にするかどうかを設定します。
+func (b *ListBuilder) Reorderable(reorderable bool) *ListBuilder {
+	b.Widget.SetReorderable(reorderable)
+	return b
+}
+
+// DragHandles は、並べ替え用のドラッグハンドルを各行に表示するかどうかを設定します。
+func (b *ListBuilder) DragHandles(show bool) *ListBuilder {
+	b.Widget.SetDragHandles(show)
+	return b
+}
+
+// OnReorder は、ドラッグによって項目が並べ替えられたときに呼び出される関数を追加します。
+func (b *ListBuilder) OnReorder(fn func(from, to int)) *ListBuilder {
+	b.Widget.AddOnReorder(fn)
+	return b
+}
+
+// RowActions は、行の操作ボタンを設定します。
+func (b *ListBuilder) RowActions(actions ...ListAction) *ListBuilder {
+	b.Widget.SetRowActions(actions...)
+	return b
+}
+
+// ActionStyle は、行の操作ボタンに使用するスタイルを設定します。
+func (b *ListBuilder) ActionStyle(s style.Style) *ListBuilder {
+	b.Widget.SetActionStyle(s)
+	return b
+}
+
+// OnRowAction は、行の操作ボタンが押されたときに呼び出される関数を追加します。
+func (b *ListBuilder) OnRowAction(fn func(index int, action string)) *ListBuilder {
+	b.Widget.AddOnRowAction(fn)
+	return b
+}
+
+// Loading は、読み込み中かどうかを設定します。読み込み中は、末尾にスピナーの行が表示されます。
+func (b *ListBuilder) Loading(loading bool) *ListBuilder {
+	b.Widget.SetLoading(loading)
+	return b
+}
+
+// OnNearEnd は、末尾までの残りがthresholdピクセル以下になったときに呼び出される関数を追加します。
+func (b *ListBuilder) OnNearEnd(threshold int, fn func()) *ListBuilder {
+	b.Widget.AddOnNearEnd(threshold, fn)
+	return b
+}
+
+// OnNearEndItems は、表示範囲より下に残っている項目の数がcount以下になったときに呼び出される関数を追加します。
+func (b *ListBuilder) OnNearEndItems(count int, fn func()) *ListBuilder {
+	b.Widget.AddOnNearEndItems(count, fn)
+	return b
+}
+
+// Model は、項目をデータモデルの要素と同期させます。factoryがnilの場合は、要素を文字列にしたラベルを表示します。
+func (b *ListBuilder) Model(model binding.ListModel, factory func(index int) component.Widget) *ListBuilder {
+	b.AddError(b.Widget.SetModel(model, factory))
+	return b
+}
+
+// Build は、最終的なListを構築して返します。
+func (b *ListBuilder) Build() (*List, error) {
+	return b.Builder.Build()
+}
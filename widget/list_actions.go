@@ -0,0 +1,336 @@
+package widget
+
+import (
+	"furoshiki/component"
+	"furoshiki/event"
+	"furoshiki/stats"
+	"furoshiki/style"
+	"image"
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+// このファイルは、Listの行の操作ボタン(削除、編集など)を提供します。
+//
+//	list.SetRowActions(
+//		widget.ListAction{ID: "edit", Text: "Edit"},
+//		widget.ListAction{ID: "delete", Text: "Delete", Color: color.RGBA{200, 40, 40, 255}},
+//	)
+//	list.AddOnRowAction(func(index int, action string) {
+//		if action == "delete" {
+//			list.RemoveItem(index)
+//		}
+//	})
+//
+// 操作ボタンは、ポインタが乗っている行の右端に重ねて表示されます。
+// また、行を左へスワイプ(ドラッグ)すると行がずれ、右側に現れたボタンが開いたまま残ります。
+// 開いた行は、ボタンを押すか、別の場所を押すと閉じます。見出しの行には操作ボタンは表示されません。
+
+// listSwipeSlop は、スワイプとみなすまでに横へ動かす必要がある距離(ピクセル)です。
+const listSwipeSlop = 8
+
+// ListAction は、Listの行に表示する操作ボタンです。
+type ListAction struct {
+	// ID は、OnRowActionで押されたボタンを識別する文字列です。空の場合はTextを使用します。
+	ID string
+	// Text は、ボタンに表示する文字列です。
+	Text string
+	// Color は、ボタンの背景色です。nilの場合はテーマ(theme.ListTheme.Action)の背景色を使用します。
+	Color color.Color
+}
+
+// id は、OnRowActionに渡す識別子を返します。
+func (a ListAction) id() string {
+	if a.ID != "" {
+		return a.ID
+	}
+	return a.Text
+}
+
+// listSwipe は、行のスワイプと、スワイプで開いた行の状態です。
+type listSwipe struct {
+	// row は、スワイプ中または開いている行です。ない場合はnilです。
+	row *listRow
+	// pressing は、行が押されたままかどうかを表します。
+	pressing bool
+	// pressX, pressY は、押下した位置です。startX は、押下した時点の行のずれです。
+	pressX, pressY int
+	startX         float64
+	swiping        bool
+	// open は、ボタンを表示したまま行を止めておくかどうかを表します。
+	open bool
+}
+
+// SetRowActions は、行の操作ボタンを設定します。何も指定しないと、操作ボタンを表示しません。
+// ボタンは指定した順に左から並び、行の右端に揃えられます。
+func (l *List) SetRowActions(actions ...ListAction) {
+	l.rowActions = slices.Clone(actions)
+	l.closeSwipe(true)
+	l.MarkDirty(false)
+}
+
+// RowActions は、行の操作ボタンを返します。
+func (l *List) RowActions() []ListAction {
+	return slices.Clone(l.rowActions)
+}
+
+// AddOnRowAction は、行の操作ボタンが押されたときに呼び出される関数を追加します。
+// indexはボタンが押された行の項目のインデックス、actionはボタンのID(ListAction.ID)です。
+func (l *List) AddOnRowAction(fn func(index int, action string)) {
+	if fn != nil {
+		l.onRowAction = append(l.onRowAction, fn)
+	}
+}
+
+// SetActionStyle は、操作ボタンに使用するスタイルを設定します。
+func (l *List) SetActionStyle(s style.Style) {
+	face := l.actionStyle.Font
+	l.actionStyle = style.Resolve(s)
+	if l.actionStyle.Font == nil {
+		l.actionStyle.Font = face
+	}
+	l.MarkDirty(false)
+}
+
+// actionWidths は、各操作ボタンの幅を返します。フォントがない場合はnilです。
+func (l *List) actionWidths() []int {
+	c := l.actionStyle
+	if c.Font == nil {
+		return nil
+	}
+	widths := make([]int, len(l.rowActions))
+	for i, a := range l.rowActions {
+		widths[i] = component.MeasureString(c.Font, a.Text).Ceil() + c.Padding.Left + c.Padding.Right
+	}
+	return widths
+}
+
+// actionRects は、右端をrightとして並べた操作ボタンの領域を、左のボタンから順に返します。
+func (l *List) actionRects(right, top, height int) []image.Rectangle {
+	widths := l.actionWidths()
+	if len(widths) == 0 {
+		return nil
+	}
+	rects := make([]image.Rectangle, len(widths))
+	for i := len(widths) - 1; i >= 0; i-- {
+		rects[i] = image.Rect(right-widths[i], top, right, top+height)
+		right -= widths[i]
+	}
+	return rects
+}
+
+// actionsWidth は、すべての操作ボタンの幅の合計を返します。スワイプで行をずらせる最大の量になります。
+func (l *List) actionsWidth() int {
+	total := 0
+	for _, w := range l.actionWidths() {
+		total += w
+	}
+	return total
+}
+
+// showsHoverActions は、行に重ねて操作ボタンを表示するかどうかを返します。
+func (l *List) showsHoverActions(row *listRow) bool {
+	if len(l.rowActions) == 0 || !row.hovered || row.header || l.drag.dragging || l.IsDisabled() {
+		return false
+	}
+	// スワイプ中やスワイプで開いている間は、重ねて表示しません。
+	return l.swipe.row == nil || (!l.swipe.swiping && l.swipe.row.swipeX == 0)
+}
+
+// hoverActionRects は、行に重ねて表示する操作ボタンの領域を返します。ドラッグハンドルがある場合は、その左側に並べます。
+func (l *List) hoverActionRects(row *listRow) []image.Rectangle {
+	x, y := row.GetPosition()
+	w, h := row.GetSize()
+	right := x + w
+	if l.reorderable && l.dragHandles {
+		right -= listDragHandleWidth
+	}
+	return l.actionRects(right, y, h)
+}
+
+// swipeActionRects は、スワイプで現れる操作ボタンの領域と、行がずれて空いた領域を返します。
+func (l *List) swipeActionRects() ([]image.Rectangle, image.Rectangle) {
+	row := l.swipe.row
+	if row == nil || row.swipeX >= 0 {
+		return nil, image.Rectangle{}
+	}
+	x, y := row.GetPosition()
+	w, h := row.GetSize()
+	revealed := image.Rect(x+w, y, row.baseX+w, y+h)
+	return l.actionRects(row.baseX+w, y, h), revealed
+}
+
+// rowActionAt は、指定された位置にある操作ボタンと、その行を返します。ない場合、ボタンのインデックスは-1です。
+func (l *List) rowActionAt(x, y int) (*listRow, int) {
+	if len(l.rowActions) == 0 {
+		return nil, -1
+	}
+	p := image.Pt(x, y)
+	if rects, revealed := l.swipeActionRects(); p.In(revealed) {
+		for i, r := range rects {
+			if p.In(r) {
+				return l.swipe.row, i
+			}
+		}
+	}
+	for _, row := range l.rows {
+		if !l.showsHoverActions(row) {
+			continue
+		}
+		for i, r := range l.hoverActionRects(row) {
+			if p.In(r) {
+				return row, i
+			}
+		}
+	}
+	return nil, -1
+}
+
+// handleActionPress は、操作ボタンの押下を処理します。ボタン以外が押された場合は、開いている行を閉じます。
+func (l *List) handleActionPress(e *event.Event) {
+	if e.MouseButton != ebiten.MouseButtonLeft {
+		return
+	}
+	row, action := l.rowActionAt(e.X, e.Y)
+	if action < 0 {
+		if !l.swipe.pressing {
+			l.closeSwipe(false)
+		}
+		return
+	}
+	index := slices.Index(l.rows, row)
+	l.closeSwipe(false)
+	if index < 0 {
+		return
+	}
+	id := l.rowActions[action].id()
+	for _, fn := range l.onRowAction {
+		fn(index, id)
+	}
+}
+
+// beginSwipePress は、行の押下を記録し、横方向のドラッグによるスワイプの開始を待ちます。
+func (l *List) beginSwipePress(row *listRow, e *event.Event) {
+	if len(l.rowActions) == 0 || row.header || e.MouseButton != ebiten.MouseButtonLeft || l.drag.handle {
+		return
+	}
+	if l.swipe.row != row {
+		l.closeSwipe(true)
+	}
+	l.swipe.row = row
+	l.swipe.pressing = true
+	l.swipe.swiping = false
+	l.swipe.pressX, l.swipe.pressY = e.X, e.Y
+	l.swipe.startX = row.swipeX
+}
+
+// updateSwipe は、押下中の行をポインタに追従させ、離されたら開いた位置か元の位置へ滑らかに移動させます。
+func (l *List) updateSwipe() {
+	s := &l.swipe
+	if s.row == nil {
+		return
+	}
+	if !l.IsVisible() || l.IsDisabled() || len(l.rowActions) == 0 || slices.Index(l.rows, s.row) < 0 || l.drag.dragging {
+		l.closeSwipe(true)
+		return
+	}
+	width := float64(l.actionsWidth())
+	if s.pressing {
+		cx, cy := ebiten.CursorPosition()
+		dx, dy := cx-s.pressX, cy-s.pressY
+		pressed := ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+		if !s.swiping && pressed {
+			// 縦方向の動きが大きい場合は、スワイプではなくスクロールや並べ替えの操作とみなします。
+			if max(dx, -dx) <= listSwipeSlop || max(dx, -dx) <= max(dy, -dy) {
+				return
+			}
+			s.swiping = true
+			l.drag = listDrag{}
+			s.row.setHovered(false)
+		}
+		if pressed {
+			s.row.setSwipe(min(0, max(-width, s.startX+float64(dx))))
+			l.MarkDirty(false)
+			return
+		}
+		// スワイプせずに離した場合は、開いていた行を閉じます。
+		s.open = s.swiping && s.row.swipeX < -width/2
+		s.pressing, s.swiping = false, false
+	}
+
+	target := 0.0
+	if s.open {
+		target = -width
+	}
+	next := s.row.swipeX + (target-s.row.swipeX)*listReorderEasing
+	if math.Abs(target-next) < 0.5 {
+		next = target
+	}
+	if next != s.row.swipeX {
+		s.row.setSwipe(next)
+		l.MarkDirty(false)
+	}
+	if !s.open && next == 0 {
+		l.swipe = listSwipe{}
+	}
+}
+
+// closeSwipe は、開いている行を閉じます。immediateがtrueの場合は、行を即座に元の位置に戻します。
+func (l *List) closeSwipe(immediate bool) {
+	if l.swipe.row == nil {
+		return
+	}
+	l.swipe.open, l.swipe.pressing, l.swipe.swiping = false, false, false
+	if immediate {
+		l.swipe.row.setSwipe(0)
+		l.swipe = listSwipe{}
+		l.MarkDirty(false)
+	}
+}
+
+// setSwipe は、行を横にずらして表示する量を設定します。
+func (r *listRow) setSwipe(x float64) {
+	if r.swipeX != x {
+		r.swipeX = x
+		r.place()
+	}
+}
+
+// drawSwipeActions は、スワイプでずれた行の右側の空いた領域に操作ボタンを描画します。
+func (l *List) drawSwipeActions(screen *ebiten.Image) {
+	rects, revealed := l.swipeActionRects()
+	if len(rects) == 0 {
+		return
+	}
+	component.FlushDraws()
+	l.drawRowActions(screen.SubImage(revealed).(*ebiten.Image), rects)
+	component.FlushDraws()
+}
+
+// drawRowActions は、操作ボタンの背景と文字列を描画します。
+func (l *List) drawRowActions(screen *ebiten.Image, rects []image.Rectangle) {
+	c := l.actionStyle
+	if c.Font == nil {
+		return
+	}
+	for i, r := range rects {
+		bg := l.rowActions[i].Color
+		if bg == nil {
+			bg = c.Background
+		}
+		component.DrawFilledRect(screen, float32(r.Min.X), float32(r.Min.Y), float32(r.Dx()), float32(r.Dy()), bg)
+	}
+	component.FlushDraws()
+	m := component.FaceMetrics(c.Font)
+	for i, r := range rects {
+		label := l.rowActions[i].Text
+		w := component.MeasureString(c.Font, label).Ceil()
+		baseline := r.Min.Y + (r.Dy()-(m.Ascent+m.Descent).Ceil())/2 + m.Ascent.Ceil()
+		text.Draw(screen, label, c.Font, r.Min.X+(r.Dx()-w)/2, baseline, c.TextColor)
+		stats.AddDrawCalls(1)
+	}
+}
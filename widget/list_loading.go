@@ -0,0 +1,139 @@
+package widget
+
+import (
+	"furoshiki/clock"
+	"furoshiki/component"
+	"furoshiki/container"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// このファイルは、ページ単位で項目を読み込むListのための、読み込み中の表示と末尾付近への到達の通知を提供します。
+//
+//	list.AddOnNearEndItems(5, func() {
+//		list.SetLoading(true)
+//		go fetchNextPage() // 取得後に項目を追加し、SetLoading(false)を呼び出します
+//	})
+//
+// 読み込み中(SetLoading(true))は、末尾にスピナーの行が表示され、末尾付近への到達は通知されません。
+
+const (
+	// listLoadingFooterHeight は、読み込み中に末尾に表示する行の高さです。
+	listLoadingFooterHeight = 28
+	// listSpinnerDots は、スピナーを構成する点の数です。
+	listSpinnerDots = 8
+	// listSpinnerRadius は、スピナーの点を並べる円の半径です。
+	listSpinnerRadius = 7
+	// listSpinnerTicksPerDot は、スピナーの強調が次の点へ進むまでのフレーム数です。
+	listSpinnerTicksPerDot = 4
+)
+
+// SetLoading は、読み込み中かどうかを設定します。読み込み中は、末尾にスピナーの行が表示されます。
+func (l *List) SetLoading(loading bool) {
+	if l.loading == loading {
+		return
+	}
+	l.loading = loading
+	l.nearEndSuspended = loading
+	if loading {
+		l.addLoadingFooter()
+		return
+	}
+	if l.loadingFooter != nil {
+		l.content.RemoveChild(l.loadingFooter)
+		l.loadingFooter = nil
+	}
+}
+
+// IsLoading は、読み込み中かどうかを返します。
+func (l *List) IsLoading() bool {
+	return l.loading
+}
+
+// AddOnNearEndItems は、表示範囲より下に残っている項目の数がcount以下になったときに呼び出される関数を追加します。
+// ピクセル単位で指定する場合は、ScrollViewのAddOnNearEndを使用します。
+func (l *List) AddOnNearEndItems(count int, fn func()) {
+	l.addNearEndListener(count, l.remainingItems, fn)
+}
+
+// remainingItems は、表示範囲の下端より下にある項目の数を返します。
+func (l *List) remainingItems() (int, bool) {
+	if !l.HasBeenLaidOut() {
+		return 0, false
+	}
+	_, y := l.GetPosition()
+	_, h := l.GetSize()
+	padding := l.GetPadding()
+	bottom := y + h - padding.Bottom
+	remaining := 0
+	for i := len(l.rows) - 1; i >= 0; i-- {
+		row := l.rows[i]
+		if !row.IsVisible() || row.header {
+			continue
+		}
+		if row.baseY < bottom {
+			break
+		}
+		remaining++
+	}
+	return remaining, true
+}
+
+// addLoadingFooter は、スピナーを描画する行を末尾に追加します。
+func (l *List) addLoadingFooter() {
+	footer, err := container.NewContainer()
+	if err != nil {
+		return
+	}
+	footer.SetMinSize(0, listLoadingFooterHeight)
+	footer.AddOnAfterDraw(func(info component.DrawInfo, bounds image.Rectangle) {
+		l.drawSpinner(info.Screen, bounds)
+	})
+	l.loadingFooter = footer
+	l.spinnerTicks = 0
+	l.content.AddChild(footer)
+}
+
+// updateLoading は、読み込み中のスピナーを1フレーム分進めます。
+func (l *List) updateLoading() {
+	// 決定的モードではスピナーを回転させず、静止した状態で描画します。
+	if l.loadingFooter == nil || clock.IsDeterministic() {
+		return
+	}
+	l.spinnerTicks++
+	if l.spinnerTicks%listSpinnerTicksPerDot == 0 {
+		l.loadingFooter.MarkDirty(false)
+	}
+	// 省電力モードでも、読み込み中は更新を続けてスピナーを回転させます。
+	component.RequestFrame()
+}
+
+// drawSpinner は、円周上に並べた点のうち1つを強調し、後続の点ほど薄く描画します。
+func (l *List) drawSpinner(screen *ebiten.Image, bounds image.Rectangle) {
+	if l.loadingColor == nil {
+		return
+	}
+	r, g, b, a := l.loadingColor.RGBA()
+	cx := float64(bounds.Min.X + bounds.Dx()/2)
+	cy := float64(bounds.Min.Y + bounds.Dy()/2)
+	lead := l.spinnerTicks / listSpinnerTicksPerDot
+	if clock.IsDeterministic() {
+		lead = 0
+	}
+	for i := range listSpinnerDots {
+		// 強調中の点から遠ざかるほど不透明度を下げます。
+		age := (lead - i + listSpinnerDots) % listSpinnerDots
+		alpha := 1 - float64(age)/listSpinnerDots
+		clr := color.RGBA64{
+			R: uint16(float64(r) * alpha), G: uint16(float64(g) * alpha),
+			B: uint16(float64(b) * alpha), A: uint16(float64(a) * alpha),
+		}
+		angle := 2 * math.Pi * float64(i) / listSpinnerDots
+		x := cx + listSpinnerRadius*math.Cos(angle)
+		y := cy + listSpinnerRadius*math.Sin(angle)
+		component.DrawFilledCircle(screen, float32(x), float32(y), 2, clr)
+	}
+}
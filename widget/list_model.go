@@ -0,0 +1,142 @@
+package widget
+
+import (
+	"fmt"
+	"furoshiki/binding"
+	"furoshiki/component"
+	"log"
+	"reflect"
+)
+
+// このファイルは、Listの項目をデータモデル(binding.ListModel)と同期させる機能を提供します。
+//
+//	todos := binding.NewList("牛乳を買う", "部屋を片付ける")
+//	list.SetModel(todos, nil)
+//	todos.Append("手紙を書く") // 末尾に1行だけ追加されます
+//
+// モデルの変更は、変更のあった行だけの差分更新として反映されます。
+// 挿入・削除・移動では既存の行(と選択状態)が保たれ、要素の置き換えでは該当する行の項目だけが作り直されます。
+//
+// NOTE: モデルを設定したListの項目はモデルによって管理されます。
+// AddItemやRemoveItemなどで項目を直接操作すると、モデルとの対応が崩れるため避けてください。
+
+// SetModel は、項目をデータモデルの要素と同期させます。既存の項目は取り除かれ、モデルの要素から作り直されます。
+// factoryはモデル内のインデックスを受け取り、その要素を表示するウィジェットを返します。
+// factoryがnilの場合は、要素をfmt.Sprintで文字列にしたラベルを表示します。
+// modelにnilを指定すると、モデルとの同期を解除します(項目はそのまま残ります)。
+func (l *List) SetModel(model binding.ListModel, factory func(index int) component.Widget) error {
+	l.releaseModel()
+	if model == nil {
+		return nil
+	}
+	l.model = model
+	l.modelFactory = func(index int) (component.Widget, error) {
+		if factory == nil {
+			return newLabel(fmt.Sprint(model.ItemAt(index)))
+		}
+		return factory(index), nil
+	}
+	if err := l.resetFromModel(); err != nil {
+		return err
+	}
+	l.unbindModel = model.Subscribe(func(change binding.Change) {
+		if err := l.applyModelChange(change); err != nil {
+			// 差分の反映に失敗した行はモデルと対応しなくなるため、すべての行をモデルから作り直します。
+			if err := l.resetFromModel(); err != nil {
+				log.Printf("List: failed to rebuild items from model: %v", err)
+			}
+		}
+	})
+	return nil
+}
+
+// Model は、項目の元になるデータモデルを返します。設定されていない場合はnilです。
+func (l *List) Model() binding.ListModel {
+	return l.model
+}
+
+// releaseModel は、データモデルの変更通知の購読を解除します。
+func (l *List) releaseModel() {
+	if l.unbindModel != nil {
+		l.unbindModel()
+	}
+	l.model, l.modelFactory, l.unbindModel = nil, nil, nil
+}
+
+// modelItem は、モデルのindex番目の要素を表示するウィジェットを生成します。
+func (l *List) modelItem(index int) (component.Widget, error) {
+	item, err := l.modelFactory(index)
+	if err != nil {
+		return nil, err
+	}
+	// 型付きnilのウィジェットも行に追加できないため、nilとして扱います。
+	if v := reflect.ValueOf(item); !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return nil, component.ErrNilChild
+	}
+	return item, nil
+}
+
+// insertModelRow は、モデルのindex番目の要素を表示する行を、index番目に挿入します。
+func (l *List) insertModelRow(index int) error {
+	item, err := l.modelItem(index)
+	if err != nil {
+		return err
+	}
+	row, err := l.newRow(item, l.itemStyle)
+	if err != nil {
+		return err
+	}
+	l.insertRow(index, row)
+	return nil
+}
+
+// resetFromModel は、すべての項目を取り除き、モデルの要素から作り直します。
+func (l *List) resetFromModel() error {
+	l.ClearItems()
+	l.content.BeginUpdate()
+	defer l.content.EndUpdate()
+	for i := range l.model.Len() {
+		if err := l.insertModelRow(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyModelChange は、モデルの変更内容を行に反映します。
+func (l *List) applyModelChange(change binding.Change) error {
+	// 複数の行が変化する場合でも、再レイアウトの要求は最後に1回だけ行います。
+	l.content.BeginUpdate()
+	defer l.content.EndUpdate()
+
+	switch change.Kind {
+	case binding.ChangeInsert:
+		for k := range change.Count {
+			if err := l.insertModelRow(change.Index + k); err != nil {
+				return err
+			}
+		}
+	case binding.ChangeRemove:
+		for range change.Count {
+			l.RemoveItem(change.Index)
+		}
+	case binding.ChangeMove:
+		l.MoveItem(change.OldIndex, change.Index)
+	case binding.ChangeUpdate:
+		row := l.rowAt(change.Index)
+		if row == nil {
+			return nil
+		}
+		item, err := l.modelItem(change.Index)
+		if err != nil {
+			return err
+		}
+		if row.ReplaceChild(row.item, item) {
+			row.item = item
+			row.fitItem()
+		}
+	case binding.ChangeReset:
+		return l.resetFromModel()
+	}
+	return nil
+}
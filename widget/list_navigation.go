@@ -0,0 +1,118 @@
+package widget
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// このファイルは、フォーカス中のListのキーボード操作を提供します。
+//
+//	↑ / ↓                 前後の項目へ移動します
+//	PageUp / PageDown     表示されている行数分だけ移動します
+//	Home / End            最初 / 最後の項目へ移動します
+//	Enter                 現在の項目を確定(アクティベート)します
+//
+// 移動先の項目は選択され、表示範囲に入るようにスクロールされます。
+// 複数選択では、Shiftと同時に押すと範囲選択を広げ、Ctrlと同時に押すと選択を変えずに現在の行だけを移動します。
+// Ctrl+Spaceで現在の行の選択を切り替えます。
+
+// AddOnActivate は、項目が確定(フォーカス中のEnterキー)されたときに呼び出される関数を追加します。
+func (l *List) AddOnActivate(fn func(index int)) {
+	if fn != nil {
+		l.onActivate = append(l.onActivate, fn)
+	}
+}
+
+// Activate は、指定されたインデックスの項目を確定し、確定時の関数を呼び出します。範囲外の場合は何もしません。
+func (l *List) Activate(index int) {
+	if index < 0 || index >= len(l.rows) {
+		return
+	}
+	for _, fn := range l.onActivate {
+		fn(index)
+	}
+}
+
+// handleNavigation は、フォーカス中の移動と確定のキー操作を処理します。
+func (l *List) handleNavigation() {
+	if len(l.rows) == 0 {
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter) {
+		l.Activate(l.cursor)
+		return
+	}
+	if l.mode == SelectionMultiple && shortcutModifierPressed() && inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		l.ToggleSelection(max(l.cursor, 0))
+		return
+	}
+
+	// dirは、移動先が選択できない行だった場合に探す向きです。
+	target, dir := -1, 1
+	switch {
+	case keyRepeated(ebiten.KeyArrowUp):
+		target, dir = l.cursor-1, -1
+	case keyRepeated(ebiten.KeyArrowDown):
+		target = l.cursor + 1
+	case keyRepeated(ebiten.KeyPageUp):
+		target, dir = l.cursor-l.rowsPerPage(), -1
+	case keyRepeated(ebiten.KeyPageDown):
+		target = l.cursor + l.rowsPerPage()
+	case inpututil.IsKeyJustPressed(ebiten.KeyHome):
+		target = 0
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnd):
+		target, dir = len(l.rows)-1, -1
+	default:
+		return
+	}
+	if l.cursor < 0 {
+		// 現在の行がない場合は、どのキーでも最初の項目から始めます。
+		target, dir = 0, 1
+	}
+	if index := l.nearestSelectable(min(max(target, 0), len(l.rows)-1), dir); index >= 0 {
+		l.moveCursor(index)
+	}
+}
+
+// nearestSelectable は、indexから向きdirへ進んで最初に見つかる選択できる行のインデックスを返します。
+// その向きに見つからない場合は逆向きに探し、選択できる行がない場合は-1を返します。
+func (l *List) nearestSelectable(index, dir int) int {
+	for _, d := range []int{dir, -dir} {
+		for i := index; i >= 0 && i < len(l.rows); i += d {
+			if l.isSelectable(i) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// moveCursor は、現在の行をindexへ移動し、押されている修飾キーに応じて選択を更新します。
+func (l *List) moveCursor(index int) {
+	if l.mode == SelectionMultiple {
+		switch {
+		case ebiten.IsKeyPressed(ebiten.KeyShift) && l.anchor >= 0:
+			l.SelectRange(l.anchor, index)
+		case shortcutModifierPressed():
+			l.setCursor(index)
+		default:
+			l.Select(index)
+		}
+	} else {
+		l.Select(index)
+	}
+	l.ScrollToItem(index)
+}
+
+// rowsPerPage は、PageUp / PageDownで移動する行数を返します。表示領域の高さを現在の行の高さで割った値で、最小は1です。
+func (l *List) rowsPerPage() int {
+	row := l.rows[max(l.cursor, 0)]
+	_, rowH := row.GetSize()
+	_, viewH := l.GetSize()
+	padding := l.GetPadding()
+	viewH -= padding.Top + padding.Bottom
+	if rowH <= 0 {
+		return 1
+	}
+	return max(1, viewH/rowH)
+}
@@ -0,0 +1,294 @@
+package widget
+
+import (
+	"furoshiki/binding"
+	"furoshiki/clock"
+	"furoshiki/component"
+	"furoshiki/event"
+	"image"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// このファイルは、Listのドラッグによる並べ替えを提供します。
+//
+// 並べ替えを有効にしたリストでは、行を長押しするか、行の右端のドラッグハンドルを押すとドラッグが始まります。
+// ドラッグ中の行はポインタに追従し、移動先の位置が空くように他の行が滑らかに移動します。
+// ボタンを離すと項目が移動し、OnReorderの関数が移動前と移動後のインデックスで呼び出されます。
+// ドラッグ中にEscキーを押すと、並べ替えを取り消します。
+
+const (
+	// listLongPressDuration は、行の長押しでドラッグを開始するまでの時間です。
+	listLongPressDuration = 400 * time.Millisecond
+	// listDragSlop は、長押しの判定中にポインタが動いてもよい距離(ピクセル)です。これを超えると長押しを取り消します。
+	listDragSlop = 4
+	// listDragHandleWidth は、行の右端にあるドラッグハンドルの領域の幅です。
+	listDragHandleWidth = 16
+	// listReorderEasing は、行が目標の位置へ1フレームごとに近づく割合です。
+	listReorderEasing = 0.35
+	// listAutoScrollMargin は、ドラッグ中に自動スクロールを始める、表示領域の上下端からの距離です。
+	listAutoScrollMargin = 24
+	// listAutoScrollSpeed は、自動スクロールで1フレームごとにスクロールする量です。
+	listAutoScrollSpeed = 4
+)
+
+// listDrag は、行の押下からドロップまでの並べ替えの状態です。
+type listDrag struct {
+	// row は、押された(ドラッグ中の)行です。押されていない場合はnilです。
+	row *listRow
+	// pressX, pressY は、押下した位置です。
+	pressX, pressY int
+	// ticks は、押下してからのフレーム数です。
+	ticks int
+	// handle は、ドラッグハンドルが押されたかどうかを表します。
+	handle   bool
+	dragging bool
+	// grabY は、押下した位置の、行の上端からの距離です。
+	grabY int
+	// height は、ドラッグ中の行の高さで、他の行を押しのける量になります。
+	height int
+	// to は、現在ドロップした場合の移動先のインデックスです。
+	to int
+}
+
+// SetReorderable は、ドラッグによる並べ替えを有効にするかどうかを設定します。
+func (l *List) SetReorderable(reorderable bool) {
+	if l.reorderable == reorderable {
+		return
+	}
+	l.reorderable = reorderable
+	if !reorderable {
+		l.endDrag()
+	}
+	l.MarkDirty(false)
+}
+
+// IsReorderable は、ドラッグによる並べ替えが有効かどうかを返します。
+func (l *List) IsReorderable() bool {
+	return l.reorderable
+}
+
+// SetDragHandles は、並べ替えが有効なときに、各行の右端にドラッグハンドルを表示するかどうかを設定します。
+// ハンドルを押すと、長押しを待たずにドラッグを開始します。
+// ハンドルは項目の上に重ねて描画されるため、必要に応じて項目の右側に余白を設けてください。
+func (l *List) SetDragHandles(show bool) {
+	if l.dragHandles != show {
+		l.dragHandles = show
+		l.MarkDirty(false)
+	}
+}
+
+// AddOnReorder は、ドラッグによって項目が並べ替えられたときに呼び出される関数を追加します。
+// fromは移動前の、toは移動後のインデックスです。呼び出しの時点で、項目は既に移動しています。
+// モデルを設定したリストでは、移動はモデル(binding.MovableListModel)に対して行われるため、関数からモデルを更新する必要はありません。
+// モデルが移動を受け付けない場合は行は移動しないため、必要に応じて関数からモデルを並べ替えてください。
+func (l *List) AddOnReorder(fn func(from, to int)) {
+	if fn != nil {
+		l.onReorder = append(l.onReorder, fn)
+	}
+}
+
+// MoveItem は、from番目の項目をto番目へ移動します。選択状態は項目とともに移動します。
+// 範囲外のインデックスは無視されます。OnReorderの関数は呼び出されません。
+func (l *List) MoveItem(from, to int) {
+	if from < 0 || from >= len(l.rows) || to < 0 || to >= len(l.rows) || from == to {
+		return
+	}
+	cursor, anchor := l.rowAt(l.cursor), l.rowAt(l.anchor)
+	row := l.rows[from]
+	l.rows = slices.Insert(slices.Delete(l.rows, from, from+1), to, row)
+	l.content.MoveChild(row, to)
+	l.cursor = slices.Index(l.rows, cursor)
+	l.anchor = slices.Index(l.rows, anchor)
+}
+
+// rowAt は、指定されたインデックスの行を返します。範囲外の場合はnilです。
+func (l *List) rowAt(index int) *listRow {
+	if index < 0 || index >= len(l.rows) {
+		return nil
+	}
+	return l.rows[index]
+}
+
+// beginReorderPress は、行の押下を記録し、長押しまたはドラッグハンドルによるドラッグの開始を待ちます。
+func (l *List) beginReorderPress(row *listRow, e *event.Event) {
+	if !l.reorderable || row.header || e.MouseButton != ebiten.MouseButtonLeft || l.drag.dragging {
+		return
+	}
+	x, _ := row.GetPosition()
+	w, _ := row.GetSize()
+	l.drag = listDrag{
+		row:    row,
+		pressX: e.X,
+		pressY: e.Y,
+		handle: l.dragHandles && e.X >= x+w-listDragHandleWidth,
+	}
+}
+
+// updateReorder は、押下中の行の長押しを判定し、ドラッグ中であれば行の位置を更新してドロップを処理します。
+func (l *List) updateReorder() {
+	d := &l.drag
+	if d.row == nil {
+		return
+	}
+	if !l.IsVisible() || l.IsDisabled() || slices.Index(l.rows, d.row) < 0 {
+		l.endDrag()
+		return
+	}
+	cx, cy := ebiten.CursorPosition()
+	if !d.dragging {
+		if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			// 長押しの前に離された場合は、通常のクリックです。
+			l.drag = listDrag{}
+			return
+		}
+		if !d.handle {
+			if max(cx-d.pressX, d.pressX-cx) > listDragSlop || max(cy-d.pressY, d.pressY-cy) > listDragSlop {
+				l.drag = listDrag{}
+				return
+			}
+			d.ticks++
+			if d.ticks < durationToTicks(listLongPressDuration) {
+				return
+			}
+		}
+		l.startDrag()
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		l.endDrag()
+		return
+	}
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		l.drop()
+		return
+	}
+	l.autoScrollDuringDrag(cy)
+	l.updateDragOffsets(cy)
+}
+
+// startDrag は、押下中の行のドラッグを開始します。
+func (l *List) startDrag() {
+	d := &l.drag
+	_, h := d.row.GetSize()
+	d.dragging = true
+	d.grabY = d.pressY - d.row.baseY
+	d.height = h
+	d.to = slices.Index(l.rows, d.row)
+	d.row.setHovered(false)
+	l.MarkDirty(false)
+}
+
+// updateDragOffsets は、ドラッグ中の行をポインタに追従させ、移動先を空けるように他の行をずらします。
+func (l *List) updateDragOffsets(cursorY int) {
+	d := &l.drag
+	top := cursorY - d.grabY
+	center := top + d.height/2
+
+	// 移動先は、ドラッグ中の行の中心より上にある他の行の数です。
+	to := 0
+	for _, row := range l.rows {
+		if row == d.row {
+			continue
+		}
+		if _, h := row.GetSize(); row.baseY+h/2 < center {
+			to++
+		}
+	}
+	d.to = to
+
+	from := slices.Index(l.rows, d.row)
+	for i, row := range l.rows {
+		if row == d.row {
+			row.setOffset(float64(top - row.baseY))
+			continue
+		}
+		var target float64
+		switch {
+		case from < to && i > from && i <= to:
+			target = -float64(d.height)
+		case to < from && i >= to && i < from:
+			target = float64(d.height)
+		}
+		row.easeOffset(target)
+	}
+}
+
+// easeOffset は、行のずれをtargetへ1フレーム分近づけます。決定的モードでは、直ちにtargetにします。
+func (r *listRow) easeOffset(target float64) {
+	next := r.offset + (target-r.offset)*listReorderEasing
+	if math.Abs(target-next) < 0.5 || clock.IsDeterministic() {
+		next = target
+	}
+	r.setOffset(next)
+}
+
+// autoScrollDuringDrag は、ドラッグ中のポインタが表示領域の上下端に近づいたときにスクロールします。
+func (l *List) autoScrollDuringDrag(cursorY int) {
+	_, y := l.GetPosition()
+	_, h := l.GetSize()
+	padding := l.GetPadding()
+	viewH := h - padding.Top - padding.Bottom
+	maxScrollY := float64(max(0, l.contentHeight-viewH))
+
+	scrollY := l.GetScrollY()
+	switch {
+	case cursorY < y+listAutoScrollMargin:
+		scrollY = max(0, scrollY-listAutoScrollSpeed)
+	case cursorY > y+h-listAutoScrollMargin:
+		scrollY = min(maxScrollY, scrollY+listAutoScrollSpeed)
+	default:
+		return
+	}
+	if scrollY != l.GetScrollY() {
+		l.SetScrollY(scrollY)
+		// コンテンツの位置はレイアウトで決まるため、再レイアウトを要求します。
+		l.MarkDirty(true)
+	}
+}
+
+// drop は、ドラッグ中の行を移動先へ移動し、並べ替えを通知します。
+func (l *List) drop() {
+	from, to := slices.Index(l.rows, l.drag.row), l.drag.to
+	l.endDrag()
+	if from < 0 || from == to {
+		return
+	}
+	// モデルを設定したリストでは、移動をモデルに対して行います。
+	// 行はモデルのChangeMoveの通知によってのみ移動するため、二重に移動したりモデルと食い違ったりしません。
+	if l.model != nil {
+		if movable, ok := l.model.(binding.MovableListModel); ok {
+			movable.Move(from, to)
+		}
+	} else {
+		l.MoveItem(from, to)
+	}
+	for _, fn := range l.onReorder {
+		fn(from, to)
+	}
+}
+
+// endDrag は、押下とドラッグの状態を破棄し、すべての行を元の位置に戻します。
+func (l *List) endDrag() {
+	if l.drag.dragging {
+		for _, row := range l.rows {
+			row.setOffset(0)
+		}
+		l.MarkDirty(false)
+	}
+	l.drag = listDrag{}
+}
+
+// drawDragHandle は、行の右端にドラッグハンドル(3本の横線)を描画します。
+func (l *List) drawDragHandle(screen *ebiten.Image, bounds image.Rectangle) {
+	const lineWidth, lineGap = 8, 3
+	x := float32(bounds.Max.X - (listDragHandleWidth+lineWidth)/2)
+	y := float32(bounds.Min.Y + bounds.Dy()/2 - lineGap)
+	for i := range 3 {
+		component.DrawFilledRect(screen, x, y+float32(i*lineGap)-0.5, lineWidth, 1, l.dragHandleColor)
+	}
+}
@@ -0,0 +1,193 @@
+package widget
+
+import (
+	"furoshiki/component"
+	"furoshiki/style"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// このファイルは、Listの複数選択とキーボードフォーカスを提供します。
+//
+// 複数選択(SelectionMultiple)では、次の操作で選択を変更します。
+//
+//	クリック         その項目だけを選択します
+//	Ctrl+クリック    その項目の選択を切り替えます (macOSではCmd)
+//	Shift+クリック   範囲選択の起点からその項目までを選択します
+//	Ctrl+A           フォーカス中にすべての項目を選択します
+
+// SelectionMode は、Listで同時に選択できる項目の数を表します。
+type SelectionMode int
+
+const (
+	// SelectionSingle は、1つの項目だけを選択できるモードです。既定値です。
+	SelectionSingle SelectionMode = iota
+	// SelectionMultiple は、複数の項目を選択できるモードです。
+	SelectionMultiple
+)
+
+// SetSelectionMode は、選択のモードを設定します。
+// 単一選択に切り替えた場合は、Selected()の項目だけが選択されたまま残ります。
+func (l *List) SetSelectionMode(mode SelectionMode) {
+	if l.mode == mode {
+		return
+	}
+	l.mode = mode
+	if mode == SelectionSingle {
+		if index := l.Selected(); index >= 0 && len(l.SelectedIndices()) > 1 {
+			l.Select(index)
+		}
+	}
+}
+
+// SelectionMode は、選択のモードを返します。
+func (l *List) SelectionMode() SelectionMode {
+	return l.mode
+}
+
+// SelectedIndices は、選択中のすべての項目のインデックスを昇順で返します。
+func (l *List) SelectedIndices() []int {
+	var indices []int
+	for i, row := range l.rows {
+		if row.selected {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// IsSelected は、指定されたインデックスの項目が選択されているかどうかを返します。
+func (l *List) IsSelected(index int) bool {
+	return index >= 0 && index < len(l.rows) && l.rows[index].selected
+}
+
+// SelectAll は、すべての項目を選択します。単一選択のモードでは何もしません。
+func (l *List) SelectAll() {
+	if l.mode != SelectionMultiple {
+		return
+	}
+	changed := false
+	for i, row := range l.rows {
+		if l.isSelectable(i) && row.setSelected(true) {
+			changed = true
+		}
+	}
+	if changed {
+		l.notifySelection()
+	}
+}
+
+// ToggleSelection は、指定されたインデックスの項目の選択を切り替え、その行を現在の行にします。
+// 単一選択のモードでは、Selectと同じくその項目だけを選択します。
+func (l *List) ToggleSelection(index int) {
+	if index < 0 || index >= len(l.rows) || !l.isSelectable(index) {
+		return
+	}
+	if l.mode != SelectionMultiple {
+		l.Select(index)
+		return
+	}
+	l.rows[index].setSelected(!l.rows[index].selected)
+	l.setCursor(index)
+	l.anchor = index
+	l.notifySelection()
+}
+
+// SelectRange は、fromからtoまで(両端を含む)の項目だけを選択し、toの行を現在の行にします。
+// 範囲選択の起点はfromになります。単一選択のモードでは、toの項目だけを選択します。
+// 範囲内の見出しの行と非表示の行は選択されません。
+func (l *List) SelectRange(from, to int) {
+	if from < 0 || from >= len(l.rows) || to < 0 || to >= len(l.rows) || !l.isSelectable(to) {
+		return
+	}
+	if l.mode != SelectionMultiple {
+		l.Select(to)
+		return
+	}
+	lo, hi := min(from, to), max(from, to)
+	changed := false
+	for i, row := range l.rows {
+		if row.setSelected(i >= lo && i <= hi && l.isSelectable(i)) {
+			changed = true
+		}
+	}
+	l.setCursor(to)
+	l.anchor = from
+	if changed {
+		l.notifySelection()
+	}
+}
+
+// SetFocusedStyle は、フォーカス中に現在の行を示すスタイルを設定します。
+func (l *List) SetFocusedStyle(s style.Style) {
+	l.focusedStyle = style.Resolve(s)
+	l.MarkDirty(false)
+}
+
+// setCursor は、現在の行を変更し、新旧の行の再描画を要求します。
+func (l *List) setCursor(index int) {
+	if l.cursor == index {
+		return
+	}
+	if l.cursor >= 0 && l.cursor < len(l.rows) {
+		l.rows[l.cursor].MarkDirty(false)
+	}
+	l.cursor = index
+	if index >= 0 {
+		l.rows[index].MarkDirty(false)
+	}
+}
+
+// handleRowPointerDown は、行の上でのマウスの押下を、押されている修飾キーに応じた選択の操作に変換します。
+func (l *List) handleRowPointerDown(index int) {
+	if l.mode == SelectionMultiple {
+		switch {
+		case ebiten.IsKeyPressed(ebiten.KeyShift) && l.anchor >= 0:
+			l.SelectRange(l.anchor, index)
+			return
+		case shortcutModifierPressed():
+			l.ToggleSelection(index)
+			return
+		}
+	}
+	l.Select(index)
+}
+
+// SetFocused は、Focusableインターフェースの実装です。フォーカスの変化に応じて現在の行を再描画します。
+func (l *List) SetFocused(focused bool) {
+	if l.focused == focused {
+		return
+	}
+	l.focused = focused
+	if l.cursor >= 0 && l.cursor < len(l.rows) {
+		l.rows[l.cursor].MarkDirty(false)
+	}
+}
+
+// IsFocused は、リストがキーボードフォーカスを持っているかどうかを返します。
+func (l *List) IsFocused() bool {
+	return l.focused
+}
+
+// handleKeys は、フォーカス中のキー操作を処理します。リストの外側が押された場合はフォーカスを手放します。
+func (l *List) handleKeys() {
+	if !l.focused {
+		return
+	}
+	if !l.IsVisible() || l.IsDisabled() {
+		component.Blur(l)
+		return
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if cx, cy := ebiten.CursorPosition(); l.HitTest(cx, cy) == nil && component.HitTestPopups(cx, cy) == nil {
+			component.Blur(l)
+			return
+		}
+	}
+	if shortcutModifierPressed() && inpututil.IsKeyJustPressed(ebiten.KeyA) {
+		l.SelectAll()
+		return
+	}
+	l.handleNavigation()
+}
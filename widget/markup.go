@@ -0,0 +1,201 @@
+package widget
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// このファイルは、文字列に埋め込んだ簡易マークアップをRichLabelの区間(Span)に変換する機能を提供します。
+//
+// 対応するタグ:
+//
+//	[color=#ff0000]...[/color]  文字色 (#rgb, #rrggbb, #rrggbbaa)
+//	[bg=#303030]...[/bg]        背景色
+//	[b]...[/b]                  太字 (MarkupStyle.BoldFont)
+//	[i]...[/i]                  斜体 (MarkupStyle.ItalicFont)
+//	[u]...[/u]                  下線
+//	[icon=sword]                インラインアイコン (MarkupStyle.Icons)
+//
+// "[[" は角括弧そのもの "[" として扱われます。タグは入れ子にできますが、閉じる順序は開いた順の逆である必要があります。
+
+var (
+	// ErrMarkupSyntax は、タグの書式が不正な場合のエラーです。
+	ErrMarkupSyntax = errors.New("invalid markup")
+	// ErrMarkupUnknownIcon は、登録されていないアイコン名が指定された場合のエラーです。
+	ErrMarkupUnknownIcon = errors.New("unknown markup icon")
+)
+
+// MarkupStyle は、マークアップのタグを解釈する際に使用するフォントとアイコンを保持します。
+// フォントが未設定の場合、[b]や[i]のタグは受け付けられますが、フォントは変化しません。
+type MarkupStyle struct {
+	BoldFont   font.Face
+	ItalicFont font.Face
+	// Icons は、[icon=name] で参照できる画像です。
+	Icons map[string]*ebiten.Image
+}
+
+var (
+	defaultMarkupStyle = &MarkupStyle{}
+	markupMutex        sync.RWMutex
+)
+
+// SetDefaultMarkupStyle は、ParseMarkupとRichLabel.SetMarkupで使用されるスタイルを設定します。
+func SetDefaultMarkupStyle(ms *MarkupStyle) {
+	if ms == nil {
+		ms = &MarkupStyle{}
+	}
+	markupMutex.Lock()
+	defer markupMutex.Unlock()
+	defaultMarkupStyle = ms
+}
+
+// GetDefaultMarkupStyle は、現在のデフォルトのマークアップスタイルを返します。
+func GetDefaultMarkupStyle() *MarkupStyle {
+	markupMutex.RLock()
+	defer markupMutex.RUnlock()
+	return defaultMarkupStyle
+}
+
+// ParseMarkup は、デフォルトのマークアップスタイルを使用して文字列を区間に変換します。
+func ParseMarkup(s string) ([]Span, error) {
+	return GetDefaultMarkupStyle().Parse(s)
+}
+
+// markupState は、解析中に開いているタグと、それによって決まる現在の装飾を保持します。
+type markupState struct {
+	tag  string
+	span Span // Textを除いた装飾
+}
+
+// Parse は、マークアップを含む文字列を区間に変換します。
+// 装飾が同じ連続したテキストは1つの区間にまとめられます。
+func (ms *MarkupStyle) Parse(s string) ([]Span, error) {
+	var spans []Span
+	var sb strings.Builder
+	stack := []markupState{{}}
+
+	flush := func() {
+		if sb.Len() == 0 {
+			return
+		}
+		span := stack[len(stack)-1].span
+		span.Text = sb.String()
+		spans = append(spans, span)
+		sb.Reset()
+	}
+
+	for i := 0; i < len(s); {
+		if s[i] != '[' {
+			n := strings.IndexByte(s[i:], '[')
+			if n < 0 {
+				n = len(s) - i
+			}
+			sb.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		if strings.HasPrefix(s[i:], "[[") {
+			sb.WriteByte('[')
+			i += 2
+			continue
+		}
+		end := strings.IndexByte(s[i:], ']')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated tag at offset %d", ErrMarkupSyntax, i)
+		}
+		tag := s[i+1 : i+end]
+		offset := i
+		i += end + 1
+
+		// 閉じタグ
+		if name, ok := strings.CutPrefix(tag, "/"); ok {
+			top := stack[len(stack)-1]
+			if len(stack) == 1 || top.tag != name {
+				return nil, fmt.Errorf("%w: unexpected [/%s] at offset %d", ErrMarkupSyntax, name, offset)
+			}
+			flush()
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(tag, "=")
+		current := stack[len(stack)-1].span
+		switch name {
+		case "icon":
+			img, ok := ms.Icons[value]
+			if !hasValue || !ok || img == nil {
+				return nil, fmt.Errorf("%w: %q at offset %d", ErrMarkupUnknownIcon, value, offset)
+			}
+			flush()
+			// アイコンは周囲の装飾(背景色や下線)を引き継ぐ、テキストを持たない区間です。
+			icon := current
+			icon.Icon = img
+			spans = append(spans, icon)
+			continue
+		case "color", "bg":
+			if !hasValue {
+				return nil, fmt.Errorf("%w: [%s] requires a value at offset %d", ErrMarkupSyntax, name, offset)
+			}
+			clr, err := parseHexColor(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: [%s=%s] at offset %d: %v", ErrMarkupSyntax, name, value, offset, err)
+			}
+			if name == "color" {
+				current.Color = clr
+			} else {
+				current.Background = clr
+			}
+		case "b", "i", "u":
+			if hasValue {
+				return nil, fmt.Errorf("%w: [%s] takes no value at offset %d", ErrMarkupSyntax, name, offset)
+			}
+			switch {
+			case name == "u":
+				current.Underline = true
+			case name == "b" && ms.BoldFont != nil:
+				current.Font = ms.BoldFont
+			case name == "i" && ms.ItalicFont != nil:
+				current.Font = ms.ItalicFont
+			}
+		default:
+			return nil, fmt.Errorf("%w: unknown tag [%s] at offset %d", ErrMarkupSyntax, tag, offset)
+		}
+		flush()
+		stack = append(stack, markupState{tag: name, span: current})
+	}
+
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("%w: unclosed [%s]", ErrMarkupSyntax, stack[len(stack)-1].tag)
+	}
+	flush()
+	return spans, nil
+}
+
+// parseHexColor は、"#rgb", "#rrggbb", "#rrggbbaa" 形式の文字列を色に変換します。
+func parseHexColor(s string) (color.Color, error) {
+	hex, ok := strings.CutPrefix(s, "#")
+	if !ok {
+		return nil, errors.New("color must start with '#'")
+	}
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return nil, errors.New("color must have 3, 6 or 8 hex digits")
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, err
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
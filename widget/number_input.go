@@ -0,0 +1,336 @@
+package widget
+
+import (
+	"errors"
+	"furoshiki/component"
+	"furoshiki/event"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var (
+	// ErrInvalidNumberRange は、NumberInputの範囲の最小値が最大値より大きい場合のエラーです。
+	ErrInvalidNumberRange = errors.New("number range must satisfy min <= max")
+	// ErrInvalidNumberStep は、NumberInputの増減幅に0以下の値が指定された場合のエラーです。
+	ErrInvalidNumberStep = errors.New("number step must be positive")
+)
+
+// thousandsSeparator は、桁区切りに使用する文字です。
+const thousandsSeparator = ','
+
+// NumberInput は、数値を入力するための入力欄です。
+// 数字、小数点、符号、桁区切りのみを受け付け、フォーカスを失ったときに範囲内に丸めて書式を整えます。
+// フォーカス中は上下の矢印キーやマウスホイールで、設定された増減幅ずつ値を変更できます。
+//
+// Value()は、TextInputの文字列ではなく数値を返します。入力されている文字列はText()で取得します。
+type NumberInput struct {
+	*TextInput
+	value    float64
+	min, max float64
+	step     float64
+	// decimals は、書式を整える際の小数点以下の桁数です。負の値の場合は必要な桁数だけ表示します。
+	decimals int
+	// grouping がtrueの場合、フォーカスを持っていない間は整数部を3桁ごとに区切って表示します。
+	grouping bool
+
+	onValueChange []func(value float64)
+}
+
+// newNumberInput は、NumberInputの新しいインスタンスを生成し、初期化します。
+// NOTE: ウィジェットの生成には常にNewNumberInputBuilder()を使用してください。
+func newNumberInput() (*NumberInput, error) {
+	n := &NumberInput{
+		TextInput: &TextInput{},
+		min:       math.Inf(-1),
+		max:       math.Inf(1),
+		step:      1,
+		decimals:  -1,
+	}
+	if err := n.initTextInput(n); err != nil {
+		return nil, err
+	}
+	n.SetInputFilter(n.accepts)
+	// 入力中は数値として解釈できた時点で値を更新し、範囲への丸めと書式の整形はフォーカスを失ったときに行います。
+	n.AddOnChange(func(text string) {
+		if v, ok := parseNumber(text); ok {
+			n.setValue(n.clamp(v))
+		}
+	})
+	n.AddOnFocusChange(func(focused bool) {
+		if focused {
+			// 編集しやすいように、桁区切りを除いた表記に戻します。
+			if raw := n.format(n.value, false); raw != n.Text() {
+				n.TextInput.SetValue(raw)
+			}
+			return
+		}
+		n.commit()
+	})
+	// フォーカス中のホイール操作で値を増減します。
+	n.AddEventHandler(event.MouseScroll, func(e *event.Event) event.Propagation {
+		if !n.IsFocused() || e.ScrollY == 0 {
+			return event.Propagate
+		}
+		if e.ScrollY > 0 {
+			n.Increment(1)
+		} else {
+			n.Increment(-1)
+		}
+		return event.StopPropagation
+	})
+	n.SetValue(0)
+	return n, nil
+}
+
+// accepts は、入力を受け付ける文字かどうかを返します。
+func (n *NumberInput) accepts(r rune) bool {
+	switch {
+	case r >= '0' && r <= '9', r == '.', r == thousandsSeparator:
+		return true
+	case r == '-':
+		return n.min < 0
+	default:
+		return false
+	}
+}
+
+// parseNumber は、桁区切りを取り除いてから文字列を数値として解釈します。
+func parseNumber(s string) (float64, bool) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), string(thousandsSeparator), "")
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, false
+	}
+	return v, true
+}
+
+// Value は、現在の数値を返します。値は常に設定された範囲内にあります。
+func (n *NumberInput) Value() float64 {
+	return n.value
+}
+
+// Text は、入力欄に表示されている文字列を返します。
+func (n *NumberInput) Text() string {
+	return n.TextInput.Value()
+}
+
+// SetValue は、値を範囲内に丸めて設定し、書式を整えて表示します。変更時のコールバックは呼び出されません。
+func (n *NumberInput) SetValue(v float64) {
+	n.value = n.clamp(v)
+	n.TextInput.SetValue(n.format(n.value, !n.IsFocused()))
+}
+
+// setValue は、値を更新し、変化があればコールバックを呼び出します。表示は変更しません。
+func (n *NumberInput) setValue(v float64) {
+	if n.value == v {
+		return
+	}
+	n.value = v
+	for _, fn := range n.onValueChange {
+		fn(v)
+	}
+}
+
+// commit は、入力された文字列を数値として確定し、範囲内に丸めて書式を整えます。
+// 数値として解釈できない場合は、直前の値に戻します。
+func (n *NumberInput) commit() {
+	if v, ok := parseNumber(n.Text()); ok {
+		n.setValue(n.clamp(v))
+	}
+	formatted := n.format(n.value, !n.IsFocused())
+	if formatted != n.Text() {
+		n.TextInput.SetValue(formatted)
+	}
+}
+
+// Increment は、値を増減幅のsteps倍だけ変更します。負の値を指定すると減少します。
+func (n *NumberInput) Increment(steps int) {
+	if n.IsDisabled() {
+		return
+	}
+	v := n.value
+	if parsed, ok := parseNumber(n.Text()); ok {
+		v = parsed
+	}
+	v = n.clamp(n.roundToDecimals(v + float64(steps)*n.step))
+	if v == n.value && n.format(v, !n.IsFocused()) == n.Text() {
+		return
+	}
+	// 増減は取り消し可能なユーザー操作として扱います。値の更新は変更時のコールバックで行われます。
+	n.replaceValue(n.format(v, !n.IsFocused()))
+}
+
+// SetRange は、値の範囲を設定し、現在の値を範囲内に丸めます。min > max の場合はエラーを返します。
+func (n *NumberInput) SetRange(min, max float64) error {
+	if min > max {
+		return ErrInvalidNumberRange
+	}
+	n.min, n.max = min, max
+	n.SetValue(n.value)
+	return nil
+}
+
+// Range は、値の範囲を返します。
+func (n *NumberInput) Range() (min, max float64) {
+	return n.min, n.max
+}
+
+// SetStep は、矢印キーやホイールによる増減幅を設定します。0以下の場合はエラーを返します。
+func (n *NumberInput) SetStep(step float64) error {
+	if step <= 0 {
+		return ErrInvalidNumberStep
+	}
+	n.step = step
+	return nil
+}
+
+// SetDecimals は、書式を整える際の小数点以下の桁数を設定します。負の値を指定すると必要な桁数だけ表示します。
+func (n *NumberInput) SetDecimals(decimals int) {
+	n.decimals = decimals
+	n.SetValue(n.value)
+}
+
+// SetGrouping は、フォーカスを持っていない間に整数部を3桁ごとに区切って表示するかどうかを設定します。
+func (n *NumberInput) SetGrouping(grouping bool) {
+	n.grouping = grouping
+	n.SetValue(n.value)
+}
+
+// AddOnValueChange は、ユーザーの操作によって数値が変化したときに呼び出される関数を追加します。
+func (n *NumberInput) AddOnValueChange(fn func(value float64)) {
+	if fn != nil {
+		n.onValueChange = append(n.onValueChange, fn)
+	}
+}
+
+func (n *NumberInput) clamp(v float64) float64 {
+	return max(n.min, min(n.max, v))
+}
+
+// roundToDecimals は、小数点以下の桁数が固定されている場合に、その桁数に丸めます。
+// 増減を繰り返したときに浮動小数点の誤差が蓄積するのを防ぎます。
+func (n *NumberInput) roundToDecimals(v float64) float64 {
+	if n.decimals < 0 {
+		return v
+	}
+	p := math.Pow(10, float64(n.decimals))
+	return math.Round(v*p) / p
+}
+
+// format は、数値を表示用の文字列に変換します。groupがtrueで桁区切りが有効な場合は、整数部を3桁ごとに区切ります。
+func (n *NumberInput) format(v float64, group bool) string {
+	s := strconv.FormatFloat(v, 'f', n.decimals, 64)
+	if !group || !n.grouping {
+		return s
+	}
+	return groupThousands(s)
+}
+
+// groupThousands は、数値の文字列の整数部に3桁ごとの区切りを挿入します。
+func groupThousands(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+	var sb strings.Builder
+	sb.WriteString(sign)
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteRune(thousandsSeparator)
+		}
+		sb.WriteRune(r)
+	}
+	sb.WriteString(frac)
+	return sb.String()
+}
+
+// Update は、TextInputの入力処理に加えて、フォーカス中の上下の矢印キーによる増減を処理します。
+func (n *NumberInput) Update() {
+	n.TextInput.Update()
+	if !n.IsFocused() {
+		return
+	}
+	switch {
+	case keyRepeated(ebiten.KeyArrowUp):
+		n.Increment(1)
+	case keyRepeated(ebiten.KeyArrowDown):
+		n.Increment(-1)
+	}
+}
+
+// Cleanup は、リソースを解放します。
+func (n *NumberInput) Cleanup() {
+	n.onValueChange = nil
+	n.TextInput.Cleanup()
+}
+
+// --- NumberInputBuilder ---
+
+// NumberInputBuilder は、NumberInputを宣言的に構築するためのビルダーです。
+type NumberInputBuilder struct {
+	component.Builder[*NumberInputBuilder, *NumberInput]
+}
+
+// NewNumberInputBuilder は新しいNumberInputBuilderを生成します。
+func NewNumberInputBuilder() *NumberInputBuilder {
+	n, err := newNumberInput()
+	b := &NumberInputBuilder{}
+	b.Init(b, n)
+	b.AddError(err)
+	return b
+}
+
+// Value は、初期値を設定します。
+func (b *NumberInputBuilder) Value(v float64) *NumberInputBuilder {
+	b.Widget.SetValue(v)
+	return b
+}
+
+// Range は、値の範囲を設定します。
+// 例: n.Range(0, 100).Step(5)
+func (b *NumberInputBuilder) Range(min, max float64) *NumberInputBuilder {
+	b.AddError(b.Widget.SetRange(min, max))
+	return b
+}
+
+// Step は、矢印キーやホイールによる増減幅を設定します。
+func (b *NumberInputBuilder) Step(step float64) *NumberInputBuilder {
+	b.AddError(b.Widget.SetStep(step))
+	return b
+}
+
+// Decimals は、書式を整える際の小数点以下の桁数を設定します。
+func (b *NumberInputBuilder) Decimals(decimals int) *NumberInputBuilder {
+	b.Widget.SetDecimals(decimals)
+	return b
+}
+
+// Grouping は、フォーカスを持っていない間に整数部を3桁ごとに区切って表示するかどうかを設定します。
+func (b *NumberInputBuilder) Grouping(grouping bool) *NumberInputBuilder {
+	b.Widget.SetGrouping(grouping)
+	return b
+}
+
+// Placeholder は、値が空でフォーカスを持っていないときに表示されるヒントの文字列を設定します。
+func (b *NumberInputBuilder) Placeholder(placeholder string) *NumberInputBuilder {
+	b.Widget.SetPlaceholder(placeholder)
+	return b
+}
+
+// OnValueChange は、ユーザーの操作によって数値が変化したときに呼び出される関数を追加します。
+func (b *NumberInputBuilder) OnValueChange(fn func(value float64)) *NumberInputBuilder {
+	b.Widget.AddOnValueChange(fn)
+	return b
+}
+
+// Build は、最終的なNumberInputを構築して返します。
+func (b *NumberInputBuilder) Build() (*NumberInput, error) {
+	return b.Builder.Build()
+}
@@ -0,0 +1,629 @@
+package widget
+
+import (
+	"furoshiki/component"
+	"furoshiki/i18n"
+	"furoshiki/stats"
+	"furoshiki/style"
+	"furoshiki/theme"
+	"image"
+	"image/color"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// Span は、RichLabelを構成するテキストの一区間（ラン）です。
+// 未設定(nil)のプロパティには、RichLabel自身のスタイルの値が使用されます。
+type Span struct {
+	Text string
+	// Color は文字色です。nilの場合はラベルのTextColorを使用します。
+	Color color.Color
+	// Font はフォントです。nilの場合はラベルのFontを使用します。
+	Font font.Face
+	// Background は、この区間の背後に敷く色です。検索結果の強調表示などに使用します。
+	Background color.Color
+	// Underline がtrueの場合、この区間に下線を引きます。
+	Underline bool
+	// Icon が設定されている場合、この区間はTextの代わりに画像を1つの単語として表示します。
+	// 画像の下端は行のテキストの下端に揃えられます。
+	Icon *ebiten.Image
+}
+
+// RichLabel は、色やフォントの異なる複数の区間(Span)からなるテキストを表示するウィジェットです。
+// 折り返しは区間をまたいで単語単位で行われるため、チャットログや、
+// 数値を色付けしたアイテム説明などを1つのウィジェットで表現できます。
+// "\n" を含む区間では、その位置で改行します。
+type RichLabel struct {
+	*component.LayoutableWidget
+	spans    []Span
+	wrapText bool
+	// textKey, textParams は、テキストをi18nのカタログから解決する場合のキーとパラメータです。
+	textKey    string
+	textParams i18n.Params
+	// localeFace は、ロケールに合わせて最後に適用したフェイスです。
+	localeFace font.Face
+
+	// layoutCache は、直近の行分割の結果です。計測と描画で同じ幅の行分割を再計算しないために使用します。
+	// 折り返さない場合(最小サイズの計測)と折り返す場合で、それぞれ1つずつ保持します。
+	layoutCache [2]richLayoutCache
+}
+
+// richRun は、1行の中で同じ区間に属する連続したテキストです。描画の単位になります。
+type richRun struct {
+	span  int // spansのインデックス
+	text  string
+	x     int // 行頭からの位置
+	width int
+}
+
+// richLine は、行分割後の1行です。
+type richLine struct {
+	runs   []richRun
+	width  int
+	ascent int
+	height int
+}
+
+// richLayoutCache は、(幅, フォント)の組に対する行分割の結果を保持します。
+type richLayoutCache struct {
+	valid  bool
+	width  int // 折り返し幅。折り返さない場合は0
+	face   font.Face
+	lines  []richLine
+	height int
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ component.HeightForWider = (*RichLabel)(nil)
+var _ component.WidthForHeighter = (*RichLabel)(nil)
+
+// newRichLabel は、RichLabelの新しいインスタンスを生成し、初期化します。
+// NOTE: ウィジェットの生成には常にNewRichLabelBuilder()を使用してください。
+func newRichLabel() (*RichLabel, error) {
+	l := &RichLabel{}
+	l.LayoutableWidget = component.NewLayoutableWidget()
+	if err := l.Init(l); err != nil {
+		return nil, err
+	}
+
+	t := theme.GetCurrent()
+	l.SetStyle(t.Label.Default)
+	l.SetSize(100, 30)
+
+	return l, nil
+}
+
+// Spans は、現在の区間のコピーを返します。
+func (l *RichLabel) Spans() []Span {
+	return append([]Span(nil), l.spans...)
+}
+
+// SetSpans は、表示する区間を置き換えます。SetTextKeyで設定したキーは解除されます。
+func (l *RichLabel) SetSpans(spans ...Span) {
+	if l.textKey != "" {
+		l.textKey, l.textParams = "", nil
+		component.UnregisterLocalizable(l)
+	}
+	l.spans = append(l.spans[:0:0], spans...)
+	l.invalidateLayout()
+	l.NotifyLabelChanged()
+}
+
+// AppendSpans は、末尾に区間を追加します。チャットログのように追記していく用途に使用します。
+func (l *RichLabel) AppendSpans(spans ...Span) {
+	if len(spans) == 0 {
+		return
+	}
+	l.spans = append(l.spans, spans...)
+	l.invalidateLayout()
+	l.NotifyLabelChanged()
+}
+
+// DefaultAccessibleRole は、component.DefaultRoleProviderインターフェースの実装です。
+func (l *RichLabel) DefaultAccessibleRole() component.Role {
+	return component.RoleText
+}
+
+// DefaultAccessibleLabel は、component.DefaultLabelProviderインターフェースの実装です。装飾を除いたテキストを名前として返します。
+func (l *RichLabel) DefaultAccessibleLabel() string {
+	return l.Text()
+}
+
+// Text は、すべての区間のテキストを連結した文字列を返します。
+func (l *RichLabel) Text() string {
+	var sb strings.Builder
+	for _, s := range l.spans {
+		sb.WriteString(s.Text)
+	}
+	return sb.String()
+}
+
+// SetText は、ラベルのスタイルをそのまま使う1つの区間でテキストを置き換えます。
+func (l *RichLabel) SetText(t string) {
+	l.SetSpans(Span{Text: t})
+}
+
+// SetTextKey は、i18nのカタログのキーを設定し、現在のロケールで解決したテキストを1つの区間として表示します。
+// ロケールが変わると、テキストとフォントは自動的に解決し直され、再レイアウトされます。
+func (l *RichLabel) SetTextKey(key string, params i18n.Params) {
+	l.localeFace = l.ApplyLocaleFace(l.localeFace)
+	l.SetText(i18n.T(key, params))
+	l.textKey, l.textParams = key, params
+	component.RegisterLocalizable(l)
+}
+
+// RefreshLocale は、component.Localizableインターフェースの実装です。現在のロケールでテキストとフォントを解決し直します。
+func (l *RichLabel) RefreshLocale() {
+	if l.textKey != "" {
+		l.SetTextKey(l.textKey, l.textParams)
+	}
+}
+
+// Cleanup は、ロケールの変更の通知を解除してから、ウィジェットのリソースを解放します。
+func (l *RichLabel) Cleanup() {
+	component.UnregisterLocalizable(l)
+	l.LayoutableWidget.Cleanup()
+}
+
+// TextKey は、SetTextKeyで設定したキーを返します。区間を直接設定している場合は空文字列です。
+func (l *RichLabel) TextKey() string {
+	return l.textKey
+}
+
+// SetMarkup は、デフォルトのマークアップスタイルで文字列を解析し、その結果で区間を置き換えます。
+// 解析に失敗した場合、区間は変更されません。
+// 例: l.SetMarkup("[color=#ff4040]crit![/color] [icon=sword] +12")
+func (l *RichLabel) SetMarkup(markup string) error {
+	spans, err := ParseMarkup(markup)
+	if err != nil {
+		return err
+	}
+	l.SetSpans(spans...)
+	return nil
+}
+
+// SetWrapText は、幅を超えるテキストを折り返すかどうかを設定します。
+func (l *RichLabel) SetWrapText(wrap bool) {
+	if l.wrapText != wrap {
+		l.wrapText = wrap
+		l.invalidateLayout()
+	}
+}
+
+// SetStyle は、スタイルを設定し、フォントやパディングの変更に備えて行分割の結果を破棄します。
+func (l *RichLabel) SetStyle(s style.Style) {
+	l.LayoutableWidget.SetStyle(s)
+	l.layoutCache = [2]richLayoutCache{}
+}
+
+// InvalidateMeasure は、基本の計測キャッシュに加えて行分割の結果も破棄し、再レイアウトを要求します。
+func (l *RichLabel) InvalidateMeasure() {
+	l.layoutCache = [2]richLayoutCache{}
+	l.LayoutableWidget.InvalidateMeasure()
+}
+
+// invalidateLayout は、行分割の結果と計測キャッシュを破棄し、再レイアウトを要求します。
+func (l *RichLabel) invalidateLayout() {
+	l.InvalidateMeasure()
+}
+
+// GetMinSize は、ユーザーが設定した最小サイズと、テキストが必要とする最小サイズの大きい方を返します。
+// 折り返しが有効な場合、最小幅は最も長い単語の幅になります。
+func (l *RichLabel) GetMinSize() (int, int) {
+	userW, userH := l.LayoutableWidget.GetMinSize()
+	c := l.ComputedStyle()
+	if c.Font == nil || len(l.spans) == 0 {
+		return userW, userH
+	}
+	var contentW, contentH int
+	if l.wrapText {
+		contentW = l.longestWordWidth(c.Font)
+		// 最も長い単語の幅で折り返した場合の高さではなく、1行分の高さを最小とします。
+		_, contentH = l.measureLine(c.Font)
+	} else {
+		contentW, contentH = l.measureLine(c.Font)
+	}
+	contentW += c.Padding.Left + c.Padding.Right
+	contentH += c.Padding.Top + c.Padding.Bottom
+	return max(userW, contentW), max(userH, contentH)
+}
+
+// GetHeightForWidth は、HeightForWiderインターフェースの実装です。
+// 指定された幅で区間をまたいで折り返した場合に必要となる高さを返します。
+func (l *RichLabel) GetHeightForWidth(width int) int {
+	if !l.wrapText {
+		_, h := l.GetMinSize()
+		return h
+	}
+	c := l.ComputedStyle()
+	if c.Font == nil || len(l.spans) == 0 {
+		return 0
+	}
+	contentWidth := width - c.Padding.Left - c.Padding.Right
+	if contentWidth <= 0 {
+		_, h := l.GetMinSize()
+		return h
+	}
+	_, height := l.layoutLines(c.Font, contentWidth)
+	return height + c.Padding.Top + c.Padding.Bottom
+}
+
+// GetWidthForHeight は、WidthForHeighterインターフェースの実装です。
+// 指定された高さに収まるように区間をまたいで折り返した場合の、最も狭い幅を返します。
+// 折り返しが無効な場合は、通常の最小幅を返します。
+func (l *RichLabel) GetWidthForHeight(height int) int {
+	minW, _ := l.GetMinSize()
+	c := l.ComputedStyle()
+	if !l.wrapText || c.Font == nil || len(l.spans) == 0 {
+		return minW
+	}
+	return l.CachedWidthForHeight(height, func(height int) int {
+		paddingX := c.Padding.Left + c.Padding.Right
+		paddingY := c.Padding.Top + c.Padding.Bottom
+		// NOTE: 探索中の行分割はlayoutLinesのキャッシュを経由せず、描画用の結果を上書きしないようにします。
+		fits := func(width int) bool {
+			total := 0
+			for _, line := range l.breakLines(c.Font, width-paddingX) {
+				total += line.height
+			}
+			return total+paddingY <= height
+		}
+		lineW, _ := l.measureLine(c.Font)
+		lo, hi := minW, max(minW, lineW+paddingX)
+		if !fits(hi) {
+			return hi
+		}
+		// 最も長い単語の幅と1行に並べた幅の間で、収まる最も狭い幅を二分探索します。
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if fits(mid) {
+				hi = mid
+			} else {
+				lo = mid + 1
+			}
+		}
+		return lo
+	})
+}
+
+// measureLine は、折り返さずに1行に並べた場合の幅と高さを返します（"\n"による改行は考慮します）。
+func (l *RichLabel) measureLine(base font.Face) (int, int) {
+	lines, height := l.layoutLines(base, 0)
+	width := 0
+	for _, line := range lines {
+		width = max(width, line.width)
+	}
+	return width, height
+}
+
+// layoutLines は、指定された幅で区間を行に分割します。maxWidthが0の場合は折り返しません。
+// 直前の呼び出しと同じ(幅, フォント)の組であれば、キャッシュされた結果を再利用します。
+func (l *RichLabel) layoutLines(base font.Face, maxWidth int) ([]richLine, int) {
+	c := &l.layoutCache[0]
+	if maxWidth > 0 {
+		c = &l.layoutCache[1]
+	}
+	if c.valid && c.width == maxWidth && c.face == base {
+		return c.lines, c.height
+	}
+	lines := l.breakLines(base, maxWidth)
+	height := 0
+	for _, line := range lines {
+		height += line.height
+	}
+	*c = richLayoutCache{valid: true, width: maxWidth, face: base, lines: lines, height: height}
+	return lines, height
+}
+
+// faceOf は、区間に使用するフォントを返します。
+func (l *RichLabel) faceOf(span int, base font.Face) font.Face {
+	if f := l.spans[span].Font; f != nil {
+		return f
+	}
+	return base
+}
+
+// richPiece は、行分割の途中で扱う、単一区間内の単語または空白の断片です。
+type richPiece struct {
+	span  int
+	text  string
+	width int
+}
+
+// breakLines は、区間を単語と空白の断片に分け、貪欲法で行に詰めていきます。
+// 単語は区間をまたいで連続していてもよく（例: 赤の"crit"と白の"!"）、空白と改行の位置で折り返します。
+// スタイルのLineBreakに従い、日本語などの文字の間でも折り返します。ただし、区間の境界をまたぐ禁則処理は行いません。
+func (l *RichLabel) breakLines(base font.Face, maxWidth int) []richLine {
+	mode := l.ComputedStyle().LineBreak
+	var lines []richLine
+	var current richLine
+	var word, spaces []richPiece
+	wordWidth, spacesWidth := 0, 0
+
+	appendPiece := func(line *richLine, p richPiece) {
+		if n := len(line.runs); n > 0 && line.runs[n-1].span == p.span {
+			// 同じ区間の断片は1つのランにまとめ、描画回数を減らします。
+			line.runs[n-1].text += p.text
+			line.runs[n-1].width += p.width
+		} else {
+			line.runs = append(line.runs, richRun{span: p.span, text: p.text, x: line.width, width: p.width})
+		}
+		line.width += p.width
+		m := component.FaceMetrics(l.faceOf(p.span, base))
+		ascent, descent := m.Ascent.Ceil(), m.Descent.Ceil()
+		if icon := l.spans[p.span].Icon; icon != nil {
+			// アイコンは下端をフォントのディセンダーの位置に合わせ、残りの高さをアセントとして扱います。
+			ascent = max(ascent, icon.Bounds().Dy()-descent)
+		}
+		line.ascent = max(line.ascent, ascent)
+		line.height = max(line.height, line.ascent+descent, (m.Ascent + m.Descent).Ceil())
+	}
+	finishLine := func() {
+		if current.height == 0 {
+			// 空行の高さは基本フォントの1行分とします。
+			m := component.FaceMetrics(base)
+			current.ascent = m.Ascent.Ceil()
+			current.height = (m.Ascent + m.Descent).Ceil()
+		}
+		lines = append(lines, current)
+		current = richLine{}
+	}
+	flushWord := func() {
+		if len(word) == 0 {
+			return
+		}
+		if maxWidth > 0 && len(current.runs) > 0 && current.width+spacesWidth+wordWidth > maxWidth {
+			// 単語が収まらない場合は改行し、行末の空白は捨てます。
+			finishLine()
+		} else {
+			for _, p := range spaces {
+				appendPiece(&current, p)
+			}
+		}
+		for _, p := range word {
+			appendPiece(&current, p)
+		}
+		word, spaces = word[:0], spaces[:0]
+		wordWidth, spacesWidth = 0, 0
+	}
+
+	for i, span := range l.spans {
+		if span.Icon != nil {
+			// アイコンは前後のテキストと連続した単語の一部として扱います。
+			w := span.Icon.Bounds().Dx()
+			word = append(word, richPiece{span: i, width: w})
+			wordWidth += w
+			continue
+		}
+		face := l.faceOf(i, base)
+		rest := span.Text
+		for rest != "" {
+			r, _ := utf8.DecodeRuneInString(rest)
+			switch {
+			case r == '\n':
+				flushWord()
+				spaces, spacesWidth = spaces[:0], 0
+				finishLine()
+				rest = rest[1:]
+			case unicode.IsSpace(r):
+				flushWord()
+				n := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsSpace(r) || r == '\n' })
+				if n < 0 {
+					n = len(rest)
+				}
+				w := component.MeasureString(face, rest[:n]).Ceil()
+				// 行頭の空白は描画しません。
+				if len(current.runs) > 0 {
+					spaces = append(spaces, richPiece{span: i, text: rest[:n], width: w})
+					spacesWidth += w
+				}
+				rest = rest[n:]
+			default:
+				n := strings.IndexFunc(rest, unicode.IsSpace)
+				if n < 0 {
+					n = len(rest)
+				}
+				// 日本語などの文字の間で改行できる位置では、空白がなくても単語を区切ります。
+				units := component.LineBreakUnits(rest[:n], mode)
+				for k, unit := range units {
+					if k > 0 {
+						flushWord()
+					}
+					w := component.MeasureString(face, unit).Ceil()
+					word = append(word, richPiece{span: i, text: unit, width: w})
+					wordWidth += w
+				}
+				rest = rest[n:]
+			}
+		}
+	}
+	flushWord()
+	finishLine()
+	return lines
+}
+
+// longestWordWidth は、区間をまたいだ単語のうち最も幅の広いものの幅を返します。
+// 日本語などの文字の間で改行できる単語は、改行できる位置で分割した部分の幅で比べます。
+func (l *RichLabel) longestWordWidth(base font.Face) int {
+	mode := l.ComputedStyle().LineBreak
+	longest, current := 0, 0
+	// addWord は、空白を含まない文字列の幅を現在の単語に加えます。途中で改行できる場合は、そこで単語を区切ります。
+	addWord := func(face font.Face, word string) {
+		for k, unit := range component.LineBreakUnits(word, mode) {
+			if k > 0 {
+				longest = max(longest, current)
+				current = 0
+			}
+			current += component.MeasureString(face, unit).Ceil()
+		}
+	}
+	for i, span := range l.spans {
+		if span.Icon != nil {
+			current += span.Icon.Bounds().Dx()
+			continue
+		}
+		face := l.faceOf(i, base)
+		start := 0
+		for j, r := range span.Text {
+			if unicode.IsSpace(r) {
+				if j > start {
+					addWord(face, span.Text[start:j])
+				}
+				longest = max(longest, current)
+				current = 0
+				start = j + utf8.RuneLen(r)
+			}
+		}
+		if start < len(span.Text) {
+			addWord(face, span.Text[start:])
+		}
+	}
+	return max(longest, current)
+}
+
+// Draw は、背景と各区間のテキストを描画します。
+func (l *RichLabel) Draw(info component.DrawInfo) {
+	if !l.IsVisible() || !l.HasBeenLaidOut() {
+		return
+	}
+	x, y := l.GetPosition()
+	width, height := l.GetSize()
+	finalX, finalY := x+info.OffsetX, y+info.OffsetY
+	c := l.ComputedStyle()
+
+	component.DrawComputedBackground(info.Screen, finalX, finalY, width, height, c)
+	if c.Font == nil || len(l.spans) == 0 {
+		return
+	}
+
+	content := image.Rect(finalX+c.Padding.Left, finalY+c.Padding.Top, finalX+width-c.Padding.Right, finalY+height-c.Padding.Bottom)
+	maxWidth := 0
+	if l.wrapText {
+		maxWidth = content.Dx()
+		if maxWidth <= 0 {
+			return
+		}
+	}
+	lines, totalHeight := l.layoutLines(c.Font, maxWidth)
+
+	lineY := content.Min.Y
+	switch c.VerticalAlign {
+	case style.VerticalAlignMiddle:
+		lineY += (content.Dy() - totalHeight) / 2
+	case style.VerticalAlignBottom:
+		lineY = content.Max.Y - totalHeight
+	}
+
+	rtl := component.IsRTL(l.Text())
+	for _, line := range lines {
+		lineX := content.Min.X
+		switch component.ResolveTextAlign(c.TextAlign, rtl) {
+		case style.TextAlignCenter:
+			lineX += (content.Dx() - line.width) / 2
+		case style.TextAlignRight:
+			lineX = content.Max.X - line.width
+		}
+		l.drawLine(info, line, lineX, lineY, c, rtl)
+		lineY += line.height
+	}
+}
+
+// drawLine は、1行分のランを描画します。
+// 区間の背景をバッチで描画した後にテキストを直接描画し、最後に下線をバッチに追加します。
+// 右から左に書く段落(rtl)では、ランを右から順に並べ、各ランのテキストを表示順に並べ替えます。
+// NOTE: 区間の境界をまたぐ並べ替えは行わないため、段落と逆方向のテキストが複数の区間にまたがると、区間の順序は段落の方向になります。
+func (l *RichLabel) drawLine(info component.DrawInfo, line richLine, lineX, lineY int, c style.Computed, rtl bool) {
+	if rtl {
+		mirrored := make([]richRun, len(line.runs))
+		for i, run := range line.runs {
+			run.x = line.width - run.x - run.width
+			run.text = component.VisualLine(run.text, true)
+			mirrored[i] = run
+		}
+		line.runs = mirrored
+	}
+	for _, run := range line.runs {
+		if bg := l.spans[run.span].Background; bg != nil {
+			component.DrawFilledRect(info.Screen, float32(lineX+run.x), float32(lineY), float32(run.width), float32(line.height), bg)
+		}
+	}
+	// テキストはバッチを経由せずに描画されるため、先に蓄積された背景を描画して順序を保ちます。
+	component.FlushDraws()
+	baseline := lineY + line.ascent
+	for _, run := range line.runs {
+		span := l.spans[run.span]
+		clr := span.Color
+		if clr == nil {
+			clr = c.TextColor
+		}
+		if span.Icon != nil {
+			descent := component.FaceMetrics(l.faceOf(run.span, c.Font)).Descent.Ceil()
+			opts := &ebiten.DrawImageOptions{}
+			opts.GeoM.Translate(float64(lineX+run.x), float64(baseline+descent-span.Icon.Bounds().Dy()))
+			info.Screen.DrawImage(span.Icon, opts)
+		} else {
+			text.Draw(info.Screen, run.text, l.faceOf(run.span, c.Font), lineX+run.x, baseline, clr)
+		}
+		stats.AddDrawCalls(1)
+		if span.Underline {
+			component.DrawFilledRect(info.Screen, float32(lineX+run.x), float32(baseline+1), float32(run.width), 1, clr)
+		}
+	}
+}
+
+// --- RichLabelBuilder ---
+
+// RichLabelBuilder は、RichLabelを宣言的に構築するためのビルダーです。
+// Text, WrapText, TextColor などのテキスト関連のメソッドはLabelBuilderと共通です。
+type RichLabelBuilder struct {
+	Builder[*RichLabelBuilder, *RichLabel]
+}
+
+// NewRichLabelBuilder は新しいRichLabelBuilderを生成します。
+func NewRichLabelBuilder() *RichLabelBuilder {
+	l, err := newRichLabel()
+	b := &RichLabelBuilder{}
+	b.Builder.Init(b, l)
+	b.AddError(err)
+	return b
+}
+
+// Spans は、表示する区間を設定します。
+func (b *RichLabelBuilder) Spans(spans ...Span) *RichLabelBuilder {
+	b.Widget.SetSpans(spans...)
+	return b
+}
+
+// Span は、末尾に区間を1つ追加します。
+// 例: r.Span(widget.Span{Text: "Attack "}).Span(widget.Span{Text: "+12", Color: color.RGBA{G: 200, A: 255}})
+func (b *RichLabelBuilder) Span(span Span) *RichLabelBuilder {
+	b.Widget.AppendSpans(span)
+	return b
+}
+
+// Markup は、マークアップを含む文字列を解析して区間を設定します。構文エラーはビルドエラーとして報告されます。
+func (b *RichLabelBuilder) Markup(markup string) *RichLabelBuilder {
+	b.AddError(b.Widget.SetMarkup(markup))
+	return b
+}
+
+// MarkupWith は、指定されたマークアップスタイルで文字列を解析して区間を設定します。
+func (b *RichLabelBuilder) MarkupWith(ms *MarkupStyle, markup string) *RichLabelBuilder {
+	spans, err := ms.Parse(markup)
+	if err != nil {
+		b.AddError(err)
+		return b
+	}
+	b.Widget.SetSpans(spans...)
+	return b
+}
+
+// Build は、最終的なRichLabelを構築して返します。
+func (b *RichLabelBuilder) Build() (*RichLabel, error) {
+	return b.Builder.Build()
+}
@@ -3,8 +3,6 @@ package widget
 import (
 	"furoshiki/component"
 	"image/color"
-
-	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 // ScrollBar は、スクロール可能な領域の状態を視覚的に示すウィジェットです。
@@ -48,7 +46,7 @@ func (s *ScrollBar) Draw(info component.DrawInfo) {
 	finalX := float32(x + info.OffsetX)
 	finalY := float32(y + info.OffsetY)
 
-	vector.DrawFilledRect(info.Screen, finalX, finalY, float32(width), float32(height), s.trackColor, false)
+	component.DrawFilledRect(info.Screen, finalX, finalY, float32(width), float32(height), s.trackColor)
 
 	if s.contentRatio >= 1.0 {
 		return
@@ -65,7 +63,7 @@ func (s *ScrollBar) Draw(info component.DrawInfo) {
 	thumbYRange := float32(height) - thumbHeight
 	thumbY := finalY + thumbYRange*float32(s.scrollRatio)
 
-	vector.DrawFilledRect(info.Screen, finalX, thumbY, float32(width), thumbHeight, s.thumbColor, false)
+	component.DrawFilledRect(info.Screen, finalX, thumbY, float32(width), thumbHeight, s.thumbColor)
 }
 
 // SetRatios は、つまみのサイズと位置を計算するための比率を設定します。
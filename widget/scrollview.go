@@ -5,7 +5,9 @@ import (
 	"furoshiki/container"
 	"furoshiki/event"
 	"furoshiki/layout"
+	"furoshiki/stats"
 	"furoshiki/style"
+	"time"
 )
 
 // ScrollView は、コンテンツをスクロール表示するためのコンテナウィジェットです。
@@ -22,6 +24,10 @@ type ScrollView struct {
 	scrollY           float64
 	contentHeight     int
 	ScrollSensitivity float64
+	// nearEnd は、末尾付近への到達を通知する関数です。scrollview_near_end.go を参照してください。
+	nearEnd []*nearEndListener
+	// nearEndSuspended がtrueの間は、末尾付近への到達を通知しません。
+	nearEndSuspended bool
 }
 
 // コンパイル時にインターフェースの実装を検証します。
@@ -33,29 +39,37 @@ var _ container.Scroller = (*ScrollView)(nil)
 // NOTE: このコンストラクタは非公開になりました。ウィジェットの生成には
 //       常にNewScrollViewBuilder()を使用してください。これにより、初期化漏れを防ぎます。
 func newScrollView() (*ScrollView, error) {
-	sv := &ScrollView{
-		ScrollSensitivity: 20.0,
+	sv := &ScrollView{}
+	if err := sv.initScrollView(sv); err != nil {
+		return nil, err
 	}
+	return sv, nil
+}
+
+// initScrollView は、ScrollViewを初期化します。selfには、ScrollViewを埋め込む具象ウィジェット(例: *List)を渡します。
+// これにより、ヒットテストやイベントの伝播が具象ウィジェットを対象に行われます。
+func (sv *ScrollView) initScrollView(self component.Container) error {
+	sv.ScrollSensitivity = 20.0
 	sv.LayoutableWidget = component.NewLayoutableWidget()
 
 	// NOTE: ScrollViewの依存コンポーネントを、それぞれの公開コンストラクタ/ビルダー経由で生成します。
 	internalContainer, err := container.NewContainer()
 	if err != nil {
-		return nil, err
+		return err
 	}
 	sv.container = internalContainer
 	sv.container.SetClipsChildren(true)
 
-	if err := sv.Init(sv); err != nil {
-		return nil, err
+	if err := sv.Init(self); err != nil {
+		return err
 	}
-	sv.container.SetParent(sv)
+	sv.container.SetParent(self)
 	sv.layout = &layout.ScrollViewLayout{}
 
 	// NOTE: ScrollBarの生成にビルダーを使用することで、安全な初期化を保証します。
 	vScrollBar, err := NewScrollBarBuilder().Build()
 	if err != nil {
-		return nil, err
+		return err
 	}
 	sv.vScrollBar = vScrollBar
 	sv.AddChild(vScrollBar)
@@ -64,7 +78,7 @@ func newScrollView() (*ScrollView, error) {
 	// これにより、イベント処理ロジックが一貫した方法で管理されます。
 	sv.AddEventHandler(event.MouseScroll, sv.onMouseScroll)
 
-	return sv, nil
+	return nil
 }
 
 // onMouseScroll は、MouseScrollイベントに応答してコンテンツをスクロールします。
@@ -111,16 +125,25 @@ func (sv *ScrollView) Update() {
 	// ScrollView自身が再レイアウトを要求されている場合のみ、専用のレイアウトを実行します。
 	if sv.NeedsRelayout() {
 		if sv.layout != nil {
-			if err := sv.layout.Layout(sv); err != nil {
+			stats.AddLayoutPass()
+			component.NotifyRelayout(sv)
+			var start time.Time
+			if stats.Enabled() {
+				start = time.Now()
+			}
+			err := sv.layout.Layout(sv)
+			if !start.IsZero() {
+				stats.AddLayoutTime(time.Since(start))
+			}
+			if err != nil {
 				// TODO: エラーハンドリング
 			}
 		}
 	}
 
 	// 内部コンテナのレイアウト処理は行わず、子要素のUpdateのみを再帰的に呼び出します。
-	for _, child := range sv.container.GetChildren() {
-		child.Update()
-	}
+	component.UpdateChildren(sv, sv.container.GetChildren())
+	sv.checkNearEnd()
 
 	if sv.IsDirty() {
 		sv.ClearDirty()
@@ -169,16 +192,21 @@ func (sv *ScrollView) SetSize(width, height int) {
 
 // HitTest は、指定された座標がヒットするウィジェットを探します。
 func (sv *ScrollView) HitTest(x, y int) component.Widget {
-	if sv.LayoutableWidget.HitTest(x, y) != nil {
+	if self := sv.LayoutableWidget.HitTest(x, y); self != nil {
 		// 自身がヒット範囲内であれば、次に内部コンテナの子要素をテストします。
 		if target := sv.container.HitTest(x, y); target != nil {
 			return target
 		}
-		return sv // 子にヒットしなければScrollView自身を返す
+		return self // 子にヒットしなければScrollView(を埋め込む具象ウィジェット)自身を返す
 	}
 	return nil
 }
 
+// DefaultAccessibleRole は、component.DefaultRoleProviderインターフェースの実装です。
+func (sv *ScrollView) DefaultAccessibleRole() component.Role {
+	return component.RoleScrollArea
+}
+
 // --- メソッドの委譲 ---
 func (sv *ScrollView) AddChild(child component.Widget)    { sv.container.AddChild(child) }
 func (sv *ScrollView) RemoveChild(child component.Widget) { sv.container.RemoveChild(child) }
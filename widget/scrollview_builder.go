@@ -39,6 +39,12 @@ func (b *ScrollViewBuilder) ScrollSensitivity(sensitivity float64) *ScrollViewBu
 	return b
 }
 
+// OnNearEnd は、末尾までの残りがthresholdピクセル以下になったときに呼び出される関数を追加します。
+func (b *ScrollViewBuilder) OnNearEnd(threshold int, fn func()) *ScrollViewBuilder {
+	b.Widget.AddOnNearEnd(threshold, fn)
+	return b
+}
+
 // Build は、最終的なScrollViewを構築して返します。
 func (b *ScrollViewBuilder) Build() (*ScrollView, error) {
 	return b.Builder.Build()
@@ -0,0 +1,65 @@
+package widget
+
+// このファイルは、ScrollViewが末尾付近までスクロールされたことを通知する機能を提供します。
+// ページ単位でデータを読み込む無限スクロールなどに使用します。
+//
+// 通知は、末尾までの残りが閾値以下になった時点で1回だけ行われます。
+// その後、コンテンツの高さが変わる(項目が追加される)か、いったん閾値の外へスクロールされると、再び通知されるようになります。
+// コンテンツが表示領域より短い場合は、レイアウトの直後に通知されます。
+
+// nearEndListener は、末尾付近への到達を通知する関数と、その通知の状態です。
+type nearEndListener struct {
+	threshold int
+	// distance は、末尾までの残りを返します。まだ計測できない場合はfalseを返します。
+	distance func() (int, bool)
+	fn       func()
+	fired    bool
+	// contentHeight は、最後に通知したときのコンテンツの高さです。
+	contentHeight int
+}
+
+// AddOnNearEnd は、末尾までの残りがthresholdピクセル以下になったときに呼び出される関数を追加します。
+func (sv *ScrollView) AddOnNearEnd(threshold int, fn func()) {
+	sv.addNearEndListener(threshold, sv.remainingScroll, fn)
+}
+
+func (sv *ScrollView) addNearEndListener(threshold int, distance func() (int, bool), fn func()) {
+	if fn == nil {
+		return
+	}
+	sv.nearEnd = append(sv.nearEnd, &nearEndListener{threshold: max(threshold, 0), distance: distance, fn: fn})
+}
+
+// remainingScroll は、末尾までスクロールできる残りのピクセル数を返します。
+func (sv *ScrollView) remainingScroll() (int, bool) {
+	if !sv.HasBeenLaidOut() {
+		return 0, false
+	}
+	_, h := sv.GetSize()
+	padding := sv.GetPadding()
+	viewH := h - padding.Top - padding.Bottom
+	if viewH <= 0 {
+		return 0, false
+	}
+	return max(0, sv.contentHeight-viewH-int(sv.scrollY)), true
+}
+
+// checkNearEnd は、末尾付近に到達した通知先の関数を呼び出します。レイアウトの後に呼び出されます。
+func (sv *ScrollView) checkNearEnd() {
+	for _, n := range sv.nearEnd {
+		remaining, ok := n.distance()
+		if !ok {
+			continue
+		}
+		if remaining > n.threshold {
+			n.fired = false
+			continue
+		}
+		if sv.nearEndSuspended || (n.fired && n.contentHeight == sv.contentHeight) {
+			continue
+		}
+		n.fired = true
+		n.contentHeight = sv.contentHeight
+		n.fn()
+	}
+}
@@ -0,0 +1,246 @@
+package widget
+
+import (
+	"errors"
+	"furoshiki/component"
+	"image"
+	"image/color"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	// defaultSearchDebounce は、SearchBoxが入力の停止を待ってから検索語の変化を通知するまでの既定の時間です。
+	defaultSearchDebounce = 250 * time.Millisecond
+	// defaultSearchPlaceholder は、SearchBoxの既定のプレースホルダーです。
+	defaultSearchPlaceholder = "Search"
+)
+
+// ErrInvalidDebounce は、デバウンスの時間に負の値が指定された場合のエラーです。
+var ErrInvalidDebounce = errors.New("debounce must be non-negative")
+
+var (
+	searchIconOnce sync.Once
+	searchIcon     *ebiten.Image
+)
+
+// defaultSearchIcon は、SearchBoxの既定のアイコン(虫眼鏡)を返します。画像は最初の呼び出しで生成されます。
+func defaultSearchIcon() *ebiten.Image {
+	searchIconOnce.Do(func() {
+		const size = 12
+		img := image.NewRGBA(image.Rect(0, 0, size, size))
+		c := color.RGBA{110, 110, 110, 255}
+		for y := range size {
+			for x := range size {
+				// 中心(4.5, 4.5)、半径4の円周と、右下に伸びる柄を描きます。
+				dx, dy := float64(x)-4.5, float64(y)-4.5
+				d := dx*dx + dy*dy
+				ring := d >= 2.5*2.5 && d <= 4*4
+				handle := x >= 7 && y >= 7 && (x-y)*(x-y) <= 1
+				if ring || handle {
+					img.Set(x, y, c)
+				}
+			}
+		}
+		searchIcon = ebiten.NewImageFromImage(img)
+	})
+	return searchIcon
+}
+
+// SearchBox は、検索語を入力するための入力欄です。
+// 虫眼鏡のアイコン、プレースホルダー、消去ボタンを備え、入力が止まってから一定時間後に検索語の変化を通知します。
+// 通知をデバウンスすることで、1文字ごとに重い検索を実行するのを避けられます。
+type SearchBox struct {
+	*TextInput
+	debounce time.Duration
+	// pendingTicks は、検索語の変化を通知するまでの残りのティック数です。0の場合は通知待ちの変化はありません。
+	pendingTicks int
+	// lastQuery は、最後に通知した検索語です。同じ検索語を繰り返し通知しないために使用します。
+	lastQuery string
+
+	onQueryChanged []func(query string)
+}
+
+// newSearchBox は、SearchBoxの新しいインスタンスを生成し、初期化します。
+// NOTE: ウィジェットの生成には常にNewSearchBoxBuilder()を使用してください。
+func newSearchBox() (*SearchBox, error) {
+	s := &SearchBox{TextInput: &TextInput{}, debounce: defaultSearchDebounce}
+	if err := s.initTextInput(s); err != nil {
+		return nil, err
+	}
+	s.SetLeadingIcon(defaultSearchIcon())
+	s.SetPlaceholder(defaultSearchPlaceholder)
+	s.SetClearButton(true)
+	s.AddOnChange(func(query string) {
+		// 検索語が空になった場合(消去ボタンなど)は、結果をすぐに戻せるように待たずに通知します。
+		if query == "" || s.debounce == 0 {
+			s.flush()
+			return
+		}
+		s.pendingTicks = durationToTicks(s.debounce)
+	})
+	// Enterで確定した場合は、デバウンスを待たずに通知します。
+	s.AddOnSubmit(func(string) { s.flush() })
+	return s, nil
+}
+
+// Query は、現在の検索語を返します。
+func (s *SearchBox) Query() string {
+	return s.Value()
+}
+
+// SetDebounce は、入力が止まってから検索語の変化を通知するまでの時間を設定します。0を指定すると入力のたびに通知します。
+func (s *SearchBox) SetDebounce(d time.Duration) {
+	s.debounce = max(0, d)
+}
+
+// AddOnQueryChanged は、検索語が変化したときに、デバウンスを経て呼び出される関数を追加します。
+func (s *SearchBox) AddOnQueryChanged(fn func(query string)) {
+	if fn != nil {
+		s.onQueryChanged = append(s.onQueryChanged, fn)
+	}
+}
+
+// flush は、待機中の変化を直ちに通知します。検索語が最後の通知から変わっていない場合は何もしません。
+func (s *SearchBox) flush() {
+	s.pendingTicks = 0
+	query := s.Value()
+	if query == s.lastQuery {
+		return
+	}
+	s.lastQuery = query
+	for _, fn := range s.onQueryChanged {
+		fn(query)
+	}
+}
+
+// Update は、TextInputの入力処理に加えて、デバウンスの経過を数えます。
+// フォーカスを失った後も、待機中の変化は時間どおりに通知されます。
+func (s *SearchBox) Update() {
+	s.TextInput.Update()
+	if s.pendingTicks > 0 {
+		s.pendingTicks--
+		if s.pendingTicks == 0 {
+			s.flush()
+		} else {
+			// 省電力モードでも、待機中は更新を続けてデバウンスを進めます。
+			component.RequestFrame()
+		}
+	}
+}
+
+// Cleanup は、リソースを解放します。
+func (s *SearchBox) Cleanup() {
+	s.onQueryChanged = nil
+	s.TextInput.Cleanup()
+}
+
+// HighlightMatches は、textのうちqueryに一致する部分(大文字と小文字を区別しない)をhighlightの装飾で強調した区間を返します。
+// 一致しない部分はTextだけを持つ区間になります。highlightのTextは無視されます。
+// 検索結果の一覧をRichLabelで表示する際に使用します。
+// 例: label.SetSpans(widget.HighlightMatches(name, query, widget.Span{Background: yellow}))
+func HighlightMatches(text, query string, highlight Span) []Span {
+	if query == "" {
+		return []Span{{Text: text}}
+	}
+	var spans []Span
+	qLen := utf8.RuneCountInString(query)
+	start := 0 // まだ区間に含めていない部分の先頭のバイト位置
+	for i := 0; i < len(text); {
+		end := runeOffset(text, i, qLen)
+		if end > 0 && strings.EqualFold(text[i:end], query) {
+			if start < i {
+				spans = append(spans, Span{Text: text[start:i]})
+			}
+			match := highlight
+			match.Text = text[i:end]
+			spans = append(spans, match)
+			start, i = end, end
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	if start < len(text) {
+		spans = append(spans, Span{Text: text[start:]})
+	}
+	return spans
+}
+
+// runeOffset は、バイト位置iからn文字後のバイト位置を返します。文字数が足りない場合は-1を返します。
+func runeOffset(s string, i, n int) int {
+	for ; n > 0; n-- {
+		if i >= len(s) {
+			return -1
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return i
+}
+
+// --- SearchBoxBuilder ---
+
+// SearchBoxBuilder は、SearchBoxを宣言的に構築するためのビルダーです。
+type SearchBoxBuilder struct {
+	component.Builder[*SearchBoxBuilder, *SearchBox]
+}
+
+// NewSearchBoxBuilder は新しいSearchBoxBuilderを生成します。
+func NewSearchBoxBuilder() *SearchBoxBuilder {
+	s, err := newSearchBox()
+	b := &SearchBoxBuilder{}
+	b.Init(b, s)
+	b.AddError(err)
+	return b
+}
+
+// Value は、初期の検索語を設定します。
+func (b *SearchBoxBuilder) Value(query string) *SearchBoxBuilder {
+	b.Widget.SetValue(query)
+	b.Widget.lastQuery = query
+	return b
+}
+
+// Placeholder は、検索語が空のときに表示されるヒントの文字列を設定します。
+func (b *SearchBoxBuilder) Placeholder(placeholder string) *SearchBoxBuilder {
+	b.Widget.SetPlaceholder(placeholder)
+	return b
+}
+
+// Icon は、左端に表示するアイコンを設定します。nilを指定するとアイコンを表示しません。
+func (b *SearchBoxBuilder) Icon(icon *ebiten.Image) *SearchBoxBuilder {
+	b.Widget.SetLeadingIcon(icon)
+	return b
+}
+
+// Debounce は、入力が止まってから検索語の変化を通知するまでの時間を設定します。
+func (b *SearchBoxBuilder) Debounce(d time.Duration) *SearchBoxBuilder {
+	if d < 0 {
+		b.AddError(ErrInvalidDebounce)
+		return b
+	}
+	b.Widget.SetDebounce(d)
+	return b
+}
+
+// OnQueryChanged は、検索語が変化したときに、デバウンスを経て呼び出される関数を追加します。
+func (b *SearchBoxBuilder) OnQueryChanged(fn func(query string)) *SearchBoxBuilder {
+	b.Widget.AddOnQueryChanged(fn)
+	return b
+}
+
+// OnSubmit は、Enterキーで検索語が確定されたときに呼び出される関数を追加します。
+func (b *SearchBoxBuilder) OnSubmit(fn func(query string)) *SearchBoxBuilder {
+	b.Widget.AddOnSubmit(fn)
+	return b
+}
+
+// Build は、最終的なSearchBoxを構築して返します。
+func (b *SearchBoxBuilder) Build() (*SearchBox, error) {
+	return b.Builder.Build()
+}
@@ -0,0 +1,522 @@
+package widget
+
+import (
+	"errors"
+	"furoshiki/component"
+	"furoshiki/event"
+	"furoshiki/style"
+	"furoshiki/theme"
+	"image"
+	"image/color"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// ErrSectionOutOfRange は、SectionedListに存在しない区分が指定された場合のエラーです。
+var ErrSectionOutOfRange = errors.New("section index out of range")
+
+// sectionIndicatorSize は、見出しの右端に描画する開閉の三角形の大きさです。
+const sectionIndicatorSize = 8
+
+// IndexPath は、SectionedListの項目の位置を、区分のインデックスと区分内の項目のインデックスで表します。
+type IndexPath struct {
+	Section, Item int
+}
+
+// NoIndexPath は、どの項目も指さないIndexPathです。選択がない場合などに使用されます。
+var NoIndexPath = IndexPath{Section: -1, Item: -1}
+
+// IsValid は、IndexPathが項目を指しうる値(どちらも0以上)かどうかを返します。
+func (p IndexPath) IsValid() bool {
+	return p.Section >= 0 && p.Item >= 0
+}
+
+// SectionedList は、項目を見出し付きの区分に分けて表示するListです。
+// 設定画面や、分類された持ち物の一覧などに使用します。
+//
+// 見出しの行は選択できず、キーボード操作でも飛ばされます。見出しをクリックすると、その区分を折りたたみ(または展開し)ます。
+// StickyHeadersを有効にすると、スクロール中の区分の見出しが表示領域の上端に固定されます。
+// 項目はIndexPath(区分と区分内の位置)で指定しますが、埋め込まれたListの平坦なインデックスによる操作も使用できます。
+// ドラッグによる並べ替えには対応していません。
+type SectionedList struct {
+	*List
+	collapsible    bool
+	stickyHeaders  bool
+	headerStyle    style.Style
+	indicatorColor color.Color
+
+	onPathSelectionChanged []func(path IndexPath)
+	onPathActivate         []func(path IndexPath)
+	onSectionToggled       []func(section int, collapsed bool)
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ component.Container = (*SectionedList)(nil)
+var _ component.Focusable = (*SectionedList)(nil)
+
+// newSectionedList は、SectionedListの新しいインスタンスを生成し、初期化します。
+// NOTE: ウィジェットの生成には常にNewSectionedListBuilder()を使用してください。
+func newSectionedList() (*SectionedList, error) {
+	s := &SectionedList{List: &List{ScrollView: &ScrollView{}}, collapsible: true}
+	if err := s.initList(s); err != nil {
+		return nil, err
+	}
+	t := theme.GetCurrent()
+	s.headerStyle = style.Merge(s.itemStyle, t.List.Header)
+	s.indicatorColor = t.TextColor
+
+	s.List.AddOnSelectionChanged(func(index int) {
+		path := s.PathOf(index)
+		for _, fn := range s.onPathSelectionChanged {
+			fn(path)
+		}
+	})
+	s.List.AddOnActivate(func(index int) {
+		path := s.PathOf(index)
+		for _, fn := range s.onPathActivate {
+			fn(path)
+		}
+	})
+	return s, nil
+}
+
+// AddSection は、見出しがtitleの区分を末尾に追加し、文字列を表示するラベルの項目を加えます。
+func (s *SectionedList) AddSection(title string, items ...string) error {
+	label, err := newLabel(title)
+	if err != nil {
+		return err
+	}
+	header, err := s.newRow(label, s.headerStyle)
+	if err != nil {
+		return err
+	}
+	header.header = true
+	header.AddEventHandler(event.MouseDown, func(e *event.Event) event.Propagation {
+		if s.collapsible {
+			if section := s.sectionOfHeader(header); section >= 0 {
+				s.ToggleSection(section)
+			}
+		}
+		return event.Propagate
+	})
+	header.AddOnAfterDraw(func(info component.DrawInfo, bounds image.Rectangle) {
+		if s.collapsible {
+			s.drawIndicator(info.Screen, bounds, header.collapsed)
+		}
+	})
+	s.insertRow(len(s.rows), header)
+
+	section := s.SectionCount() - 1
+	for _, text := range items {
+		item, err := newLabel(text)
+		if err != nil {
+			return err
+		}
+		if err := s.AddSectionItem(section, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddSectionItem は、ウィジェットを項目として指定された区分の末尾に追加します。
+func (s *SectionedList) AddSectionItem(section int, item component.Widget) error {
+	if item == nil {
+		return component.ErrNilChild
+	}
+	header, _, end, ok := s.sectionRange(section)
+	if !ok {
+		return ErrSectionOutOfRange
+	}
+	row, err := s.newRow(item, s.itemStyle)
+	if err != nil {
+		return err
+	}
+	row.SetVisible(!s.rows[header].collapsed)
+	s.insertRow(end, row)
+	return nil
+}
+
+// SectionCount は、区分の数を返します。
+func (s *SectionedList) SectionCount() int {
+	return len(s.headerIndices())
+}
+
+// SectionLen は、指定された区分の項目の数を返します。範囲外の場合は0です。
+func (s *SectionedList) SectionLen(section int) int {
+	_, start, end, ok := s.sectionRange(section)
+	if !ok {
+		return 0
+	}
+	return end - start
+}
+
+// SectionHeader は、指定された区分の見出しのウィジェットを返します。範囲外の場合はnilです。
+func (s *SectionedList) SectionHeader(section int) component.Widget {
+	header, _, _, ok := s.sectionRange(section)
+	if !ok {
+		return nil
+	}
+	return s.rows[header].item
+}
+
+// ItemAt は、指定された位置の項目のウィジェットを返します。存在しない場合はnilです。
+func (s *SectionedList) ItemAt(path IndexPath) component.Widget {
+	return s.Item(s.FlatIndex(path))
+}
+
+// PathOf は、埋め込まれたListのインデックスを項目の位置に変換します。見出しの行や範囲外の場合はNoIndexPathを返します。
+func (s *SectionedList) PathOf(index int) IndexPath {
+	if index < 0 || index >= len(s.rows) || s.rows[index].header {
+		return NoIndexPath
+	}
+	section, header := -1, -1
+	for i, row := range s.rows[:index] {
+		if row.header {
+			section, header = section+1, i
+		}
+	}
+	if section < 0 {
+		return NoIndexPath
+	}
+	return IndexPath{Section: section, Item: index - header - 1}
+}
+
+// FlatIndex は、項目の位置を埋め込まれたListのインデックスに変換します。存在しない場合は-1を返します。
+func (s *SectionedList) FlatIndex(path IndexPath) int {
+	if !path.IsValid() {
+		return -1
+	}
+	_, start, end, ok := s.sectionRange(path.Section)
+	if !ok || start+path.Item >= end {
+		return -1
+	}
+	return start + path.Item
+}
+
+// SelectPath は、指定された位置の項目だけを選択します。NoIndexPathを指定すると選択を解除します。
+// 存在しない位置や、折りたたまれた区分の項目は無視されます。
+func (s *SectionedList) SelectPath(path IndexPath) {
+	if !path.IsValid() {
+		s.ClearSelection()
+		return
+	}
+	if index := s.FlatIndex(path); index >= 0 {
+		s.Select(index)
+	}
+}
+
+// SelectedPath は、選択中の項目の位置を返します。選択がない場合はNoIndexPathです。
+func (s *SectionedList) SelectedPath() IndexPath {
+	return s.PathOf(s.Selected())
+}
+
+// SelectedPaths は、選択中のすべての項目の位置を、表示順に返します。
+func (s *SectionedList) SelectedPaths() []IndexPath {
+	var paths []IndexPath
+	for _, index := range s.SelectedIndices() {
+		paths = append(paths, s.PathOf(index))
+	}
+	return paths
+}
+
+// AddOnPathSelectionChanged は、選択中の項目が変化したときに呼び出される関数を追加します。
+// pathはSelectedPath()の値で、選択が解除された場合はNoIndexPathです。
+func (s *SectionedList) AddOnPathSelectionChanged(fn func(path IndexPath)) {
+	if fn != nil {
+		s.onPathSelectionChanged = append(s.onPathSelectionChanged, fn)
+	}
+}
+
+// AddOnPathActivate は、項目が確定(フォーカス中のEnterキー)されたときに呼び出される関数を追加します。
+func (s *SectionedList) AddOnPathActivate(fn func(path IndexPath)) {
+	if fn != nil {
+		s.onPathActivate = append(s.onPathActivate, fn)
+	}
+}
+
+// AddOnSectionToggled は、区分が折りたたまれた、または展開されたときに呼び出される関数を追加します。
+func (s *SectionedList) AddOnSectionToggled(fn func(section int, collapsed bool)) {
+	if fn != nil {
+		s.onSectionToggled = append(s.onSectionToggled, fn)
+	}
+}
+
+// SetSectionCollapsed は、指定された区分を折りたたむか展開します。
+// 折りたたんだ区分の項目は非表示になり、その選択は解除されます。
+func (s *SectionedList) SetSectionCollapsed(section int, collapsed bool) {
+	header, start, end, ok := s.sectionRange(section)
+	if !ok || s.rows[header].collapsed == collapsed {
+		return
+	}
+	s.rows[header].collapsed = collapsed
+	s.rows[header].MarkDirty(false)
+
+	changed := false
+	for i := start; i < end; i++ {
+		row := s.rows[i]
+		row.SetVisible(!collapsed)
+		if collapsed && row.setSelected(false) {
+			changed = true
+		}
+	}
+	if collapsed {
+		if s.cursor >= start && s.cursor < end {
+			s.setCursor(-1)
+		}
+		if s.anchor >= start && s.anchor < end {
+			s.anchor = -1
+		}
+	}
+	if changed {
+		s.notifySelection()
+	}
+	for _, fn := range s.onSectionToggled {
+		fn(section, collapsed)
+	}
+}
+
+// IsSectionCollapsed は、指定された区分が折りたたまれているかどうかを返します。
+func (s *SectionedList) IsSectionCollapsed(section int) bool {
+	header, _, _, ok := s.sectionRange(section)
+	return ok && s.rows[header].collapsed
+}
+
+// ToggleSection は、指定された区分の折りたたみを切り替えます。
+func (s *SectionedList) ToggleSection(section int) {
+	s.SetSectionCollapsed(section, !s.IsSectionCollapsed(section))
+}
+
+// SetCollapsible は、見出しのクリックで区分を折りたためるかどうかを設定します。既定値はtrueです。
+// falseにしても、既に折りたたまれている区分はそのままです。
+func (s *SectionedList) SetCollapsible(collapsible bool) {
+	if s.collapsible != collapsible {
+		s.collapsible = collapsible
+		s.MarkDirty(false)
+	}
+}
+
+// SetStickyHeaders は、スクロール中の区分の見出しを表示領域の上端に固定するかどうかを設定します。
+func (s *SectionedList) SetStickyHeaders(sticky bool) {
+	if s.stickyHeaders == sticky {
+		return
+	}
+	s.stickyHeaders = sticky
+	if !sticky {
+		s.unpinHeader()
+	}
+}
+
+// SetHeaderStyle は、見出しの行のスタイルを設定します。既存の見出しにも適用されます。
+func (s *SectionedList) SetHeaderStyle(st style.Style) {
+	s.headerStyle = style.Merge(s.itemStyle, st)
+	for _, index := range s.headerIndices() {
+		s.rows[index].SetStyle(s.headerStyle)
+	}
+}
+
+// Update は、Listの更新に加えて、固定表示する見出しの位置を更新します。
+func (s *SectionedList) Update() {
+	s.List.Update()
+	s.updateStickyHeader()
+}
+
+// HitTest は、固定表示中の見出しを、その下に重なっている行より優先してテストします。
+func (s *SectionedList) HitTest(x, y int) component.Widget {
+	target := s.List.HitTest(x, y)
+	if target != nil && s.pinnedRow != nil {
+		if pinned := s.pinnedRow.HitTest(x, y); pinned != nil {
+			return pinned
+		}
+	}
+	return target
+}
+
+// Cleanup は、リソースを解放します。
+func (s *SectionedList) Cleanup() {
+	s.onPathSelectionChanged = nil
+	s.onPathActivate = nil
+	s.onSectionToggled = nil
+	s.List.Cleanup()
+}
+
+// headerIndices は、見出しの行のインデックスを順に返します。
+func (s *SectionedList) headerIndices() []int {
+	var indices []int
+	for i, row := range s.rows {
+		if row.header {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// sectionRange は、区分の見出しの行のインデックスと、項目の行の範囲[start, end)を返します。
+func (s *SectionedList) sectionRange(section int) (header, start, end int, ok bool) {
+	headers := s.headerIndices()
+	if section < 0 || section >= len(headers) {
+		return -1, -1, -1, false
+	}
+	header = headers[section]
+	end = len(s.rows)
+	if section+1 < len(headers) {
+		end = headers[section+1]
+	}
+	return header, header + 1, end, true
+}
+
+// sectionOfHeader は、見出しの行が表す区分のインデックスを返します。
+func (s *SectionedList) sectionOfHeader(header *listRow) int {
+	return slices.Index(s.headerIndices(), slices.Index(s.rows, header))
+}
+
+// updateStickyHeader は、表示領域の上端より上へスクロールされた最後の見出しを上端に固定します。
+// 次の見出しが近づくと、固定中の見出しはそれに押し出されるように上へずれます。
+func (s *SectionedList) updateStickyHeader() {
+	if !s.stickyHeaders || !s.HasBeenLaidOut() {
+		return
+	}
+	_, y := s.GetPosition()
+	viewTop := y + s.GetPadding().Top
+
+	var current, next *listRow
+	for _, index := range s.headerIndices() {
+		row := s.rows[index]
+		if row.baseY < viewTop {
+			current = row
+			continue
+		}
+		next = row
+		break
+	}
+	if current == nil {
+		s.unpinHeader()
+		return
+	}
+	if s.pinnedRow != nil && s.pinnedRow != current {
+		s.pinnedRow.setOffset(0)
+	}
+	top := viewTop
+	if next != nil {
+		_, h := current.GetSize()
+		top = min(top, next.baseY-h)
+	}
+	current.setOffset(float64(top - current.baseY))
+	s.pinnedRow = current
+}
+
+// unpinHeader は、固定表示中の見出しを元の位置に戻します。
+func (s *SectionedList) unpinHeader() {
+	if s.pinnedRow != nil {
+		s.pinnedRow.setOffset(0)
+		s.pinnedRow = nil
+	}
+}
+
+// drawIndicator は、見出しの右端に、展開中は下向き、折りたたみ中は右向きの三角形を描画します。
+func (s *SectionedList) drawIndicator(screen *ebiten.Image, bounds image.Rectangle, collapsed bool) {
+	const half = sectionIndicatorSize / 2
+	cx := float32(bounds.Max.X - sectionIndicatorSize - 4)
+	cy := float32(bounds.Min.Y + bounds.Dy()/2)
+	path := &vector.Path{}
+	if collapsed {
+		path.MoveTo(cx-half/2, cy-half)
+		path.LineTo(cx+half/2+1, cy)
+		path.LineTo(cx-half/2, cy+half)
+	} else {
+		path.MoveTo(cx-half, cy-half/2)
+		path.LineTo(cx+half, cy-half/2)
+		path.LineTo(cx, cy+half/2+1)
+	}
+	path.Close()
+	component.DrawFilledPath(screen, path, s.indicatorColor)
+}
+
+// --- SectionedListBuilder ---
+
+// SectionedListBuilder は、SectionedListを宣言的に構築するためのビルダーです。
+type SectionedListBuilder struct {
+	component.Builder[*SectionedListBuilder, *SectionedList]
+}
+
+// NewSectionedListBuilder は新しいSectionedListBuilderを生成します。
+func NewSectionedListBuilder() *SectionedListBuilder {
+	s, err := newSectionedList()
+	b := &SectionedListBuilder{}
+	b.Init(b, s)
+	b.AddError(err)
+	return b
+}
+
+// Section は、見出しがtitleの区分を末尾に追加し、文字列を表示するラベルの項目を加えます。
+func (b *SectionedListBuilder) Section(title string, items ...string) *SectionedListBuilder {
+	b.AddError(b.Widget.AddSection(title, items...))
+	return b
+}
+
+// SectionItem は、ウィジェットを項目として指定された区分の末尾に追加します。
+func (b *SectionedListBuilder) SectionItem(section int, item component.Widget) *SectionedListBuilder {
+	b.AddError(b.Widget.AddSectionItem(section, item))
+	return b
+}
+
+// Collapsed は、指定された区分を折りたたんだ状態にするかどうかを設定します。
+func (b *SectionedListBuilder) Collapsed(section int, collapsed bool) *SectionedListBuilder {
+	b.Widget.SetSectionCollapsed(section, collapsed)
+	return b
+}
+
+// Collapsible は、見出しのクリックで区分を折りたためるかどうかを設定します。
+func (b *SectionedListBuilder) Collapsible(collapsible bool) *SectionedListBuilder {
+	b.Widget.SetCollapsible(collapsible)
+	return b
+}
+
+// StickyHeaders は、スクロール中の区分の見出しを表示領域の上端に固定するかどうかを設定します。
+func (b *SectionedListBuilder) StickyHeaders(sticky bool) *SectionedListBuilder {
+	b.Widget.SetStickyHeaders(sticky)
+	return b
+}
+
+// HeaderStyle は、見出しの行のスタイルを設定します。
+func (b *SectionedListBuilder) HeaderStyle(s style.Style) *SectionedListBuilder {
+	b.Widget.SetHeaderStyle(s)
+	return b
+}
+
+// SelectionMode は、単一選択か複数選択かを設定します。
+func (b *SectionedListBuilder) SelectionMode(mode SelectionMode) *SectionedListBuilder {
+	b.Widget.SetSelectionMode(mode)
+	return b
+}
+
+// Selected は、初期の選択を設定します。
+func (b *SectionedListBuilder) Selected(path IndexPath) *SectionedListBuilder {
+	b.Widget.SelectPath(path)
+	return b
+}
+
+// OnSelectionChanged は、選択中の項目が変化したときに呼び出される関数を追加します。
+func (b *SectionedListBuilder) OnSelectionChanged(fn func(path IndexPath)) *SectionedListBuilder {
+	b.Widget.AddOnPathSelectionChanged(fn)
+	return b
+}
+
+// OnActivate は、項目が確定(フォーカス中のEnterキー)されたときに呼び出される関数を追加します。
+func (b *SectionedListBuilder) OnActivate(fn func(path IndexPath)) *SectionedListBuilder {
+	b.Widget.AddOnPathActivate(fn)
+	return b
+}
+
+// OnSectionToggled は、区分が折りたたまれた、または展開されたときに呼び出される関数を追加します。
+func (b *SectionedListBuilder) OnSectionToggled(fn func(section int, collapsed bool)) *SectionedListBuilder {
+	b.Widget.AddOnSectionToggled(fn)
+	return b
+}
+
+// Build は、最終的なSectionedListを構築して返します。
+func (b *SectionedListBuilder) Build() (*SectionedList, error) {
+	return b.Builder.Build()
+}
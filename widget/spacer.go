@@ -1,14 +1,20 @@
 package widget
 
 import (
+	"fmt"
 	"furoshiki/component"
 )
 
 // SpacerはFlexLayout内で余白を埋めるために使用される、描画されないウィジェットです。
 type Spacer struct {
 	*component.LayoutableWidget
+	// maxWidth, maxHeight は、Flexで伸長される際の上限です。0は上限なしを意味します。
+	maxWidth, maxHeight int
 }
 
+// コンパイル時にインターフェースの実装を検証します。
+var _ component.MaxSizer = (*Spacer)(nil)
+
 // newSpacerは、Spacerウィジェットの新しいインスタンスを生成し、初期化します。
 // NOTE: このコンストラクタは非公開になりました。ウィジェットの生成には
 //
@@ -26,6 +32,19 @@ func newSpacer() (*Spacer, error) {
 // Draw は何もしません。Spacerは視覚的な表現を持たないためです。
 func (s *Spacer) Draw(info component.DrawInfo) {}
 
+// SetMaxSize は、Flexで伸長される際の最大サイズを設定します。0の軸は上限なしを意味します。
+func (s *Spacer) SetMaxSize(width, height int) {
+	if s.maxWidth != width || s.maxHeight != height {
+		s.maxWidth, s.maxHeight = width, height
+		s.MarkDirty(true)
+	}
+}
+
+// GetMaxSize は、Flexで伸長される際の最大サイズを返します。
+func (s *Spacer) GetMaxSize() (width, height int) {
+	return s.maxWidth, s.maxHeight
+}
+
 // --- SpacerBuilder ---
 type SpacerBuilder struct {
 	component.Builder[*SpacerBuilder, *Spacer]
@@ -40,6 +59,17 @@ func NewSpacerBuilder() *SpacerBuilder {
 	return b
 }
 
+// MaxSize は、Flexで伸長される際の最大サイズを設定します。0の軸は上限なしを意味します。
+// MinSizeと組み合わせることで、伸縮する余白の範囲を制限できます。
+func (b *SpacerBuilder) MaxSize(width, height int) *SpacerBuilder {
+	if width < 0 || height < 0 {
+		b.AddError(fmt.Errorf("%w, got %dx%d", component.ErrInvalidSize, width, height))
+		return b
+	}
+	b.Widget.SetMaxSize(width, height)
+	return b
+}
+
 // Build は最終的なSpacerウィジェットを返します。
 func (b *SpacerBuilder) Build() (*Spacer, error) {
 	return b.Builder.Build()
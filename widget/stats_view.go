@@ -0,0 +1,68 @@
+package widget
+
+import (
+	"fmt"
+	"furoshiki/component"
+	"furoshiki/stats"
+	"furoshiki/theme"
+)
+
+// StatsView は、直前のフレームのパフォーマンスカウンタを1行のテキストで表示するウィジェットです。
+// 表示内容は stats.LastFrame() から取得され、フレーム番号が変わったときだけ更新されます。
+// 計測そのものは furoshiki.EnableStats(true) で有効にする必要があります。
+type StatsView struct {
+	*component.TextWidget
+	lastFrame uint64
+}
+
+// newStatsView は、StatsViewの新しいインスタンスを生成し、初期化します。
+func newStatsView() (*StatsView, error) {
+	sv := &StatsView{}
+	sv.TextWidget = component.NewTextWidget("stats: disabled")
+	if err := sv.Init(sv); err != nil {
+		return nil, err
+	}
+
+	t := theme.GetCurrent()
+	sv.SetStyle(t.Label.Default)
+	sv.SetSize(400, 20)
+	// NOTE: 表示テキストは毎フレーム変わり得るため、レイアウト境界として
+	//       親コンテナへの再レイアウト要求の伝播を止めます。
+	sv.SetLayoutBoundary(true)
+
+	return sv, nil
+}
+
+// Update は、新しいフレームの集計結果があれば表示テキストを更新します。
+func (sv *StatsView) Update() {
+	sv.TextWidget.Update()
+	if !stats.Enabled() {
+		return
+	}
+	s := stats.LastFrame()
+	if s.Frame == sv.lastFrame {
+		return
+	}
+	sv.lastFrame = s.Frame
+	sv.SetText(fmt.Sprintf("layout:%d measured:%d arranged:%d draws:%d offscreen:%d events:%s",
+		s.LayoutPasses, s.WidgetsMeasured, s.WidgetsArranged, s.DrawCalls, s.OffscreenAllocations, s.EventDispatchTime))
+}
+
+// --- StatsViewBuilder ---
+type StatsViewBuilder struct {
+	Builder[*StatsViewBuilder, *StatsView]
+}
+
+// NewStatsViewBuilder は新しいStatsViewBuilderを生成します。
+func NewStatsViewBuilder() *StatsViewBuilder {
+	sv, err := newStatsView()
+	b := &StatsViewBuilder{}
+	b.Builder.Init(b, sv)
+	b.AddError(err)
+	return b
+}
+
+// Build は、最終的なStatsViewを構築して返します。
+func (b *StatsViewBuilder) Build() (*StatsView, error) {
+	return b.Builder.Build()
+}
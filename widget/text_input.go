@@ -0,0 +1,851 @@
+package widget
+
+import (
+	"furoshiki/clock"
+	"furoshiki/component"
+	"furoshiki/event"
+	"furoshiki/stats"
+	"furoshiki/style"
+	"furoshiki/theme"
+	"image"
+	"image/color"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+const (
+	// defaultMaskRune は、マスク表示で各文字の代わりに描画される文字です。
+	defaultMaskRune = '•'
+	// fallbackMaskRune は、フォントがdefaultMaskRuneのグリフを持たない場合に使用される文字です。
+	fallbackMaskRune = '*'
+
+	// keyRepeatDelay と keyRepeatInterval は、キーを押し続けたときの入力の繰り返しのタイミングです。
+	keyRepeatDelay    = 400 * time.Millisecond
+	keyRepeatInterval = 40 * time.Millisecond
+	// caretBlinkPeriod は、キャレットの点滅の周期です。
+	caretBlinkPeriod = time.Second
+
+	// revealLabelShow と revealLabelHide は、マスク表示の切り替えボタンに表示される文字列です。
+	revealLabelShow = "Show"
+	revealLabelHide = "Hide"
+	// clearLabel は、値を消去するボタンに表示される文字列です。
+	clearLabel = "x"
+	// inlineButtonPadding は、入力欄内に配置するボタンの文字列の左右に空ける余白です。
+	inlineButtonPadding = 4
+)
+
+// TextInput は、1行のテキストを編集するための入力欄です。
+// クリックするとキーボードフォーカスを取得し、文字の入力、Backspaceによる削除、Enterによる確定を受け付けます。
+//
+// 入力された値はValue()で取得します。パスワードなどの値がログやデバッグ表示に漏れないように、
+// TextInputはText()メソッドを持たず、ビルドエラーやデバッグツールの表示には値が含まれません。
+type TextInput struct {
+	*component.LayoutableWidget
+	runes  []rune
+	cursor int // runesにおけるキャレットの位置
+	// anchor は、選択範囲のキャレットと反対側の端の位置です。選択がない場合はcursorと等しくなります。
+	anchor int
+	// selectionColor は、選択範囲の背景色です。
+	selectionColor color.Color
+
+	// dragging は、テキスト領域で押下したまま選択範囲を広げている最中かどうかです。
+	dragging bool
+	// history は、取り消しとやり直しの履歴です。詳細は text_input_history.go を参照してください。
+	history editHistory
+
+	// lastClick と lastClickIndex は、ダブルクリックを判定するための直前の押下の時刻と位置です。
+	lastClick      int64
+	lastClickIndex int
+
+	focused bool
+	// focusStyle は、フォーカス中に基本スタイルにマージされるスタイルです。
+	focusStyle style.Style
+	// invalidStyle は、検証に失敗している間に基本スタイルにマージされるスタイルです。
+	invalidStyle style.Style
+	// errorStyle は、入力欄の下に表示される検証エラーのメッセージのスタイルです。
+	errorStyle style.Style
+	// stateStyles は、(フォーカス, 検証エラー)の組み合わせごとに解決したスタイルのキャッシュです。
+	// インデックスはstateStyleIndexで求めます。
+	stateStyles [4]style.Computed
+	stateValid  [4]bool
+
+	// validation は、入力値の検証の設定と結果です。詳細は text_input_validation.go を参照してください。
+	validation inputValidation
+
+	// masked がtrueの場合、各文字の代わりにmaskRuneを描画します。
+	masked   bool
+	maskRune rune
+	// revealed は、マスク表示中に一時的に値を表示しているかどうかです。
+	revealed bool
+	// revealButton がtrueの場合、マスク表示中に入力欄の右端に表示切り替えボタンを配置します。
+	revealButton bool
+
+	// placeholder は、値が空でフォーカスを持っていないときに控えめな色で表示されるヒントです。
+	placeholder string
+	// clearButton がtrueの場合、値が空でない間は入力欄の右端に値を消去するボタンを配置します。
+	clearButton bool
+	// leadingIcon は、入力欄の左端に表示するアイコンです。
+	leadingIcon *ebiten.Image
+	// leadingWidth は、アイコンの右側、テキストの前に確保する幅です。ChipInputがチップを並べるために使用します。
+	leadingWidth int
+
+	// scrollX は、キャレットを表示領域内に保つための、テキストの水平方向のスクロール量です。
+	scrollX    int
+	blinkTicks int
+
+	// filter は、入力を受け付ける文字を判定する関数です。nilの場合は表示可能なすべての文字を受け付けます。
+	filter func(r rune) bool
+	// inputMask は、入力できる文字の位置と種類を制限する入力マスクです。詳細は input_mask.go を参照してください。
+	inputMask *InputMask
+	// inputHooks は、フォーカス中に毎フレーム、キー入力の処理の前に呼び出される関数です。
+	// Autocompleteのように入力欄に機能を追加する部品が使用します。
+	// いずれかがtrueを返した場合、そのフレームのEnterキーによる確定は行われません。
+	inputHooks []func() bool
+	// pointerHooks は、入力欄内での押下を、テキストの操作より先に処理する関数です。
+	// いずれかがtrueを返した場合、その押下ではキャレットを移動しません。
+	pointerHooks []func(p image.Point) bool
+
+	onChange      []func(value string)
+	onSubmit      []func(value string)
+	onFocusChange []func(focused bool)
+}
+
+// コンパイル時にインターフェースの実装を検証します。
+var _ component.Focusable = (*TextInput)(nil)
+
+// newTextInput は、TextInputの新しいインスタンスを生成し、初期化します。
+// NOTE: ウィジェットの生成には常にNewTextInputBuilder()を使用してください。
+func newTextInput() (*TextInput, error) {
+	t := &TextInput{}
+	if err := t.initTextInput(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// initTextInput は、TextInputを初期化します。
+// selfには、TextInputを埋め込む具象ウィジェット(例: NumberInput)を渡します。
+func (t *TextInput) initTextInput(self component.Widget) error {
+	t.maskRune = defaultMaskRune
+	t.LayoutableWidget = component.NewLayoutableWidget()
+	if err := t.Init(self); err != nil {
+		return err
+	}
+
+	th := theme.GetCurrent()
+	t.SetStyle(th.TextInput.Default)
+	t.focusStyle = th.TextInput.Focused
+	t.invalidStyle = th.TextInput.Invalid
+	t.errorStyle = th.TextInput.ErrorMessage
+	t.selectionColor = th.TextInput.SelectionColor
+	t.validation.trigger = ValidateOnChange | ValidateOnBlur
+	t.SetSize(160, 28)
+
+	// クリックでフォーカスを取得します。入力欄内のボタンの上であれば、そのボタンの操作も行います。
+	t.AddEventHandler(event.MouseDown, func(e *event.Event) event.Propagation {
+		if t.IsDisabled() {
+			return event.Propagate
+		}
+		p := image.Point{X: e.X, Y: e.Y}
+		switch {
+		case p.In(t.revealButtonRect()):
+			t.SetRevealed(!t.revealed)
+		case p.In(t.clearButtonRect()):
+			t.clear()
+		case t.runPointerHooks(p):
+		default:
+			t.handlePointerDown(e)
+		}
+		component.SetFocus(t)
+		return event.StopPropagation
+	})
+	return nil
+}
+
+// Value は、入力されている値を返します。マスク表示中でも実際の値を返します。
+func (t *TextInput) Value() string {
+	return string(t.runes)
+}
+
+// DefaultAccessibleRole は、component.DefaultRoleProviderインターフェースの実装です。
+func (t *TextInput) DefaultAccessibleRole() component.Role {
+	return component.RoleTextField
+}
+
+// DefaultAccessibleLabel は、component.DefaultLabelProviderインターフェースの実装です。プレースホルダーを名前として返します。
+func (t *TextInput) DefaultAccessibleLabel() string {
+	return t.placeholder
+}
+
+// AccessibleValue は、component.AccessibleValuerインターフェースの実装です。
+// マスク表示中は、実際の値の代わりにマスク文字を並べた文字列を返します。
+func (t *TextInput) AccessibleValue() string {
+	if t.masked {
+		return strings.Repeat(string(t.maskRune), len(t.runes))
+	}
+	return string(t.runes)
+}
+
+// SetValue は、値を置き換え、選択を解除してキャレットを末尾に移動します。変更時のコールバックは呼び出されません。
+// プログラムからの値の設定は取り消しの対象ではないため、取り消しとやり直しの履歴は破棄されます。
+// 入力マスクが設定されている場合、値はマスクに合わせて書式が整えられます。
+func (t *TextInput) SetValue(value string) {
+	t.ClearHistory()
+	if t.inputMask != nil {
+		value = t.inputMask.Format(t.inputMask.Raw(value))
+	}
+	t.runes = []rune(value)
+	t.cursor = len(t.runes)
+	t.anchor = t.cursor
+	t.MarkDirty(false)
+}
+
+// AddOnChange は、ユーザーの操作によって値が変化したときに呼び出される関数を追加します。
+func (t *TextInput) AddOnChange(fn func(value string)) {
+	if fn != nil {
+		t.onChange = append(t.onChange, fn)
+	}
+}
+
+// AddOnSubmit は、フォーカス中にEnterキーが押されたときに呼び出される関数を追加します。
+func (t *TextInput) AddOnSubmit(fn func(value string)) {
+	if fn != nil {
+		t.onSubmit = append(t.onSubmit, fn)
+	}
+}
+
+// AddOnFocusChange は、キーボードフォーカスを取得または喪失したときに呼び出される関数を追加します。
+// フォーカスを失ったときの関数は、検証(ValidateOnBlur)より前に呼び出されます。
+func (t *TextInput) AddOnFocusChange(fn func(focused bool)) {
+	if fn != nil {
+		t.onFocusChange = append(t.onFocusChange, fn)
+	}
+}
+
+// SetInputFilter は、入力を受け付ける文字を判定する関数を設定します。
+// 関数がfalseを返した文字は入力されません。nilを指定するとすべての表示可能な文字を受け付けます。
+func (t *TextInput) SetInputFilter(filter func(r rune) bool) {
+	t.filter = filter
+}
+
+// addInputHook は、フォーカス中に毎フレーム、キー入力の処理の前に呼び出される関数を追加します。
+// 関数がtrueを返した場合、そのフレームのEnterキーによる確定は行われません。
+func (t *TextInput) addInputHook(hook func() bool) {
+	t.inputHooks = append(t.inputHooks, hook)
+}
+
+// addPointerHook は、入力欄内での押下を、テキストの操作より先に処理する関数を追加します。
+// 関数がtrueを返した場合、その押下ではキャレットを移動しません。
+func (t *TextInput) addPointerHook(hook func(p image.Point) bool) {
+	t.pointerHooks = append(t.pointerHooks, hook)
+}
+
+// runPointerHooks は、押下を処理する関数を順に呼び出し、いずれかが押下を処理したかどうかを返します。
+func (t *TextInput) runPointerHooks(p image.Point) bool {
+	for _, hook := range t.pointerHooks {
+		if hook(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMasked は、各文字を伏せ字で表示するかどうかを設定します。パスワードの入力欄に使用します。
+func (t *TextInput) SetMasked(masked bool) {
+	if t.masked != masked {
+		t.masked = masked
+		t.revealed = false
+		t.MarkDirty(false)
+	}
+}
+
+// IsMasked は、伏せ字で表示する設定かどうかを返します。
+func (t *TextInput) IsMasked() bool {
+	return t.masked
+}
+
+// SetMaskRune は、伏せ字として描画する文字を設定します。
+// フォントが既定の "•" を持たない場合、明示的に設定されていなければ "*" が使用されます。
+func (t *TextInput) SetMaskRune(r rune) {
+	t.maskRune = r
+	t.MarkDirty(false)
+}
+
+// SetRevealButton は、マスク表示中に入力欄の右端へ、値の表示を切り替えるボタンを配置するかどうかを設定します。
+func (t *TextInput) SetRevealButton(enabled bool) {
+	if t.revealButton != enabled {
+		t.revealButton = enabled
+		t.MarkDirty(false)
+	}
+}
+
+// SetRevealed は、マスク表示中に一時的に値を表示するかどうかを設定します。
+func (t *TextInput) SetRevealed(revealed bool) {
+	if t.revealed != revealed {
+		t.revealed = revealed
+		t.MarkDirty(false)
+	}
+}
+
+// IsRevealed は、マスク表示中に値を表示しているかどうかを返します。
+func (t *TextInput) IsRevealed() bool {
+	return t.revealed
+}
+
+// SetPlaceholder は、値が空でフォーカスを持っていないときに表示されるヒントの文字列を設定します。
+func (t *TextInput) SetPlaceholder(placeholder string) {
+	if t.placeholder != placeholder {
+		t.placeholder = placeholder
+		t.MarkDirty(false)
+	}
+}
+
+// Placeholder は、ヒントの文字列を返します。
+func (t *TextInput) Placeholder() string {
+	return t.placeholder
+}
+
+// SetLeadingIcon は、入力欄の左端に表示するアイコンを設定します。nilを指定すると取り除きます。
+// 検索欄の虫眼鏡などに使用します。アイコンは縮小されずに元の大きさで描画されます。
+func (t *TextInput) SetLeadingIcon(icon *ebiten.Image) {
+	if t.leadingIcon != icon {
+		t.leadingIcon = icon
+		t.MarkDirty(false)
+	}
+}
+
+// SetClearButton は、値が空でない間、入力欄の右端に値を消去するボタンを配置するかどうかを設定します。
+func (t *TextInput) SetClearButton(enabled bool) {
+	if t.clearButton != enabled {
+		t.clearButton = enabled
+		t.MarkDirty(false)
+	}
+}
+
+// SetFocused は、Focusableインターフェースの実装です。フォーカスの変化に応じて再描画します。
+func (t *TextInput) SetFocused(focused bool) {
+	if t.focused != focused {
+		t.focused = focused
+		t.blinkTicks = 0
+		t.MarkDirty(false)
+		for _, fn := range t.onFocusChange {
+			fn(focused)
+		}
+		if !focused && t.validation.trigger&ValidateOnBlur != 0 {
+			t.Validate()
+		}
+	}
+}
+
+// IsFocused は、入力欄がキーボードフォーカスを持っているかどうかを返します。
+func (t *TextInput) IsFocused() bool {
+	return t.focused
+}
+
+// SetStyle は、基本スタイルを設定し、状態ごとのスタイルとエラーメッセージのスタイルを再計算させます。
+func (t *TextInput) SetStyle(s style.Style) {
+	t.LayoutableWidget.SetStyle(s)
+	t.stateValid = [4]bool{}
+	t.validation.messageValid = false
+}
+
+// SetFocusStyle は、フォーカス中に基本スタイルへマージされるスタイルを設定します。
+func (t *TextInput) SetFocusStyle(s style.Style) {
+	t.focusStyle = s
+	t.stateValid = [4]bool{}
+	t.MarkDirty(false)
+}
+
+// SetInvalidStyle は、検証に失敗している間に基本スタイルへマージされるスタイルを設定します。
+func (t *TextInput) SetInvalidStyle(s style.Style) {
+	t.invalidStyle = s
+	t.stateValid = [4]bool{}
+	t.MarkDirty(false)
+}
+
+// stateStyleIndex は、フォーカスと検証エラーの有無からstateStylesのインデックスを求めます。
+func stateStyleIndex(focused, invalid bool) int {
+	i := 0
+	if focused {
+		i |= 1
+	}
+	if invalid {
+		i |= 2
+	}
+	return i
+}
+
+// currentStyle は、フォーカスと検証エラーの有無に応じた描画用のスタイルを返します。
+// 検証エラーのスタイルは、フォーカスのスタイルよりも優先されます。
+func (t *TextInput) currentStyle() style.Computed {
+	if t.IsDisabled() {
+		return t.ComputedStyleForState(component.StateDisabled)
+	}
+	invalid := t.validation.err != nil
+	if !t.focused && !invalid {
+		return t.ComputedStyle()
+	}
+	i := stateStyleIndex(t.focused, invalid)
+	if !t.stateValid[i] {
+		s := t.ReadOnlyStyle()
+		if t.focused {
+			s = style.Merge(s, t.focusStyle)
+		}
+		if invalid {
+			s = style.Merge(s, t.invalidStyle)
+		}
+		t.stateStyles[i] = style.Resolve(s)
+		t.stateValid[i] = true
+	}
+	return t.stateStyles[i]
+}
+
+// GetMinSize は、1行分のテキストとパディングが収まる高さを最小の高さとして返します。
+// 検証が設定されている場合は、エラーメッセージの表示領域の高さが加わります。
+func (t *TextInput) GetMinSize() (int, int) {
+	userW, userH := t.UserMinSize()
+	c := t.ComputedStyle()
+	if c.Font == nil {
+		return userW, userH
+	}
+	m := component.FaceMetrics(c.Font)
+	h := (m.Ascent + m.Descent).Ceil() + c.Padding.Top + c.Padding.Bottom + t.errorSlotHeight()
+	return userW, max(userH, h)
+}
+
+// Cleanup は、フォーカスを持っている場合は解除してからリソースを解放します。
+func (t *TextInput) Cleanup() {
+	component.Blur(t)
+	t.onChange = nil
+	t.onSubmit = nil
+	t.onFocusChange = nil
+	t.inputHooks = nil
+	t.pointerHooks = nil
+	t.LayoutableWidget.Cleanup()
+}
+
+// --- 入力処理 ---
+
+// keyRepeated は、キーが押された瞬間、または押し続けて繰り返し入力のタイミングになったときにtrueを返します。
+func keyRepeated(key ebiten.Key) bool {
+	d := inpututil.KeyPressDuration(key)
+	if d == 1 {
+		return true
+	}
+	delay := durationToTicks(keyRepeatDelay)
+	return d > delay && (d-delay)%durationToTicks(keyRepeatInterval) == 0
+}
+
+// Update は、フォーカス中のキーボード入力を処理します。
+// 入力欄の外でマウスボタンが押された場合はフォーカスを手放します。
+func (t *TextInput) Update() {
+	t.LayoutableWidget.Update()
+	if !t.focused {
+		return
+	}
+	if !t.IsVisible() || t.IsDisabled() {
+		component.Blur(t)
+		return
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		// ポップアップ(候補リストなど)の上での押下では、フォーカスを手放しません。
+		if cx, cy := ebiten.CursorPosition(); t.HitTest(cx, cy) == nil && component.HitTestPopups(cx, cy) == nil {
+			component.Blur(t)
+			return
+		}
+	}
+
+	// 決定的モードではキャレットを点滅させず、常に表示します。
+	if !clock.IsDeterministic() {
+		t.blinkTicks++
+		if t.blinkTicks%max(1, durationToTicks(caretBlinkPeriod)/2) == 0 {
+			t.MarkDirty(false)
+		}
+		// 省電力モードでも、フォーカス中は更新を続けてキャレットを点滅させます。
+		component.RequestFrame()
+	}
+	t.updateDrag()
+	t.handleKeys()
+}
+
+// handleKeys は、文字の入力、編集キー、およびキャレットの移動を処理します。
+func (t *TextInput) handleKeys() {
+	consumed := false
+	for _, hook := range t.inputHooks {
+		if hook() {
+			consumed = true
+		}
+	}
+	changed := false
+	var buf [16]rune
+	for _, r := range ebiten.AppendInputChars(buf[:0]) {
+		if unicode.IsPrint(r) && (t.filter == nil || t.filter(r)) {
+			t.insert(r)
+			changed = true
+		}
+	}
+	if t.handleDeletion() {
+		changed = true
+	}
+	t.handleNavigation()
+	t.handleHistoryKeys()
+	if changed {
+		t.applyMask()
+		t.blinkTicks = 0
+		t.MarkDirty(false)
+		t.notifyChange()
+		if t.validation.trigger&ValidateOnChange != 0 {
+			t.Validate()
+		}
+	}
+	if !consumed && (inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter)) {
+		value := t.Value()
+		for _, fn := range t.onSubmit {
+			fn(value)
+		}
+	}
+}
+
+// insert は、キャレットの位置に1文字を挿入します。選択範囲がある場合は、選択範囲を置き換えます。
+// 連続した入力は、取り消しの際に1つの編集として扱われます。
+func (t *TextInput) insert(r rune) {
+	if t.HasSelection() {
+		t.recordEdit(editOther)
+		t.deleteSelection()
+		// 選択範囲を置き換えた後に続けて入力した文字は、置き換えと同じ編集にまとめます。
+		t.history.lastKind, t.history.coalescing = editInsert, true
+	} else {
+		t.recordEdit(editInsert)
+	}
+	t.runes = append(t.runes, 0)
+	copy(t.runes[t.cursor+1:], t.runes[t.cursor:])
+	t.runes[t.cursor] = r
+	t.cursor++
+	t.anchor = t.cursor
+}
+
+// clear は、消去ボタンの操作として値を空にし、ユーザーの操作による変更として通知します。
+func (t *TextInput) clear() {
+	if len(t.runes) == 0 {
+		return
+	}
+	t.scrollX = 0
+	t.replaceValue("")
+}
+
+// replaceValue は、ユーザーの操作として値全体を置き換え、キャレットを末尾に移動します。
+// SetValueと異なり、取り消しの履歴に記録され、変更がコールバックに通知されます。
+func (t *TextInput) replaceValue(value string) {
+	t.recordEdit(editOther)
+	t.runes = []rune(value)
+	t.cursor = len(t.runes)
+	t.anchor = t.cursor
+	t.applyMask()
+	t.blinkTicks = 0
+	t.MarkDirty(false)
+	t.notifyChange()
+	if t.validation.trigger&ValidateOnChange != 0 {
+		t.Validate()
+	}
+}
+
+// notifyChange は、値の変更をコールバックに通知します。
+func (t *TextInput) notifyChange() {
+	value := t.Value()
+	for _, fn := range t.onChange {
+		fn(value)
+	}
+}
+
+// --- 描画 ---
+
+// displayText は、描画する文字列を返します。マスク表示中は伏せ字に置き換えます。
+func (t *TextInput) displayText(f font.Face) string {
+	if !t.masked || t.revealed {
+		return string(t.runes)
+	}
+	mask := t.maskRune
+	if mask == defaultMaskRune {
+		if _, ok := component.GlyphAdvance(f, mask); !ok {
+			mask = fallbackMaskRune
+		}
+	}
+	masked := make([]rune, len(t.runes))
+	for i := range masked {
+		masked[i] = mask
+	}
+	return string(masked)
+}
+
+// boxRect は、エラーメッセージの表示領域を除いた、入力欄の枠の領域を絶対座標で返します。
+func (t *TextInput) boxRect() image.Rectangle {
+	x, y := t.GetPosition()
+	w, h := t.GetSize()
+	return image.Rect(x, y, x+w, y+h-t.errorSlotHeight())
+}
+
+// contentRect は、入力欄の枠からパディングを除いたコンテンツ領域を絶対座標で返します。
+func (t *TextInput) contentRect(c style.Computed) image.Rectangle {
+	box := t.boxRect()
+	return image.Rect(box.Min.X+c.Padding.Left, box.Min.Y+c.Padding.Top, box.Max.X-c.Padding.Right, box.Max.Y-c.Padding.Bottom)
+}
+
+// revealButtonRect は、表示切り替えボタンの領域を絶対座標で返します。ボタンがない場合は空の矩形です。
+// 表示切り替えボタンはコンテンツ領域の右端に配置されます。
+func (t *TextInput) revealButtonRect() image.Rectangle {
+	if !t.masked || !t.revealButton {
+		return image.Rectangle{}
+	}
+	c := t.ComputedStyle()
+	if c.Font == nil {
+		return image.Rectangle{}
+	}
+	content := t.contentRect(c)
+	w := component.MeasureString(c.Font, revealLabelShow).Ceil()
+	w = max(w, component.MeasureString(c.Font, revealLabelHide).Ceil()) + inlineButtonPadding*2
+	return image.Rect(content.Max.X-w, content.Min.Y, content.Max.X, content.Max.Y)
+}
+
+// clearButtonRect は、消去ボタンの領域を絶対座標で返します。ボタンがない場合や値が空の場合は空の矩形です。
+// 表示切り替えボタンがある場合は、その左隣に配置されます。
+func (t *TextInput) clearButtonRect() image.Rectangle {
+	if !t.clearButton || len(t.runes) == 0 || t.IsDisabled() {
+		return image.Rectangle{}
+	}
+	c := t.ComputedStyle()
+	if c.Font == nil {
+		return image.Rectangle{}
+	}
+	content := t.contentRect(c)
+	right := content.Max.X
+	if reveal := t.revealButtonRect(); !reveal.Empty() {
+		right = reveal.Min.X
+	}
+	w := component.MeasureString(c.Font, clearLabel).Ceil() + inlineButtonPadding*2
+	return image.Rect(right-w, content.Min.Y, right, content.Max.Y)
+}
+
+// leadingIconRect は、左端のアイコンの領域を絶対座標で返します。アイコンがない場合は空の矩形です。
+func (t *TextInput) leadingIconRect() image.Rectangle {
+	if t.leadingIcon == nil {
+		return image.Rectangle{}
+	}
+	content := t.contentRect(t.ComputedStyle())
+	size := t.leadingIcon.Bounds().Size()
+	y := content.Min.Y + (content.Dy()-size.Y)/2
+	return image.Rect(content.Min.X, y, content.Min.X+size.X, y+size.Y)
+}
+
+// Draw は、背景、テキスト、キャレット、および表示切り替えボタンを描画します。
+func (t *TextInput) Draw(info component.DrawInfo) {
+	if !t.IsVisible() || !t.HasBeenLaidOut() {
+		return
+	}
+	c := t.currentStyle()
+	offset := image.Pt(info.OffsetX, info.OffsetY)
+	box := t.boxRect().Add(offset)
+	component.DrawComputedBackground(info.Screen, box.Min.X, box.Min.Y, box.Dx(), box.Dy(), c)
+	if c.Font == nil {
+		return
+	}
+	t.drawErrorMessage(info.Screen, box)
+
+	textArea := t.contentRect(c)
+	if icon := t.leadingIconRect(); !icon.Empty() {
+		textArea.Min.X = icon.Max.X + inlineButtonPadding
+		// アイコンはバッチを経由せずに描画されるため、先に蓄積された背景を描画して順序を保ちます。
+		component.FlushDraws()
+		opts := &ebiten.DrawImageOptions{}
+		opts.GeoM.Translate(float64(icon.Min.X+offset.X), float64(icon.Min.Y+offset.Y))
+		if t.IsDisabled() {
+			opts.ColorScale.ScaleAlpha(0.5)
+		}
+		info.Screen.DrawImage(t.leadingIcon, opts)
+		stats.AddDrawCalls(1)
+	}
+	textArea.Min.X += t.leadingWidth
+	if button := t.revealButtonRect(); !button.Empty() {
+		textArea.Max.X = button.Min.X
+		label := revealLabelShow
+		if t.revealed {
+			label = revealLabelHide
+		}
+		drawInlineButton(info.Screen, label, button.Add(offset), c)
+	}
+	if button := t.clearButtonRect(); !button.Empty() {
+		textArea.Max.X = button.Min.X
+		drawInlineButton(info.Screen, clearLabel, button.Add(offset), c)
+	}
+	textArea = textArea.Add(offset)
+	if textArea.Empty() {
+		return
+	}
+	// テキストの前にチップなどが並んでいる場合は、プレースホルダーを表示しません。
+	if len(t.runes) == 0 && !t.focused && t.placeholder != "" && t.leadingWidth == 0 {
+		t.drawPlaceholder(info.Screen, textArea, c)
+		return
+	}
+
+	// テキストは表示順に並べ替えて描画します。キャレットと選択範囲の位置も、並べ替えた配置から求めます。
+	layout := layoutInputText(c.Font, []rune(t.displayText(c.Font)))
+	caretX := layout.caretX(t.cursor)
+	// キャレットが表示領域の外に出ないように、スクロール量を調整します。
+	if layout.rtl && layout.width < textArea.Dx() {
+		t.scrollX = 0
+	} else if caretX-t.scrollX > textArea.Dx()-1 {
+		t.scrollX = caretX - textArea.Dx() + 1
+	} else if caretX < t.scrollX {
+		t.scrollX = caretX
+	}
+	originX := t.textOrigin(layout, textArea)
+
+	m := component.FaceMetrics(c.Font)
+	lineHeight := (m.Ascent + m.Descent).Ceil()
+	top := textArea.Min.Y + (textArea.Dy()-lineHeight)/2
+	baseline := top + m.Ascent.Ceil()
+
+	component.FlushDraws()
+	clipped := info.Screen.SubImage(textArea).(*ebiten.Image)
+	start, end := t.SelectionRange()
+	if start != end && t.focused {
+		for _, span := range layout.selectionSpans(start, end) {
+			component.DrawFilledRect(clipped, float32(originX+span[0]), float32(top), float32(span[1]-span[0]), float32(lineHeight), t.selectionColor)
+		}
+		component.FlushDraws()
+	}
+	if len(layout.visual) > 0 {
+		text.Draw(clipped, string(layout.visual), c.Font, originX, baseline, c.TextColor)
+		stats.AddDrawCalls(1)
+	}
+	if t.focused && start == end && t.caretVisible() {
+		component.DrawFilledRect(clipped, float32(originX+caretX), float32(top), 1, float32(lineHeight), c.TextColor)
+		component.FlushDraws()
+	}
+}
+
+// caretVisible は、点滅中のキャレットを現在のフレームで表示するかどうかを返します。
+func (t *TextInput) caretVisible() bool {
+	if clock.IsDeterministic() {
+		return true
+	}
+	half := max(1, durationToTicks(caretBlinkPeriod)/2)
+	return (t.blinkTicks/half)%2 == 0
+}
+
+// mutedColor は、プレースホルダーや入力欄内のボタンに使用する、テキストより控えめな色を返します。
+func mutedColor(c color.Color) color.Color {
+	muted := color.NRGBAModel.Convert(c).(color.NRGBA)
+	muted.A /= 2
+	return muted
+}
+
+// drawPlaceholder は、プレースホルダーをテキストと同じ位置に控えめな色で描画します。
+// 収まらない部分は省略記号で切り詰めます。
+func (t *TextInput) drawPlaceholder(screen *ebiten.Image, r image.Rectangle, c style.Computed) {
+	placeholder := component.TruncateText(c.Font, t.placeholder, r.Dx(), style.TextTruncateEllipsis)
+	if placeholder == "" {
+		return
+	}
+	m := component.FaceMetrics(c.Font)
+	baseline := r.Min.Y + (r.Dy()-(m.Ascent+m.Descent).Ceil())/2 + m.Ascent.Ceil()
+	x := r.Min.X
+	rtl := component.IsRTL(placeholder)
+	if rtl {
+		// 右から左に書くプレースホルダーは、右端に揃えます。
+		x = r.Max.X - component.MeasureString(c.Font, placeholder).Ceil()
+	}
+	component.FlushDraws()
+	text.Draw(screen, component.VisualLine(placeholder, rtl), c.Font, x, baseline, mutedColor(c.TextColor))
+	stats.AddDrawCalls(1)
+}
+
+// drawInlineButton は、入力欄内に配置するボタンの文字列を、テキストより控えめな色で描画します。
+func drawInlineButton(screen *ebiten.Image, label string, r image.Rectangle, c style.Computed) {
+	m := component.FaceMetrics(c.Font)
+	baseline := r.Min.Y + (r.Dy()-(m.Ascent+m.Descent).Ceil())/2 + m.Ascent.Ceil()
+	component.FlushDraws()
+	text.Draw(screen, label, c.Font, r.Min.X+inlineButtonPadding, baseline, mutedColor(c.TextColor))
+	stats.AddDrawCalls(1)
+}
+
+// --- TextInputBuilder ---
+
+// TextInputBuilder は、TextInputを宣言的に構築するためのビルダーです。
+type TextInputBuilder struct {
+	component.Builder[*TextInputBuilder, *TextInput]
+}
+
+// NewTextInputBuilder は新しいTextInputBuilderを生成します。
+func NewTextInputBuilder() *TextInputBuilder {
+	t, err := newTextInput()
+	b := &TextInputBuilder{}
+	b.Init(b, t)
+	b.AddError(err)
+	return b
+}
+
+// Value は、初期値を設定します。
+func (b *TextInputBuilder) Value(value string) *TextInputBuilder {
+	b.Widget.SetValue(value)
+	return b
+}
+
+// Masked は、入力された文字を伏せ字で表示するかどうかを設定します。
+// 例: t.Masked(true).RevealButton(true)
+func (b *TextInputBuilder) Masked(masked bool) *TextInputBuilder {
+	b.Widget.SetMasked(masked)
+	return b
+}
+
+// MaskRune は、伏せ字として描画する文字を設定します。
+func (b *TextInputBuilder) MaskRune(r rune) *TextInputBuilder {
+	b.Widget.SetMaskRune(r)
+	return b
+}
+
+// RevealButton は、マスク表示中に値の表示を切り替えるボタンを配置するかどうかを設定します。
+func (b *TextInputBuilder) RevealButton(enabled bool) *TextInputBuilder {
+	b.Widget.SetRevealButton(enabled)
+	return b
+}
+
+// Placeholder は、値が空でフォーカスを持っていないときに表示されるヒントの文字列を設定します。
+func (b *TextInputBuilder) Placeholder(placeholder string) *TextInputBuilder {
+	b.Widget.SetPlaceholder(placeholder)
+	return b
+}
+
+// ClearButton は、値が空でない間、値を消去するボタンを配置するかどうかを設定します。
+func (b *TextInputBuilder) ClearButton(enabled bool) *TextInputBuilder {
+	b.Widget.SetClearButton(enabled)
+	return b
+}
+
+// FocusStyle は、フォーカス中に基本スタイルへマージされるスタイルを設定します。
+func (b *TextInputBuilder) FocusStyle(s style.Style) *TextInputBuilder {
+	b.Widget.SetFocusStyle(s)
+	return b
+}
+
+// OnChange は、ユーザーの操作によって値が変化したときに呼び出される関数を追加します。
+func (b *TextInputBuilder) OnChange(fn func(value string)) *TextInputBuilder {
+	b.Widget.AddOnChange(fn)
+	return b
+}
+
+// OnSubmit は、Enterキーで入力が確定されたときに呼び出される関数を追加します。
+func (b *TextInputBuilder) OnSubmit(fn func(value string)) *TextInputBuilder {
+	b.Widget.AddOnSubmit(fn)
+	return b
+}
+
+// Build は、最終的なTextInputを構築して返します。
+func (b *TextInputBuilder) Build() (*TextInput, error) {
+	return b.Builder.Build()
+}
@@ -0,0 +1,168 @@
+package widget
+
+import (
+	"furoshiki/clock"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	// defaultHistoryLimit は、元に戻せる編集の既定の最大数です。
+	defaultHistoryLimit = 100
+	// editCoalesceIdle は、連続した入力を1つの編集としてまとめる最大の間隔です。
+	// これより長く手を止めた後の入力は、新しい編集として記録されます。
+	editCoalesceIdle = time.Second
+)
+
+// editKind は、編集の種類です。同じ種類の編集が続く間は、1つの取り消し単位にまとめられます。
+type editKind int
+
+const (
+	// editOther は、他の編集とまとめない編集(選択範囲の置き換えや消去ボタンなど)です。
+	editOther editKind = iota
+	editInsert
+	editDelete
+)
+
+// editSnapshot は、取り消しのために保存する、編集前の値とキャレットの状態です。
+type editSnapshot struct {
+	runes          []rune
+	cursor, anchor int
+}
+
+// editHistory は、TextInputの取り消しとやり直しの履歴です。
+type editHistory struct {
+	undo, redo []editSnapshot
+	limit      int
+	// lastKind と lastEdit は、直前に記録した編集の種類と時刻です。
+	// 同じ種類の編集が間を置かずに続いた場合、履歴を追加せずに直前の編集にまとめます。
+	lastKind editKind
+	lastEdit time.Time
+	// coalescing は、次の編集を直前の編集にまとめてよいかどうかです。
+	coalescing bool
+}
+
+// snapshot は、現在の値とキャレットの状態を複製して返します。
+func (t *TextInput) snapshot() editSnapshot {
+	return editSnapshot{runes: slices.Clone(t.runes), cursor: t.cursor, anchor: t.anchor}
+}
+
+// restore は、保存した状態に戻し、値の変更として通知します。
+func (t *TextInput) restore(s editSnapshot) {
+	t.runes = s.runes
+	t.cursor = s.cursor
+	t.anchor = s.anchor
+	t.blinkTicks = 0
+	t.MarkDirty(false)
+	t.notifyChange()
+	if t.validation.trigger&ValidateOnChange != 0 {
+		t.Validate()
+	}
+}
+
+// recordEdit は、これから行う編集の前の状態を履歴に記録します。
+// 直前と同じ種類の入力または削除が続いている場合は、記録せずに直前の編集にまとめます。
+func (t *TextInput) recordEdit(kind editKind) {
+	h := &t.history
+	now := clock.Now()
+	merge := h.coalescing && kind != editOther && kind == h.lastKind && now.Sub(h.lastEdit) < editCoalesceIdle
+	h.lastKind, h.lastEdit = kind, now
+	h.coalescing = kind != editOther
+	h.redo = h.redo[:0]
+	if merge {
+		return
+	}
+	h.undo = append(h.undo, t.snapshot())
+	if limit := t.historyLimit(); len(h.undo) > limit {
+		h.undo = slices.Delete(h.undo, 0, len(h.undo)-limit)
+	}
+}
+
+// breakCoalescing は、次の編集を直前の編集にまとめないようにします。キャレットの移動などで呼び出されます。
+func (t *TextInput) breakCoalescing() {
+	t.history.coalescing = false
+}
+
+func (t *TextInput) historyLimit() int {
+	if t.history.limit <= 0 {
+		return defaultHistoryLimit
+	}
+	return t.history.limit
+}
+
+// Undo は、直前の編集を取り消します。取り消す編集がない場合はfalseを返します。
+func (t *TextInput) Undo() bool {
+	h := &t.history
+	if len(h.undo) == 0 {
+		return false
+	}
+	prev := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, t.snapshot())
+	h.coalescing = false
+	t.restore(prev)
+	return true
+}
+
+// Redo は、取り消した編集をやり直します。やり直す編集がない場合はfalseを返します。
+func (t *TextInput) Redo() bool {
+	h := &t.history
+	if len(h.redo) == 0 {
+		return false
+	}
+	next := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, t.snapshot())
+	h.coalescing = false
+	t.restore(next)
+	return true
+}
+
+// CanUndo は、取り消せる編集があるかどうかを返します。
+func (t *TextInput) CanUndo() bool {
+	return len(t.history.undo) > 0
+}
+
+// CanRedo は、やり直せる編集があるかどうかを返します。
+func (t *TextInput) CanRedo() bool {
+	return len(t.history.redo) > 0
+}
+
+// ClearHistory は、取り消しとやり直しの履歴をすべて破棄します。
+func (t *TextInput) ClearHistory() {
+	t.history.undo = nil
+	t.history.redo = nil
+	t.history.coalescing = false
+}
+
+// SetHistoryLimit は、元に戻せる編集の最大数を設定します。0以下を指定すると既定値(100)になります。
+// 上限を超えた古い編集から破棄されます。
+func (t *TextInput) SetHistoryLimit(limit int) {
+	t.history.limit = limit
+	if l := t.historyLimit(); len(t.history.undo) > l {
+		t.history.undo = slices.Delete(t.history.undo, 0, len(t.history.undo)-l)
+	}
+}
+
+// handleHistoryKeys は、Ctrl+Z(取り消し)と、Ctrl+YまたはCtrl+Shift+Z(やり直し)を処理します。
+func (t *TextInput) handleHistoryKeys() {
+	if !shortcutModifierPressed() {
+		return
+	}
+	switch {
+	case keyRepeated(ebiten.KeyZ) && ebiten.IsKeyPressed(ebiten.KeyShift), keyRepeated(ebiten.KeyY):
+		t.Redo()
+	case keyRepeated(ebiten.KeyZ):
+		t.Undo()
+	}
+}
+
+// --- TextInputBuilder ---
+
+// HistoryLimit は、元に戻せる編集の最大数を設定します。
+func (b *TextInputBuilder) HistoryLimit(limit int) *TextInputBuilder {
+	b.Widget.SetHistoryLimit(limit)
+	return b
+}
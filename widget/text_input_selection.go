@@ -0,0 +1,437 @@
+package widget
+
+import (
+	"furoshiki/component"
+	"furoshiki/event"
+	"image"
+	"time"
+	"unicode"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// doubleClickInterval は、2回の押下をダブルクリックとみなす最大の間隔です。
+const doubleClickInterval = 500 * time.Millisecond
+
+// runeClass は、単語単位の移動で使用する文字の分類です。
+type runeClass int
+
+const (
+	classSpace runeClass = iota
+	classWord
+	classPunct
+)
+
+func classOf(r rune) runeClass {
+	switch {
+	case unicode.IsSpace(r):
+		return classSpace
+	case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+		return classWord
+	default:
+		return classPunct
+	}
+}
+
+// prevWordStart は、位置iから左にある単語の先頭の位置を返します。直前の空白は読み飛ばします。
+func prevWordStart(runes []rune, i int) int {
+	for i > 0 && classOf(runes[i-1]) == classSpace {
+		i--
+	}
+	if i == 0 {
+		return 0
+	}
+	c := classOf(runes[i-1])
+	for i > 0 && classOf(runes[i-1]) == c {
+		i--
+	}
+	return i
+}
+
+// nextWordEnd は、位置iから右にある単語の末尾の位置を返します。直後の空白は読み飛ばします。
+func nextWordEnd(runes []rune, i int) int {
+	for i < len(runes) && classOf(runes[i]) == classSpace {
+		i++
+	}
+	if i == len(runes) {
+		return i
+	}
+	c := classOf(runes[i])
+	for i < len(runes) && classOf(runes[i]) == c {
+		i++
+	}
+	return i
+}
+
+// wordAt は、位置iにある単語(または同じ分類の文字の並び)の範囲を返します。
+func wordAt(runes []rune, i int) (int, int) {
+	if len(runes) == 0 {
+		return 0, 0
+	}
+	i = min(i, len(runes)-1)
+	c := classOf(runes[i])
+	start, end := i, i+1
+	for start > 0 && classOf(runes[start-1]) == c {
+		start--
+	}
+	for end < len(runes) && classOf(runes[end]) == c {
+		end++
+	}
+	return start, end
+}
+
+// --- 選択範囲 ---
+
+// SelectionRange は、選択範囲を文字単位の [start, end) で返します。選択がない場合はstartとendがキャレットの位置になります。
+func (t *TextInput) SelectionRange() (start, end int) {
+	return min(t.anchor, t.cursor), max(t.anchor, t.cursor)
+}
+
+// HasSelection は、1文字以上が選択されているかどうかを返します。
+func (t *TextInput) HasSelection() bool {
+	return t.anchor != t.cursor
+}
+
+// SetSelection は、選択範囲を設定します。キャレットはendの位置に置かれます。範囲は値の長さに収まるよう補正されます。
+func (t *TextInput) SetSelection(start, end int) {
+	t.anchor = max(0, min(start, len(t.runes)))
+	t.cursor = max(0, min(end, len(t.runes)))
+	t.breakCoalescing()
+	t.blinkTicks = 0
+	t.MarkDirty(false)
+}
+
+// SelectAll は、値全体を選択します。
+func (t *TextInput) SelectAll() {
+	t.SetSelection(0, len(t.runes))
+}
+
+// SelectedText は、選択されている文字列を返します。
+func (t *TextInput) SelectedText() string {
+	start, end := t.SelectionRange()
+	return string(t.runes[start:end])
+}
+
+// CaretPosition は、キャレットの位置を文字単位で返します。
+func (t *TextInput) CaretPosition() int {
+	return t.cursor
+}
+
+// SetCaretPosition は、選択を解除してキャレットを指定された位置に移動します。
+func (t *TextInput) SetCaretPosition(pos int) {
+	t.SetSelection(pos, pos)
+}
+
+// moveCaret は、キャレットを移動します。extendがtrueの場合は選択範囲を広げ、そうでなければ選択を解除します。
+func (t *TextInput) moveCaret(pos int, extend bool) {
+	t.cursor = max(0, min(pos, len(t.runes)))
+	if !extend {
+		t.anchor = t.cursor
+	}
+	t.breakCoalescing()
+	t.blinkTicks = 0
+	t.MarkDirty(false)
+}
+
+// deleteRange は、[start, end) の文字を削除し、キャレットをstartに置きます。
+func (t *TextInput) deleteRange(start, end int) {
+	t.runes = append(t.runes[:start], t.runes[end:]...)
+	t.cursor = start
+	t.anchor = start
+}
+
+// deleteSelection は、選択されている文字を削除します。選択がない場合はfalseを返します。
+func (t *TextInput) deleteSelection() bool {
+	if !t.HasSelection() {
+		return false
+	}
+	t.deleteRange(t.SelectionRange())
+	return true
+}
+
+// hidesWords は、単語の区切りを隠すべきかどうかを返します。
+// マスク表示中は値の構造を推測されないよう、単語単位の操作は値の先頭または末尾までを対象にします。
+func (t *TextInput) hidesWords() bool {
+	return t.masked && !t.revealed
+}
+
+// wordLeft と wordRight は、単語単位でキャレットを移動する先の位置を返します。
+func (t *TextInput) wordLeft() int {
+	if t.hidesWords() {
+		return 0
+	}
+	return prevWordStart(t.runes, t.cursor)
+}
+
+func (t *TextInput) wordRight() int {
+	if t.hidesWords() {
+		return len(t.runes)
+	}
+	return nextWordEnd(t.runes, t.cursor)
+}
+
+// --- キー操作 ---
+
+// wordModifierPressed は、単語単位の操作の修飾キー(CtrlまたはAlt)が押されているかどうかを返します。
+func wordModifierPressed() bool {
+	return ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyAlt)
+}
+
+// shortcutModifierPressed は、ショートカットの修飾キー(CtrlまたはCmd)が押されているかどうかを返します。
+func shortcutModifierPressed() bool {
+	return ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)
+}
+
+// handleDeletion は、BackspaceとDeleteキーによる削除を処理し、値が変化したかどうかを返します。
+// Ctrl(またはAlt)と同時に押された場合は単語単位で削除します。
+func (t *TextInput) handleDeletion() bool {
+	word := wordModifierPressed()
+	changed := false
+	if keyRepeated(ebiten.KeyBackspace) {
+		switch {
+		case t.HasSelection():
+			t.recordEdit(editOther)
+			t.deleteSelection()
+			changed = true
+		case t.cursor > 0:
+			t.recordEdit(editDelete)
+			start := t.cursor - 1
+			if word {
+				start = t.wordLeft()
+			}
+			t.deleteRange(start, t.cursor)
+			changed = true
+		}
+	}
+	if keyRepeated(ebiten.KeyDelete) {
+		switch {
+		case t.HasSelection():
+			t.recordEdit(editOther)
+			t.deleteSelection()
+			changed = true
+		case t.cursor < len(t.runes):
+			t.recordEdit(editDelete)
+			end := t.cursor + 1
+			if word {
+				end = t.wordRight()
+			}
+			t.deleteRange(t.cursor, end)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// handleNavigation は、矢印キー、Home、End、および全選択のショートカットを処理します。
+// Shiftと同時に押された場合は選択範囲を広げます。
+// 右から左に書くテキストでは、左矢印キーで論理順の後ろ(画面上の左)へ、右矢印キーで前へ移動します。
+func (t *TextInput) handleNavigation() {
+	extend := ebiten.IsKeyPressed(ebiten.KeyShift)
+	word := wordModifierPressed()
+	left, right := keyRepeated(ebiten.KeyArrowLeft), keyRepeated(ebiten.KeyArrowRight)
+	if (left || right) && (!t.masked || t.revealed) && component.IsRTL(string(t.runes)) {
+		left, right = right, left
+	}
+	switch {
+	case left:
+		switch {
+		case word:
+			t.moveCaret(t.wordLeft(), extend)
+		case t.HasSelection() && !extend:
+			// 選択中に戻る方向へ移動すると、選択範囲の先頭で選択を解除します。
+			start, _ := t.SelectionRange()
+			t.moveCaret(start, false)
+		default:
+			t.moveCaret(t.cursor-1, extend)
+		}
+	case right:
+		switch {
+		case word:
+			t.moveCaret(t.wordRight(), extend)
+		case t.HasSelection() && !extend:
+			_, end := t.SelectionRange()
+			t.moveCaret(end, false)
+		default:
+			t.moveCaret(t.cursor+1, extend)
+		}
+	case keyRepeated(ebiten.KeyHome):
+		t.moveCaret(0, extend)
+	case keyRepeated(ebiten.KeyEnd):
+		t.moveCaret(len(t.runes), extend)
+	case shortcutModifierPressed() && keyRepeated(ebiten.KeyA):
+		t.SelectAll()
+	}
+}
+
+// --- マウス操作 ---
+
+// textRect は、入力欄内のボタンを除いた、テキストが描画される領域を絶対座標で返します。
+func (t *TextInput) textRect() image.Rectangle {
+	c := t.currentStyle()
+	r := t.contentRect(c)
+	if icon := t.leadingIconRect(); !icon.Empty() {
+		r.Min.X = icon.Max.X + inlineButtonPadding
+	}
+	r.Min.X += t.leadingWidth
+	if button := t.revealButtonRect(); !button.Empty() {
+		r.Max.X = button.Min.X
+	}
+	if button := t.clearButtonRect(); !button.Empty() {
+		r.Max.X = button.Min.X
+	}
+	return r
+}
+
+// runeIndexAt は、絶対座標のxに最も近い文字の境界の位置(論理順のインデックス)を返します。
+func (t *TextInput) runeIndexAt(x int) int {
+	c := t.currentStyle()
+	if c.Font == nil {
+		return 0
+	}
+	l := layoutInputText(c.Font, []rune(t.displayText(c.Font)))
+	return l.indexAt(x - t.textOrigin(l, t.textRect()))
+}
+
+// handlePointerDown は、テキスト領域での押下を処理します。
+// クリックでキャレットを移動し、Shift+クリックで選択範囲を広げ、ダブルクリックで単語を選択します。
+func (t *TextInput) handlePointerDown(e *event.Event) {
+	if !(image.Point{X: e.X, Y: e.Y}).In(t.textRect()) {
+		return
+	}
+	index := t.runeIndexAt(e.X)
+	doubleClick := e.Timestamp-t.lastClick < int64(doubleClickInterval) && index == t.lastClickIndex
+	t.lastClick, t.lastClickIndex = e.Timestamp, index
+	if doubleClick {
+		if t.hidesWords() {
+			t.SelectAll()
+		} else {
+			t.SetSelection(wordAt(t.runes, index))
+		}
+		// 続けて押された場合に、3回目をダブルクリックとみなさないようにします。
+		t.lastClick = 0
+		return
+	}
+	t.moveCaret(index, ebiten.IsKeyPressed(ebiten.KeyShift))
+	t.dragging = true
+}
+
+// updateDrag は、ボタンを押したままカーソルを動かしている間、選択範囲を広げます。
+func (t *TextInput) updateDrag() {
+	if !t.dragging {
+		return
+	}
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		t.dragging = false
+		return
+	}
+	x, _ := ebiten.CursorPosition()
+	if index := t.runeIndexAt(x); index != t.cursor {
+		t.moveCaret(index, true)
+	}
+}
+
+// --- 双方向テキストの配置 ---
+
+// inputTextLayout は、入力欄のテキストを表示順に並べ、各文字の位置を求めた結果です。
+// テキストは論理順(入力された順)で保持され、キャレットや選択範囲も論理順のインデックスで表されます。
+// 右から左に書く文字を含む場合、論理順で隣り合う文字が画面上で離れることがあるため、位置はこの配置から求めます。
+type inputTextLayout struct {
+	// visual は、表示順に並べた描画する文字です。
+	visual []rune
+	// order は、表示順のi番目の文字の論理順のインデックスです。
+	order []int
+	// levels は、論理順の各文字の埋め込みレベルです。奇数は右から左に表示されることを示します。
+	levels []uint8
+	// x と adv は、論理順の各文字の左端の位置(テキストの左端が0)と送り幅です。
+	x, adv []int
+	width  int
+	rtl    bool
+}
+
+// layoutInputText は、論理順の文字列を表示順に配置します。
+func layoutInputText(f font.Face, runes []rune) inputTextLayout {
+	l := inputTextLayout{rtl: component.IsRTL(string(runes))}
+	l.visual, l.order, l.levels = component.VisualRunes(runes, l.rtl)
+	l.x = make([]int, len(runes))
+	l.adv = make([]int, len(runes))
+	prev := rune(-1)
+	for i, r := range l.visual {
+		if prev >= 0 {
+			l.width += component.Kern(f, prev, r).Round()
+		}
+		adv, _ := component.GlyphAdvance(f, r)
+		l.x[l.order[i]] = l.width
+		l.adv[l.order[i]] = adv.Round()
+		l.width += adv.Round()
+		prev = r
+	}
+	return l
+}
+
+// isRTLAt は、論理順のi番目の文字が右から左に表示されるかどうかを返します。
+func (l inputTextLayout) isRTLAt(i int) bool {
+	return l.levels[i]%2 == 1
+}
+
+// caretX は、論理順のインデックスindexのキャレットの位置を返します。
+// キャレットは直前の文字の後ろ側(左から右の文字なら右端、右から左の文字なら左端)に置かれます。
+// 先頭では、最初の文字の前側に置かれます。
+func (l inputTextLayout) caretX(index int) int {
+	if len(l.x) == 0 {
+		return 0
+	}
+	if index > 0 {
+		i := index - 1
+		if l.isRTLAt(i) {
+			return l.x[i]
+		}
+		return l.x[i] + l.adv[i]
+	}
+	if l.isRTLAt(0) {
+		return l.x[0] + l.adv[0]
+	}
+	return l.x[0]
+}
+
+// indexAt は、テキストの左端からの位置xに最も近い文字の境界の、論理順のインデックスを返します。
+func (l inputTextLayout) indexAt(x int) int {
+	for j, i := range l.order {
+		if x >= l.x[i]+l.adv[i] && j < len(l.order)-1 {
+			continue
+		}
+		// 文字の中心より左であれば、左から右の文字ではその文字の前を、右から左の文字ではその文字の後ろを指しているとみなします。
+		left := x < l.x[i]+l.adv[i]/2
+		if left != l.isRTLAt(i) {
+			return i
+		}
+		return i + 1
+	}
+	return 0
+}
+
+// selectionSpans は、論理順の範囲[start, end)の文字が画面上で占める区間を、左から順に返します。
+// 双方向のテキストでは、1つの選択範囲が画面上で複数の区間に分かれることがあります。
+func (l inputTextLayout) selectionSpans(start, end int) [][2]int {
+	var spans [][2]int
+	for _, i := range l.order {
+		if i < start || i >= end {
+			continue
+		}
+		if n := len(spans); n > 0 && spans[n-1][1] == l.x[i] {
+			spans[n-1][1] = l.x[i] + l.adv[i]
+			continue
+		}
+		spans = append(spans, [2]int{l.x[i], l.x[i] + l.adv[i]})
+	}
+	return spans
+}
+
+// textOrigin は、テキスト領域areaにテキストを描画する際の、テキストの左端の絶対座標を返します。
+// 右から左に書くテキストは、領域に収まる間は右端に揃えます。収まらない場合は、左から右のテキストと同様にスクロールします。
+func (t *TextInput) textOrigin(l inputTextLayout, area image.Rectangle) int {
+	if l.rtl && l.width < area.Dx() {
+		return area.Max.X - 1 - l.width
+	}
+	return area.Min.X - t.scrollX
+}
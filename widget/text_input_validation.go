@@ -0,0 +1,245 @@
+package widget
+
+import (
+	"errors"
+	"fmt"
+	"furoshiki/component"
+	"furoshiki/stats"
+	"furoshiki/style"
+	"image"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+// errorMessageGap は、入力欄の枠とその下のエラーメッセージとの間隔です。
+const errorMessageGap = 2
+
+// Validator は、入力値を検証する関数です。値が不正な場合は、ユーザーに表示するメッセージを持つエラーを返します。
+type Validator func(value string) error
+
+// ValidationTrigger は、検証を実行するタイミングを表すビットフラグです。
+type ValidationTrigger int
+
+const (
+	// ValidateOnChange は、ユーザーの操作によって値が変化するたびに検証します。
+	ValidateOnChange ValidationTrigger = 1 << iota
+	// ValidateOnBlur は、入力欄がフォーカスを失ったときに検証します。
+	ValidateOnBlur
+)
+
+// inputValidation は、TextInputの検証の設定と直近の結果です。
+type inputValidation struct {
+	validators []Validator
+	trigger    ValidationTrigger
+	// err は、直近の検証で最初に失敗したバリデータのエラーです。検証に成功している場合はnilです。
+	err error
+
+	// messageStyle は、エラーメッセージの描画用に解決したスタイルのキャッシュです。
+	messageStyle style.Computed
+	messageValid bool
+}
+
+// Required は、空白以外の文字が入力されていることを検証するバリデータを返します。
+// messageが空の場合は既定のメッセージを使用します。
+func Required(message string) Validator {
+	if message == "" {
+		message = "This field is required"
+	}
+	return func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// MatchRegexp は、値が正規表現に一致することを検証するバリデータを返します。
+// 空の値は検証の対象外です。入力を必須にする場合はRequiredと組み合わせてください。
+// messageが空の場合は既定のメッセージを使用します。
+func MatchRegexp(re *regexp.Regexp, message string) Validator {
+	if message == "" {
+		message = "Invalid format"
+	}
+	return func(value string) error {
+		if value != "" && !re.MatchString(value) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// NumberRange は、値が min 以上 max 以下の数値であることを検証するバリデータを返します。
+// 空の値は検証の対象外です。入力を必須にする場合はRequiredと組み合わせてください。
+func NumberRange(min, max float64) Validator {
+	return func(value string) error {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil
+		}
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.New("Must be a number")
+		}
+		if n < min || n > max {
+			return fmt.Errorf("Must be between %g and %g", min, max)
+		}
+		return nil
+	}
+}
+
+// AddValidator は、値の検証に使用するバリデータを追加します。バリデータは追加された順に実行されます。
+// バリデータを持つ入力欄は、エラーメッセージを表示する領域を枠の下に確保します。
+func (t *TextInput) AddValidator(v ...Validator) {
+	hadValidators := len(t.validation.validators) > 0
+	for _, fn := range v {
+		if fn != nil {
+			t.validation.validators = append(t.validation.validators, fn)
+		}
+	}
+	if !hadValidators && len(t.validation.validators) > 0 {
+		// エラーメッセージの表示領域の分だけ最小の高さが変わるため、再レイアウトを要求します。
+		t.MarkDirty(true)
+	}
+}
+
+// SetValidationTrigger は、検証を実行するタイミングを設定します。
+// 0を指定した場合、検証はValidate()を明示的に呼び出したときにのみ実行されます。
+func (t *TextInput) SetValidationTrigger(trigger ValidationTrigger) {
+	t.validation.trigger = trigger
+}
+
+// Validate は、現在の値をすべてのバリデータで検証し、最初に失敗したバリデータのエラーを返します。
+// 結果は入力欄の見た目とエラーメッセージに反映されます。
+// フォームの送信前などに、まだ操作されていない入力欄も含めて検証する場合に呼び出します。
+func (t *TextInput) Validate() error {
+	err := t.runValidators()
+	if !sameValidationError(t.validation.err, err) {
+		t.MarkDirty(false)
+	}
+	t.validation.err = err
+	return err
+}
+
+// CheckValidity は、現在の値がすべてのバリデータを満たすかどうかを返します。
+// Validateと異なり、結果は入力欄の見た目に反映されません。
+// まだ操作されていない入力欄にエラーを表示せずに、送信ボタンの有効・無効を切り替える場合などに使用します。
+func (t *TextInput) CheckValidity() bool {
+	return t.runValidators() == nil
+}
+
+// runValidators は、現在の値をバリデータで順に検証し、最初に失敗したバリデータのエラーを返します。
+func (t *TextInput) runValidators() error {
+	value := t.Value()
+	for _, v := range t.validation.validators {
+		if err := v(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameValidationError は、2つの検証結果が同じ表示になるかどうかを返します。
+func sameValidationError(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Error() == b.Error()
+}
+
+// IsValid は、直近の検証に成功しているかどうかを返します。まだ検証していない場合はtrueです。
+func (t *TextInput) IsValid() bool {
+	return t.validation.err == nil
+}
+
+// ValidationError は、直近の検証のエラーを返します。検証に成功している場合はnilです。
+func (t *TextInput) ValidationError() error {
+	return t.validation.err
+}
+
+// ClearValidation は、検証の結果を消去し、入力欄を通常の見た目に戻します。
+func (t *TextInput) ClearValidation() {
+	if t.validation.err != nil {
+		t.validation.err = nil
+		t.MarkDirty(false)
+	}
+}
+
+// SetErrorMessageStyle は、エラーメッセージのスタイルを設定します。
+func (t *TextInput) SetErrorMessageStyle(s style.Style) {
+	t.errorStyle = s
+	t.validation.messageValid = false
+	if len(t.validation.validators) > 0 {
+		t.MarkDirty(true)
+	} else {
+		t.MarkDirty(false)
+	}
+}
+
+// errorMessageStyle は、エラーメッセージの描画用のスタイルを返します。
+// フォントや文字色が指定されていない場合は入力欄のものを使用します。
+func (t *TextInput) errorMessageStyle() style.Computed {
+	v := &t.validation
+	if !v.messageValid {
+		base := t.ReadOnlyStyle()
+		s := style.Style{Font: base.Font, TextColor: base.TextColor}
+		v.messageStyle = style.Resolve(style.Merge(s, t.errorStyle))
+		v.messageValid = true
+	}
+	return v.messageStyle
+}
+
+// errorSlotHeight は、枠の下に確保するエラーメッセージの表示領域の高さを返します。
+// バリデータがない場合は0です。エラーの有無で高さを変えないことで、検証のたびにレイアウトが動くのを防ぎます。
+func (t *TextInput) errorSlotHeight() int {
+	if len(t.validation.validators) == 0 {
+		return 0
+	}
+	c := t.errorMessageStyle()
+	if c.Font == nil {
+		return 0
+	}
+	m := component.FaceMetrics(c.Font)
+	return (m.Ascent + m.Descent).Ceil() + errorMessageGap
+}
+
+// drawErrorMessage は、検証エラーがある場合に、そのメッセージを枠の下に左揃えで描画します。
+// 領域に収まらないメッセージは省略記号で切り詰めます。
+func (t *TextInput) drawErrorMessage(screen *ebiten.Image, box image.Rectangle) {
+	if t.validation.err == nil || t.errorSlotHeight() == 0 {
+		return
+	}
+	c := t.errorMessageStyle()
+	message := component.TruncateText(c.Font, t.validation.err.Error(), box.Dx(), style.TextTruncateEllipsis)
+	if message == "" {
+		return
+	}
+	baseline := box.Max.Y + errorMessageGap + component.FaceMetrics(c.Font).Ascent.Ceil()
+	component.FlushDraws()
+	text.Draw(screen, component.VisualLine(message, component.IsRTL(message)), c.Font, box.Min.X, baseline, c.TextColor)
+	stats.AddDrawCalls(1)
+}
+
+// --- TextInputBuilder ---
+
+// Validate は、値の検証に使用するバリデータを追加します。
+// 例: t.Validate(widget.Required(""), widget.NumberRange(1, 100))
+func (b *TextInputBuilder) Validate(v ...Validator) *TextInputBuilder {
+	b.Widget.AddValidator(v...)
+	return b
+}
+
+// ValidateOn は、検証を実行するタイミングを設定します。既定はValidateOnChange|ValidateOnBlurです。
+func (b *TextInputBuilder) ValidateOn(trigger ValidationTrigger) *TextInputBuilder {
+	b.Widget.SetValidationTrigger(trigger)
+	return b
+}
+
+// InvalidStyle は、検証に失敗している間に基本スタイルへマージされるスタイルを設定します。
+func (b *TextInputBuilder) InvalidStyle(s style.Style) *TextInputBuilder {
+	b.Widget.SetInvalidStyle(s)
+	return b
+}
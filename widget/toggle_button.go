@@ -0,0 +1,273 @@
+package widget
+
+import (
+	"furoshiki/component"
+	"furoshiki/event"
+	"slices"
+)
+
+// ToggleButton は、クリックするたびに選択状態が切り替わるボタンです。
+// 選択中は押下状態(StatePressed)のスタイルで描画され、もう一度クリックされるまでその見た目を保ちます。
+// ButtonGroupに追加すると、グループ内で1つだけが選択される排他的な選択になります。
+type ToggleButton struct {
+	*Button
+	selected bool
+	group    *ButtonGroup
+	onToggle []func(selected bool)
+}
+
+// newToggleButton は、ToggleButtonの新しいインスタンスを生成し、初期化します。
+// NOTE: ウィジェットの生成には常にNewToggleButtonBuilder()を使用してください。
+func newToggleButton(text string) (*ToggleButton, error) {
+	t := &ToggleButton{Button: &Button{}}
+	if err := t.initButton(t, text); err != nil {
+		return nil, err
+	}
+	// 選択状態の切り替えは、ユーザーが追加したクリックハンドラより先に実行されます。
+	// これにより、ハンドラ内のIsSelected()は切り替え後の状態を返します。
+	t.AddEventHandler(event.EventClick, func(e *event.Event) event.Propagation {
+		t.toggle()
+		return event.Propagate
+	})
+	return t, nil
+}
+
+// IsSelected は、ボタンが選択されているかどうかを返します。
+func (t *ToggleButton) IsSelected() bool {
+	return t.selected
+}
+
+// DefaultAccessibleRole は、component.DefaultRoleProviderインターフェースの実装です。
+func (t *ToggleButton) DefaultAccessibleRole() component.Role {
+	return component.RoleToggleButton
+}
+
+// SetSelected は、選択状態を設定します。ButtonGroupに属している場合、
+// 選択するとグループ内の他のボタンの選択は解除されます。
+func (t *ToggleButton) SetSelected(selected bool) {
+	if t.group != nil {
+		if selected {
+			t.group.Select(t)
+		} else {
+			t.group.deselect(t)
+		}
+		return
+	}
+	t.setSelected(selected)
+}
+
+// setSelected は、グループを介さずに選択状態を変更し、変化があればコールバックを呼び出します。
+func (t *ToggleButton) setSelected(selected bool) {
+	if t.selected == selected {
+		return
+	}
+	t.selected = selected
+	t.MarkDirty(false)
+	for _, fn := range t.onToggle {
+		fn(selected)
+	}
+}
+
+// toggle は、クリックによる選択状態の切り替えを行います。
+func (t *ToggleButton) toggle() {
+	t.SetSelected(!t.selected)
+}
+
+// AddOnToggle は、選択状態が変化したときに呼び出される関数を追加します。
+func (t *ToggleButton) AddOnToggle(fn func(selected bool)) {
+	if fn != nil {
+		t.onToggle = append(t.onToggle, fn)
+	}
+}
+
+// Group は、ボタンが属しているButtonGroupを返します。属していない場合はnilです。
+func (t *ToggleButton) Group() *ButtonGroup {
+	return t.group
+}
+
+// Draw は、選択中であれば押下状態のスタイルで、そうでなければ通常のボタンと同様に描画します。
+func (t *ToggleButton) Draw(info component.DrawInfo) {
+	state := t.CurrentState()
+	if t.selected && state != component.StateDisabled {
+		state = component.StatePressed
+	}
+	t.drawInState(info, state)
+}
+
+// Cleanup は、ボタンをグループから取り除いてからリソースを解放します。
+func (t *ToggleButton) Cleanup() {
+	if t.group != nil {
+		t.group.Remove(t)
+	}
+	t.onToggle = nil
+	t.Button.Cleanup()
+}
+
+// --- ButtonGroup ---
+
+// ButtonGroup は、複数のToggleButtonの排他的な選択を管理します。
+// ツールバー、セグメントコントロール、タブ列などの構成要素として使用します。
+type ButtonGroup struct {
+	buttons    []*ToggleButton
+	allowEmpty bool
+	onChange   []func(index int, selected *ToggleButton)
+}
+
+// NewButtonGroup は、新しいButtonGroupを生成します。
+// デフォルトでは、選択中のボタンをもう一度クリックしても選択は解除されません。
+func NewButtonGroup() *ButtonGroup {
+	return &ButtonGroup{}
+}
+
+// SetAllowEmpty は、選択中のボタンのクリックで選択を解除し、何も選択されていない状態を許可するかどうかを設定します。
+func (g *ButtonGroup) SetAllowEmpty(allow bool) {
+	g.allowEmpty = allow
+}
+
+// Add は、ボタンをグループに追加します。既に別のグループに属している場合はそのグループから移動します。
+// 選択済みのボタンを追加した場合、それ以前に選択されていたボタンの選択は解除されます。
+func (g *ButtonGroup) Add(buttons ...*ToggleButton) {
+	for _, b := range buttons {
+		if b == nil || b.group == g {
+			continue
+		}
+		if b.group != nil {
+			b.group.Remove(b)
+		}
+		b.group = g
+		g.buttons = append(g.buttons, b)
+		if b.selected {
+			g.Select(b)
+		}
+	}
+}
+
+// Remove は、ボタンをグループから取り除きます。ボタンの選択状態は変更されません。
+func (g *ButtonGroup) Remove(b *ToggleButton) {
+	if i := slices.Index(g.buttons, b); i >= 0 {
+		g.buttons = slices.Delete(g.buttons, i, i+1)
+		b.group = nil
+	}
+}
+
+// Buttons は、グループに属するボタンを追加された順に返します。
+func (g *ButtonGroup) Buttons() []*ToggleButton {
+	return slices.Clone(g.buttons)
+}
+
+// Selected は、選択中のボタンを返します。選択されていない場合はnilです。
+func (g *ButtonGroup) Selected() *ToggleButton {
+	for _, b := range g.buttons {
+		if b.selected {
+			return b
+		}
+	}
+	return nil
+}
+
+// SelectedIndex は、選択中のボタンのインデックスを返します。選択されていない場合は-1です。
+func (g *ButtonGroup) SelectedIndex() int {
+	return slices.IndexFunc(g.buttons, func(b *ToggleButton) bool { return b.selected })
+}
+
+// Select は、指定されたボタンを選択し、他のボタンの選択を解除します。
+// グループに属していないボタンが渡された場合は何もしません。
+func (g *ButtonGroup) Select(b *ToggleButton) {
+	index := slices.Index(g.buttons, b)
+	if index < 0 {
+		return
+	}
+	changed := !b.selected
+	for _, other := range g.buttons {
+		if other != b {
+			other.setSelected(false)
+		}
+	}
+	b.setSelected(true)
+	if changed {
+		g.notify(index, b)
+	}
+}
+
+// SelectIndex は、指定されたインデックスのボタンを選択します。範囲外の場合は何もしません。
+func (g *ButtonGroup) SelectIndex(index int) {
+	if index >= 0 && index < len(g.buttons) {
+		g.Select(g.buttons[index])
+	}
+}
+
+// ClearSelection は、すべてのボタンの選択を解除します。
+func (g *ButtonGroup) ClearSelection() {
+	if g.Selected() == nil {
+		return
+	}
+	for _, b := range g.buttons {
+		b.setSelected(false)
+	}
+	g.notify(-1, nil)
+}
+
+// AddOnChange は、選択が変化したときに呼び出される関数を追加します。
+// 選択が解除された場合は、indexに-1、selectedにnilが渡されます。
+func (g *ButtonGroup) AddOnChange(fn func(index int, selected *ToggleButton)) {
+	if fn != nil {
+		g.onChange = append(g.onChange, fn)
+	}
+}
+
+// deselect は、ボタンの選択解除の要求を処理します。空の選択が許可されていない場合は無視されます。
+func (g *ButtonGroup) deselect(b *ToggleButton) {
+	if !b.selected || !g.allowEmpty {
+		return
+	}
+	b.setSelected(false)
+	g.notify(-1, nil)
+}
+
+func (g *ButtonGroup) notify(index int, selected *ToggleButton) {
+	for _, fn := range g.onChange {
+		fn(index, selected)
+	}
+}
+
+// --- ToggleButtonBuilder ---
+
+// ToggleButtonBuilder は、ToggleButtonを宣言的に構築するためのビルダーです。
+// スタイルやアイコンの設定はButtonBuilderと共通です。
+type ToggleButtonBuilder struct {
+	IconButtonBuilder[*ToggleButtonBuilder, *ToggleButton]
+}
+
+// NewToggleButtonBuilder は新しいToggleButtonBuilderを生成します。
+func NewToggleButtonBuilder() *ToggleButtonBuilder {
+	button, err := newToggleButton("")
+	b := &ToggleButtonBuilder{}
+	b.Init(b, button)
+	b.AddError(err)
+	return b
+}
+
+// Selected は、初期の選択状態を設定します。
+func (b *ToggleButtonBuilder) Selected(selected bool) *ToggleButtonBuilder {
+	b.Widget.SetSelected(selected)
+	return b
+}
+
+// Group は、ボタンを指定されたButtonGroupに追加します。
+func (b *ToggleButtonBuilder) Group(g *ButtonGroup) *ToggleButtonBuilder {
+	if g != nil {
+		g.Add(b.Widget)
+	}
+	return b
+}
+
+// OnToggle は、選択状態が変化したときに呼び出される関数を追加します。
+func (b *ToggleButtonBuilder) OnToggle(fn func(selected bool)) *ToggleButtonBuilder {
+	b.Widget.AddOnToggle(fn)
+	return b
+}
+
+// Build は、最終的なToggleButtonを構築して返します。
+func (b *ToggleButtonBuilder) Build() (*ToggleButton, error) {
+	return b.Builder.Build()
+}